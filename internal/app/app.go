@@ -9,23 +9,37 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/julienschmidt/httprouter"
 	"github.com/shandysiswandi/test-amartha/internal/loan"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgnotify"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgworker"
+	"github.com/shandysiswandi/test-amartha/internal/user"
+	"github.com/shandysiswandi/test-amartha/internal/webhook"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
 type App struct {
-	database     *sql.DB
-	queryBuilder pkgsql.GoquBuilder
-	validator    *validator.Validate
-	logger       *zap.Logger
-	router       *httprouter.Router
-	httpServer   *http.Server
-	closersFn    []func(context.Context) error
-	config       *viper.Viper
-	snowflakeGen pkguid.Snowflake
-	err          error
+	database         *sql.DB
+	queryBuilder     pkgsql.GoquBuilder
+	validator        *validator.Validate
+	logger           *zap.Logger
+	router           *httprouter.Router
+	httpServer       *http.Server
+	closersFn        []func(context.Context) error
+	config           *viper.Viper
+	snowflakeGen     pkguid.Snowflake
+	tokenVerifier    pkgauth.TokenVerifier
+	documentStore    pkgstorage.DocumentStore
+	notifier         pkgnotify.Notifier
+	outboxStore      *pkgoutbox.SQLStore
+	outboxDispatcher *pkgoutbox.Dispatcher
+	workers          []*pkgworker.Periodic
+	workerLease      *pkgworker.SQLLease
+	err              error
 }
 
 func Run() {
@@ -36,6 +50,15 @@ func Run() {
 
 func (app *App) Start() error {
 	app.logger.Sugar().Info("starting application")
+
+	// handlers are registered while spinning up the modules, the dispatcher only starts once they all are
+	app.outboxDispatcher.Start()
+
+	for _, worker := range app.workers {
+		app.logger.Sugar().Infow("starting background worker", "worker", worker.Name())
+		worker.Start()
+	}
+
 	go func() {
 		app.logger.Sugar().Info("http server listen on", app.httpServer.Addr)
 		if err := app.httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
@@ -65,21 +88,68 @@ func (app *App) spinUp() *App {
 	app.makeHTTPServer()
 	app.initSnowflakeGen()
 	app.initValidator()
+	app.initAuth()
+	app.initDocumentStore()
+	app.initNotifier()
+	app.initOutbox()
+	app.initWorkerLease()
 	app.setUpClosers()
 
 	// spin up module
-	app.spinUpLoan()
+	app.spinUpUser()
+	loanModule := app.spinUpLoan()
+	app.spinUpWebhook(loanModule.EventTypes)
 
 	return app
 }
 
-func (app *App) spinUpLoan() {
-	loan.New(loan.Dependencies{
-		DB:           app.database,
-		Logger:       app.logger.Sugar(),
-		QueryBuilder: app.queryBuilder,
-		SnowflakeGen: app.snowflakeGen,
-		HttpRouter:   app.router,
-		Validator:    app.validator,
+func (app *App) spinUpLoan() *loan.Exposed {
+	loanModule := loan.New(loan.Dependencies{
+		Config:        app.config,
+		DB:            app.database,
+		Logger:        app.logger.Sugar(),
+		QueryBuilder:  app.queryBuilder,
+		SnowflakeGen:  app.snowflakeGen,
+		HttpRouter:    app.router,
+		Validator:     app.validator,
+		TokenVerifier: app.tokenVerifier,
+		DocumentStore: app.documentStore,
+		Notifier:      app.notifier,
+		Outbox:        app.outboxStore,
+		OutboxEvents:  app.outboxDispatcher,
+		WorkerLease:   app.workerLease,
+	})
+
+	app.workers = append(app.workers, loanModule.Workers...)
+
+	return loanModule
+}
+
+func (app *App) spinUpWebhook(eventTypes []string) {
+	webhookModule := webhook.New(webhook.Dependencies{
+		Config:        app.config,
+		DB:            app.database,
+		Logger:        app.logger.Sugar(),
+		QueryBuilder:  app.queryBuilder,
+		SnowflakeGen:  app.snowflakeGen,
+		HttpRouter:    app.router,
+		Validator:     app.validator,
+		TokenVerifier: app.tokenVerifier,
+		OutboxEvents:  app.outboxDispatcher,
+		EventTypes:    eventTypes,
+	})
+
+	app.workers = append(app.workers, webhookModule.Workers...)
+}
+
+func (app *App) spinUpUser() {
+	user.New(user.Dependencies{
+		DB:            app.database,
+		Logger:        app.logger.Sugar(),
+		QueryBuilder:  app.queryBuilder,
+		SnowflakeGen:  app.snowflakeGen,
+		HttpRouter:    app.router,
+		Validator:     app.validator,
+		TokenVerifier: app.tokenVerifier,
 	})
 }
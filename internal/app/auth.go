@@ -0,0 +1,19 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+)
+
+func (app *App) initAuth() {
+	token, err := pkgauth.NewHMACToken(
+		[]byte(app.config.GetString("auth.jwt.secret")),
+		app.config.GetString("auth.jwt.issuer"),
+	)
+	if err != nil {
+		app.err = errors.Join(app.err, err)
+	}
+
+	app.tokenVerifier = token
+}
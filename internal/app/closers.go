@@ -1,11 +1,25 @@
 package app
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 func (app *App) setUpClosers() {
 	app.closersFn = append(app.closersFn, []func(context.Context) error{
 		func(ctx context.Context) error {
 			return app.httpServer.Shutdown(ctx)
 		},
+		func(ctx context.Context) error {
+			return app.outboxDispatcher.Stop(ctx)
+		},
+		func(ctx context.Context) error {
+			var err error
+			for _, worker := range app.workers {
+				err = errors.Join(err, worker.Stop(ctx))
+			}
+
+			return err
+		},
 	}...)
 }
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+)
+
+// localDocumentPath is the route serving the signed URLs of the local document store.
+const localDocumentPath = "/document/"
+
+func (app *App) initDocumentStore() {
+	switch driver := app.config.GetString("storage.driver"); driver {
+	case "s3":
+		documentStore, err := pkgstorage.NewS3DocumentStore(pkgstorage.S3Config{
+			Endpoint:     app.config.GetString("storage.s3.endpoint"),
+			Region:       app.config.GetString("storage.s3.region"),
+			Bucket:       app.config.GetString("storage.s3.bucket"),
+			AccessKey:    app.config.GetString("storage.s3.access_key"),
+			SecretKey:    app.config.GetString("storage.s3.secret_key"),
+			UsePathStyle: app.config.GetBool("storage.s3.use_path_style"),
+		})
+		if err != nil {
+			app.err = errors.Join(app.err, err)
+
+			return
+		}
+
+		app.documentStore = documentStore
+	case "", "local":
+		documentStore, err := pkgstorage.NewLocalDocumentStore(
+			app.config.GetString("storage.local.root"),
+			app.config.GetString("storage.local.base_url"),
+			[]byte(app.config.GetString("storage.local.signing_key")),
+		)
+		if err != nil {
+			app.err = errors.Join(app.err, err)
+
+			return
+		}
+
+		app.router.Handler(http.MethodGet, localDocumentPath+"*key", documentStore.Handler(localDocumentPath))
+
+		app.documentStore = documentStore
+	default:
+		app.err = errors.Join(app.err, fmt.Errorf("unknown storage driver %q", driver))
+	}
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgnotify"
+)
+
+func (app *App) initNotifier() {
+	switch driver := app.config.GetString("notification.driver"); driver {
+	case "smtp":
+		notifier, err := pkgnotify.NewSMTPNotifier(pkgnotify.SMTPConfig{
+			Host:     app.config.GetString("notification.smtp.host"),
+			Port:     app.config.GetInt("notification.smtp.port"),
+			Username: app.config.GetString("notification.smtp.username"),
+			Password: app.config.GetString("notification.smtp.password"),
+			From:     app.config.GetString("notification.smtp.from"),
+			Timeout:  app.config.GetDuration("notification.smtp.timeout"),
+		})
+		if err != nil {
+			app.err = errors.Join(app.err, err)
+
+			return
+		}
+
+		app.notifier = notifier
+	case "", "log":
+		app.notifier = pkgnotify.NewLogNotifier(app.logger.Sugar())
+	default:
+		app.err = errors.Join(app.err, fmt.Errorf("unknown notification driver %q", driver))
+	}
+}
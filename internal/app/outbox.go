@@ -0,0 +1,17 @@
+package app
+
+import (
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+)
+
+func (app *App) initOutbox() {
+	app.outboxStore = pkgoutbox.NewSQLStore(app.database, app.queryBuilder)
+
+	app.outboxDispatcher = pkgoutbox.NewDispatcher(app.outboxStore, app.logger.Sugar(), pkgoutbox.DispatcherConfig{
+		PollInterval: app.config.GetDuration("outbox.poll_interval"),
+		BatchSize:    app.config.GetInt("outbox.batch_size"),
+		Lease:        app.config.GetDuration("outbox.lease"),
+		MaxAttempts:  app.config.GetInt("outbox.max_attempts"),
+		RetryBackoff: app.config.GetDuration("outbox.retry_backoff"),
+	})
+}
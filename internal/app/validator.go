@@ -13,7 +13,8 @@ func (app *App) initValidator() {
 	app.validator.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
 		val, ok := field.Interface().(decimal.Decimal)
 		if ok {
-			return val.IntPart()
+			// compared as a number, so that required accepts an amount below 1 and rejects only zero
+			return val.InexactFloat64()
 		}
 
 		return nil
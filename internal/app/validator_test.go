@@ -0,0 +1,109 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_initValidator(t *testing.T) {
+	// the decimal inputs of the loan module only use required, these mirror their tags since the module usecases
+	// cannot be imported from here
+	type (
+		createProposedLoanInput struct {
+			InterestRate decimal.Decimal `validate:"required"`
+			Amount       decimal.Decimal `validate:"required"`
+		}
+		repayLoanInput struct {
+			Amount decimal.Decimal `validate:"required"`
+		}
+		topUpWalletInput struct {
+			Amount decimal.Decimal `validate:"required"`
+		}
+		withdrawWalletInput struct {
+			Amount decimal.Decimal `validate:"required"`
+		}
+		investLoanInput struct {
+			Amount decimal.Decimal `validate:"required"`
+		}
+	)
+
+	tests := []struct {
+		name    string
+		input   any
+		wantErr bool
+	}{
+		{
+			name: "loan principal and interest rate",
+			input: createProposedLoanInput{
+				InterestRate: decimal.RequireFromString("12.5"),
+				Amount:       decimal.NewFromInt(5_000),
+			},
+		},
+		{
+			name: "loan interest rate below 1",
+			input: createProposedLoanInput{
+				InterestRate: decimal.RequireFromString("0.75"),
+				Amount:       decimal.NewFromInt(5_000),
+			},
+		},
+		{
+			name:    "loan principal zero",
+			input:   createProposedLoanInput{InterestRate: decimal.NewFromInt(10), Amount: decimal.Zero},
+			wantErr: true,
+		},
+		{
+			name:    "loan interest rate missing",
+			input:   createProposedLoanInput{Amount: decimal.NewFromInt(5_000)},
+			wantErr: true,
+		},
+		{
+			name:  "repayment below 1",
+			input: repayLoanInput{Amount: decimal.RequireFromString("0.01")},
+		},
+		{
+			name:    "repayment zero",
+			input:   repayLoanInput{Amount: decimal.Zero},
+			wantErr: true,
+		},
+		{
+			name:  "top-up below 1",
+			input: topUpWalletInput{Amount: decimal.RequireFromString("0.50")},
+		},
+		{
+			name:    "top-up zero",
+			input:   topUpWalletInput{Amount: decimal.RequireFromString("0.00")},
+			wantErr: true,
+		},
+		{
+			// the sign is checked by the interactor, which answers with a clearer message
+			name:  "withdrawal negative",
+			input: withdrawWalletInput{Amount: decimal.NewFromInt(-10)},
+		},
+		{
+			name:    "withdrawal missing",
+			input:   withdrawWalletInput{},
+			wantErr: true,
+		},
+		{
+			name:  "investment below 1",
+			input: investLoanInput{Amount: decimal.RequireFromString("0.50")},
+		},
+		{
+			name:    "investment zero",
+			input:   investLoanInput{Amount: decimal.Zero},
+			wantErr: true,
+		},
+	}
+
+	app := &App{}
+	app.initValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.validator.Struct(tt.input)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
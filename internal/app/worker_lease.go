@@ -0,0 +1,22 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgworker"
+)
+
+// initWorkerLease names this instance after its host and process, so the instances sharing the database tell their
+// leases apart.
+func (app *App) initWorkerLease() {
+	hostname, err := os.Hostname()
+	if err != nil {
+		app.err = errors.Join(app.err, err)
+	}
+
+	holder := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+
+	app.workerLease = pkgworker.NewSQLLease(app.database, app.queryBuilder, holder)
+}
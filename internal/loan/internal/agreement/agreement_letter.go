@@ -0,0 +1,140 @@
+// Package agreement renders the agreement letters a loan's investors receive once it is fully funded, and the emails
+// handing them out.
+package agreement
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/shopspring/decimal"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+//nolint:gochecknoglobals // intended to be global
+var (
+	letterTemplate = template.Must(template.ParseFS(templates, "templates/agreement_letter.tmpl"))
+	emailTemplate  = template.Must(template.ParseFS(templates, "templates/agreement_letter_email.tmpl"))
+	hundred        = decimal.NewFromInt(100)
+)
+
+// LetterData is what an agreement letter states about the loan and the investor's part in it.
+type LetterData struct {
+	LoanID           uint64
+	BorrowerName     string
+	PrincipalAmount  decimal.Decimal
+	InterestRate     decimal.Decimal // percent per annum
+	InvestmentID     uint64
+	InvestorName     string
+	InvestmentAmount decimal.Decimal
+	IssuedAt         time.Time
+}
+
+// SharePercent is the part of the principal funded by the investor.
+func (d LetterData) SharePercent() decimal.Decimal {
+	if d.PrincipalAmount.IsZero() {
+		return decimal.Zero
+	}
+
+	return d.InvestmentAmount.Div(d.PrincipalAmount).Mul(hundred)
+}
+
+// ExpectedReturn is the yearly interest the investor earns on their investment.
+func (d LetterData) ExpectedReturn() decimal.Decimal {
+	return d.InvestmentAmount.Mul(d.InterestRate).Div(hundred)
+}
+
+// Generator renders agreement letters into PDF documents.
+type Generator struct {
+	tmpl *template.Template
+}
+
+func NewGenerator() *Generator {
+	return &Generator{tmpl: letterTemplate}
+}
+
+// Generate renders the letter for one investment. Lines of the template starting with "# " or "## " become headings,
+// every other line is a paragraph.
+func (g *Generator) Generate(data LetterData) ([]byte, error) {
+	text, err := g.render(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("Loan %d agreement letter", data.LoanID), true)
+	pdf.SetCreationDate(data.IssuedAt)
+	pdf.SetModificationDate(data.IssuedAt)
+	pdf.AddPage()
+
+	// the core fonts are not unicode, names are translated to their code page instead
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "## "):
+			pdf.Ln(2)
+			pdf.SetFont("Helvetica", "B", 12)
+			pdf.MultiCell(0, 7, tr(strings.TrimPrefix(line, "## ")), "", "L", false)
+		case strings.HasPrefix(line, "# "):
+			pdf.SetFont("Helvetica", "B", 16)
+			pdf.MultiCell(0, 10, tr(strings.TrimPrefix(line, "# ")), "", "C", false)
+			pdf.Ln(4)
+		default:
+			pdf.SetFont("Helvetica", "", 11)
+			pdf.MultiCell(0, 6, tr(line), "", "L", false)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write agreement letter pdf: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *Generator) render(data LetterData) (string, error) {
+	var buf strings.Builder
+	if err := g.tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render agreement letter template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// EmailData is what the email handing an investor their agreement letter says.
+type EmailData struct {
+	LoanID           uint64
+	InvestorName     string
+	InvestmentAmount decimal.Decimal
+	LetterURL        string
+	LinkExpiresAt    time.Time
+}
+
+// Email renders the subject and the plain text body of the email linking an investor to their agreement letter.
+func Email(data EmailData) (subject, body string, err error) {
+	var buf strings.Builder
+	if err := emailTemplate.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render agreement letter email subject: %w", err)
+	}
+
+	subject = buf.String()
+	buf.Reset()
+
+	if err := emailTemplate.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", fmt.Errorf("failed to render agreement letter email body: %w", err)
+	}
+
+	return subject, buf.String(), nil
+}
@@ -0,0 +1,78 @@
+package agreement
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func letterData() LetterData {
+	return LetterData{
+		LoanID:           1,
+		BorrowerName:     "Siti Aminah",
+		PrincipalAmount:  decimal.NewFromInt(5_000_000),
+		InterestRate:     decimal.RequireFromString("12.5"),
+		InvestmentID:     7,
+		InvestorName:     "Budi Santoso",
+		InvestmentAmount: decimal.NewFromInt(1_250_000),
+		IssuedAt:         time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestLetterData(t *testing.T) {
+	data := letterData()
+
+	assert.Equal(t, "25.00", data.SharePercent().StringFixed(2))
+	assert.Equal(t, "156250.00", data.ExpectedReturn().StringFixed(2))
+	assert.True(t, LetterData{}.SharePercent().IsZero())
+}
+
+func TestGenerator_render(t *testing.T) {
+	g := NewGenerator()
+
+	text, err := g.render(letterData())
+	assert.NoError(t, err)
+
+	for _, want := range []string{
+		"Loan ID: 1",
+		"Investment ID: 7",
+		"Issued at: 2024-10-01",
+		"Budi Santoso (investor)",
+		"Siti Aminah (borrower)",
+		"Principal amount: 5000000.00",
+		"Interest rate: 12.50% per annum",
+		"Investment amount: 1250000.00",
+		"Share of the loan: 25.00%",
+		"Expected return: 156250.00 per annum",
+	} {
+		assert.Contains(t, text, want)
+	}
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	g := NewGenerator()
+
+	got, err := g.Generate(letterData())
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(got, []byte("%PDF-")))
+}
+
+func TestEmail(t *testing.T) {
+	subject, body, err := Email(EmailData{
+		LoanID:           1,
+		InvestorName:     "Budi Santoso",
+		InvestmentAmount: decimal.NewFromInt(1_250_000),
+		LetterURL:        "http://localhost:8081/document/loan/1/investment/7/agreement-letter.pdf?signature=abc",
+		LinkExpiresAt:    time.Date(2024, 10, 8, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Your agreement letter for loan 1", subject)
+	assert.True(t, strings.HasPrefix(body, "Dear Budi Santoso,\n"))
+	assert.Contains(t, body, "investing 1250000.00")
+	assert.Contains(t, body, "until 2024-10-08 00:00 UTC")
+	assert.Contains(t, body, "\nhttp://localhost:8081/document/loan/1/investment/7/agreement-letter.pdf?signature=abc\n")
+}
@@ -0,0 +1,139 @@
+// Package distribution splits what a borrower repays among the investors of the loan, proportionally to the amount of
+// their investment.
+//
+// Every part is rounded down to the cent and the cents left over are handed out one at a time to the parts that lost
+// the most to rounding, ties going to the oldest investment, the lowest id. Parts always add up to the split amount
+// and the same amount is always split the same way. The platform fee is only taken from the interest, rounded half
+// away from zero to the cent, and split among the investors like the rest.
+package distribution
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// amountScale is the number of decimals of the stored amounts.
+	amountScale = 2
+	// ratioScale is the precision the exact share of every investment is computed with before rounding.
+	ratioScale = 16
+)
+
+// ErrInvalidShares is returned when there is no positive investment to split an amount among.
+var ErrInvalidShares = errors.New("invalid investment shares")
+
+// ErrInvalidAmount is returned for negative amounts or fee rates outside 0 to 100.
+var ErrInvalidAmount = errors.New("invalid distribution amount")
+
+//nolint:gochecknoglobals // intended to be global
+var (
+	hundred = decimal.NewFromInt(100)
+	cent    = decimal.New(1, -amountScale)
+)
+
+// Share is the investment a part of the repayment is owed to.
+type Share struct {
+	InvestmentID uint64
+	Amount       decimal.Decimal
+}
+
+// Credit is what an investment receives of a repayment. Interest is net of the platform Fee.
+type Credit struct {
+	InvestmentID uint64
+	Principal    decimal.Decimal
+	Interest     decimal.Decimal
+	Fee          decimal.Decimal
+}
+
+// Distribute splits the principal and interest of a repayment among the shares, after taking the platform fee of
+// feeRate percent from the interest. The credits are in the order of the shares.
+func Distribute(principal, interest, feeRate decimal.Decimal, shares []Share) ([]Credit, error) {
+	if feeRate.IsNegative() || feeRate.GreaterThan(hundred) {
+		return nil, ErrInvalidAmount
+	}
+
+	fee := interest.Mul(feeRate).Div(hundred).Round(amountScale)
+
+	principals, err := Split(principal, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	interests, err := Split(interest.Sub(fee), shares)
+	if err != nil {
+		return nil, err
+	}
+
+	fees, err := Split(fee, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	credits := make([]Credit, 0, len(shares))
+	for i, share := range shares {
+		credits = append(credits, Credit{
+			InvestmentID: share.InvestmentID,
+			Principal:    principals[i],
+			Interest:     interests[i],
+			Fee:          fees[i],
+		})
+	}
+
+	return credits, nil
+}
+
+// Split divides amount among the shares proportionally to their amount, in the order of the shares.
+func Split(amount decimal.Decimal, shares []Share) ([]decimal.Decimal, error) {
+	if amount.IsNegative() {
+		return nil, ErrInvalidAmount
+	}
+
+	total := decimal.Zero
+	for _, share := range shares {
+		if share.Amount.IsNegative() {
+			return nil, ErrInvalidShares
+		}
+
+		total = total.Add(share.Amount)
+	}
+
+	if !total.IsPositive() {
+		return nil, ErrInvalidShares
+	}
+
+	amount = amount.Round(amountScale)
+	parts := make([]decimal.Decimal, len(shares))
+	remainders := make([]decimal.Decimal, len(shares))
+	left := amount
+
+	for i, share := range shares {
+		exact := amount.Mul(share.Amount).DivRound(total, ratioScale)
+		parts[i] = exact.RoundDown(amountScale)
+		remainders[i] = exact.Sub(parts[i])
+		left = left.Sub(parts[i])
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortFunc(order, func(a, b int) int {
+		if c := remainders[b].Cmp(remainders[a]); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(shares[a].InvestmentID, shares[b].InvestmentID)
+	})
+
+	for i := 0; left.IsPositive(); i++ {
+		index := order[i%len(order)]
+		parts[index] = parts[index].Add(cent)
+		left = left.Sub(cent)
+	}
+
+	return parts, nil
+}
@@ -0,0 +1,118 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		shares  []Share
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "error no shares",
+			amount:  "100",
+			wantErr: true,
+		},
+		{
+			name:    "error negative amount",
+			amount:  "-1",
+			shares:  []Share{{InvestmentID: 1, Amount: decimal.NewFromInt(100)}},
+			wantErr: true,
+		},
+		{
+			name:   "success even split",
+			amount: "100",
+			shares: []Share{
+				{InvestmentID: 1, Amount: decimal.NewFromInt(500)},
+				{InvestmentID: 2, Amount: decimal.NewFromInt(500)},
+			},
+			want: []string{"50", "50"},
+		},
+		{
+			name:   "success tie goes to the oldest investment",
+			amount: "100",
+			shares: []Share{
+				{InvestmentID: 3, Amount: decimal.NewFromInt(100)},
+				{InvestmentID: 1, Amount: decimal.NewFromInt(100)},
+				{InvestmentID: 2, Amount: decimal.NewFromInt(100)},
+			},
+			want: []string{"33.33", "33.34", "33.33"},
+		},
+		{
+			name:   "success leftover cent goes to the largest remainder",
+			amount: "0.1",
+			shares: []Share{
+				{InvestmentID: 1, Amount: decimal.NewFromInt(100)},
+				{InvestmentID: 2, Amount: decimal.NewFromInt(200)},
+			},
+			want: []string{"0.03", "0.07"},
+		},
+		{
+			name:   "success uneven shares",
+			amount: "343333.33",
+			shares: []Share{
+				{InvestmentID: 1, Amount: decimal.NewFromInt(600_000)},
+				{InvestmentID: 2, Amount: decimal.NewFromInt(250_000)},
+				{InvestmentID: 3, Amount: decimal.NewFromInt(150_000)},
+			},
+			want: []string{"206000", "85833.33", "51500"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Split(decimal.RequireFromString(tt.amount), tt.shares)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			parts := make([]string, 0, len(got))
+			sum := decimal.Zero
+			for _, part := range got {
+				parts = append(parts, part.String())
+				sum = sum.Add(part)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.want, parts)
+			assert.True(t, sum.Equal(decimal.RequireFromString(tt.amount)))
+		})
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	shares := []Share{
+		{InvestmentID: 1, Amount: decimal.NewFromInt(750)},
+		{InvestmentID: 2, Amount: decimal.NewFromInt(250)},
+	}
+
+	_, err := Distribute(decimal.NewFromInt(100), decimal.NewFromInt(10), decimal.NewFromInt(101), shares)
+	assert.ErrorIs(t, err, ErrInvalidAmount)
+
+	got, err := Distribute(decimal.NewFromInt(100), decimal.RequireFromString("10.05"), decimal.NewFromInt(10), shares)
+	assert.NoError(t, err)
+
+	type credit struct {
+		investmentID             uint64
+		principal, interest, fee string
+	}
+	credits := make([]credit, 0, len(got))
+	for _, c := range got {
+		credits = append(credits, credit{c.InvestmentID, c.Principal.String(), c.Interest.String(), c.Fee.String()})
+	}
+
+	// the fee is 1.01, 10% of 10.05 rounded half away from zero, and 9.04 is left for the investors
+	assert.Equal(t, []credit{
+		{1, "75", "6.78", "0.76"},
+		{2, "25", "2.26", "0.25"},
+	}, credits)
+}
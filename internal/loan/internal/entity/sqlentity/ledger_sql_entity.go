@@ -0,0 +1,281 @@
+package sqlentity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LedgerAccount holds money in the ledger, OwnerID is the user owning it and zero for the accounts of the platform.
+type LedgerAccount struct {
+	ID      uint64
+	Type    LedgerAccountType
+	OwnerID uint64
+}
+
+func (l LedgerAccount) Columns() []any {
+	return []any{
+		"id",
+		"type",
+		"owner_id",
+	}
+}
+
+func (l LedgerAccount) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LedgerAccount) Values() []any {
+	return []any{
+		&l.ID,
+		&l.Type,
+		&l.OwnerID,
+	}
+}
+
+func (l *LedgerAccount) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LedgerAccounts []LedgerAccount
+
+func (l LedgerAccounts) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LedgerAccounts) Len() int {
+	return len(l)
+}
+
+// LedgerEntry is a journal entry, Reference names the money movement it records and is unique.
+type LedgerEntry struct {
+	ID          uint64
+	Reference   string
+	LoanID      uint64
+	Description string
+	PostedAt    time.Time
+}
+
+func (l LedgerEntry) Columns() []any {
+	return []any{
+		"id",
+		"reference",
+		"loan_id",
+		"description",
+		"posted_at",
+	}
+}
+
+func (l LedgerEntry) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LedgerEntry) Values() []any {
+	return []any{
+		&l.ID,
+		&l.Reference,
+		&l.LoanID,
+		&l.Description,
+		&l.PostedAt,
+	}
+}
+
+func (l *LedgerEntry) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+// LedgerLine moves money of a journal entry, DebitAmount leaves the account and CreditAmount enters it.
+type LedgerLine struct {
+	ID           uint64
+	EntryID      uint64
+	AccountID    uint64
+	DebitAmount  decimal.Decimal
+	CreditAmount decimal.Decimal
+}
+
+func (l LedgerLine) Columns() []any {
+	return []any{
+		"id",
+		"entry_id",
+		"account_id",
+		"debit_amount",
+		"credit_amount",
+	}
+}
+
+func (l LedgerLine) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LedgerLine) Values() []any {
+	return []any{
+		&l.ID,
+		&l.EntryID,
+		&l.AccountID,
+		&l.DebitAmount,
+		&l.CreditAmount,
+	}
+}
+
+func (l *LedgerLine) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LedgerLines []LedgerLine
+
+func (l LedgerLines) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LedgerLines) Len() int {
+	return len(l)
+}
+
+// LedgerAccountBalance is the total of the lines posted to an account.
+type LedgerAccountBalance struct {
+	AccountID    uint64
+	Type         LedgerAccountType
+	OwnerID      uint64
+	DebitAmount  decimal.Decimal
+	CreditAmount decimal.Decimal
+}
+
+func (l LedgerAccountBalance) Columns() []any {
+	return []any{
+		"account_id",
+		"type",
+		"owner_id",
+		"debit_amount",
+		"credit_amount",
+	}
+}
+
+func (l LedgerAccountBalance) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LedgerAccountBalance) Values() []any {
+	return []any{
+		&l.AccountID,
+		&l.Type,
+		&l.OwnerID,
+		&l.DebitAmount,
+		&l.CreditAmount,
+	}
+}
+
+func (l *LedgerAccountBalance) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LedgerAccountBalances []LedgerAccountBalance
+
+func (l LedgerAccountBalances) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LedgerAccountBalances) Len() int {
+	return len(l)
+}
+
+type LedgerAccountType int
+
+const (
+	UnknownLedgerAccountType LedgerAccountType = iota
+	// InvestorWallet is the money of an investor.
+	InvestorWallet
+	// BorrowerAccount is the money a borrower received and repaid.
+	BorrowerAccount
+	// PlatformEscrow holds the money between the investors and the borrowers.
+	PlatformEscrow
+	// FeeRevenue is the platform fee taken from the repaid interest.
+	FeeRevenue
+	// Settlement is the money outside the platform the investors top up their wallet from and withdraw to.
+	Settlement
+)
+
+func (lt LedgerAccountType) String() string {
+	return [...]string{"UNKNOWN", "INVESTOR_WALLET", "BORROWER", "PLATFORM_ESCROW", "FEE_REVENUE", "SETTLEMENT"}[lt]
+}
+
+func (lt LedgerAccountType) Value() (driver.Value, error) {
+	return lt.String(), nil
+}
+
+func (lt LedgerAccountType) getMap() map[string]LedgerAccountType {
+	return map[string]LedgerAccountType{
+		"UNKNOWN":         UnknownLedgerAccountType,
+		"INVESTOR_WALLET": InvestorWallet,
+		"BORROWER":        BorrowerAccount,
+		"PLATFORM_ESCROW": PlatformEscrow,
+		"FEE_REVENUE":     FeeRevenue,
+		"SETTLEMENT":      Settlement,
+	}
+}
+
+func (lt *LedgerAccountType) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*lt = lt.getMap()[string(v)]
+	case string:
+		*lt = lt.getMap()[v]
+	default:
+		return errors.New("failed to scan ledger account type")
+	}
+
+	return nil
+}
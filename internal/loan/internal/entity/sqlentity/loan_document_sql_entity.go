@@ -0,0 +1,115 @@
+package sqlentity
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// LoanDocument is a document uploaded for a loan. DocumentKey addresses it in the document store and ChecksumSHA256
+// is the hex encoded digest of its content at upload time.
+type LoanDocument struct {
+	ID             uint64
+	LoanID         uint64
+	Type           LoanDocumentType
+	DocumentKey    string
+	ContentType    string
+	Size           int64
+	ChecksumSHA256 string
+	UploadedBy     uint64
+	CreatedAt      time.Time
+}
+
+func (l LoanDocument) Columns() []any {
+	return []any{
+		"id",
+		"loan_id",
+		"type",
+		"document_key",
+		"content_type",
+		"size",
+		"checksum_sha256",
+		"uploaded_by",
+		"created_at",
+	}
+}
+
+func (l LoanDocument) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanDocument) Values() []any {
+	return []any{
+		&l.ID,
+		&l.LoanID,
+		&l.Type,
+		&l.DocumentKey,
+		&l.ContentType,
+		&l.Size,
+		&l.ChecksumSHA256,
+		&l.UploadedBy,
+		&l.CreatedAt,
+	}
+}
+
+func (l *LoanDocument) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanDocuments []LoanDocument
+
+func (l LoanDocuments) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanDocuments) Len() int {
+	return len(l)
+}
+
+type LoanDocumentType int
+
+const (
+	UnknownDocumentType LoanDocumentType = iota
+	AgreementLetterDocument
+)
+
+func (t LoanDocumentType) String() string {
+	return [...]string{"UNKNOWN", "AGREEMENT_LETTER"}[t]
+}
+
+func (t LoanDocumentType) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+func (t LoanDocumentType) getMap() map[string]LoanDocumentType {
+	return map[string]LoanDocumentType{
+		"UNKNOWN":          UnknownDocumentType,
+		"AGREEMENT_LETTER": AgreementLetterDocument,
+	}
+}
+
+func (t *LoanDocumentType) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*t = t.getMap()[string(v)]
+	case string:
+		*t = t.getMap()[v]
+	default:
+		return errors.New("failed to scan loan document type")
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package sqlentity
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LoanInstallment is one monthly repayment of a disbursed loan. OutstandingBalance is the principal left once it is
+// repaid, PaidPrincipalAmount and PaidInterestAmount what the borrower repaid of it so far.
+type LoanInstallment struct {
+	ID                  uint64
+	LoanID              uint64
+	InstallmentNumber   int
+	DueDate             time.Time
+	PrincipalAmount     decimal.Decimal
+	InterestAmount      decimal.Decimal
+	TotalAmount         decimal.Decimal
+	OutstandingBalance  decimal.Decimal
+	PaidPrincipalAmount decimal.Decimal
+	PaidInterestAmount  decimal.Decimal
+	Status              InstallmentStatus
+	PaidAt              sql.NullTime
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+func (l LoanInstallment) Columns() []any {
+	return []any{
+		"id",
+		"loan_id",
+		"installment_number",
+		"due_date",
+		"principal_amount",
+		"interest_amount",
+		"total_amount",
+		"outstanding_balance",
+		"paid_principal_amount",
+		"paid_interest_amount",
+		"status",
+		"paid_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func (l LoanInstallment) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanInstallment) Values() []any {
+	return []any{
+		&l.ID,
+		&l.LoanID,
+		&l.InstallmentNumber,
+		&l.DueDate,
+		&l.PrincipalAmount,
+		&l.InterestAmount,
+		&l.TotalAmount,
+		&l.OutstandingBalance,
+		&l.PaidPrincipalAmount,
+		&l.PaidInterestAmount,
+		&l.Status,
+		&l.PaidAt,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+	}
+}
+
+func (l *LoanInstallment) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanInstallments []LoanInstallment
+
+func (l LoanInstallments) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanInstallments) Len() int {
+	return len(l)
+}
+
+func (l LoanInstallments) First() LoanInstallment {
+	if l.IsEmpty() {
+		return LoanInstallment{}
+	}
+
+	return l[0]
+}
+
+// UpdateLoanInstallmentPayment records what a repayment paid of an installment.
+type UpdateLoanInstallmentPayment struct {
+	PaidPrincipalAmount decimal.Decimal
+	PaidInterestAmount  decimal.Decimal
+	Status              InstallmentStatus
+	PaidAt              sql.NullTime
+	UpdatedAt           time.Time
+}
+
+func (u UpdateLoanInstallmentPayment) Columns() []any {
+	return []any{
+		"paid_principal_amount",
+		"paid_interest_amount",
+		"status",
+		"paid_at",
+		"updated_at",
+	}
+}
+
+func (u UpdateLoanInstallmentPayment) StringColumns() []string {
+	vals := make([]string, len(u.Columns()))
+	for i, col := range u.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (u *UpdateLoanInstallmentPayment) Values() []any {
+	return []any{
+		u.PaidPrincipalAmount,
+		u.PaidInterestAmount,
+		u.Status,
+		u.PaidAt,
+		u.UpdatedAt,
+	}
+}
+
+func (u UpdateLoanInstallmentPayment) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(u.Values()))
+	for i, v := range u.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (u UpdateLoanInstallmentPayment) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := u.StringColumns()
+	for i, col := range cols {
+		vals[col] = u.DriverValues()[i]
+	}
+
+	return vals
+}
+
+type InstallmentStatus int
+
+const (
+	UnknownInstallmentStatus InstallmentStatus = iota
+	InstallmentPending
+	// InstallmentPartial is an installment repaid in part.
+	InstallmentPartial
+	InstallmentPaid
+)
+
+func (is InstallmentStatus) String() string {
+	return [...]string{"UNKNOWN", "PENDING", "PARTIAL", "PAID"}[is]
+}
+
+func (is InstallmentStatus) Value() (driver.Value, error) {
+	return is.String(), nil
+}
+
+func (is InstallmentStatus) getMap() map[string]InstallmentStatus {
+	return map[string]InstallmentStatus{
+		"UNKNOWN": UnknownInstallmentStatus,
+		"PENDING": InstallmentPending,
+		"PARTIAL": InstallmentPartial,
+		"PAID":    InstallmentPaid,
+	}
+}
+
+func (is *InstallmentStatus) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*is = is.getMap()[string(v)]
+	case string:
+		*is = is.getMap()[v]
+	default:
+		return errors.New("failed to scan installment status")
+	}
+
+	return nil
+}
@@ -1,7 +1,9 @@
 package sqlentity
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"errors"
 
 	"github.com/shopspring/decimal"
 )
@@ -11,6 +13,13 @@ type LoanInvestment struct {
 	LoanID     uint64
 	InvestorID uint64
 	Amount     decimal.Decimal
+
+	// AgreementLetterDocumentKey addresses the investor's generated agreement letter in the document store.
+	AgreementLetterDocumentKey sql.NullString
+
+	Status      LoanInvestmentStatus
+	CancelledBy sql.NullInt64
+	CancelledAt sql.NullTime
 }
 
 func (l LoanInvestment) Columns() []any {
@@ -19,6 +28,10 @@ func (l LoanInvestment) Columns() []any {
 		"loan_id",
 		"investor_id",
 		"amount",
+		"agreement_letter_document_key",
+		"status",
+		"cancelled_by",
+		"cancelled_at",
 	}
 }
 
@@ -36,10 +49,14 @@ func (l LoanInvestment) StringColumns() []string {
 
 func (l *LoanInvestment) Values() []any {
 	return []any{
-		l.ID,
-		l.LoanID,
-		l.InvestorID,
-		l.Amount,
+		&l.ID,
+		&l.LoanID,
+		&l.InvestorID,
+		&l.Amount,
+		&l.AgreementLetterDocumentKey,
+		&l.Status,
+		&l.CancelledBy,
+		&l.CancelledAt,
 	}
 }
 
@@ -51,3 +68,209 @@ func (l *LoanInvestment) DriverValues() []driver.Value {
 
 	return vals
 }
+
+type LoanInvestments []LoanInvestment
+
+func (l LoanInvestments) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanInvestments) Len() int {
+	return len(l)
+}
+
+func (l LoanInvestments) First() LoanInvestment {
+	if l.IsEmpty() {
+		return LoanInvestment{}
+	}
+
+	return l[0]
+}
+
+type UpdateLoanInvestmentAgreementLetter struct {
+	AgreementLetterDocumentKey sql.NullString
+}
+
+func (a UpdateLoanInvestmentAgreementLetter) Columns() []any {
+	return []any{
+		"agreement_letter_document_key",
+	}
+}
+
+func (a UpdateLoanInvestmentAgreementLetter) StringColumns() []string {
+	vals := make([]string, len(a.Columns()))
+	for i, col := range a.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (a *UpdateLoanInvestmentAgreementLetter) Values() []any {
+	return []any{
+		a.AgreementLetterDocumentKey,
+	}
+}
+
+func (a UpdateLoanInvestmentAgreementLetter) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(a.Values()))
+	for i, v := range a.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (a UpdateLoanInvestmentAgreementLetter) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := a.StringColumns()
+	for i, col := range cols {
+		vals[col] = a.DriverValues()[i]
+	}
+
+	return vals
+}
+
+// CancelLoanInvestment marks an investment cancelled by an investor before the loan was fully funded.
+type CancelLoanInvestment struct {
+	CancelledBy sql.NullInt64
+	CancelledAt sql.NullTime
+}
+
+func (a CancelLoanInvestment) Columns() []any {
+	return []any{
+		"status",
+		"cancelled_by",
+		"cancelled_at",
+	}
+}
+
+func (a CancelLoanInvestment) StringColumns() []string {
+	vals := make([]string, len(a.Columns()))
+	for i, col := range a.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (a *CancelLoanInvestment) Values() []any {
+	return []any{
+		InvestmentCancelled,
+		a.CancelledBy,
+		a.CancelledAt,
+	}
+}
+
+func (a CancelLoanInvestment) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(a.Values()))
+	for i, v := range a.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (a CancelLoanInvestment) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := a.StringColumns()
+	for i, col := range cols {
+		vals[col] = a.DriverValues()[i]
+	}
+
+	return vals
+}
+
+// ReleaseLoanInvestment marks an investment whose money went back to the investor because its loan expired.
+type ReleaseLoanInvestment struct{}
+
+func (a ReleaseLoanInvestment) Columns() []any {
+	return []any{
+		"status",
+	}
+}
+
+func (a ReleaseLoanInvestment) StringColumns() []string {
+	vals := make([]string, len(a.Columns()))
+	for i, col := range a.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (a *ReleaseLoanInvestment) Values() []any {
+	return []any{
+		InvestmentReleased,
+	}
+}
+
+func (a ReleaseLoanInvestment) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(a.Values()))
+	for i, v := range a.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (a ReleaseLoanInvestment) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := a.StringColumns()
+	for i, col := range cols {
+		vals[col] = a.DriverValues()[i]
+	}
+
+	return vals
+}
+
+type LoanInvestmentStatus int
+
+const (
+	UnknownLoanInvestmentStatus LoanInvestmentStatus = iota
+	// InvestmentActive is an investment counted in the invested amount of its loan.
+	InvestmentActive
+	// InvestmentCancelled is an investment withdrawn by its investor while the loan was still open for investment.
+	InvestmentCancelled
+	// InvestmentReleased is an investment given back to its investor because the loan expired before being funded.
+	InvestmentReleased
+)
+
+func (is LoanInvestmentStatus) String() string {
+	return [...]string{"UNKNOWN", "ACTIVE", "CANCELLED", "RELEASED"}[is]
+}
+
+func (is LoanInvestmentStatus) Value() (driver.Value, error) {
+	return is.String(), nil
+}
+
+func (is LoanInvestmentStatus) getMap() map[string]LoanInvestmentStatus {
+	return map[string]LoanInvestmentStatus{
+		"UNKNOWN":   UnknownLoanInvestmentStatus,
+		"ACTIVE":    InvestmentActive,
+		"CANCELLED": InvestmentCancelled,
+		"RELEASED":  InvestmentReleased,
+	}
+}
+
+func (is *LoanInvestmentStatus) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*is = is.getMap()[string(v)]
+	case string:
+		*is = is.getMap()[v]
+	default:
+		return errors.New("failed to scan loan investment status")
+	}
+
+	return nil
+}
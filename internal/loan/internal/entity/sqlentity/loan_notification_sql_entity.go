@@ -0,0 +1,191 @@
+package sqlentity
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// AgreementLetterNotification is the template of the email sending an investor their agreement letter.
+const AgreementLetterNotification = "agreement_letter"
+
+// LoanNotification records the delivery of one templated message to the investor of an investment. Attempts counts
+// every send tried so far and LastError keeps why the latest one failed.
+type LoanNotification struct {
+	ID           uint64
+	LoanID       uint64
+	InvestmentID uint64
+	UserID       uint64
+	Template     string
+	Recipient    string
+	Status       NotificationStatus
+	Attempts     int
+	LastError    sql.NullString
+	SentAt       sql.NullTime
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (l LoanNotification) Columns() []any {
+	return []any{
+		"id",
+		"loan_id",
+		"investment_id",
+		"user_id",
+		"template",
+		"recipient",
+		"status",
+		"attempts",
+		"last_error",
+		"sent_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func (l LoanNotification) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanNotification) Values() []any {
+	return []any{
+		&l.ID,
+		&l.LoanID,
+		&l.InvestmentID,
+		&l.UserID,
+		&l.Template,
+		&l.Recipient,
+		&l.Status,
+		&l.Attempts,
+		&l.LastError,
+		&l.SentAt,
+		&l.CreatedAt,
+		&l.UpdatedAt,
+	}
+}
+
+func (l *LoanNotification) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanNotifications []LoanNotification
+
+func (l LoanNotifications) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanNotifications) Len() int {
+	return len(l)
+}
+
+type UpdateLoanNotificationDelivery struct {
+	Status    NotificationStatus
+	Attempts  int
+	LastError sql.NullString
+	SentAt    sql.NullTime
+	UpdatedAt time.Time
+}
+
+func (u UpdateLoanNotificationDelivery) Columns() []any {
+	return []any{
+		"status",
+		"attempts",
+		"last_error",
+		"sent_at",
+		"updated_at",
+	}
+}
+
+func (u UpdateLoanNotificationDelivery) StringColumns() []string {
+	vals := make([]string, len(u.Columns()))
+	for i, col := range u.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (u *UpdateLoanNotificationDelivery) Values() []any {
+	return []any{
+		u.Status,
+		u.Attempts,
+		u.LastError,
+		u.SentAt,
+		u.UpdatedAt,
+	}
+}
+
+func (u UpdateLoanNotificationDelivery) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(u.Values()))
+	for i, v := range u.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (u UpdateLoanNotificationDelivery) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := u.StringColumns()
+	for i, col := range cols {
+		vals[col] = u.DriverValues()[i]
+	}
+
+	return vals
+}
+
+type NotificationStatus int
+
+const (
+	UnknownNotificationStatus NotificationStatus = iota
+	NotificationPending
+	NotificationSent
+	NotificationFailed
+)
+
+func (s NotificationStatus) String() string {
+	return [...]string{"UNKNOWN", "PENDING", "SENT", "FAILED"}[s]
+}
+
+func (s NotificationStatus) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+func (s NotificationStatus) getMap() map[string]NotificationStatus {
+	return map[string]NotificationStatus{
+		"UNKNOWN": UnknownNotificationStatus,
+		"PENDING": NotificationPending,
+		"SENT":    NotificationSent,
+		"FAILED":  NotificationFailed,
+	}
+}
+
+func (s *NotificationStatus) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*s = s.getMap()[string(v)]
+	case string:
+		*s = s.getMap()[v]
+	default:
+		return errors.New("failed to scan notification status")
+	}
+
+	return nil
+}
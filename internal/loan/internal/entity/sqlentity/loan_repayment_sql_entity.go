@@ -0,0 +1,216 @@
+package sqlentity
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LoanRepayment is a payment of the borrower. PrincipalAmount and InterestAmount are what it repaid of the
+// installments, OverpaidAmount what was left of it once every installment was paid.
+type LoanRepayment struct {
+	ID              uint64
+	LoanID          uint64
+	BorrowerID      uint64
+	Amount          decimal.Decimal
+	PrincipalAmount decimal.Decimal
+	InterestAmount  decimal.Decimal
+	OverpaidAmount  decimal.Decimal
+	PaidAt          time.Time
+}
+
+func (l LoanRepayment) Columns() []any {
+	return []any{
+		"id",
+		"loan_id",
+		"borrower_id",
+		"amount",
+		"principal_amount",
+		"interest_amount",
+		"overpaid_amount",
+		"paid_at",
+	}
+}
+
+func (l LoanRepayment) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanRepayment) Values() []any {
+	return []any{
+		&l.ID,
+		&l.LoanID,
+		&l.BorrowerID,
+		&l.Amount,
+		&l.PrincipalAmount,
+		&l.InterestAmount,
+		&l.OverpaidAmount,
+		&l.PaidAt,
+	}
+}
+
+func (l *LoanRepayment) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanRepayments []LoanRepayment
+
+func (l LoanRepayments) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanRepayments) Len() int {
+	return len(l)
+}
+
+func (l LoanRepayments) First() LoanRepayment {
+	if l.IsEmpty() {
+		return LoanRepayment{}
+	}
+
+	return l[0]
+}
+
+// LoanRepaymentAllocation is the part of a repayment that went to one installment.
+type LoanRepaymentAllocation struct {
+	ID              uint64
+	RepaymentID     uint64
+	InstallmentID   uint64
+	PrincipalAmount decimal.Decimal
+	InterestAmount  decimal.Decimal
+}
+
+func (l LoanRepaymentAllocation) Columns() []any {
+	return []any{
+		"id",
+		"repayment_id",
+		"installment_id",
+		"principal_amount",
+		"interest_amount",
+	}
+}
+
+func (l LoanRepaymentAllocation) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanRepaymentAllocation) Values() []any {
+	return []any{
+		&l.ID,
+		&l.RepaymentID,
+		&l.InstallmentID,
+		&l.PrincipalAmount,
+		&l.InterestAmount,
+	}
+}
+
+func (l *LoanRepaymentAllocation) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanRepaymentAllocations []LoanRepaymentAllocation
+
+func (l LoanRepaymentAllocations) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanRepaymentAllocations) Len() int {
+	return len(l)
+}
+
+// LoanInvestorCredit is the part of a repayment credited to one investment. InterestAmount is net of the platform
+// FeeAmount.
+type LoanInvestorCredit struct {
+	ID              uint64
+	LoanID          uint64
+	RepaymentID     uint64
+	InvestmentID    uint64
+	InvestorID      uint64
+	PrincipalAmount decimal.Decimal
+	InterestAmount  decimal.Decimal
+	FeeAmount       decimal.Decimal
+}
+
+func (l LoanInvestorCredit) Columns() []any {
+	return []any{
+		"id",
+		"loan_id",
+		"repayment_id",
+		"investment_id",
+		"investor_id",
+		"principal_amount",
+		"interest_amount",
+		"fee_amount",
+	}
+}
+
+func (l LoanInvestorCredit) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanInvestorCredit) Values() []any {
+	return []any{
+		&l.ID,
+		&l.LoanID,
+		&l.RepaymentID,
+		&l.InvestmentID,
+		&l.InvestorID,
+		&l.PrincipalAmount,
+		&l.InterestAmount,
+		&l.FeeAmount,
+	}
+}
+
+func (l *LoanInvestorCredit) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanInvestorCredits []LoanInvestorCredit
+
+func (l LoanInvestorCredits) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanInvestorCredits) Len() int {
+	return len(l)
+}
@@ -15,11 +15,19 @@ type Loan struct {
 	PrincipalAmount            decimal.Decimal
 	InvestedAmount             decimal.Decimal
 	InterestRate               decimal.Decimal
+	TenorMonths                sql.NullInt32
+	RepaymentMethod            RepaymentMethod
 	Status                     LoanStatus
 	ApprovalDate               sql.NullTime
 	ApprovalEmployeeID         sql.NullInt64
 	DisbursementDate           sql.NullTime
 	AgreementLetterDocumentURL sql.NullString
+	ApprovalProofDocumentKey   sql.NullString
+	FundingDeadline            sql.NullTime
+	RejectionDate              sql.NullTime
+	RejectionEmployeeID        sql.NullInt64
+	RejectionReason            sql.NullString
+	Version                    uint64
 	CreatedAt                  time.Time
 	UpdatedAt                  time.Time
 }
@@ -31,11 +39,19 @@ func (l Loan) Columns() []any {
 		"principal_amount",
 		"invested_amount",
 		"interest_rate",
+		"tenor_months",
+		"repayment_method",
 		"status",
 		"approval_date",
 		"approval_employee_id",
 		"disbursement_date",
 		"agreement_letter_document_url",
+		"approval_proof_document_key",
+		"funding_deadline",
+		"rejection_date",
+		"rejection_employee_id",
+		"rejection_reason",
+		"version",
 	}
 }
 
@@ -58,11 +74,19 @@ func (l *Loan) Values() []any {
 		&l.PrincipalAmount,
 		&l.InvestedAmount,
 		&l.InterestRate,
+		&l.TenorMonths,
+		&l.RepaymentMethod,
 		&l.Status,
 		&l.ApprovalDate,
 		&l.ApprovalEmployeeID,
 		&l.DisbursementDate,
 		&l.AgreementLetterDocumentURL,
+		&l.ApprovalProofDocumentKey,
+		&l.FundingDeadline,
+		&l.RejectionDate,
+		&l.RejectionEmployeeID,
+		&l.RejectionReason,
+		&l.Version,
 	}
 }
 
@@ -101,10 +125,18 @@ const (
 	Approved
 	Invested
 	Disbursed
+	Rejected
+	Repaid
+	Late
+	Defaulted
+	Expired
 )
 
 func (ls LoanStatus) String() string {
-	return [...]string{"UNKNOWN", "PROPOSED", "APPROVED", "INVESTED", "DISBURSED"}[ls]
+	return [...]string{
+		"UNKNOWN", "PROPOSED", "APPROVED", "INVESTED", "DISBURSED", "REJECTED", "REPAID", "LATE", "DEFAULTED",
+		"EXPIRED",
+	}[ls]
 }
 
 func (ls LoanStatus) Value() (driver.Value, error) {
@@ -118,9 +150,18 @@ func (ls LoanStatus) getMap() map[string]LoanStatus {
 		"APPROVED":  Approved,
 		"INVESTED":  Invested,
 		"DISBURSED": Disbursed,
+		"REJECTED":  Rejected,
+		"REPAID":    Repaid,
+		"LATE":      Late,
+		"DEFAULTED": Defaulted,
+		"EXPIRED":   Expired,
 	}
 }
 
+func LoanStatusFromString(s string) LoanStatus {
+	return UnknownStatus.getMap()[s]
+}
+
 func (ls *LoanStatus) Scan(value any) error {
 	b, ok := value.([]byte)
 	if ok {
@@ -134,9 +175,56 @@ func (ls *LoanStatus) Scan(value any) error {
 	return errors.New("failed to scan loan status")
 }
 
+// RepaymentMethod is how the interest of a loan is spread over its installments.
+type RepaymentMethod int
+
+const (
+	UnknownRepaymentMethod RepaymentMethod = iota
+	// FlatRepayment charges interest on the original principal every month.
+	FlatRepayment
+	// AnnuityRepayment charges interest on the outstanding balance with equal installments, the effective method.
+	AnnuityRepayment
+)
+
+func (rm RepaymentMethod) String() string {
+	return [...]string{"UNKNOWN", "FLAT", "ANNUITY"}[rm]
+}
+
+func (rm RepaymentMethod) Value() (driver.Value, error) {
+	return rm.String(), nil
+}
+
+func (rm RepaymentMethod) getMap() map[string]RepaymentMethod {
+	return map[string]RepaymentMethod{
+		"UNKNOWN": UnknownRepaymentMethod,
+		"FLAT":    FlatRepayment,
+		"ANNUITY": AnnuityRepayment,
+	}
+}
+
+func RepaymentMethodFromString(s string) RepaymentMethod {
+	return UnknownRepaymentMethod.getMap()[s]
+}
+
+func (rm *RepaymentMethod) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*rm = rm.getMap()[string(v)]
+	case string:
+		*rm = rm.getMap()[v]
+	default:
+		return errors.New("failed to scan repayment method")
+	}
+
+	return nil
+}
+
 type ApproveLoan struct {
-	ApprovalDate       sql.NullTime
-	ApprovalEmployeeID sql.NullInt64
+	ApprovalDate             sql.NullTime
+	ApprovalEmployeeID       sql.NullInt64
+	ApprovalProofDocumentKey sql.NullString
+	FundingDeadline          sql.NullTime
+	Version                  uint64
 }
 
 func (a ApproveLoan) Columns() []any {
@@ -144,6 +232,9 @@ func (a ApproveLoan) Columns() []any {
 		"status",
 		"approval_date",
 		"approval_employee_id",
+		"approval_proof_document_key",
+		"funding_deadline",
+		"version",
 	}
 }
 
@@ -164,6 +255,9 @@ func (a *ApproveLoan) Values() []any {
 		Approved,
 		a.ApprovalDate,
 		a.ApprovalEmployeeID,
+		a.ApprovalProofDocumentKey,
+		a.FundingDeadline,
+		a.Version,
 	}
 }
 
@@ -186,13 +280,123 @@ func (a ApproveLoan) MappedValues() map[string]driver.Value {
 	return vals
 }
 
+type RejectLoan struct {
+	RejectionDate       sql.NullTime
+	RejectionEmployeeID sql.NullInt64
+	RejectionReason     sql.NullString
+	Version             uint64
+}
+
+func (a RejectLoan) Columns() []any {
+	return []any{
+		"status",
+		"rejection_date",
+		"rejection_employee_id",
+		"rejection_reason",
+		"version",
+	}
+}
+
+func (a RejectLoan) StringColumns() []string {
+	vals := make([]string, len(a.Columns()))
+	for i, col := range a.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (a *RejectLoan) Values() []any {
+	return []any{
+		Rejected,
+		a.RejectionDate,
+		a.RejectionEmployeeID,
+		a.RejectionReason,
+		a.Version,
+	}
+}
+
+func (a RejectLoan) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(a.Values()))
+	for i, v := range a.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (a RejectLoan) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := a.StringColumns()
+	for i, col := range cols {
+		vals[col] = a.DriverValues()[i]
+	}
+
+	return vals
+}
+
+// ExpireLoan ends an approved loan whose funding deadline passed before it was fully funded.
+type ExpireLoan struct {
+	Version uint64
+}
+
+func (a ExpireLoan) Columns() []any {
+	return []any{
+		"status",
+		"version",
+	}
+}
+
+func (a ExpireLoan) StringColumns() []string {
+	vals := make([]string, len(a.Columns()))
+	for i, col := range a.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (a *ExpireLoan) Values() []any {
+	return []any{
+		Expired,
+		a.Version,
+	}
+}
+
+func (a ExpireLoan) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(a.Values()))
+	for i, v := range a.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (a ExpireLoan) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := a.StringColumns()
+	for i, col := range cols {
+		vals[col] = a.DriverValues()[i]
+	}
+
+	return vals
+}
+
 type UpdateAmountLoan struct {
-	Amount decimal.Decimal
+	Amount  decimal.Decimal
+	Version uint64
 }
 
 func (a UpdateAmountLoan) Columns() []any {
 	return []any{
 		"invested_amount",
+		"version",
 	}
 }
 
@@ -211,6 +415,7 @@ func (a UpdateAmountLoan) StringColumns() []string {
 func (a *UpdateAmountLoan) Values() []any {
 	return []any{
 		a.Amount,
+		a.Version,
 	}
 }
 
@@ -280,9 +485,61 @@ func (a UpdateLoanStatus) MappedValues() map[string]driver.Value {
 	return vals
 }
 
+// UpdateRepaidLoan bumps the version of a loan a repayment is recorded against, and its status once it is repaid.
+type UpdateRepaidLoan struct {
+	Status  LoanStatus
+	Version uint64
+}
+
+func (a UpdateRepaidLoan) Columns() []any {
+	return []any{
+		"status",
+		"version",
+	}
+}
+
+func (a UpdateRepaidLoan) StringColumns() []string {
+	vals := make([]string, len(a.Columns()))
+	for i, col := range a.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (a *UpdateRepaidLoan) Values() []any {
+	return []any{
+		a.Status,
+		a.Version,
+	}
+}
+
+func (a UpdateRepaidLoan) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(a.Values()))
+	for i, v := range a.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (a UpdateRepaidLoan) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := a.StringColumns()
+	for i, col := range cols {
+		vals[col] = a.DriverValues()[i]
+	}
+
+	return vals
+}
+
 type DisburseLoan struct {
 	DisburesmentDate           sql.NullTime
 	AgreementLetterDocumentURL sql.NullString
+	Version                    uint64
 }
 
 func (a DisburseLoan) Columns() []any {
@@ -290,6 +547,7 @@ func (a DisburseLoan) Columns() []any {
 		"status",
 		"disbursement_date",
 		"agreement_letter_document_url",
+		"version",
 	}
 }
 
@@ -310,6 +568,7 @@ func (a *DisburseLoan) Values() []any {
 		Disbursed,
 		a.DisburesmentDate,
 		a.AgreementLetterDocumentURL,
+		a.Version,
 	}
 }
 
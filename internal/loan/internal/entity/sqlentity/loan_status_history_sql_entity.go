@@ -0,0 +1,71 @@
+package sqlentity
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+type LoanStatusHistory struct {
+	ID          uint64
+	LoanID      uint64
+	FromStatus  LoanStatus
+	ToStatus    LoanStatus
+	ActorUserID uint64
+	Reason      string
+	CreatedAt   time.Time
+}
+
+func (l LoanStatusHistory) Columns() []any {
+	return []any{
+		"id",
+		"loan_id",
+		"from_status",
+		"to_status",
+		"actor_user_id",
+		"reason",
+		"created_at",
+	}
+}
+
+func (l LoanStatusHistory) StringColumns() []string {
+	vals := make([]string, len(l.Columns()))
+	for i, col := range l.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (l *LoanStatusHistory) Values() []any {
+	return []any{
+		&l.ID,
+		&l.LoanID,
+		&l.FromStatus,
+		&l.ToStatus,
+		&l.ActorUserID,
+		&l.Reason,
+		&l.CreatedAt,
+	}
+}
+
+func (l *LoanStatusHistory) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(l.Values()))
+	for i, v := range l.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type LoanStatusHistories []LoanStatusHistory
+
+func (l LoanStatusHistories) IsEmpty() bool {
+	return l.Len() == 0
+}
+
+func (l LoanStatusHistories) Len() int {
+	return len(l)
+}
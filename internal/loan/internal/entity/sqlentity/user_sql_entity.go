@@ -1,23 +1,29 @@
 package sqlentity
 
 import (
+	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"time"
 )
 
 type User struct {
-	ID        uint64
-	Name      string
-	Type      UserType
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID            uint64
+	Name          string
+	Email         sql.NullString
+	Type          UserType
+	DeactivatedAt sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 func (u User) Columns() []any {
 	return []any{
 		"id",
 		"name",
+		"email",
 		"type",
+		"deactivated_at",
 		"created_at",
 		"updated_at",
 	}
@@ -37,11 +43,13 @@ func (u User) StringColumns() []string {
 
 func (u *User) Values() []any {
 	return []any{
-		u.ID,
-		u.Name,
-		u.Type,
-		u.CreatedAt,
-		u.UpdatedAt,
+		&u.ID,
+		&u.Name,
+		&u.Email,
+		&u.Type,
+		&u.DeactivatedAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
 	}
 }
 
@@ -54,6 +62,24 @@ func (u *User) DriverValues() []driver.Value {
 	return vals
 }
 
+type Users []User
+
+func (u Users) IsEmpty() bool {
+	return u.Len() == 0
+}
+
+func (u Users) Len() int {
+	return len(u)
+}
+
+func (u Users) First() User {
+	if u.IsEmpty() {
+		return User{}
+	}
+
+	return u[0]
+}
+
 type UserType int
 
 const (
@@ -64,7 +90,7 @@ const (
 )
 
 func (ut UserType) String() string {
-	return [...]string{"Unknown", "Borrower", "Investor", "Employee"}[ut]
+	return [...]string{"unknown", "borrower", "investor", "employee"}[ut]
 }
 
 func (ut UserType) Value() (driver.Value, error) {
@@ -73,19 +99,21 @@ func (ut UserType) Value() (driver.Value, error) {
 
 func (ut UserType) getMap() map[string]UserType {
 	return map[string]UserType{
-		"Unknown":  Unknown,
-		"Borrower": Borrower,
-		"Investor": Investor,
-		"Employee": Employee,
+		"unknown":  Unknown,
+		"borrower": Borrower,
+		"investor": Investor,
+		"employee": Employee,
 	}
 }
 
 func (ut *UserType) Scan(value any) error {
-	s, ok := value.(string)
-	if ok {
-		val := ut.getMap()[s]
-
-		*ut = val
+	switch v := value.(type) {
+	case []byte:
+		*ut = ut.getMap()[string(v)]
+	case string:
+		*ut = ut.getMap()[v]
+	default:
+		return errors.New("failed to scan user type")
 	}
 
 	return nil
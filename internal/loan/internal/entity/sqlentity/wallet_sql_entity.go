@@ -0,0 +1,277 @@
+package sqlentity
+
+import (
+	"database/sql/driver"
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+// Wallet is the money of an investor, Balance includes the ReservedAmount held for their pending investments.
+type Wallet struct {
+	InvestorID     uint64
+	Balance        decimal.Decimal
+	ReservedAmount decimal.Decimal
+}
+
+func (w Wallet) Columns() []any {
+	return []any{
+		"investor_id",
+		"balance",
+		"reserved_amount",
+	}
+}
+
+func (w Wallet) StringColumns() []string {
+	vals := make([]string, len(w.Columns()))
+	for i, col := range w.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (w *Wallet) Values() []any {
+	return []any{
+		&w.InvestorID,
+		&w.Balance,
+		&w.ReservedAmount,
+	}
+}
+
+func (w *Wallet) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(w.Values()))
+	for i, v := range w.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+// AvailableAmount is the money the investor can still invest or withdraw.
+func (w Wallet) AvailableAmount() decimal.Decimal {
+	return w.Balance.Sub(w.ReservedAmount)
+}
+
+type Wallets []Wallet
+
+func (w Wallets) IsEmpty() bool {
+	return w.Len() == 0
+}
+
+func (w Wallets) Len() int {
+	return len(w)
+}
+
+func (w Wallets) First() Wallet {
+	if w.IsEmpty() {
+		return Wallet{}
+	}
+
+	return w[0]
+}
+
+type UpdateWalletBalance struct {
+	Balance        decimal.Decimal
+	ReservedAmount decimal.Decimal
+}
+
+func (u UpdateWalletBalance) Columns() []any {
+	return []any{
+		"balance",
+		"reserved_amount",
+	}
+}
+
+func (u UpdateWalletBalance) StringColumns() []string {
+	vals := make([]string, len(u.Columns()))
+	for i, col := range u.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (u *UpdateWalletBalance) Values() []any {
+	return []any{
+		u.Balance,
+		u.ReservedAmount,
+	}
+}
+
+func (u UpdateWalletBalance) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(u.Values()))
+	for i, v := range u.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (u UpdateWalletBalance) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := u.StringColumns()
+	for i, col := range cols {
+		vals[col] = u.DriverValues()[i]
+	}
+
+	return vals
+}
+
+// WalletReservation holds the money of an investment in the wallet of the investor until the loan is fully funded.
+type WalletReservation struct {
+	ID           uint64
+	InvestorID   uint64
+	LoanID       uint64
+	InvestmentID uint64
+	Amount       decimal.Decimal
+	Status       WalletReservationStatus
+}
+
+func (w WalletReservation) Columns() []any {
+	return []any{
+		"id",
+		"investor_id",
+		"loan_id",
+		"investment_id",
+		"amount",
+		"status",
+	}
+}
+
+func (w WalletReservation) StringColumns() []string {
+	vals := make([]string, len(w.Columns()))
+	for i, col := range w.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (w *WalletReservation) Values() []any {
+	return []any{
+		&w.ID,
+		&w.InvestorID,
+		&w.LoanID,
+		&w.InvestmentID,
+		&w.Amount,
+		&w.Status,
+	}
+}
+
+func (w *WalletReservation) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(w.Values()))
+	for i, v := range w.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type WalletReservations []WalletReservation
+
+func (w WalletReservations) IsEmpty() bool {
+	return w.Len() == 0
+}
+
+func (w WalletReservations) Len() int {
+	return len(w)
+}
+
+type UpdateWalletReservationStatus struct {
+	Status WalletReservationStatus
+}
+
+func (u UpdateWalletReservationStatus) Columns() []any {
+	return []any{
+		"status",
+	}
+}
+
+func (u UpdateWalletReservationStatus) StringColumns() []string {
+	vals := make([]string, len(u.Columns()))
+	for i, col := range u.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (u *UpdateWalletReservationStatus) Values() []any {
+	return []any{
+		u.Status,
+	}
+}
+
+func (u UpdateWalletReservationStatus) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(u.Values()))
+	for i, v := range u.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (u UpdateWalletReservationStatus) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := u.StringColumns()
+	for i, col := range cols {
+		vals[col] = u.DriverValues()[i]
+	}
+
+	return vals
+}
+
+type WalletReservationStatus int
+
+const (
+	UnknownWalletReservationStatus WalletReservationStatus = iota
+	// ReservationHeld is money held for an investment in a loan not fully funded yet.
+	ReservationHeld
+	// ReservationCaptured is money moved to the escrow once the loan was fully funded.
+	ReservationCaptured
+	// ReservationReleased is money given back to the wallet because the investment will not be funded.
+	ReservationReleased
+)
+
+func (ws WalletReservationStatus) String() string {
+	return [...]string{"UNKNOWN", "HELD", "CAPTURED", "RELEASED"}[ws]
+}
+
+func (ws WalletReservationStatus) Value() (driver.Value, error) {
+	return ws.String(), nil
+}
+
+func (ws WalletReservationStatus) getMap() map[string]WalletReservationStatus {
+	return map[string]WalletReservationStatus{
+		"UNKNOWN":  UnknownWalletReservationStatus,
+		"HELD":     ReservationHeld,
+		"CAPTURED": ReservationCaptured,
+		"RELEASED": ReservationReleased,
+	}
+}
+
+func (ws *WalletReservationStatus) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*ws = ws.getMap()[string(v)]
+	case string:
+		*ws = ws.getMap()[v]
+	default:
+		return errors.New("failed to scan wallet reservation status")
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package event defines the domain events of the loan module. They are recorded in the outbox together with the state
+// change they describe and published once it commits.
+package event
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AggregateLoan is the aggregate type of every loan event, their aggregate id is the loan id.
+const AggregateLoan = "loan"
+
+const (
+	LoanProposed       = "LoanProposed"
+	LoanApproved       = "LoanApproved"
+	LoanInvestmentMade = "LoanInvestmentMade"
+	LoanFullyFunded    = "LoanFullyFunded"
+	LoanDisbursed      = "LoanDisbursed"
+	LoanRepaymentMade  = "LoanRepaymentMade"
+	LoanRepaid         = "LoanRepaid"
+	LoanExpired        = "LoanExpired"
+
+	LoanInvestmentCancelled = "LoanInvestmentCancelled"
+)
+
+// Types lists every loan event type, in the order a loan goes through them.
+func Types() []string {
+	return []string{
+		LoanProposed, LoanApproved, LoanInvestmentMade, LoanInvestmentCancelled, LoanFullyFunded, LoanDisbursed,
+		LoanRepaymentMade, LoanRepaid, LoanExpired,
+	}
+}
+
+type (
+	LoanProposedPayload struct {
+		LoanID          uint64          `json:"loan_id"`
+		BorrowerID      uint64          `json:"borrower_id"`
+		PrincipalAmount decimal.Decimal `json:"principal_amount"`
+		InterestRate    decimal.Decimal `json:"interest_rate"`
+		TenorMonths     int             `json:"tenor_months"`
+		RepaymentMethod string          `json:"repayment_method"`
+	}
+
+	LoanApprovedPayload struct {
+		LoanID          uint64    `json:"loan_id"`
+		EmployeeID      uint64    `json:"employee_id"`
+		ApprovalDate    time.Time `json:"approval_date"`
+		FundingDeadline time.Time `json:"funding_deadline"`
+	}
+
+	LoanInvestmentMadePayload struct {
+		LoanID       uint64          `json:"loan_id"`
+		InvestmentID uint64          `json:"investment_id"`
+		InvestorID   uint64          `json:"investor_id"`
+		Amount       decimal.Decimal `json:"amount"`
+	}
+
+	LoanInvestmentCancelledPayload struct {
+		LoanID       uint64          `json:"loan_id"`
+		InvestmentID uint64          `json:"investment_id"`
+		InvestorID   uint64          `json:"investor_id"`
+		Amount       decimal.Decimal `json:"amount"`
+		CancelledBy  uint64          `json:"cancelled_by"`
+	}
+
+	LoanFullyFundedPayload struct {
+		LoanID         uint64          `json:"loan_id"`
+		InvestedAmount decimal.Decimal `json:"invested_amount"`
+	}
+
+	LoanDisbursedPayload struct {
+		LoanID           uint64    `json:"loan_id"`
+		EmployeeID       uint64    `json:"employee_id"`
+		DisbursementDate time.Time `json:"disbursement_date"`
+	}
+
+	LoanRepaymentMadePayload struct {
+		LoanID            uint64          `json:"loan_id"`
+		RepaymentID       uint64          `json:"repayment_id"`
+		BorrowerID        uint64          `json:"borrower_id"`
+		Amount            decimal.Decimal `json:"amount"`
+		PrincipalAmount   decimal.Decimal `json:"principal_amount"`
+		InterestAmount    decimal.Decimal `json:"interest_amount"`
+		OverpaidAmount    decimal.Decimal `json:"overpaid_amount"`
+		OutstandingAmount decimal.Decimal `json:"outstanding_amount"`
+	}
+
+	LoanRepaidPayload struct {
+		LoanID     uint64    `json:"loan_id"`
+		RepaidDate time.Time `json:"repaid_date"`
+	}
+
+	LoanExpiredPayload struct {
+		LoanID          uint64          `json:"loan_id"`
+		InvestedAmount  decimal.Decimal `json:"invested_amount"`
+		FundingDeadline time.Time       `json:"funding_deadline"`
+	}
+)
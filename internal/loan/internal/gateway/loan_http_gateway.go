@@ -3,62 +3,164 @@ package gateway
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
-	"path/filepath"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/julienschmidt/httprouter"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkghttp/v1"
 	"go.uber.org/zap"
 )
 
+const (
+	// maxProofPhotoSize limits the field validator's proof photo to 5MB.
+	maxProofPhotoSize = 5 << 20
+	// maxAgreementLetterSize limits the signed agreement letter to 5MB.
+	maxAgreementLetterSize = 5 << 20
+)
+
 func NewLoanHTTPGateway(
 	httpRouter *httprouter.Router,
 	logger *zap.SugaredLogger,
 	loanHTTPEndpoint *LoanHTTPEndpoint,
 	validator *validator.Validate,
+	tokenVerifier pkgauth.TokenVerifier,
 ) {
 	server := pkghttp.NewServer(
 		pkghttp.WithResponseEncoder(pkghttp.CodeMessageResponseEncoder),
 		pkghttp.WithErrorResponseEncoder(pkghttp.CodeMessageErrorEncoder),
+		pkghttp.WithRequestDecoders(pkghttp.WithPopulateContextFromHeader),
+		pkghttp.WithPreRequestMiddlewares(pkghttp.WithAuthentication(tokenVerifier)),
 	)
 
+	borrowers := pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleBorrower))
+	investors := pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleInvestor))
+	employees := pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleEmployee))
+	// read endpoints are open to every role, the usecases scope them to the caller's own loans
+	everyone := pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleBorrower, pkgauth.RoleInvestor, pkgauth.RoleEmployee))
+
 	httpRouter.Handler(
 		http.MethodPost,
 		"/loan/:loan_id/approve",
-		server.Serve(loanHTTPEndpoint.ApproveLoan),
+		server.Serve(loanHTTPEndpoint.ApproveLoan, employees),
+	)
+
+	httpRouter.Handler(
+		http.MethodPost,
+		"/loan/:loan_id/reject",
+		server.Serve(loanHTTPEndpoint.RejectLoan, employees),
 	)
 
-	httpRouter.Handler(http.MethodPost, "/loan", server.Serve(loanHTTPEndpoint.CreateNewLoan))
+	httpRouter.Handler(http.MethodPost, "/loan", server.Serve(loanHTTPEndpoint.CreateNewLoan, borrowers))
 
 	httpRouter.Handler(
 		http.MethodPost,
 		"/loan/:loan_id/invest",
-		server.Serve(loanHTTPEndpoint.InvestLoan),
+		server.Serve(loanHTTPEndpoint.InvestLoan, investors),
+	)
+
+	httpRouter.Handler(
+		http.MethodDelete,
+		"/loan/:loan_id/investments/:investment_id",
+		server.Serve(loanHTTPEndpoint.CancelLoanInvestment, investors),
 	)
 
 	httpRouter.Handler(
 		http.MethodPost,
 		"/loan/:loan_id/disburse",
-		server.Serve(loanHTTPEndpoint.DisburseLoan),
+		server.Serve(loanHTTPEndpoint.DisburseLoan, employees),
+	)
+
+	httpRouter.Handler(
+		http.MethodPost,
+		"/loan/:loan_id/repayments",
+		server.Serve(loanHTTPEndpoint.RepayLoan, borrowers),
 	)
 
 	httpRouter.Handler(
 		http.MethodPost,
 		"/loan/:loan_id/upload-agreement-letter",
-		server.Serve(loanHTTPEndpoint.UploadAgreementLetter),
+		server.Serve(loanHTTPEndpoint.UploadAgreementLetter, employees),
 	)
+
+	httpRouter.Handler(http.MethodGet, "/loan", server.Serve(loanHTTPEndpoint.ListLoans, everyone))
+
+	httpRouter.Handler(
+		http.MethodGet,
+		"/loan/:loan_id",
+		server.Serve(loanHTTPEndpoint.GetLoanDetail, everyone),
+	)
+
+	httpRouter.Handler(
+		http.MethodGet,
+		"/loan/:loan_id/history",
+		server.Serve(loanHTTPEndpoint.GetLoanStatusHistory, everyone),
+	)
+
+	httpRouter.Handler(
+		http.MethodGet,
+		"/loan/:loan_id/schedule",
+		server.Serve(loanHTTPEndpoint.GetLoanSchedule, everyone),
+	)
+
+	// borrowers have no share of the repayments
+	httpRouter.Handler(
+		http.MethodGet,
+		"/loan/:loan_id/distributions",
+		server.Serve(
+			loanHTTPEndpoint.GetLoanDistribution,
+			pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleInvestor, pkgauth.RoleEmployee)),
+		),
+	)
+
+	// investors only read their own portfolio
+	httpRouter.Handler(
+		http.MethodGet,
+		"/investors/:investor_id/portfolio",
+		server.Serve(
+			loanHTTPEndpoint.GetInvestorPortfolio,
+			pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleInvestor, pkgauth.RoleEmployee)),
+		),
+	)
+
+	httpRouter.Handler(
+		http.MethodGet,
+		"/ledger/trial-balance",
+		server.Serve(loanHTTPEndpoint.GetLedgerTrialBalance, employees),
+	)
+
+	httpRouter.Handler(http.MethodGet, "/wallet", server.Serve(loanHTTPEndpoint.GetWallet, investors))
+
+	httpRouter.Handler(http.MethodPost, "/wallet/top-up", server.Serve(loanHTTPEndpoint.TopUpWallet, investors))
+
+	httpRouter.Handler(http.MethodPost, "/wallet/withdraw", server.Serve(loanHTTPEndpoint.WithdrawWallet, investors))
 }
 
 type LoanHTTPEndpoint struct {
-	createProposedLoanUsecase usecase.CreateProposedLoan
-	approveLoanUsecase        usecase.ApprovedLoan
-	investLoanUsecase         usecase.InvestLoan
-	disburseLoanUsecase       usecase.DisburseLoan
+	createProposedLoanUsecase    usecase.CreateProposedLoan
+	approveLoanUsecase           usecase.ApprovedLoan
+	rejectLoanUsecase            usecase.RejectLoan
+	investLoanUsecase            usecase.InvestLoan
+	disburseLoanUsecase          usecase.DisburseLoan
+	repayLoanUsecase             usecase.RepayLoan
+	getLoanDetailUsecase         usecase.GetLoanDetail
+	listLoansUsecase             usecase.ListLoans
+	getLoanStatusHistoryUsecase  usecase.GetLoanStatusHistory
+	getLoanScheduleUsecase       usecase.GetLoanSchedule
+	getLoanDistributionUsecase   usecase.GetLoanDistribution
+	getInvestorPortfolioUsecase  usecase.GetInvestorPortfolio
+	getLedgerTrialBalanceUsecase usecase.GetLedgerTrialBalance
+	getWalletUsecase             usecase.GetWallet
+	topUpWalletUsecase           usecase.TopUpWallet
+	withdrawWalletUsecase        usecase.WithdrawWallet
+	cancelLoanInvestmentUsecase  usecase.CancelLoanInvestment
+	uploadAgreementLetterUsecase usecase.UploadAgreementLetter
 
 	validator *validator.Validate
 	logger    *zap.SugaredLogger
@@ -67,18 +169,46 @@ type LoanHTTPEndpoint struct {
 func NewLoanHTTPEndpoint(
 	createNewLoanUsecase usecase.CreateProposedLoan,
 	approveLoanUsecase usecase.ApprovedLoan,
+	rejectLoanUsecase usecase.RejectLoan,
 	investLoanUsecase usecase.InvestLoan,
 	disburseLoanUsecase usecase.DisburseLoan,
+	repayLoanUsecase usecase.RepayLoan,
+	getLoanDetailUsecase usecase.GetLoanDetail,
+	listLoansUsecase usecase.ListLoans,
+	getLoanStatusHistoryUsecase usecase.GetLoanStatusHistory,
+	getLoanScheduleUsecase usecase.GetLoanSchedule,
+	getLoanDistributionUsecase usecase.GetLoanDistribution,
+	getInvestorPortfolioUsecase usecase.GetInvestorPortfolio,
+	getLedgerTrialBalanceUsecase usecase.GetLedgerTrialBalance,
+	getWalletUsecase usecase.GetWallet,
+	topUpWalletUsecase usecase.TopUpWallet,
+	withdrawWalletUsecase usecase.WithdrawWallet,
+	cancelLoanInvestmentUsecase usecase.CancelLoanInvestment,
+	uploadAgreementLetterUsecase usecase.UploadAgreementLetter,
 
 	logger *zap.SugaredLogger,
 	validator *validator.Validate,
 
 ) *LoanHTTPEndpoint {
 	return &LoanHTTPEndpoint{
-		createProposedLoanUsecase: createNewLoanUsecase,
-		approveLoanUsecase:        approveLoanUsecase,
-		investLoanUsecase:         investLoanUsecase,
-		disburseLoanUsecase:       disburseLoanUsecase,
+		createProposedLoanUsecase:    createNewLoanUsecase,
+		approveLoanUsecase:           approveLoanUsecase,
+		rejectLoanUsecase:            rejectLoanUsecase,
+		investLoanUsecase:            investLoanUsecase,
+		disburseLoanUsecase:          disburseLoanUsecase,
+		repayLoanUsecase:             repayLoanUsecase,
+		getLoanDetailUsecase:         getLoanDetailUsecase,
+		listLoansUsecase:             listLoansUsecase,
+		getLoanStatusHistoryUsecase:  getLoanStatusHistoryUsecase,
+		getLoanScheduleUsecase:       getLoanScheduleUsecase,
+		getLoanDistributionUsecase:   getLoanDistributionUsecase,
+		getInvestorPortfolioUsecase:  getInvestorPortfolioUsecase,
+		getLedgerTrialBalanceUsecase: getLedgerTrialBalanceUsecase,
+		getWalletUsecase:             getWalletUsecase,
+		topUpWalletUsecase:           topUpWalletUsecase,
+		withdrawWalletUsecase:        withdrawWalletUsecase,
+		cancelLoanInvestmentUsecase:  cancelLoanInvestmentUsecase,
+		uploadAgreementLetterUsecase: uploadAgreementLetterUsecase,
 
 		logger:    logger,
 		validator: validator,
@@ -96,6 +226,13 @@ func (l *LoanHTTPEndpoint) CreateNewLoan(
 		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.UserID = principal.UserID
+
 	if err := l.validator.Struct(input); err != nil {
 		l.logger.Errorw("failed to validate request", "error", err)
 
@@ -111,17 +248,78 @@ func (l *LoanHTTPEndpoint) CreateNewLoan(
 	return nil, nil
 }
 
+// ApproveLoan expects a multipart form with the approval_date (YYYY-MM-DD) and the field validator's proof_photo.
 func (l *LoanHTTPEndpoint) ApproveLoan(
 	ctx context.Context,
 	request pkghttp.Request,
 ) (resp any, err error) {
 	var input usecase.ApprovedLoanInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.EmployeeID = principal.UserID
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	loanID := params.ByName("loan_id")
+
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	rawRequest := request.Raw()
+
+	if input.ProofPhoto, err = l.readFormFile(rawRequest, "proof_photo", maxProofPhotoSize); err != nil {
+		return nil, err
+	}
+
+	if v := rawRequest.FormValue("approval_date"); v != "" {
+		if input.ApprovalDate, err = time.Parse(time.DateOnly, v); err != nil {
+			l.logger.Errorw("failed to parse approval date", "error", err)
+
+			return nil, pkgerror.ValidationErrorFrom(fmt.Errorf("invalid approval_date: %w", err))
+		}
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.approveLoanUsecase.Execute(ctx, input); err != nil {
+		l.logger.Errorw("failed to approve loan", "error", err)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (l *LoanHTTPEndpoint) RejectLoan(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.RejectLoanInput
 	if err := request.Decode(&input); err != nil {
 		l.logger.Errorw("failed to decode request", "error", err)
 
 		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.EmployeeID = principal.UserID
+
 	params := httprouter.ParamsFromContext(ctx)
 
 	loanID := params.ByName("loan_id")
@@ -139,8 +337,8 @@ func (l *LoanHTTPEndpoint) ApproveLoan(
 		return nil, pkgerror.ValidationErrorFrom(err)
 	}
 
-	if err := l.approveLoanUsecase.Execute(ctx, input); err != nil {
-		l.logger.Errorw("failed to approve loan", "error", err)
+	if err := l.rejectLoanUsecase.Execute(ctx, input); err != nil {
+		l.logger.Errorw("failed to reject loan", "error", err)
 
 		return nil, err
 	}
@@ -159,6 +357,13 @@ func (l *LoanHTTPEndpoint) InvestLoan(
 		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.InvestorID = principal.UserID
+
 	if err := l.validator.Struct(input); err != nil {
 		l.logger.Errorw("failed to validate request", "error", err)
 
@@ -176,13 +381,108 @@ func (l *LoanHTTPEndpoint) InvestLoan(
 		return nil, pkgerror.ValidationErrorFrom(err)
 	}
 
-	if err := l.investLoanUsecase.Execute(ctx, input); err != nil {
+	out, err := l.investLoanUsecase.Execute(ctx, input)
+	if err != nil {
 		l.logger.Errorw("failed to invest loan", "error", err)
 
 		return nil, err
 	}
 
-	return nil, nil
+	return out, nil
+}
+
+func (l *LoanHTTPEndpoint) CancelLoanInvestment(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.CancelLoanInvestmentInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.InvestorID = principal.UserID
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	loanID := params.ByName("loan_id")
+
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	investmentID := params.ByName("investment_id")
+
+	input.InvestmentID, err = strconv.ParseUint(investmentID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse investment id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	out, err := l.cancelLoanInvestmentUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to cancel loan investment", "error", err)
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (l *LoanHTTPEndpoint) RepayLoan(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.RepayLoanInput
+	if err := request.Decode(&input); err != nil {
+		l.logger.Errorw("failed to decode request", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.BorrowerID = principal.UserID
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	loanID := params.ByName("loan_id")
+
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	out, err := l.repayLoanUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to repay loan", "error", err)
+
+		return nil, err
+	}
+
+	return out, nil
 }
 
 func (l *LoanHTTPEndpoint) DisburseLoan(
@@ -196,6 +496,13 @@ func (l *LoanHTTPEndpoint) DisburseLoan(
 		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.EmployeeID = principal.UserID
+
 	if err := l.validator.Struct(input); err != nil {
 		l.logger.Errorw("failed to validate request", "error", err)
 
@@ -222,69 +529,478 @@ func (l *LoanHTTPEndpoint) DisburseLoan(
 	return nil, nil
 }
 
+// UploadAgreementLetter expects a multipart form with the agreement_letter file and returns its key, which the
+// disbursement of the loan references.
 func (l *LoanHTTPEndpoint) UploadAgreementLetter(
 	ctx context.Context,
 	request pkghttp.Request,
 ) (resp any, err error) {
-	rawRequest := request.Raw()
+	var input usecase.UploadAgreementLetterInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.EmployeeID = principal.UserID
+
 	params := httprouter.ParamsFromContext(ctx)
 
-	// 5MB max file size
-	if err := rawRequest.ParseMultipartForm(5 << 20); err != nil {
-		l.logger.Errorw("failed to parse multipart form", "error", err)
+	input.LoanID, err = strconv.ParseUint(params.ByName("loan_id"), 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
 
-		return nil, pkgerror.ServerErrorFrom(err)
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if input.Content, err = l.readFormFile(request.Raw(), "agreement_letter", maxAgreementLetterSize); err != nil {
+		return nil, err
 	}
 
-	multipartFile, multipartHeader, err := rawRequest.FormFile("agreement_letter")
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	resp, err = l.uploadAgreementLetterUsecase.Execute(ctx, input)
 	if err != nil {
-		l.logger.Errorw("failed to get multipart file", "error", err)
+		l.logger.Errorw("failed to upload agreement letter", "error", err)
 
-		return nil, pkgerror.ServerErrorFrom(err)
+		return nil, err
 	}
-	defer multipartFile.Close()
 
-	workDir, err := os.Getwd()
+	return resp, nil
+}
+
+func (l *LoanHTTPEndpoint) GetLoanDetail(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.GetLoanDetailInput
+
+	principal, err := l.principal(ctx)
 	if err != nil {
-		l.logger.Errorw("failed to get current working directory", "error", err)
+		return nil, err
+	}
 
-		return nil, pkgerror.ServerErrorFrom(err)
+	input.Scope = l.loanScope(principal)
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	loanID := params.ByName("loan_id")
+
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	loan, err := l.getLoanDetailUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to get loan detail", "error", err)
+
+		return nil, err
 	}
 
+	return loan, nil
+}
+
+func (l *LoanHTTPEndpoint) ListLoans(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	input, err := l.decodeListLoansQuery(request.URL().Query())
+	if err != nil {
+		l.logger.Errorw("failed to decode query", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Scope = l.loanScope(principal)
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	loans, err := l.listLoansUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to list loans", "error", err)
+
+		return nil, err
+	}
+
+	return loans, nil
+}
+
+func (l *LoanHTTPEndpoint) GetLoanStatusHistory(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.GetLoanStatusHistoryInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Scope = l.loanScope(principal)
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	loanID := params.ByName("loan_id")
+
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	histories, err := l.getLoanStatusHistoryUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to get loan status history", "error", err)
+
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (l *LoanHTTPEndpoint) GetLoanSchedule(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.GetLoanScheduleInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Scope = l.loanScope(principal)
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	loanID := params.ByName("loan_id")
+
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	schedule, err := l.getLoanScheduleUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to get loan schedule", "error", err)
+
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (l *LoanHTTPEndpoint) GetLoanDistribution(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.GetLoanDistributionInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Scope = l.loanScope(principal)
+
+	params := httprouter.ParamsFromContext(ctx)
+
 	loanID := params.ByName("loan_id")
-	uploadPath := fmt.Sprintf("%s/%s/%s", workDir, "files/agreement-letter/", loanID)
 
-	if err := os.MkdirAll(uploadPath, os.ModePerm); err != nil {
-		l.logger.Errorw("failed to create upload directory", "error", err)
+	input.LoanID, err = strconv.ParseUint(loanID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse loan id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	distribution, err := l.getLoanDistributionUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to get loan distribution", "error", err)
+
+		return nil, err
+	}
+
+	return distribution, nil
+}
+
+func (l *LoanHTTPEndpoint) GetInvestorPortfolio(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.GetInvestorPortfolioInput
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.Scope = l.loanScope(principal)
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	investorID := params.ByName("investor_id")
+
+	input.InvestorID, err = strconv.ParseUint(investorID, 10, 64)
+	if err != nil {
+		l.logger.Errorw("failed to parse investor id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	portfolio, err := l.getInvestorPortfolioUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to get investor portfolio", "error", err)
+
+		return nil, err
+	}
+
+	return portfolio, nil
+}
+
+func (l *LoanHTTPEndpoint) GetLedgerTrialBalance(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	trialBalance, err := l.getLedgerTrialBalanceUsecase.Execute(ctx, usecase.GetLedgerTrialBalanceInput{})
+	if err != nil {
+		l.logger.Errorw("failed to get ledger trial balance", "error", err)
+
+		return nil, err
+	}
+
+	return trialBalance, nil
+}
+
+func (l *LoanHTTPEndpoint) GetWallet(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := l.getWalletUsecase.Execute(ctx, usecase.GetWalletInput{InvestorID: principal.UserID})
+	if err != nil {
+		l.logger.Errorw("failed to get wallet", "error", err)
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (l *LoanHTTPEndpoint) TopUpWallet(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.TopUpWalletInput
+	if err := request.Decode(&input); err != nil {
+		l.logger.Errorw("failed to decode request", "error", err)
 
 		return nil, pkgerror.ServerErrorFrom(err)
+	}
 
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	file, err := os.Create(
-		fmt.Sprintf(
-			"%s/%s%s",
-			uploadPath,
-			l.fileNameWithoutExtension(multipartHeader.Filename),
-			filepath.Ext(multipartHeader.Filename),
-		),
-	)
+	input.InvestorID = principal.UserID
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	out, err := l.topUpWalletUsecase.Execute(ctx, input)
 	if err != nil {
-		l.logger.Errorw("failed to create temp file", "error", err)
+		l.logger.Errorw("failed to top up wallet", "error", err)
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (l *LoanHTTPEndpoint) WithdrawWallet(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.WithdrawWalletInput
+	if err := request.Decode(&input); err != nil {
+		l.logger.Errorw("failed to decode request", "error", err)
 
 		return nil, pkgerror.ServerErrorFrom(err)
 	}
+
+	principal, err := l.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.InvestorID = principal.UserID
+
+	if err := l.validator.Struct(input); err != nil {
+		l.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	out, err := l.withdrawWalletUsecase.Execute(ctx, input)
+	if err != nil {
+		l.logger.Errorw("failed to withdraw from wallet", "error", err)
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (l *LoanHTTPEndpoint) decodeListLoansQuery(query url.Values) (input usecase.ListLoansInput, err error) {
+	input.Status = query.Get("status")
+	input.Sort = query.Get("sort")
+
+	if v := query.Get("borrower_id"); v != "" {
+		if input.BorrowerID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return input, fmt.Errorf("invalid borrower_id: %w", err)
+		}
+	}
+
+	if v := query.Get("cursor"); v != "" {
+		if input.Cursor, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return input, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return input, fmt.Errorf("invalid limit: %w", err)
+		}
+
+		input.Limit = uint(limit)
+	}
+
+	if v := query.Get("created_from"); v != "" {
+		if input.CreatedFrom, err = time.Parse(time.DateOnly, v); err != nil {
+			return input, fmt.Errorf("invalid created_from: %w", err)
+		}
+	}
+
+	if v := query.Get("created_to"); v != "" {
+		if input.CreatedTo, err = time.Parse(time.DateOnly, v); err != nil {
+			return input, fmt.Errorf("invalid created_to: %w", err)
+		}
+	}
+
+	return input, nil
+}
+
+// principal returns the caller authenticated by the WithAuthentication middleware.
+func (l *LoanHTTPEndpoint) principal(ctx context.Context) (pkgauth.Principal, error) {
+	principal, ok := pkgauth.PrincipalFromContext(ctx)
+	if !ok {
+		l.logger.Errorw("request has no authenticated principal")
+
+		return pkgauth.Principal{}, pkgerror.NewAuthenticationError("request is not authenticated")
+	}
+
+	return principal, nil
+}
+
+// loanScope limits borrowers and investors to their own loans, employees read every loan.
+func (l *LoanHTTPEndpoint) loanScope(principal pkgauth.Principal) usecase.LoanScope {
+	switch principal.Role {
+	case pkgauth.RoleBorrower:
+		return usecase.LoanScope{BorrowerID: principal.UserID}
+	case pkgauth.RoleInvestor:
+		return usecase.LoanScope{InvestorID: principal.UserID}
+	default:
+		return usecase.LoanScope{}
+	}
+}
+
+// readFormFile reads the multipart form file named field, a missing or oversized file is a validation error.
+func (l *LoanHTTPEndpoint) readFormFile(rawRequest *http.Request, field string, maxSize int64) ([]byte, error) {
+	if err := rawRequest.ParseMultipartForm(maxSize); err != nil {
+		l.logger.Errorw("failed to parse multipart form", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(fmt.Errorf("invalid multipart form: %w", err))
+	}
+
+	file, _, err := rawRequest.FormFile(field)
+	if err != nil {
+		l.logger.Errorw("failed to get form file", "field", field, "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(fmt.Errorf("invalid %s: %w", field, err))
+	}
 	defer file.Close()
 
-	if _, err := file.ReadFrom(multipartFile); err != nil {
-		l.logger.Errorw("failed to read file", "error", err)
+	// reading one byte past the limit tells an oversized file apart from one of exactly maxSize
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		l.logger.Errorw("failed to read form file", "field", field, "error", err)
 
 		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
-	return nil, nil
-}
+	if int64(len(content)) > maxSize {
+		l.logger.Errorw("form file is too large", "field", field, "max_size", maxSize)
+
+		return nil, pkgerror.NewValidationError(fmt.Sprintf("%s must not exceed %d bytes", field, maxSize))
+	}
 
-func (l *LoanHTTPEndpoint) fileNameWithoutExtension(filename string) string {
-	return filename[:len(filename)-len(filepath.Ext(filename))]
+	return content, nil
 }
@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"go.uber.org/zap"
+)
+
+func NewLoanOutboxGateway(registry pkgoutbox.Registry, loanOutboxHandler *LoanOutboxHandler) {
+	registry.Register(event.LoanFullyFunded, pkgoutbox.HandlerFunc(loanOutboxHandler.LoanFullyFunded))
+	registry.Register(event.LoanRepaymentMade, pkgoutbox.HandlerFunc(loanOutboxHandler.LoanRepaymentMade))
+}
+
+// LoanOutboxHandler runs the side effects of the loan events published by the outbox.
+type LoanOutboxHandler struct {
+	issueAgreementLettersUsecase     usecase.IssueAgreementLetters
+	notifyAgreementLettersUsecase    usecase.NotifyAgreementLetters
+	distributeRepaymentUsecase       usecase.DistributeRepayment
+	captureWalletReservationsUsecase usecase.CaptureWalletReservations
+
+	logger *zap.SugaredLogger
+}
+
+func NewLoanOutboxHandler(
+	issueAgreementLettersUsecase usecase.IssueAgreementLetters,
+	notifyAgreementLettersUsecase usecase.NotifyAgreementLetters,
+	distributeRepaymentUsecase usecase.DistributeRepayment,
+	captureWalletReservationsUsecase usecase.CaptureWalletReservations,
+	logger *zap.SugaredLogger,
+) *LoanOutboxHandler {
+	return &LoanOutboxHandler{
+		issueAgreementLettersUsecase:     issueAgreementLettersUsecase,
+		notifyAgreementLettersUsecase:    notifyAgreementLettersUsecase,
+		distributeRepaymentUsecase:       distributeRepaymentUsecase,
+		captureWalletReservationsUsecase: captureWalletReservationsUsecase,
+		logger:                           logger,
+	}
+}
+
+// LoanFullyFunded takes the reserved investments of the loan out of the wallets of its investors, issues the agreement
+// letters of the loan and emails them to its investors. Each step skips what it already did, so a redelivered event
+// only retries what failed. Business errors are not retried, another delivery would fail the same way.
+func (h *LoanOutboxHandler) LoanFullyFunded(ctx context.Context, e pkgoutbox.Event) error {
+	var payload event.LoanFullyFundedPayload
+	if err := e.Decode(&payload); err != nil {
+		h.logger.Errorw("failed to decode loan event", "event_id", e.ID, "error", err)
+
+		return nil
+	}
+
+	captureErr := h.captureWalletReservationsUsecase.Execute(
+		ctx,
+		usecase.CaptureWalletReservationsInput{LoanID: payload.LoanID},
+	)
+	if captureErr != nil {
+		h.logger.Errorw("failed to capture wallet reservations", "loan_id", payload.LoanID, "error", captureErr)
+	}
+
+	issueErr := h.issueAgreementLettersUsecase.Execute(
+		ctx,
+		usecase.IssueAgreementLettersInput{LoanID: payload.LoanID},
+	)
+	if issueErr != nil {
+		h.logger.Errorw("failed to issue agreement letters", "loan_id", payload.LoanID, "error", issueErr)
+	}
+
+	// investors whose letter was issued are emailed even when others failed
+	notifyErr := h.notifyAgreementLettersUsecase.Execute(
+		ctx,
+		usecase.NotifyAgreementLettersInput{LoanID: payload.LoanID},
+	)
+	if notifyErr != nil {
+		h.logger.Errorw("failed to notify investors of agreement letters", "loan_id", payload.LoanID, "error", notifyErr)
+	}
+
+	var errs []error
+	for _, err := range []error{captureErr, issueErr, notifyErr} {
+		if err != nil && !pkgerror.IsBusinessError(err) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LoanRepaymentMade credits the investors of the loan with their share of the repayment. A repayment is only
+// distributed once, a redelivered event changes nothing.
+func (h *LoanOutboxHandler) LoanRepaymentMade(ctx context.Context, e pkgoutbox.Event) error {
+	var payload event.LoanRepaymentMadePayload
+	if err := e.Decode(&payload); err != nil {
+		h.logger.Errorw("failed to decode loan event", "event_id", e.ID, "error", err)
+
+		return nil
+	}
+
+	err := h.distributeRepaymentUsecase.Execute(
+		ctx,
+		usecase.DistributeRepaymentInput{LoanID: payload.LoanID, RepaymentID: payload.RepaymentID},
+	)
+	if err != nil {
+		h.logger.Errorw("failed to distribute loan repayment", "repayment_id", payload.RepaymentID, "error", err)
+	}
+
+	if pkgerror.IsBusinessError(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,204 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestLoanOutboxHandler_LoanFullyFunded(t *testing.T) {
+	fullyFunded, err := pkgoutbox.NewEvent(1, event.AggregateLoan, 2, event.LoanFullyFunded,
+		event.LoanFullyFundedPayload{LoanID: 2})
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx   context.Context
+		event pkgoutbox.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			capture *loanmocks.MockCaptureWalletReservations,
+			issue *loanmocks.MockIssueAgreementLetters,
+			notify *loanmocks.MockNotifyAgreementLetters,
+			a args,
+		)
+		wantErr bool
+	}{
+		{
+			name: "success malformed payload is not retried",
+			args: args{
+				ctx:   context.Background(),
+				event: pkgoutbox.Event{ID: 1, Type: event.LoanFullyFunded, Payload: []byte("{")},
+			},
+			mockFn: func(
+				*loanmocks.MockCaptureWalletReservations,
+				*loanmocks.MockIssueAgreementLetters,
+				*loanmocks.MockNotifyAgreementLetters,
+				args,
+			) {
+			},
+		},
+		{
+			name: "error letters fail to issue, the issued ones are still emailed",
+			args: args{ctx: context.Background(), event: fullyFunded},
+			mockFn: func(
+				capture *loanmocks.MockCaptureWalletReservations,
+				issue *loanmocks.MockIssueAgreementLetters,
+				notify *loanmocks.MockNotifyAgreementLetters,
+				a args,
+			) {
+				capture.EXPECT().Execute(a.ctx, usecase.CaptureWalletReservationsInput{LoanID: 2}).Return(nil).Once()
+				issue.EXPECT().Execute(a.ctx, usecase.IssueAgreementLettersInput{LoanID: 2}).
+					Return(pkgerror.ServerErrorFrom(errors.New("render failed"))).Once()
+				notify.EXPECT().Execute(a.ctx, usecase.NotifyAgreementLettersInput{LoanID: 2}).Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error reservations fail to capture, the letters are still issued",
+			args: args{ctx: context.Background(), event: fullyFunded},
+			mockFn: func(
+				capture *loanmocks.MockCaptureWalletReservations,
+				issue *loanmocks.MockIssueAgreementLetters,
+				notify *loanmocks.MockNotifyAgreementLetters,
+				a args,
+			) {
+				capture.EXPECT().Execute(a.ctx, usecase.CaptureWalletReservationsInput{LoanID: 2}).
+					Return(pkgerror.ServerErrorFrom(errors.New("db down"))).Once()
+				issue.EXPECT().Execute(a.ctx, usecase.IssueAgreementLettersInput{LoanID: 2}).Return(nil).Once()
+				notify.EXPECT().Execute(a.ctx, usecase.NotifyAgreementLettersInput{LoanID: 2}).Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success business errors are not retried",
+			args: args{ctx: context.Background(), event: fullyFunded},
+			mockFn: func(
+				capture *loanmocks.MockCaptureWalletReservations,
+				issue *loanmocks.MockIssueAgreementLetters,
+				notify *loanmocks.MockNotifyAgreementLetters,
+				a args,
+			) {
+				capture.EXPECT().Execute(a.ctx, usecase.CaptureWalletReservationsInput{LoanID: 2}).Return(nil).Once()
+				issue.EXPECT().Execute(a.ctx, usecase.IssueAgreementLettersInput{LoanID: 2}).
+					Return(pkgerror.NewBusinessErrorCode(pkgerror.LoanDocumentNotAccepted)).Once()
+				notify.EXPECT().Execute(a.ctx, usecase.NotifyAgreementLettersInput{LoanID: 2}).
+					Return(pkgerror.NewBusinessErrorCode(pkgerror.LoanDocumentNotAccepted)).Once()
+			},
+		},
+		{
+			name: "success",
+			args: args{ctx: context.Background(), event: fullyFunded},
+			mockFn: func(
+				capture *loanmocks.MockCaptureWalletReservations,
+				issue *loanmocks.MockIssueAgreementLetters,
+				notify *loanmocks.MockNotifyAgreementLetters,
+				a args,
+			) {
+				capture.EXPECT().Execute(a.ctx, usecase.CaptureWalletReservationsInput{LoanID: 2}).Return(nil).Once()
+				issue.EXPECT().Execute(a.ctx, usecase.IssueAgreementLettersInput{LoanID: 2}).Return(nil).Once()
+				notify.EXPECT().Execute(a.ctx, usecase.NotifyAgreementLettersInput{LoanID: 2}).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			capture := loanmocks.NewMockCaptureWalletReservations(t)
+			issue := loanmocks.NewMockIssueAgreementLetters(t)
+			notify := loanmocks.NewMockNotifyAgreementLetters(t)
+			tt.mockFn(capture, issue, notify, tt.args)
+
+			h := gateway.NewLoanOutboxHandler(
+				issue,
+				notify,
+				loanmocks.NewMockDistributeRepayment(t),
+				capture,
+				zap.NewNop().Sugar(),
+			)
+			err := h.LoanFullyFunded(tt.args.ctx, tt.args.event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanOutboxHandler.LoanFullyFunded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoanOutboxHandler_LoanRepaymentMade(t *testing.T) {
+	repaymentMade, err := pkgoutbox.NewEvent(1, event.AggregateLoan, 2, event.LoanRepaymentMade,
+		event.LoanRepaymentMadePayload{LoanID: 2, RepaymentID: 40})
+	assert.NoError(t, err)
+
+	type args struct {
+		ctx   context.Context
+		event pkgoutbox.Event
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(distribute *loanmocks.MockDistributeRepayment, a args)
+		wantErr bool
+	}{
+		{
+			name: "success malformed payload is not retried",
+			args: args{
+				ctx:   context.Background(),
+				event: pkgoutbox.Event{ID: 1, Type: event.LoanRepaymentMade, Payload: []byte("{")},
+			},
+			mockFn: func(*loanmocks.MockDistributeRepayment, args) {},
+		},
+		{
+			name: "error when distribute repayment",
+			args: args{ctx: context.Background(), event: repaymentMade},
+			mockFn: func(distribute *loanmocks.MockDistributeRepayment, a args) {
+				distribute.EXPECT().Execute(a.ctx, usecase.DistributeRepaymentInput{LoanID: 2, RepaymentID: 40}).
+					Return(pkgerror.ServerErrorFrom(errors.New("db down"))).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success business errors are not retried",
+			args: args{ctx: context.Background(), event: repaymentMade},
+			mockFn: func(distribute *loanmocks.MockDistributeRepayment, a args) {
+				distribute.EXPECT().Execute(a.ctx, usecase.DistributeRepaymentInput{LoanID: 2, RepaymentID: 40}).
+					Return(pkgerror.NewBusinessErrorCode(pkgerror.LoanRepaymentNotFound)).Once()
+			},
+		},
+		{
+			name: "success",
+			args: args{ctx: context.Background(), event: repaymentMade},
+			mockFn: func(distribute *loanmocks.MockDistributeRepayment, a args) {
+				distribute.EXPECT().Execute(a.ctx, usecase.DistributeRepaymentInput{LoanID: 2, RepaymentID: 40}).
+					Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distribute := loanmocks.NewMockDistributeRepayment(t)
+			tt.mockFn(distribute, tt.args)
+
+			h := gateway.NewLoanOutboxHandler(
+				loanmocks.NewMockIssueAgreementLetters(t),
+				loanmocks.NewMockNotifyAgreementLetters(t),
+				distribute,
+				loanmocks.NewMockCaptureWalletReservations(t),
+				zap.NewNop().Sugar(),
+			)
+			err := h.LoanRepaymentMade(tt.args.ctx, tt.args.event)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoanOutboxHandler.LoanRepaymentMade() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
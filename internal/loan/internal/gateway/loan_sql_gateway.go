@@ -3,24 +3,52 @@ package gateway
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
 
 	"go.uber.org/zap"
 )
 
+// ErrLoanNotUpdated is returned when an update matches no loan, either because it does not exist or because its
+// version changed since it was read.
+var ErrLoanNotUpdated = errors.New("loan not updated")
+
+// ErrWalletReservationNotUpdated is returned when an update matches no wallet reservation, either because it does not
+// exist or because it left the status it was filtered on since it was read.
+var ErrWalletReservationNotUpdated = errors.New("wallet reservation not updated")
+
+// ErrLoanInvestmentNotUpdated is returned when an update matches no loan investment, either because it does not exist
+// or because it left the status it was filtered on since it was read.
+var ErrLoanInvestmentNotUpdated = errors.New("loan investment not updated")
+
 type LoanSQLGateway struct {
 	db           pkgsql.SQL
+	transactor   pkgsql.Transactor
 	logger       *zap.SugaredLogger
 	queryBuilder pkgsql.GoquBuilder
 
-	loanTableName           string
-	loanInvestmentTableName string
-	userTableName           string
+	loanTableName              string
+	loanInvestmentTableName    string
+	loanStatusHistoryTableName string
+	loanDocumentTableName      string
+	loanNotificationTableName  string
+	loanInstallmentTableName   string
+	loanRepaymentTableName     string
+	loanAllocationTableName    string
+	loanCreditTableName        string
+	ledgerAccountTableName     string
+	ledgerEntryTableName       string
+	ledgerLineTableName        string
+	walletTableName            string
+	walletReservationTableName string
+	userTableName              string
 }
 
 func NewLoanSQLGateway(
@@ -30,15 +58,33 @@ func NewLoanSQLGateway(
 ) *LoanSQLGateway {
 	return &LoanSQLGateway{
 		db:           db,
+		transactor:   pkgsql.NewTransactor(db),
 		logger:       logger,
 		queryBuilder: queryBuilder,
 
-		loanTableName:           "loans",
-		loanInvestmentTableName: "loan_investments",
-		userTableName:           "users",
+		loanTableName:              "loans",
+		loanInvestmentTableName:    "loan_investments",
+		loanStatusHistoryTableName: "loan_status_histories",
+		loanDocumentTableName:      "loan_documents",
+		loanNotificationTableName:  "loan_notifications",
+		loanInstallmentTableName:   "loan_installments",
+		loanRepaymentTableName:     "loan_repayments",
+		loanAllocationTableName:    "loan_repayment_allocations",
+		loanCreditTableName:        "loan_investor_credits",
+		ledgerAccountTableName:     "ledger_accounts",
+		ledgerEntryTableName:       "ledger_entries",
+		ledgerLineTableName:        "ledger_lines",
+		walletTableName:            "investor_wallets",
+		walletReservationTableName: "wallet_reservations",
+		userTableName:              "users",
 	}
 }
 
+// WithinTx runs fn in a transaction, every gateway call made with the context given to fn joins it.
+func (r *LoanSQLGateway) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return r.transactor.WithinTx(ctx, fn)
+}
+
 func (r *LoanSQLGateway) InsertLoan(ctx context.Context, in sqlentity.Loan) error {
 	query := r.queryBuilder.Insert(r.loanTableName).Cols(in.Columns()...).Vals(in.Values())
 	sql, _, err := query.ToSQL()
@@ -48,7 +94,7 @@ func (r *LoanSQLGateway) InsertLoan(ctx context.Context, in sqlentity.Loan) erro
 		return err
 	}
 
-	res, err := r.db.ExecContext(ctx, sql)
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
 	if err != nil {
 		r.logger.Errorw("failed to execute query", "error", err)
 
@@ -77,18 +123,90 @@ func GetLoanWithBorrowerIDFilter(borrowerID uint64) GetLoanOption {
 	}
 }
 
+// GetLoanWithInvestorVisibilityFilter filters loans the investor has an active investment in or that are still open
+// for investment.
+func GetLoanWithInvestorVisibilityFilter(investorID uint64) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Or(
+			goqu.Ex{"status": sqlentity.Approved},
+			goqu.L(
+				"? IN (SELECT loan_id FROM loan_investments WHERE investor_id = ? AND status = ?)",
+				goqu.C("id"),
+				investorID,
+				sqlentity.InvestmentActive,
+			),
+		))
+	}
+}
+
 func GetLoanWithLoanIDFilter(loanID uint64) GetLoanOption {
 	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
 		return query.Where(goqu.Ex{"id": loanID})
 	}
 }
 
+func GetLoanWithLoanIDsFilter(loanIDs ...uint64) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": loanIDs})
+	}
+}
+
 func GetLoanWithStatusFilter(status sqlentity.LoanStatus) GetLoanOption {
 	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
 		return query.Where(goqu.Ex{"status": status})
 	}
 }
 
+// GetLoanWithFundingDeadlinePassedFilter filters loans whose funding deadline is at or before now.
+func GetLoanWithFundingDeadlinePassedFilter(now time.Time) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("funding_deadline").Lte(now))
+	}
+}
+
+func GetLoanWithIDAfterFilter(loanID uint64) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("id").Gt(loanID))
+	}
+}
+
+func GetLoanWithIDBeforeFilter(loanID uint64) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("id").Lt(loanID))
+	}
+}
+
+// GetLoanWithCreatedAtRangeFilter filters loans created within [from, to). A zero time leaves that side open.
+func GetLoanWithCreatedAtRangeFilter(from, to time.Time) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		if !from.IsZero() {
+			query = query.Where(goqu.C("created_at").Gte(from))
+		}
+
+		if !to.IsZero() {
+			query = query.Where(goqu.C("created_at").Lt(to))
+		}
+
+		return query
+	}
+}
+
+func GetLoanWithOrderByID(descending bool) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		if descending {
+			return query.Order(goqu.C("id").Desc())
+		}
+
+		return query.Order(goqu.C("id").Asc())
+	}
+}
+
+func GetLoanWithLimit(limit uint) GetLoanOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Limit(limit)
+	}
+}
+
 func (r *LoanSQLGateway) GetLoan(
 	ctx context.Context,
 	opts ...GetLoanOption,
@@ -107,12 +225,13 @@ func (r *LoanSQLGateway) GetLoan(
 		return nil, err
 	}
 
-	rows, err := r.db.QueryContext(ctx, sql)
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
 	if err != nil {
 		r.logger.Errorw("failed to execute query", "error", err)
 
 		return nil, err
 	}
+	defer rows.Close()
 
 	var loans sqlentity.Loans
 	for rows.Next() {
@@ -126,6 +245,12 @@ func (r *LoanSQLGateway) GetLoan(
 		loans = append(loans, loan)
 	}
 
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
 	return loans, nil
 }
 
@@ -137,6 +262,13 @@ func UpdateLoanWithLoanIDFilter(loanID uint64) UpdateLoanOption {
 	}
 }
 
+// UpdateLoanWithVersionFilter makes the update a compare-and-swap on the version the loan was read with.
+func UpdateLoanWithVersionFilter(version uint64) UpdateLoanOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"version": version})
+	}
+}
+
 func (r *LoanSQLGateway) UpdateLoan(
 	ctx context.Context,
 	in sqlentity.UpdateEntity,
@@ -155,9 +287,8 @@ func (r *LoanSQLGateway) UpdateLoan(
 		return err
 	}
 
-	res, err := r.db.ExecContext(ctx, sql)
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
 	if err != nil {
-		fmt.Printf("err: %v\n", err)
 		r.logger.Errorw("failed to execute query", "error", err)
 
 		return err
@@ -171,7 +302,7 @@ func (r *LoanSQLGateway) UpdateLoan(
 	}
 
 	if row == 0 {
-		return fmt.Errorf("loan not found")
+		return ErrLoanNotUpdated
 	}
 
 	return nil
@@ -191,7 +322,7 @@ func (r *LoanSQLGateway) InsertLoanInvestment(
 		return err
 	}
 
-	res, err := r.db.ExecContext(ctx, sql)
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
 	if err != nil {
 		r.logger.Errorw("failed to execute query", "error", err)
 
@@ -211,3 +342,1383 @@ func (r *LoanSQLGateway) InsertLoanInvestment(
 
 	return nil
 }
+
+type GetLoanInvestmentOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetLoanInvestmentWithLoanIDFilter(loanIDs ...uint64) GetLoanInvestmentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanIDs})
+	}
+}
+
+func GetLoanInvestmentWithInvestorIDFilter(investorID uint64) GetLoanInvestmentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"investor_id": investorID})
+	}
+}
+
+func GetLoanInvestmentWithIDFilter(investmentID uint64) GetLoanInvestmentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": investmentID})
+	}
+}
+
+// GetLoanInvestmentWithActiveFilter leaves out the cancelled investments, which no longer count towards their loan.
+func GetLoanInvestmentWithActiveFilter() GetLoanInvestmentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"status": sqlentity.InvestmentActive})
+	}
+}
+
+func (r *LoanSQLGateway) GetLoanInvestment(
+	ctx context.Context,
+	opts ...GetLoanInvestmentOption,
+) (sqlentity.LoanInvestments, error) {
+	var investment sqlentity.LoanInvestment
+	query := r.queryBuilder.Select(investment.Columns()...).
+		From(r.loanInvestmentTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var investments sqlentity.LoanInvestments
+	for rows.Next() {
+		err := rows.Scan(investment.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		investments = append(investments, investment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return investments, nil
+}
+
+type UpdateLoanInvestmentOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateLoanInvestmentWithIDFilter(investmentID uint64) UpdateLoanInvestmentOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"id": investmentID})
+	}
+}
+
+func UpdateLoanInvestmentWithLoanIDFilter(loanID uint64) UpdateLoanInvestmentOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+// UpdateLoanInvestmentWithStatusFilter only updates an investment still in the status it was read with.
+func UpdateLoanInvestmentWithStatusFilter(status sqlentity.LoanInvestmentStatus) UpdateLoanInvestmentOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"status": status})
+	}
+}
+
+func (r *LoanSQLGateway) UpdateLoanInvestment(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateLoanInvestmentOption,
+) error {
+	query := r.queryBuilder.Update(r.loanInvestmentTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return ErrLoanInvestmentNotUpdated
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLoanStatusHistory(
+	ctx context.Context,
+	in sqlentity.LoanStatusHistory,
+) error {
+	query := r.queryBuilder.Insert(r.loanStatusHistoryTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert loan status history")
+	}
+
+	return nil
+}
+
+type GetLoanStatusHistoryOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetLoanStatusHistoryWithLoanIDFilter(loanID uint64) GetLoanStatusHistoryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+func (r *LoanSQLGateway) GetLoanStatusHistory(
+	ctx context.Context,
+	opts ...GetLoanStatusHistoryOption,
+) (sqlentity.LoanStatusHistories, error) {
+	var history sqlentity.LoanStatusHistory
+	query := r.queryBuilder.Select(history.Columns()...).
+		From(r.loanStatusHistoryTableName).
+		Order(goqu.C("created_at").Asc(), goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories sqlentity.LoanStatusHistories
+	for rows.Next() {
+		err := rows.Scan(history.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		histories = append(histories, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (r *LoanSQLGateway) InsertLoanDocument(
+	ctx context.Context,
+	in sqlentity.LoanDocument,
+) error {
+	query := r.queryBuilder.Insert(r.loanDocumentTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert loan document")
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLoanNotification(
+	ctx context.Context,
+	in sqlentity.LoanNotification,
+) error {
+	query := r.queryBuilder.Insert(r.loanNotificationTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert loan notification")
+	}
+
+	return nil
+}
+
+type GetLoanNotificationOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetLoanNotificationWithLoanIDFilter(loanID uint64) GetLoanNotificationOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+func GetLoanNotificationWithTemplateFilter(template string) GetLoanNotificationOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"template": template})
+	}
+}
+
+func (r *LoanSQLGateway) GetLoanNotification(
+	ctx context.Context,
+	opts ...GetLoanNotificationOption,
+) (sqlentity.LoanNotifications, error) {
+	var notification sqlentity.LoanNotification
+	query := r.queryBuilder.Select(notification.Columns()...).
+		From(r.loanNotificationTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications sqlentity.LoanNotifications
+	for rows.Next() {
+		err := rows.Scan(notification.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+type UpdateLoanNotificationOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateLoanNotificationWithIDFilter(notificationID uint64) UpdateLoanNotificationOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"id": notificationID})
+	}
+}
+
+func (r *LoanSQLGateway) UpdateLoanNotification(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateLoanNotificationOption,
+) error {
+	query := r.queryBuilder.Update(r.loanNotificationTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to update loan notification")
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLoanInstallments(ctx context.Context, in sqlentity.LoanInstallments) error {
+	if in.IsEmpty() {
+		return nil
+	}
+
+	var installment sqlentity.LoanInstallment
+	query := r.queryBuilder.Insert(r.loanInstallmentTableName).Cols(installment.Columns()...)
+
+	for _, i := range in {
+		query = query.Vals(i.Values())
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row != int64(in.Len()) {
+		return fmt.Errorf("failed to insert loan installments")
+	}
+
+	return nil
+}
+
+type GetLoanInstallmentOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetLoanInstallmentWithLoanIDFilter(loanID uint64) GetLoanInstallmentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+// GetLoanInstallmentWithUnpaidFilter keeps the installments not fully repaid yet.
+func GetLoanInstallmentWithUnpaidFilter() GetLoanInstallmentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("status").Neq(sqlentity.InstallmentPaid.String()))
+	}
+}
+
+func (r *LoanSQLGateway) GetLoanInstallment(
+	ctx context.Context,
+	opts ...GetLoanInstallmentOption,
+) (sqlentity.LoanInstallments, error) {
+	var installment sqlentity.LoanInstallment
+	query := r.queryBuilder.Select(installment.Columns()...).
+		From(r.loanInstallmentTableName).
+		Order(goqu.C("installment_number").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var installments sqlentity.LoanInstallments
+	for rows.Next() {
+		err := rows.Scan(installment.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		installments = append(installments, installment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return installments, nil
+}
+
+type UpdateLoanInstallmentOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateLoanInstallmentWithIDFilter(installmentID uint64) UpdateLoanInstallmentOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"id": installmentID})
+	}
+}
+
+func (r *LoanSQLGateway) UpdateLoanInstallment(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateLoanInstallmentOption,
+) error {
+	query := r.queryBuilder.Update(r.loanInstallmentTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to update loan installment")
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLoanRepayment(ctx context.Context, in sqlentity.LoanRepayment) error {
+	query := r.queryBuilder.Insert(r.loanRepaymentTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert loan repayment")
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLoanRepaymentAllocations(
+	ctx context.Context,
+	in sqlentity.LoanRepaymentAllocations,
+) error {
+	if in.IsEmpty() {
+		return nil
+	}
+
+	var allocation sqlentity.LoanRepaymentAllocation
+	query := r.queryBuilder.Insert(r.loanAllocationTableName).Cols(allocation.Columns()...)
+
+	for _, a := range in {
+		query = query.Vals(a.Values())
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row != int64(in.Len()) {
+		return fmt.Errorf("failed to insert loan repayment allocations")
+	}
+
+	return nil
+}
+
+type GetLoanRepaymentOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetLoanRepaymentWithIDFilter(repaymentID uint64) GetLoanRepaymentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": repaymentID})
+	}
+}
+
+func GetLoanRepaymentWithLoanIDFilter(loanID uint64) GetLoanRepaymentOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+func (r *LoanSQLGateway) GetLoanRepayment(
+	ctx context.Context,
+	opts ...GetLoanRepaymentOption,
+) (sqlentity.LoanRepayments, error) {
+	var repayment sqlentity.LoanRepayment
+	query := r.queryBuilder.Select(repayment.Columns()...).
+		From(r.loanRepaymentTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var repayments sqlentity.LoanRepayments
+	for rows.Next() {
+		err := rows.Scan(repayment.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		repayments = append(repayments, repayment)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return repayments, nil
+}
+
+func (r *LoanSQLGateway) InsertLoanInvestorCredits(ctx context.Context, in sqlentity.LoanInvestorCredits) error {
+	if in.IsEmpty() {
+		return nil
+	}
+
+	var credit sqlentity.LoanInvestorCredit
+	query := r.queryBuilder.Insert(r.loanCreditTableName).Cols(credit.Columns()...)
+
+	for _, c := range in {
+		query = query.Vals(c.Values())
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row != int64(in.Len()) {
+		return fmt.Errorf("failed to insert loan investor credits")
+	}
+
+	return nil
+}
+
+type GetLoanInvestorCreditOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetLoanInvestorCreditWithLoanIDFilter(loanID uint64) GetLoanInvestorCreditOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+func GetLoanInvestorCreditWithRepaymentIDFilter(repaymentID uint64) GetLoanInvestorCreditOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"repayment_id": repaymentID})
+	}
+}
+
+func GetLoanInvestorCreditWithInvestorIDFilter(investorID uint64) GetLoanInvestorCreditOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"investor_id": investorID})
+	}
+}
+
+func (r *LoanSQLGateway) GetLoanInvestorCredit(
+	ctx context.Context,
+	opts ...GetLoanInvestorCreditOption,
+) (sqlentity.LoanInvestorCredits, error) {
+	var credit sqlentity.LoanInvestorCredit
+	query := r.queryBuilder.Select(credit.Columns()...).
+		From(r.loanCreditTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credits sqlentity.LoanInvestorCredits
+	for rows.Next() {
+		err := rows.Scan(credit.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		credits = append(credits, credit)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return credits, nil
+}
+
+type GetLedgerAccountOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+// GetLedgerAccountWithKeysFilter keeps the accounts of the same type and owner as one of accounts.
+func GetLedgerAccountWithKeysFilter(accounts ...sqlentity.LedgerAccount) GetLedgerAccountOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		keys := make([]exp.Expression, 0, len(accounts))
+		for _, account := range accounts {
+			keys = append(keys, goqu.Ex{"type": account.Type, "owner_id": account.OwnerID})
+		}
+
+		return query.Where(goqu.Or(keys...))
+	}
+}
+
+// GetLedgerAccountWithLock reads the accounts with a shared lock, it sees the accounts committed by other
+// transactions since this one started.
+func GetLedgerAccountWithLock() GetLedgerAccountOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.ForShare(exp.Wait)
+	}
+}
+
+func (r *LoanSQLGateway) GetLedgerAccount(
+	ctx context.Context,
+	opts ...GetLedgerAccountOption,
+) (sqlentity.LedgerAccounts, error) {
+	var account sqlentity.LedgerAccount
+	query := r.queryBuilder.Select(account.Columns()...).
+		From(r.ledgerAccountTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts sqlentity.LedgerAccounts
+	for rows.Next() {
+		err := rows.Scan(account.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// InsertLedgerAccounts skips the accounts another transaction already opened for the same type and owner.
+func (r *LoanSQLGateway) InsertLedgerAccounts(ctx context.Context, in sqlentity.LedgerAccounts) error {
+	if in.IsEmpty() {
+		return nil
+	}
+
+	var account sqlentity.LedgerAccount
+	query := r.queryBuilder.Insert(r.ledgerAccountTableName).
+		Cols(account.Columns()...).
+		OnConflict(goqu.DoNothing())
+
+	for _, a := range in {
+		query = query.Vals(a.Values())
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	if _, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql); err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLedgerEntry(ctx context.Context, in sqlentity.LedgerEntry) error {
+	query := r.queryBuilder.Insert(r.ledgerEntryTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert ledger entry")
+	}
+
+	return nil
+}
+
+func (r *LoanSQLGateway) InsertLedgerLines(ctx context.Context, in sqlentity.LedgerLines) error {
+	if in.IsEmpty() {
+		return nil
+	}
+
+	var line sqlentity.LedgerLine
+	query := r.queryBuilder.Insert(r.ledgerLineTableName).Cols(line.Columns()...)
+
+	for _, l := range in {
+		query = query.Vals(l.Values())
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row != int64(in.Len()) {
+		return fmt.Errorf("failed to insert ledger lines")
+	}
+
+	return nil
+}
+
+// GetLedgerTrialBalance totals the debits and credits posted to every ledger account.
+func (r *LoanSQLGateway) GetLedgerTrialBalance(ctx context.Context) (sqlentity.LedgerAccountBalances, error) {
+	query := r.queryBuilder.From(goqu.T(r.ledgerAccountTableName).As("a")).
+		LeftJoin(
+			goqu.T(r.ledgerLineTableName).As("l"),
+			goqu.On(goqu.I("l.account_id").Eq(goqu.I("a.id"))),
+		).
+		Select(
+			goqu.I("a.id").As("account_id"),
+			goqu.I("a.type"),
+			goqu.I("a.owner_id"),
+			goqu.COALESCE(goqu.SUM(goqu.I("l.debit_amount")), 0).As("debit_amount"),
+			goqu.COALESCE(goqu.SUM(goqu.I("l.credit_amount")), 0).As("credit_amount"),
+		).
+		GroupBy(goqu.I("a.id"), goqu.I("a.type"), goqu.I("a.owner_id")).
+		Order(goqu.I("a.id").Asc())
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balance sqlentity.LedgerAccountBalance
+	var balances sqlentity.LedgerAccountBalances
+	for rows.Next() {
+		err := rows.Scan(balance.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		balances = append(balances, balance)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+type GetWalletOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetWalletWithInvestorIDFilter(investorIDs ...uint64) GetWalletOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"investor_id": investorIDs})
+	}
+}
+
+// GetWalletWithLock locks the wallets until the end of the transaction, they are read in the order of their investor
+// so that transactions locking several wallets cannot deadlock each other.
+func GetWalletWithLock() GetWalletOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.ForUpdate(exp.Wait)
+	}
+}
+
+func (r *LoanSQLGateway) GetWallet(ctx context.Context, opts ...GetWalletOption) (sqlentity.Wallets, error) {
+	var wallet sqlentity.Wallet
+	query := r.queryBuilder.Select(wallet.Columns()...).
+		From(r.walletTableName).
+		Order(goqu.C("investor_id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wallets sqlentity.Wallets
+	for rows.Next() {
+		err := rows.Scan(wallet.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		wallets = append(wallets, wallet)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+// InsertWallet opens the wallet of an investor, it skips a wallet that is already open.
+func (r *LoanSQLGateway) InsertWallet(ctx context.Context, in sqlentity.Wallet) error {
+	query := r.queryBuilder.Insert(r.walletTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values()).
+		OnConflict(goqu.DoNothing())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	if _, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql); err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	return nil
+}
+
+type UpdateWalletOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateWalletWithInvestorIDFilter(investorID uint64) UpdateWalletOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"investor_id": investorID})
+	}
+}
+
+func (r *LoanSQLGateway) UpdateWallet(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateWalletOption,
+) error {
+	query := r.queryBuilder.Update(r.walletTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to update wallet")
+	}
+
+	return nil
+}
+
+type GetWalletReservationOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetWalletReservationWithLoanIDFilter(loanID uint64) GetWalletReservationOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"loan_id": loanID})
+	}
+}
+
+func GetWalletReservationWithInvestmentIDFilter(investmentID uint64) GetWalletReservationOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"investment_id": investmentID})
+	}
+}
+
+func GetWalletReservationWithStatusFilter(status sqlentity.WalletReservationStatus) GetWalletReservationOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"status": status})
+	}
+}
+
+func (r *LoanSQLGateway) GetWalletReservation(
+	ctx context.Context,
+	opts ...GetWalletReservationOption,
+) (sqlentity.WalletReservations, error) {
+	var reservation sqlentity.WalletReservation
+	query := r.queryBuilder.Select(reservation.Columns()...).
+		From(r.walletReservationTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations sqlentity.WalletReservations
+	for rows.Next() {
+		err := rows.Scan(reservation.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+func (r *LoanSQLGateway) InsertWalletReservation(ctx context.Context, in sqlentity.WalletReservation) error {
+	query := r.queryBuilder.Insert(r.walletReservationTableName).
+		Cols(in.Columns()...).
+		Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert wallet reservation")
+	}
+
+	return nil
+}
+
+type UpdateWalletReservationOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateWalletReservationWithIDFilter(reservationID uint64) UpdateWalletReservationOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"id": reservationID})
+	}
+}
+
+// UpdateWalletReservationWithStatusFilter only updates a reservation still in the status it was read with.
+func UpdateWalletReservationWithStatusFilter(status sqlentity.WalletReservationStatus) UpdateWalletReservationOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"status": status})
+	}
+}
+
+func (r *LoanSQLGateway) UpdateWalletReservation(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateWalletReservationOption,
+) error {
+	query := r.queryBuilder.Update(r.walletReservationTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return ErrWalletReservationNotUpdated
+	}
+
+	return nil
+}
+
+type GetUserOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetUserWithIDFilter(userIDs ...uint64) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": userIDs})
+	}
+}
+
+func GetUserWithTypeFilter(userType sqlentity.UserType) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"type": userType})
+	}
+}
+
+func (r *LoanSQLGateway) GetUser(ctx context.Context, opts ...GetUserOption) (sqlentity.Users, error) {
+	var user sqlentity.User
+	query := r.queryBuilder.Select(user.Columns()...).
+		From(r.userTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users sqlentity.Users
+	for rows.Next() {
+		err := rows.Scan(user.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return users, nil
+}
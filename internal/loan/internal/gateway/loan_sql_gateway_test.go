@@ -10,6 +10,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/doug-martin/goqu/v9"
 	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
 	"github.com/shopspring/decimal"
@@ -22,9 +23,18 @@ type loanSQLGatewaySuite struct {
 	dbmock       sqlmock.Sqlmock
 	queryBuilder pkgsql.GoquBuilder
 
-	loanTableName           string
-	loanInvestmentTableName string
-	userTableName           string
+	loanTableName              string
+	loanInvestmentTableName    string
+	loanDocumentTableName      string
+	loanNotificationTableName  string
+	loanInstallmentTableName   string
+	loanRepaymentTableName     string
+	loanCreditTableName        string
+	ledgerAccountTableName     string
+	ledgerLineTableName        string
+	walletTableName            string
+	walletReservationTableName string
+	userTableName              string
 
 	suite.Suite
 }
@@ -43,6 +53,15 @@ func (ls *loanSQLGatewaySuite) SetupSuite() {
 	ls.queryBuilder = goqu.New("mysql", ls.db)
 	ls.loanTableName = "loans"
 	ls.loanInvestmentTableName = "loan_investments"
+	ls.loanDocumentTableName = "loan_documents"
+	ls.loanNotificationTableName = "loan_notifications"
+	ls.loanInstallmentTableName = "loan_installments"
+	ls.loanRepaymentTableName = "loan_repayments"
+	ls.loanCreditTableName = "loan_investor_credits"
+	ls.ledgerAccountTableName = "ledger_accounts"
+	ls.ledgerLineTableName = "ledger_lines"
+	ls.walletTableName = "investor_wallets"
+	ls.walletReservationTableName = "wallet_reservations"
 	ls.userTableName = "users"
 }
 
@@ -259,3 +278,1684 @@ func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_UpdateLoan() {
 		})
 	}
 }
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_UpdateLoanWithVersionFilter() {
+	update := sqlentity.ApproveLoan{
+		ApprovalDate:       sql.NullTime{Time: time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC), Valid: true},
+		ApprovalEmployeeID: sql.NullInt64{Int64: 4, Valid: true},
+		Version:            4,
+	}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Update(ls.loanTableName).
+			Set(update.MappedValues()).
+			Where(goqu.Ex{"id": uint64(1)}).
+			Where(goqu.Ex{"version": uint64(3)}).
+			ToSQL()
+		ls.NoError(err)
+		ls.Contains(query, "AND (`version` = 3)")
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		wantErr error
+	}{
+		{
+			name: "error loan version changed",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: ErrLoanNotUpdated,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.UpdateLoan(
+				context.Background(),
+				update,
+				UpdateLoanWithLoanIDFilter(1),
+				UpdateLoanWithVersionFilter(3),
+			)
+			ls.ErrorIs(err, tt.wantErr)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLoan() {
+	var loan sqlentity.Loan
+
+	type args struct {
+		ctx  context.Context
+		opts []GetLoanOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		want    sqlentity.Loans
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetLoanOption{GetLoanWithLoanIDFilter(1)},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(loan.Columns()...).
+					From(ls.loanTableName).
+					Where(goqu.Ex{"id": uint64(1)}).
+					ToSQL()
+				ls.NoError(err)
+
+				ls.dbmock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success with funding deadline passed filter",
+			args: args{
+				ctx: context.Background(),
+				opts: []GetLoanOption{
+					GetLoanWithStatusFilter(sqlentity.Approved),
+					GetLoanWithFundingDeadlinePassedFilter(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+				},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(loan.Columns()...).From(ls.loanTableName).ToSQL()
+				ls.NoError(err)
+
+				query += " WHERE ((`status` = 'APPROVED') AND (`funding_deadline` <= '2024-01-02 03:04:05'))"
+
+				ls.dbmock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(loan.StringColumns()))
+			},
+		},
+		{
+			name: "success with investor visibility filter",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetLoanOption{GetLoanWithInvestorVisibilityFilter(3)},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(loan.Columns()...).From(ls.loanTableName).ToSQL()
+				ls.NoError(err)
+
+				query += " WHERE ((`status` = 'APPROVED') OR `id` IN " +
+					"(SELECT loan_id FROM loan_investments WHERE investor_id = 3 AND status = 'ACTIVE'))"
+
+				ls.dbmock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(loan.StringColumns()))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLoan(tt.args.ctx, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLoan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLoanInvestment() {
+	var investment sqlentity.LoanInvestment
+
+	type args struct {
+		ctx  context.Context
+		opts []GetLoanInvestmentOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		want    sqlentity.LoanInvestments
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetLoanInvestmentOption{GetLoanInvestmentWithLoanIDFilter(1)},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(investment.Columns()...).
+					From(ls.loanInvestmentTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"loan_id": []uint64{1}}).
+					ToSQL()
+				ls.NoError(err)
+
+				ls.dbmock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetLoanInvestmentOption{GetLoanInvestmentWithLoanIDFilter(1, 2)},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(investment.Columns()...).
+					From(ls.loanInvestmentTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"loan_id": []uint64{1, 2}}).
+					ToSQL()
+				ls.NoError(err)
+
+				ls.dbmock.ExpectQuery(query).WillReturnRows(
+					sqlmock.NewRows(investment.StringColumns()).
+						AddRow(10, 1, 2, "100.50", "loan/1/investment/10/agreement-letter.pdf", "ACTIVE", nil, nil).
+						AddRow(11, 2, 3, "200", nil, "CANCELLED", 3, time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)),
+				)
+			},
+			want: sqlentity.LoanInvestments{
+				{
+					ID:         10,
+					LoanID:     1,
+					InvestorID: 2,
+					Amount:     decimal.RequireFromString("100.50"),
+					AgreementLetterDocumentKey: sql.NullString{
+						String: "loan/1/investment/10/agreement-letter.pdf",
+						Valid:  true,
+					},
+					Status: sqlentity.InvestmentActive,
+				},
+				{
+					ID:          11,
+					LoanID:      2,
+					InvestorID:  3,
+					Amount:      decimal.RequireFromString("200"),
+					Status:      sqlentity.InvestmentCancelled,
+					CancelledBy: sql.NullInt64{Int64: 3, Valid: true},
+					CancelledAt: sql.NullTime{Time: time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC), Valid: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLoanInvestment(tt.args.ctx, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLoanInvestment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_UpdateLoanInvestment() {
+	type args struct {
+		ctx  context.Context
+		in   sqlentity.UpdateEntity
+		opts []UpdateLoanInvestmentOption
+	}
+
+	defaultArgs := args{
+		ctx: context.Background(),
+		in: sqlentity.UpdateLoanInvestmentAgreementLetter{
+			AgreementLetterDocumentKey: sql.NullString{
+				String: "loan/1/investment/10/agreement-letter.pdf",
+				Valid:  true,
+			},
+		},
+		opts: []UpdateLoanInvestmentOption{
+			UpdateLoanInvestmentWithIDFilter(10),
+		},
+	}
+
+	query := func(a args) string {
+		query, _, err := ls.queryBuilder.Update(ls.loanInvestmentTableName).
+			Set(a.in.MappedValues()).
+			Where(goqu.Ex{"id": 10}).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no rows affected",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+		{
+			name: "success cancel while active",
+			args: args{
+				ctx: context.Background(),
+				in: sqlentity.CancelLoanInvestment{
+					CancelledBy: sql.NullInt64{Int64: 3, Valid: true},
+					CancelledAt: sql.NullTime{Time: time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC), Valid: true},
+				},
+				opts: []UpdateLoanInvestmentOption{
+					UpdateLoanInvestmentWithIDFilter(10),
+					UpdateLoanInvestmentWithStatusFilter(sqlentity.InvestmentActive),
+				},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Update(ls.loanInvestmentTableName).
+					Set(a.in.MappedValues()).
+					Where(goqu.Ex{"id": 10}, goqu.Ex{"status": sqlentity.InvestmentActive}).
+					ToSQL()
+				ls.NoError(err)
+
+				ls.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "success release the active investments of a loan",
+			args: args{
+				ctx: context.Background(),
+				in:  sqlentity.ReleaseLoanInvestment{},
+				opts: []UpdateLoanInvestmentOption{
+					UpdateLoanInvestmentWithLoanIDFilter(1),
+					UpdateLoanInvestmentWithStatusFilter(sqlentity.InvestmentActive),
+				},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Update(ls.loanInvestmentTableName).
+					Set(a.in.MappedValues()).
+					Where(goqu.Ex{"loan_id": 1}, goqu.Ex{"status": sqlentity.InvestmentActive}).
+					ToSQL()
+				ls.NoError(err)
+
+				ls.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			if err := r.UpdateLoanInvestment(tt.args.ctx, tt.args.in, tt.args.opts...); (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.UpdateLoanInvestment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_InsertLoanDocument() {
+	type args struct {
+		ctx context.Context
+		in  sqlentity.LoanDocument
+	}
+
+	defaultArgs := args{
+		ctx: context.Background(),
+		in: sqlentity.LoanDocument{
+			ID:             2,
+			LoanID:         1,
+			Type:           sqlentity.AgreementLetterDocument,
+			DocumentKey:    "loan/1/agreement-letter/2.pdf",
+			ContentType:    "application/pdf",
+			Size:           10,
+			ChecksumSHA256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			UploadedBy:     3,
+		},
+	}
+
+	query := func(a args) string {
+		query, _, err := ls.queryBuilder.Insert(ls.loanDocumentTableName).
+			Cols(a.in.Columns()...).
+			Vals(a.in.Values()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no rows affected",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			if err := r.InsertLoanDocument(tt.args.ctx, tt.args.in); (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.InsertLoanDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLoanNotification() {
+	var notification sqlentity.LoanNotification
+	createdAt := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx  context.Context
+		opts []GetLoanNotificationOption
+	}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Select(notification.Columns()...).
+			From(ls.loanNotificationTableName).
+			Order(goqu.C("id").Asc()).
+			Where(goqu.Ex{"loan_id": 1}).
+			Where(goqu.Ex{"template": sqlentity.AgreementLetterNotification}).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	defaultArgs := args{
+		ctx: context.Background(),
+		opts: []GetLoanNotificationOption{
+			GetLoanNotificationWithLoanIDFilter(1),
+			GetLoanNotificationWithTemplateFilter(sqlentity.AgreementLetterNotification),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		want    sqlentity.LoanNotifications
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectQuery(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(notification.StringColumns()).
+						AddRow(
+							20, 1, 10, 3, "agreement_letter", "c@example.com", []byte("SENT"), 1,
+							nil, createdAt, createdAt, createdAt,
+						).
+						AddRow(
+							21, 1, 11, 4, "agreement_letter", "d@example.com", []byte("FAILED"), 3,
+							"connection refused", nil, createdAt, createdAt,
+						),
+				)
+			},
+			want: sqlentity.LoanNotifications{
+				{
+					ID:           20,
+					LoanID:       1,
+					InvestmentID: 10,
+					UserID:       3,
+					Template:     sqlentity.AgreementLetterNotification,
+					Recipient:    "c@example.com",
+					Status:       sqlentity.NotificationSent,
+					Attempts:     1,
+					SentAt:       sql.NullTime{Time: createdAt, Valid: true},
+					CreatedAt:    createdAt,
+					UpdatedAt:    createdAt,
+				},
+				{
+					ID:           21,
+					LoanID:       1,
+					InvestmentID: 11,
+					UserID:       4,
+					Template:     sqlentity.AgreementLetterNotification,
+					Recipient:    "d@example.com",
+					Status:       sqlentity.NotificationFailed,
+					Attempts:     3,
+					LastError:    sql.NullString{String: "connection refused", Valid: true},
+					CreatedAt:    createdAt,
+					UpdatedAt:    createdAt,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLoanNotification(tt.args.ctx, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLoanNotification() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_InsertLoanInstallments() {
+	createdAt := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+	installments := sqlentity.LoanInstallments{
+		{
+			ID:                 30,
+			LoanID:             1,
+			InstallmentNumber:  1,
+			DueDate:            time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC),
+			PrincipalAmount:    decimal.RequireFromString("500000"),
+			InterestAmount:     decimal.RequireFromString("10000"),
+			TotalAmount:        decimal.RequireFromString("510000"),
+			OutstandingBalance: decimal.RequireFromString("500000"),
+			CreatedAt:          createdAt,
+			UpdatedAt:          createdAt,
+		},
+		{
+			ID:                 31,
+			LoanID:             1,
+			InstallmentNumber:  2,
+			DueDate:            time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			PrincipalAmount:    decimal.RequireFromString("500000"),
+			InterestAmount:     decimal.RequireFromString("10000"),
+			TotalAmount:        decimal.RequireFromString("510000"),
+			OutstandingBalance: decimal.Zero,
+			CreatedAt:          createdAt,
+			UpdatedAt:          createdAt,
+		},
+	}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Insert(ls.loanInstallmentTableName).
+			Cols(installments[0].Columns()...).
+			Vals(installments[0].Values()).
+			Vals(installments[1].Values()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		in      sqlentity.LoanInstallments
+		mockFn  func()
+		wantErr bool
+	}{
+		{
+			name:   "success nothing to insert",
+			mockFn: func() {},
+		},
+		{
+			name: "error exec",
+			in:   installments,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error partially inserted",
+			in:   installments,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			in:   installments,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.InsertLoanInstallments(context.Background(), tt.in)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.InsertLoanInstallments() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLoanInstallment() {
+	var installment sqlentity.LoanInstallment
+	createdAt := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Select(installment.Columns()...).
+			From(ls.loanInstallmentTableName).
+			Order(goqu.C("installment_number").Asc()).
+			Where(goqu.Ex{"loan_id": 1}).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		want    sqlentity.LoanInstallments
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(installment.StringColumns()).
+						AddRow(
+							30, 1, 1, dueDate, "510000.00", "10000.00", "520000.00", "500000.00", "0.00", "10000.00",
+							"PARTIAL", nil, createdAt, createdAt,
+						),
+				)
+			},
+			want: sqlentity.LoanInstallments{
+				{
+					ID:                  30,
+					LoanID:              1,
+					InstallmentNumber:   1,
+					DueDate:             dueDate,
+					PrincipalAmount:     decimal.RequireFromString("510000.00"),
+					InterestAmount:      decimal.RequireFromString("10000.00"),
+					TotalAmount:         decimal.RequireFromString("520000.00"),
+					OutstandingBalance:  decimal.RequireFromString("500000.00"),
+					PaidPrincipalAmount: decimal.RequireFromString("0.00"),
+					PaidInterestAmount:  decimal.RequireFromString("10000.00"),
+					Status:              sqlentity.InstallmentPartial,
+					CreatedAt:           createdAt,
+					UpdatedAt:           createdAt,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLoanInstallment(context.Background(), GetLoanInstallmentWithLoanIDFilter(1))
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLoanInstallment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_UpdateLoanInstallment() {
+	type args struct {
+		ctx  context.Context
+		in   sqlentity.UpdateEntity
+		opts []UpdateLoanInstallmentOption
+	}
+
+	paidAt := time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC)
+	defaultArgs := args{
+		ctx: context.Background(),
+		in: sqlentity.UpdateLoanInstallmentPayment{
+			PaidPrincipalAmount: decimal.RequireFromString("500000"),
+			PaidInterestAmount:  decimal.RequireFromString("10000"),
+			Status:              sqlentity.InstallmentPaid,
+			PaidAt:              sql.NullTime{Time: paidAt, Valid: true},
+			UpdatedAt:           paidAt,
+		},
+		opts: []UpdateLoanInstallmentOption{
+			UpdateLoanInstallmentWithIDFilter(30),
+		},
+	}
+
+	query := func(a args) string {
+		query, _, err := ls.queryBuilder.Update(ls.loanInstallmentTableName).
+			Set(a.in.MappedValues()).
+			Where(goqu.Ex{"id": 30}).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no rows affected",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			if err := r.UpdateLoanInstallment(tt.args.ctx, tt.args.in, tt.args.opts...); (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.UpdateLoanInstallment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_InsertLoanRepayment() {
+	type args struct {
+		ctx context.Context
+		in  sqlentity.LoanRepayment
+	}
+
+	defaultArgs := args{
+		ctx: context.Background(),
+		in: sqlentity.LoanRepayment{
+			ID:              40,
+			LoanID:          1,
+			BorrowerID:      2,
+			Amount:          decimal.RequireFromString("600000"),
+			PrincipalAmount: decimal.RequireFromString("580000"),
+			InterestAmount:  decimal.RequireFromString("20000"),
+			OverpaidAmount:  decimal.Zero,
+			PaidAt:          time.Date(2024, 11, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	query := func(a args) string {
+		query, _, err := ls.queryBuilder.Insert(ls.loanRepaymentTableName).
+			Cols(a.in.Columns()...).
+			Vals(a.in.Values()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no rows affected",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				ls.dbmock.ExpectExec(query(a)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			if err := r.InsertLoanRepayment(tt.args.ctx, tt.args.in); (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.InsertLoanRepayment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_InsertLoanInvestorCredits() {
+	credits := sqlentity.LoanInvestorCredits{
+		{
+			ID:              50,
+			LoanID:          1,
+			RepaymentID:     40,
+			InvestmentID:    10,
+			InvestorID:      3,
+			PrincipalAmount: decimal.RequireFromString("435000"),
+			InterestAmount:  decimal.RequireFromString("13500"),
+			FeeAmount:       decimal.RequireFromString("1500"),
+		},
+		{
+			ID:              51,
+			LoanID:          1,
+			RepaymentID:     40,
+			InvestmentID:    11,
+			InvestorID:      4,
+			PrincipalAmount: decimal.RequireFromString("145000"),
+			InterestAmount:  decimal.RequireFromString("4500"),
+			FeeAmount:       decimal.RequireFromString("500"),
+		},
+	}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Insert(ls.loanCreditTableName).
+			Cols(credits[0].Columns()...).
+			Vals(credits[0].Values()).
+			Vals(credits[1].Values()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		in      sqlentity.LoanInvestorCredits
+		mockFn  func()
+		wantErr bool
+	}{
+		{
+			name:   "success nothing to insert",
+			mockFn: func() {},
+		},
+		{
+			name: "error exec",
+			in:   credits,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error partially inserted",
+			in:   credits,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			in:   credits,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.InsertLoanInvestorCredits(context.Background(), tt.in)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.InsertLoanInvestorCredits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLoanInvestorCredit() {
+	var credit sqlentity.LoanInvestorCredit
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Select(credit.Columns()...).
+			From(ls.loanCreditTableName).
+			Order(goqu.C("id").Asc()).
+			Where(goqu.Ex{"loan_id": 1}).
+			Where(goqu.Ex{"repayment_id": 40}).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		want    sqlentity.LoanInvestorCredits
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(credit.StringColumns()).
+						AddRow("invalid", 1, 40, 10, 3, "435000.00", "13500.00", "1500.00"),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(credit.StringColumns()).
+						AddRow(50, 1, 40, 10, 3, "435000.00", "13500.00", "1500.00"),
+				)
+			},
+			want: sqlentity.LoanInvestorCredits{
+				{
+					ID:              50,
+					LoanID:          1,
+					RepaymentID:     40,
+					InvestmentID:    10,
+					InvestorID:      3,
+					PrincipalAmount: decimal.RequireFromString("435000.00"),
+					InterestAmount:  decimal.RequireFromString("13500.00"),
+					FeeAmount:       decimal.RequireFromString("1500.00"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLoanInvestorCredit(
+				context.Background(),
+				GetLoanInvestorCreditWithLoanIDFilter(1),
+				GetLoanInvestorCreditWithRepaymentIDFilter(40),
+			)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLoanInvestorCredit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLedgerAccount() {
+	var account sqlentity.LedgerAccount
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Select(account.Columns()...).
+			From(ls.ledgerAccountTableName).
+			Order(goqu.C("id").Asc()).
+			Where(goqu.Or(
+				goqu.Ex{"type": sqlentity.InvestorWallet, "owner_id": 3},
+				goqu.Ex{"type": sqlentity.PlatformEscrow, "owner_id": 0},
+			)).
+			ForShare(exp.Wait).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		want    sqlentity.LedgerAccounts
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(account.StringColumns()).AddRow(1, 10, 3),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(account.StringColumns()).
+						AddRow(1, "INVESTOR_WALLET", 3).
+						AddRow(2, "PLATFORM_ESCROW", 0),
+				)
+			},
+			want: sqlentity.LedgerAccounts{
+				{ID: 1, Type: sqlentity.InvestorWallet, OwnerID: 3},
+				{ID: 2, Type: sqlentity.PlatformEscrow},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLedgerAccount(
+				context.Background(),
+				GetLedgerAccountWithKeysFilter(
+					sqlentity.LedgerAccount{Type: sqlentity.InvestorWallet, OwnerID: 3},
+					sqlentity.LedgerAccount{Type: sqlentity.PlatformEscrow},
+				),
+				GetLedgerAccountWithLock(),
+			)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLedgerAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_InsertLedgerLines() {
+	lines := sqlentity.LedgerLines{
+		{ID: 60, EntryID: 70, AccountID: 1, DebitAmount: decimal.NewFromInt(1_000), CreditAmount: decimal.Zero},
+		{ID: 61, EntryID: 70, AccountID: 2, DebitAmount: decimal.Zero, CreditAmount: decimal.NewFromInt(1_000)},
+	}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Insert(ls.ledgerLineTableName).
+			Cols(lines[0].Columns()...).
+			Vals(lines[0].Values()).
+			Vals(lines[1].Values()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		in      sqlentity.LedgerLines
+		mockFn  func()
+		wantErr bool
+	}{
+		{
+			name:   "success nothing to insert",
+			mockFn: func() {},
+		},
+		{
+			name: "error exec",
+			in:   lines,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error partially inserted",
+			in:   lines,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			in:   lines,
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.InsertLedgerLines(context.Background(), tt.in)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.InsertLedgerLines() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetLedgerTrialBalance() {
+	var balance sqlentity.LedgerAccountBalance
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.From(goqu.T(ls.ledgerAccountTableName).As("a")).
+			LeftJoin(
+				goqu.T(ls.ledgerLineTableName).As("l"),
+				goqu.On(goqu.I("l.account_id").Eq(goqu.I("a.id"))),
+			).
+			Select(
+				goqu.I("a.id").As("account_id"),
+				goqu.I("a.type"),
+				goqu.I("a.owner_id"),
+				goqu.COALESCE(goqu.SUM(goqu.I("l.debit_amount")), 0).As("debit_amount"),
+				goqu.COALESCE(goqu.SUM(goqu.I("l.credit_amount")), 0).As("credit_amount"),
+			).
+			GroupBy(goqu.I("a.id"), goqu.I("a.type"), goqu.I("a.owner_id")).
+			Order(goqu.I("a.id").Asc()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		want    sqlentity.LedgerAccountBalances
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(balance.StringColumns()).AddRow("invalid", "PLATFORM_ESCROW", 0, "0.00", "0.00"),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(balance.StringColumns()).
+						AddRow(1, "INVESTOR_WALLET", 3, "1000.00", "0").
+						AddRow(2, "PLATFORM_ESCROW", 0, "0", "1000.00"),
+				)
+			},
+			want: sqlentity.LedgerAccountBalances{
+				{
+					AccountID:    1,
+					Type:         sqlentity.InvestorWallet,
+					OwnerID:      3,
+					DebitAmount:  decimal.RequireFromString("1000.00"),
+					CreditAmount: decimal.RequireFromString("0"),
+				},
+				{
+					AccountID:    2,
+					Type:         sqlentity.PlatformEscrow,
+					DebitAmount:  decimal.RequireFromString("0"),
+					CreditAmount: decimal.RequireFromString("1000.00"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetLedgerTrialBalance(context.Background())
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetLedgerTrialBalance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetWallet() {
+	var wallet sqlentity.Wallet
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Select(wallet.Columns()...).
+			From(ls.walletTableName).
+			Order(goqu.C("investor_id").Asc()).
+			Where(goqu.Ex{"investor_id": []uint64{3, 4}}).
+			ForUpdate(exp.Wait).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		want    sqlentity.Wallets
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error scan",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(wallet.StringColumns()).AddRow(3, "abc", "0"),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectQuery(query()).WillReturnRows(
+					sqlmock.NewRows(wallet.StringColumns()).
+						AddRow(3, "1000.00", "600.00").
+						AddRow(4, "50.50", "0.00"),
+				)
+			},
+			want: sqlentity.Wallets{
+				{
+					InvestorID:     3,
+					Balance:        decimal.RequireFromString("1000.00"),
+					ReservedAmount: decimal.RequireFromString("600.00"),
+				},
+				{
+					InvestorID:     4,
+					Balance:        decimal.RequireFromString("50.50"),
+					ReservedAmount: decimal.RequireFromString("0.00"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetWallet(
+				context.Background(),
+				GetWalletWithInvestorIDFilter(3, 4),
+				GetWalletWithLock(),
+			)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetWallet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_InsertWallet() {
+	wallet := sqlentity.Wallet{InvestorID: 3, Balance: decimal.Zero, ReservedAmount: decimal.Zero}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Insert(ls.walletTableName).
+			Cols(wallet.Columns()...).
+			Vals(wallet.Values()).
+			OnConflict(goqu.DoNothing()).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success wallet already open",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.InsertWallet(context.Background(), wallet)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.InsertWallet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_UpdateWalletReservation() {
+	update := sqlentity.UpdateWalletReservationStatus{Status: sqlentity.ReservationCaptured}
+
+	query := func() string {
+		query, _, err := ls.queryBuilder.Update(ls.walletReservationTableName).
+			Set(update.MappedValues()).
+			Where(goqu.Ex{"id": uint64(20)}).
+			Where(goqu.Ex{"status": sqlentity.ReservationHeld}).
+			ToSQL()
+		ls.NoError(err)
+
+		return query
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func()
+		wantErr error
+	}{
+		{
+			name: "error exec",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+		{
+			name: "error reservation no longer held",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: ErrWalletReservationNotUpdated,
+		},
+		{
+			name: "success",
+			mockFn: func() {
+				ls.dbmock.ExpectExec(query()).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn()
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.UpdateWalletReservation(
+				context.Background(),
+				update,
+				UpdateWalletReservationWithIDFilter(20),
+				UpdateWalletReservationWithStatusFilter(sqlentity.ReservationHeld),
+			)
+			ls.ErrorIs(err, tt.wantErr)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_GetUser() {
+	var user sqlentity.User
+	createdAt := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx  context.Context
+		opts []GetUserOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		want    sqlentity.Users
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetUserOption{GetUserWithIDFilter(1)},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(user.Columns()...).
+					From(ls.userTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"id": []uint64{1}}).
+					ToSQL()
+				ls.NoError(err)
+
+				ls.dbmock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetUserOption{GetUserWithIDFilter(2), GetUserWithTypeFilter(sqlentity.Investor)},
+			},
+			mockFn: func(a args) {
+				query, _, err := ls.queryBuilder.Select(user.Columns()...).
+					From(ls.userTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"id": []uint64{2}}).
+					Where(goqu.Ex{"type": sqlentity.Investor}).
+					ToSQL()
+				ls.NoError(err)
+
+				// the mysql driver returns text columns as bytes
+				ls.dbmock.ExpectQuery(query).WillReturnRows(
+					sqlmock.NewRows(user.StringColumns()).
+						AddRow(2, "B", "b@example.com", []byte("investor"), nil, createdAt, createdAt),
+				)
+			},
+			want: sqlentity.Users{
+				{
+					ID:        2,
+					Name:      "B",
+					Email:     sql.NullString{String: "b@example.com", Valid: true},
+					Type:      sqlentity.Investor,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			got, err := r.GetUser(tt.args.ctx, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.GetUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ls.Equal(tt.want, got)
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (ls *loanSQLGatewaySuite) TestLoanSQLGateway_WithinTx() {
+	now := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx     context.Context
+		in      sqlentity.UpdateEntity
+		history sqlentity.LoanStatusHistory
+		opts    []UpdateLoanOption
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		in:  sqlentity.UpdateLoanStatus{Status: sqlentity.Invested},
+		history: sqlentity.LoanStatusHistory{
+			ID:          2,
+			LoanID:      1,
+			FromStatus:  sqlentity.Approved,
+			ToStatus:    sqlentity.Invested,
+			ActorUserID: 3,
+			Reason:      "loan fully funded",
+			CreatedAt:   now,
+		},
+		opts: []UpdateLoanOption{UpdateLoanWithLoanIDFilter(1)},
+	}
+	queries := func(a args) (string, string) {
+		updateQuery, _, err := ls.queryBuilder.Update(ls.loanTableName).
+			Set(a.in.MappedValues()).
+			Where(goqu.Ex{"id": 1}).
+			ToSQL()
+		ls.NoError(err)
+
+		insertQuery, _, err := ls.queryBuilder.Insert("loan_status_histories").
+			Cols(a.history.Columns()...).
+			Vals(a.history.Values()).
+			ToSQL()
+		ls.NoError(err)
+
+		return updateQuery, insertQuery
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "error insert history rolls back",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				updateQuery, insertQuery := queries(a)
+
+				ls.dbmock.ExpectBegin()
+				ls.dbmock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				ls.dbmock.ExpectExec(insertQuery).WillReturnError(sql.ErrConnDone)
+				ls.dbmock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				updateQuery, insertQuery := queries(a)
+
+				ls.dbmock.ExpectBegin()
+				ls.dbmock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				ls.dbmock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				ls.dbmock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		ls.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewLoanSQLGateway(
+				ls.db,
+				zap.NewNop().Sugar(),
+				ls.queryBuilder,
+			)
+			err := r.WithinTx(tt.args.ctx, func(ctx context.Context) error {
+				if err := r.UpdateLoan(ctx, tt.args.in, tt.args.opts...); err != nil {
+					return err
+				}
+
+				return r.InsertLoanStatusHistory(ctx, tt.args.history)
+			})
+			if (err != nil) != tt.wantErr {
+				ls.T().Errorf("LoanSQLGateway.WithinTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			ls.NoError(ls.dbmock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgworker"
+	"go.uber.org/zap"
+)
+
+// NewLoanExpiryWorkerGateway returns the worker expiring the overdue loans every interval. Only the instance holding
+// the lease runs it, for leaseTTL at a time; it runs again right away while batches come back full.
+func NewLoanExpiryWorkerGateway(
+	interval time.Duration,
+	lease pkgworker.Lease,
+	leaseTTL time.Duration,
+	expireLoansUsecase usecase.ExpireLoans,
+	logger *zap.SugaredLogger,
+) *pkgworker.Periodic {
+	job := func(ctx context.Context) (bool, error) {
+		out, err := expireLoansUsecase.Execute(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		return out.HasMore, nil
+	}
+
+	return pkgworker.NewPeriodic("loan-expiry", interval, pkgworker.Leased(lease, "loan-expiry", leaseTTL, job), logger)
+}
@@ -1,17 +1,37 @@
 package interactor
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
 	"go.uber.org/zap"
 )
 
+// defaultFundingWindow is how long an approved loan stays open for investment when no window is configured.
+const defaultFundingWindow = 30 * 24 * time.Hour
+
+// proofPhotoExtensions maps the accepted content types of a proof photo to the extension it is stored with.
+//
+//nolint:gochecknoglobals // intended to be global
+var proofPhotoExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
 type (
 	UpdateLoanStore interface {
 		UpdateLoan(
@@ -19,23 +39,49 @@ type (
 			in sqlentity.UpdateEntity,
 			opts ...gateway.UpdateLoanOption,
 		) error
+		InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error
 		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
 	}
 
 	ApproveLoan struct {
-		store UpdateLoanStore
+		store         UpdateLoanStore
+		userStore     UserStore
+		transactor    pkgsql.Transactor
+		outbox        pkgoutbox.Recorder
+		documentStore pkgstorage.DocumentStore
+		stateMachine  *statemachine.LoanStateMachine
+		fundingWindow time.Duration
 
-		logger *zap.SugaredLogger
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
 	}
 )
 
 func NewApproveLoan(
 	store UpdateLoanStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
+	documentStore pkgstorage.DocumentStore,
+	stateMachine *statemachine.LoanStateMachine,
+	fundingWindow time.Duration,
 	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
 ) *ApproveLoan {
+	if fundingWindow <= 0 {
+		fundingWindow = defaultFundingWindow
+	}
+
 	return &ApproveLoan{
-		store:  store,
-		logger: logger,
+		store:         store,
+		userStore:     userStore,
+		transactor:    transactor,
+		outbox:        outbox,
+		documentStore: documentStore,
+		stateMachine:  stateMachine,
+		fundingWindow: fundingWindow,
+		logger:        logger,
+		snowflakeGen:  snowflakeGen,
 	}
 }
 
@@ -43,40 +89,136 @@ func (a *ApproveLoan) Execute(
 	ctx context.Context,
 	in usecase.ApprovedLoanInput,
 ) error {
+	if in.ApprovalDate.After(time.Now()) {
+		a.logger.Errorw("approval date is in the future", "approval_date", in.ApprovalDate)
+
+		return pkgerror.NewValidationError("approval date cannot be in the future")
+	}
+
+	contentType := http.DetectContentType(in.ProofPhoto)
+	if _, ok := proofPhotoExtensions[contentType]; !ok {
+		a.logger.Errorw("proof photo is not an image")
+
+		return pkgerror.NewValidationError("proof photo must be a JPEG or PNG image")
+	}
+
+	if err := requireUserType(ctx, a.userStore, in.EmployeeID, sqlentity.Employee, pkgerror.UserNotEmployee); err != nil {
+		a.logger.Errorw("user cannot approve a loan", "error", err)
+
+		return err
+	}
+
+	var proofKey string
+	err := a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		proofKey, err = a.approve(ctx, in, contentType)
+
+		return err
+	})
+
+	// the stored photo is unreachable once the approval rolls back, failing to remove it only leaves an orphan behind
+	if err != nil && proofKey != "" {
+		if err := a.documentStore.Delete(ctx, proofKey); err != nil {
+			a.logger.Warnw("failed to delete orphaned proof photo", "key", proofKey, "error", err)
+		}
+	}
+
+	return err
+}
+
+// approve returns the key of the proof photo as soon as it is stored, so the caller can remove it when the
+// approval does not commit.
+func (a *ApproveLoan) approve(ctx context.Context, in usecase.ApprovedLoanInput, contentType string) (string, error) {
 	loans, err := a.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
 	if err != nil {
 		a.logger.Errorw("failed to get loan", "error", err)
 
-		return pkgerror.ServerErrorFrom(err)
+		return "", pkgerror.ServerErrorFrom(err)
 	}
 
 	var loan sqlentity.Loan
 	if loan = loans.First(); loans.IsEmpty() {
 		a.logger.Errorw("loan not found")
 
-		return pkgerror.NewBusinessError("loan not found")
+		return "", pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
 	}
 
-	if loan.Status != sqlentity.Proposed {
-		a.logger.Errorw("loan already approved")
+	proofKey := fmt.Sprintf(
+		"loan/%d/approval-proof/%d%s",
+		loan.ID,
+		a.snowflakeGen.Generate(),
+		proofPhotoExtensions[contentType],
+	)
+	loan.ApprovalProofDocumentKey = sql.NullString{String: proofKey, Valid: true}
+
+	approvedLoan, err := a.stateMachine.Transition(ctx, loan, sqlentity.Approved)
+	if err != nil {
+		a.logger.Errorw("loan cannot be approved", "error", err)
 
-		return pkgerror.NewBusinessError("loan already approved")
+		return "", err
 	}
 
-	if err := a.store.UpdateLoan(ctx, sqlentity.ApproveLoan{
-		ApprovalDate: sql.NullTime{
-			Valid: true,
-			Time:  time.Now(),
-		},
-		ApprovalEmployeeID: sql.NullInt64{
-			Valid: true,
-			Int64: int64(in.EmployeeID),
+	if err := a.documentStore.Put(ctx, proofKey, bytes.NewReader(in.ProofPhoto), contentType); err != nil {
+		a.logger.Errorw("failed to store proof photo", "error", err)
+
+		return "", pkgerror.ServerErrorFrom(err)
+	}
+
+	now := time.Now()
+	fundingDeadline := now.Add(a.fundingWindow)
+
+	if err := a.store.UpdateLoan(
+		ctx,
+		sqlentity.ApproveLoan{
+			ApprovalDate: sql.NullTime{
+				Valid: true,
+				Time:  in.ApprovalDate,
+			},
+			ApprovalEmployeeID: sql.NullInt64{
+				Valid: true,
+				Int64: int64(in.EmployeeID),
+			},
+			ApprovalProofDocumentKey: loan.ApprovalProofDocumentKey,
+			FundingDeadline: sql.NullTime{
+				Valid: true,
+				Time:  fundingDeadline,
+			},
+			Version: loan.Version + 1,
 		},
-	}, gateway.UpdateLoanWithLoanIDFilter(in.LoanID)); err != nil {
+		gateway.UpdateLoanWithLoanIDFilter(in.LoanID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
+	); err != nil {
 		a.logger.Errorw("failed to update loan", "error", err)
 
-		return pkgerror.ServerErrorFrom(err)
+		return proofKey, updateLoanError(err)
+	}
+
+	if err := a.store.InsertLoanStatusHistory(ctx, sqlentity.LoanStatusHistory{
+		ID:          a.snowflakeGen.Generate(),
+		LoanID:      loan.ID,
+		FromStatus:  loan.Status,
+		ToStatus:    approvedLoan.Status,
+		ActorUserID: in.EmployeeID,
+		Reason:      "loan approved by employee",
+		CreatedAt:   now,
+	}); err != nil {
+		a.logger.Errorw("failed to insert loan status history", "error", err)
+
+		return proofKey, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := recordLoanEvent(ctx, a.outbox, a.snowflakeGen.Generate(), loan.ID, event.LoanApproved,
+		event.LoanApprovedPayload{
+			LoanID:          loan.ID,
+			EmployeeID:      in.EmployeeID,
+			ApprovalDate:    in.ApprovalDate,
+			FundingDeadline: fundingDeadline,
+		},
+	); err != nil {
+		a.logger.Errorw("failed to record loan event", "error", err)
+
+		return proofKey, pkgerror.ServerErrorFrom(err)
 	}
 
-	return nil
+	return proofKey, nil
 }
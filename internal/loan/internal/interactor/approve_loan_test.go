@@ -0,0 +1,402 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// pngProofPhoto starts with the PNG signature, which is all content sniffing looks at.
+var pngProofPhoto = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func approveLoanInput(loanID, employeeID uint64) usecase.ApprovedLoanInput {
+	return usecase.ApprovedLoanInput{
+		LoanID:       loanID,
+		EmployeeID:   employeeID,
+		ApprovalDate: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		ProofPhoto:   pngProofPhoto,
+	}
+}
+
+func TestApproveLoan_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.ApprovedLoanInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockUpdateLoanStore,
+			userStore *loanmocks.MockUserStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			documentStore *pkgmocks.MockDocumentStore,
+			a args,
+		)
+		commitErr error
+		wantCode  pkgerror.Code
+		wantErr   bool
+		wantEvent string
+	}{
+		{
+			name: "error approval date in the future",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.ApprovedLoanInput{
+					LoanID:       1,
+					EmployeeID:   4,
+					ApprovalDate: time.Now().AddDate(0, 0, 1),
+					ProofPhoto:   pngProofPhoto,
+				},
+			},
+			mockFn: func(
+				_ *loanmocks.MockUpdateLoanStore,
+				_ *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				_ *pkgmocks.MockDocumentStore,
+				_ args,
+			) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error proof photo is not an image",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.ApprovedLoanInput{
+					LoanID:       1,
+					EmployeeID:   4,
+					ApprovalDate: time.Now(),
+					ProofPhoto:   []byte("%PDF-1.4 not a photo"),
+				},
+			},
+			mockFn: func(
+				_ *loanmocks.MockUpdateLoanStore,
+				_ *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				_ *pkgmocks.MockDocumentStore,
+				_ args,
+			) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error user not found",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				_ *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				_ *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantCode: pkgerror.UserNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error user is not an employee",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 2),
+			},
+			mockFn: func(
+				_ *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				_ *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 2, Type: sqlentity.Investor}}, nil).Once()
+			},
+			wantCode: pkgerror.UserNotEmployee,
+			wantErr:  true,
+		},
+		{
+			name: "error loan not found",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				_ *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantCode: pkgerror.LoanNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error loan already approved",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				_ *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Once()
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+		{
+			name: "error when store proof photo",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				documentStore *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Once()
+
+				documentStore.EXPECT().Put(a.ctx, "loan/1/approval-proof/2.png", mock.Anything, "image/png").
+					Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when update loan",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				documentStore *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Once()
+
+				documentStore.EXPECT().Put(a.ctx, "loan/1/approval-proof/2.png", mock.Anything, "image/png").Return(nil).Once()
+
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanNotUpdated).Once()
+
+				documentStore.EXPECT().Delete(a.ctx, "loan/1/approval-proof/2.png").Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when insert status history",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				documentStore *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Twice()
+
+				documentStore.EXPECT().Put(a.ctx, "loan/1/approval-proof/2.png", mock.Anything, "image/png").Return(nil).Once()
+
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+
+				store.EXPECT().InsertLoanStatusHistory(a.ctx, mock.Anything).
+					Return(errors.New("any error")).Once()
+
+				documentStore.EXPECT().Delete(a.ctx, "loan/1/approval-proof/2.png").
+					Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when commit approval",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				documentStore *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Times(3)
+
+				documentStore.EXPECT().Put(a.ctx, "loan/1/approval-proof/2.png", mock.Anything, "image/png").Return(nil).Once()
+
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+
+				store.EXPECT().InsertLoanStatusHistory(a.ctx, mock.Anything).
+					Return(nil).Once()
+
+				documentStore.EXPECT().Delete(a.ctx, "loan/1/approval-proof/2.png").Return(nil).Once()
+			},
+			commitErr: errors.New("any error"),
+			wantErr:   true,
+			wantEvent: event.LoanApproved,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				in:  approveLoanInput(1, 4),
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				documentStore *pkgmocks.MockDocumentStore,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Times(3)
+
+				documentStore.EXPECT().Put(a.ctx, "loan/1/approval-proof/2.png", mock.Anything, "image/png").Return(nil).Once()
+
+				store.EXPECT().UpdateLoan(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.ApproveLoan) bool {
+						return in.ApprovalDate.Time.Equal(a.in.ApprovalDate) &&
+							in.ApprovalProofDocumentKey.String == "loan/1/approval-proof/2.png" &&
+							in.FundingDeadline.Valid &&
+							in.FundingDeadline.Time.After(time.Now().Add(59*time.Minute))
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+
+				store.EXPECT().InsertLoanStatusHistory(
+					a.ctx,
+					mock.MatchedBy(func(h sqlentity.LoanStatusHistory) bool {
+						return h.ID == 2 &&
+							h.LoanID == 1 &&
+							h.FromStatus == sqlentity.Proposed &&
+							h.ToStatus == sqlentity.Approved &&
+							h.ActorUserID == 4
+					}),
+				).Return(nil).Once()
+			},
+			wantErr:   false,
+			wantEvent: event.LoanApproved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockUpdateLoanStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			documentStore := pkgmocks.NewMockDocumentStore(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(tt.args.ctx, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					if err := fn(ctx); err != nil {
+						return err
+					}
+
+					return tt.commitErr
+				}).Maybe()
+			outbox := pkgmocks.NewMockRecorder(t)
+			tt.mockFn(store, userStore, snowflakeGen, documentStore, tt.args)
+			if tt.wantEvent != "" {
+				outbox.EXPECT().Record(tt.args.ctx, mock.MatchedBy(func(e pkgoutbox.Event) bool {
+					return e.Type == tt.wantEvent && e.AggregateID == tt.args.in.LoanID
+				})).Return(nil).Once()
+			}
+
+			a := NewApproveLoan(
+				store,
+				userStore,
+				transactor,
+				outbox,
+				documentStore,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				time.Hour,
+				logger,
+				snowflakeGen,
+			)
+			err := a.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ApproveLoan.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"go.uber.org/zap"
+)
+
+type (
+	CancelLoanInvestmentStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		UpdateLoan(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanOption,
+		) error
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		UpdateLoanInvestment(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanInvestmentOption,
+		) error
+	}
+
+	CancelLoanInvestment struct {
+		store        CancelLoanInvestmentStore
+		userStore    UserStore
+		transactor   pkgsql.Transactor
+		outbox       pkgoutbox.Recorder
+		wallets      wallet.Keeper
+		stateMachine *statemachine.LoanStateMachine
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+
+		// a cancellation losing the optimistic lock of the loan to an investment is retried up to maxAttempts times
+		maxAttempts  int
+		retryBackoff time.Duration
+	}
+)
+
+func NewCancelLoanInvestment(
+	store CancelLoanInvestmentStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
+	wallets wallet.Keeper,
+	stateMachine *statemachine.LoanStateMachine,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *CancelLoanInvestment {
+	return &CancelLoanInvestment{
+		store:        store,
+		userStore:    userStore,
+		transactor:   transactor,
+		outbox:       outbox,
+		wallets:      wallets,
+		stateMachine: stateMachine,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+		maxAttempts:  loanUpdateMaxAttempts,
+		retryBackoff: loanUpdateRetryBackoff,
+	}
+}
+
+func (c *CancelLoanInvestment) Execute(
+	ctx context.Context,
+	in usecase.CancelLoanInvestmentInput,
+) (*usecase.CancelLoanInvestmentOutput, error) {
+	if err := requireUserType(ctx, c.userStore, in.InvestorID, sqlentity.Investor, pkgerror.UserNotInvestor); err != nil {
+		c.logger.Errorw("user cannot cancel a loan investment", "error", err)
+
+		return nil, err
+	}
+
+	var out *usecase.CancelLoanInvestmentOutput
+	var err error
+
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		err = c.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+			out, err = c.cancel(ctx, in)
+
+			return err
+		})
+		if !errors.Is(err, gateway.ErrLoanNotUpdated) {
+			break
+		}
+
+		c.logger.Warnw("loan was modified concurrently, retrying cancellation", "loan_id", in.LoanID, "attempt", attempt)
+
+		if waitErr := waitBeforeLoanUpdateRetry(ctx, attempt, c.retryBackoff); waitErr != nil {
+			return nil, pkgerror.ServerErrorFrom(waitErr)
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, gateway.ErrLoanNotUpdated) {
+			return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanConcurrentUpdate)
+		}
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// cancel marks the investment cancelled, takes its amount off the loan and gives the money reserved for it back to
+// the investor. The loan is updated with its version, an investment made or cancelled meanwhile rolls the
+// cancellation back to be retried on the loan as it is now.
+func (c *CancelLoanInvestment) cancel(
+	ctx context.Context,
+	in usecase.CancelLoanInvestmentInput,
+) (*usecase.CancelLoanInvestmentOutput, error) {
+	loans, err := c.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		c.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		c.logger.Errorw("loan not found")
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	// like investing, cancelling is only possible while the loan can still become fully funded; once it is, the
+	// reservations are captured and the investment can no longer be given back
+	if err := c.stateMachine.CanTransition(loan.Status, sqlentity.Invested); err != nil {
+		c.logger.Errorw("loan investment cannot be cancelled", "loan_id", loan.ID, "error", err)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanInvestmentNotCancellable)
+	}
+
+	investments, err := c.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithIDFilter(in.InvestmentID),
+		gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+	)
+	if err != nil {
+		c.logger.Errorw("failed to get loan investment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var investment sqlentity.LoanInvestment
+	if investment = investments.First(); investments.IsEmpty() {
+		c.logger.Errorw("loan investment not found", "loan_id", loan.ID, "investment_id", in.InvestmentID)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanInvestmentNotFound)
+	}
+
+	if investment.InvestorID != in.InvestorID {
+		c.logger.Errorw("loan investment belongs to another investor", "investment_id", investment.ID)
+
+		return nil, pkgerror.NewAuthorizationError("investment belongs to another user")
+	}
+
+	if investment.Status == sqlentity.InvestmentCancelled {
+		c.logger.Errorw("loan investment already cancelled", "investment_id", investment.ID)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanInvestmentAlreadyCancelled)
+	}
+
+	if err := c.store.UpdateLoanInvestment(
+		ctx,
+		sqlentity.CancelLoanInvestment{
+			CancelledBy: sql.NullInt64{
+				Valid: true,
+				Int64: int64(in.InvestorID),
+			},
+			CancelledAt: sql.NullTime{
+				Valid: true,
+				Time:  time.Now(),
+			},
+		},
+		gateway.UpdateLoanInvestmentWithIDFilter(investment.ID),
+		gateway.UpdateLoanInvestmentWithStatusFilter(sqlentity.InvestmentActive),
+	); err != nil {
+		c.logger.Errorw("failed to cancel loan investment", "error", err)
+
+		if errors.Is(err, gateway.ErrLoanInvestmentNotUpdated) {
+			return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanConcurrentUpdate)
+		}
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	loan.InvestedAmount = loan.InvestedAmount.Sub(investment.Amount)
+
+	if err := c.store.UpdateLoan(
+		ctx,
+		sqlentity.UpdateAmountLoan{
+			Amount:  loan.InvestedAmount,
+			Version: loan.Version + 1,
+		},
+		gateway.UpdateLoanWithLoanIDFilter(loan.ID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
+	); err != nil {
+		c.logger.Errorw("failed to update loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := c.wallets.ReleaseInvestment(ctx, investment.ID); err != nil {
+		c.logger.Errorw("failed to release loan investment in wallet", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := recordLoanEvent(ctx, c.outbox, c.snowflakeGen.Generate(), loan.ID, event.LoanInvestmentCancelled,
+		event.LoanInvestmentCancelledPayload{
+			LoanID:       loan.ID,
+			InvestmentID: investment.ID,
+			InvestorID:   investment.InvestorID,
+			Amount:       investment.Amount,
+			CancelledBy:  in.InvestorID,
+		},
+	); err != nil {
+		c.logger.Errorw("failed to record loan event", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	return &usecase.CancelLoanInvestmentOutput{
+		InvestmentID:    investment.ID,
+		CancelledAmount: investment.Amount,
+		InvestedAmount:  loan.InvestedAmount,
+		RemainingAmount: remainingAmount(loan),
+	}, nil
+}
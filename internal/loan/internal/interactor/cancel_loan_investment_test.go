@@ -0,0 +1,268 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestCancelLoanInvestment_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	in := usecase.CancelLoanInvestmentInput{LoanID: 1, InvestmentID: 10, InvestorID: 3}
+	investor := sqlentity.Users{{ID: 3, Type: sqlentity.Investor}}
+	approvedLoan := sqlentity.Loans{{
+		ID:              1,
+		PrincipalAmount: decimal.NewFromInt(1_000),
+		InvestedAmount:  decimal.NewFromInt(600),
+		Status:          sqlentity.Approved,
+		Version:         4,
+	}}
+	investment := sqlentity.LoanInvestment{
+		ID:         10,
+		LoanID:     1,
+		InvestorID: 3,
+		Amount:     decimal.NewFromInt(250),
+		Status:     sqlentity.InvestmentActive,
+	}
+
+	type mocks struct {
+		store        *loanmocks.MockCancelLoanInvestmentStore
+		userStore    *loanmocks.MockUserStore
+		wallets      *loanmocks.MockKeeper
+		outbox       *pkgmocks.MockRecorder
+		snowflakeGen *pkgmocks.MockSnowflake
+	}
+
+	tests := []struct {
+		name     string
+		mockFn   func(m mocks)
+		want     *usecase.CancelLoanInvestmentOutput
+		wantErr  bool
+		wantCode pkgerror.Code
+	}{
+		{
+			name: "error user not investor",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).
+					Return(sqlentity.Users{{ID: 3, Type: sqlentity.Borrower}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.UserNotInvestor,
+		},
+		{
+			name: "error loan not found",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanNotFound,
+		},
+		{
+			name: "error loan fully funded",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Invested}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanInvestmentNotCancellable,
+		},
+		{
+			name: "error investment not found",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Once()
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanInvestmentNotFound,
+		},
+		{
+			name: "error investment of another investor",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Once()
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{{ID: 10, LoanID: 1, InvestorID: 4}}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error investment already cancelled",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Once()
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{{
+						ID:         10,
+						LoanID:     1,
+						InvestorID: 3,
+						Status:     sqlentity.InvestmentCancelled,
+					}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanInvestmentAlreadyCancelled,
+		},
+		{
+			name: "error investment cancelled meanwhile",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Once()
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{investment}, nil).Once()
+				m.store.EXPECT().UpdateLoanInvestment(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanInvestmentNotUpdated).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanConcurrentUpdate,
+		},
+		{
+			name: "error loan changed meanwhile on every attempt",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Times(2)
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{investment}, nil).Times(2)
+				m.store.EXPECT().UpdateLoanInvestment(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Times(2)
+				m.store.EXPECT().UpdateLoan(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanNotUpdated).Times(2)
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanConcurrentUpdate,
+		},
+		{
+			name: "success after loan changed meanwhile",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Times(2)
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{investment}, nil).Times(2)
+				m.store.EXPECT().UpdateLoanInvestment(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Times(2)
+				m.store.EXPECT().UpdateLoan(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanNotUpdated).Once()
+				m.store.EXPECT().UpdateLoan(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+				m.wallets.EXPECT().ReleaseInvestment(mock.Anything, uint64(10)).Return(nil).Once()
+				m.snowflakeGen.EXPECT().Generate().Return(uint64(99)).Once()
+				m.outbox.EXPECT().Record(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			want: &usecase.CancelLoanInvestmentOutput{
+				InvestmentID:    10,
+				CancelledAmount: decimal.NewFromInt(250),
+				InvestedAmount:  decimal.NewFromInt(350),
+				RemainingAmount: decimal.NewFromInt(650),
+			},
+		},
+		{
+			name: "error when release",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Once()
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{investment}, nil).Once()
+				m.store.EXPECT().UpdateLoanInvestment(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+				m.store.EXPECT().UpdateLoan(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+				m.wallets.EXPECT().ReleaseInvestment(mock.Anything, uint64(10)).Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func(m mocks) {
+				m.userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				m.store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(approvedLoan, nil).Once()
+				m.store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{investment}, nil).Once()
+				m.store.EXPECT().UpdateLoanInvestment(
+					mock.Anything,
+					mock.MatchedBy(func(in sqlentity.CancelLoanInvestment) bool {
+						return in.CancelledBy.Valid && in.CancelledBy.Int64 == 3 && in.CancelledAt.Valid
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+				m.store.EXPECT().UpdateLoan(
+					mock.Anything,
+					mock.MatchedBy(func(in sqlentity.UpdateAmountLoan) bool {
+						return in.Amount.Equal(decimal.NewFromInt(350)) && in.Version == 5
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+				m.wallets.EXPECT().ReleaseInvestment(mock.Anything, uint64(10)).Return(nil).Once()
+				m.snowflakeGen.EXPECT().Generate().Return(uint64(99)).Once()
+				m.outbox.EXPECT().Record(mock.Anything, mock.MatchedBy(func(e pkgoutbox.Event) bool {
+					return e.Type == event.LoanInvestmentCancelled
+				})).Return(nil).Once()
+			},
+			want: &usecase.CancelLoanInvestmentOutput{
+				InvestmentID:    10,
+				CancelledAmount: decimal.NewFromInt(250),
+				InvestedAmount:  decimal.NewFromInt(350),
+				RemainingAmount: decimal.NewFromInt(650),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks{
+				store:        loanmocks.NewMockCancelLoanInvestmentStore(t),
+				userStore:    loanmocks.NewMockUserStore(t),
+				wallets:      loanmocks.NewMockKeeper(t),
+				outbox:       pkgmocks.NewMockRecorder(t),
+				snowflakeGen: pkgmocks.NewMockSnowflake(t),
+			}
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Maybe()
+			tt.mockFn(m)
+
+			c := NewCancelLoanInvestment(
+				m.store,
+				m.userStore,
+				transactor,
+				m.outbox,
+				m.wallets,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				logger,
+				m.snowflakeGen,
+			)
+			c.maxAttempts = 2
+			c.retryBackoff = 0
+			got, err := c.Execute(context.Background(), in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CancelLoanInvestment.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"go.uber.org/zap"
+)
+
+type CaptureWalletReservations struct {
+	transactor pkgsql.Transactor
+	wallets    wallet.Keeper
+	logger     *zap.SugaredLogger
+}
+
+func NewCaptureWalletReservations(
+	transactor pkgsql.Transactor,
+	wallets wallet.Keeper,
+	logger *zap.SugaredLogger,
+) *CaptureWalletReservations {
+	return &CaptureWalletReservations{
+		transactor: transactor,
+		wallets:    wallets,
+		logger:     logger,
+	}
+}
+
+func (c *CaptureWalletReservations) Execute(ctx context.Context, in usecase.CaptureWalletReservationsInput) error {
+	err := c.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return c.wallets.Capture(ctx, in.LoanID)
+	})
+	if err != nil {
+		c.logger.Errorw("failed to capture wallet reservations", "loan_id", in.LoanID, "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
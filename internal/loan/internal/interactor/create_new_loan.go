@@ -2,9 +2,14 @@ package interactor
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
@@ -17,6 +22,9 @@ type (
 
 	CreateProposedLoan struct {
 		store        InsertLoanStore
+		userStore    UserStore
+		transactor   pkgsql.Transactor
+		outbox       pkgoutbox.Recorder
 		logger       *zap.SugaredLogger
 		snowflakeGen pkguid.Snowflake
 	}
@@ -24,11 +32,17 @@ type (
 
 func NewCreateProposedLoan(
 	store InsertLoanStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
 	logger *zap.SugaredLogger,
 	snowflakeGen pkguid.Snowflake,
 ) *CreateProposedLoan {
 	return &CreateProposedLoan{
 		store:        store,
+		userStore:    userStore,
+		transactor:   transactor,
+		outbox:       outbox,
 		logger:       logger,
 		snowflakeGen: snowflakeGen,
 	}
@@ -38,19 +52,50 @@ func (c *CreateProposedLoan) Execute(
 	ctx context.Context,
 	in usecase.CreateProposedLoanInput,
 ) error {
+	if err := requireUserType(ctx, c.userStore, in.UserID, sqlentity.Borrower, pkgerror.UserNotBorrower); err != nil {
+		c.logger.Errorw("user cannot propose a loan", "error", err)
 
-	if err := c.store.InsertLoan(ctx, sqlentity.Loan{
+		return err
+	}
+
+	method := sqlentity.FlatRepayment
+	if in.RepaymentMethod != "" {
+		method = sqlentity.RepaymentMethodFromString(in.RepaymentMethod)
+	}
+
+	loan := sqlentity.Loan{
 		ID:              c.snowflakeGen.Generate(),
 		BorrowerID:      in.UserID,
 		PrincipalAmount: in.Amount,
 		InterestRate:    in.InterestRate,
+		TenorMonths:     sql.NullInt32{Int32: int32(in.TenorMonths), Valid: true},
+		RepaymentMethod: method,
 		InvestedAmount:  decimal.Zero,
 		Status:          sqlentity.Proposed,
-	}); err != nil {
-		c.logger.Errorw("failed to insert loan", "error", err)
-
-		return err
 	}
 
-	return nil
+	return c.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := c.store.InsertLoan(ctx, loan); err != nil {
+			c.logger.Errorw("failed to insert loan", "error", err)
+
+			return err
+		}
+
+		if err := recordLoanEvent(ctx, c.outbox, c.snowflakeGen.Generate(), loan.ID, event.LoanProposed,
+			event.LoanProposedPayload{
+				LoanID:          loan.ID,
+				BorrowerID:      loan.BorrowerID,
+				PrincipalAmount: loan.PrincipalAmount,
+				InterestRate:    loan.InterestRate,
+				TenorMonths:     in.TenorMonths,
+				RepaymentMethod: loan.RepaymentMethod.String(),
+			},
+		); err != nil {
+			c.logger.Errorw("failed to record loan event", "error", err)
+
+			return pkgerror.ServerErrorFrom(err)
+		}
+
+		return nil
+	})
 }
@@ -2,25 +2,40 @@ package interactor
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
 	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap"
 )
 
 func TestCreateProposedLoan_Execute(t *testing.T) {
 	store := loanmocks.NewMockInsertLoanStore(t)
+	userStore := loanmocks.NewMockUserStore(t)
 	logger := zap.NewNop().Sugar()
 	snowflakeGen := pkgmocks.NewMockSnowflake(t)
+	outbox := pkgmocks.NewMockRecorder(t)
+	transactor := pkgmocks.NewMockTransactor(t)
+	transactor.EXPECT().WithinTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).Maybe()
 
 	type fields struct {
 		store        InsertLoanStore
+		userStore    UserStore
+		transactor   pkgsql.Transactor
+		outbox       pkgoutbox.Recorder
 		logger       *zap.SugaredLogger
 		snowflakeGen pkguid.Snowflake
 	}
@@ -36,20 +51,50 @@ func TestCreateProposedLoan_Execute(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "error when insert loan",
+			name: "error when user is not a borrower",
 			fields: fields{
 				store:        store,
+				userStore:    userStore,
+				transactor:   transactor,
+				outbox:       outbox,
 				logger:       logger,
 				snowflakeGen: snowflakeGen,
 			},
 			args: args{
 				ctx: context.Background(),
 				in: usecase.CreateProposedLoanInput{
-					UserID: 1,
+					UserID: 2,
 					Amount: decimal.NewFromInt(1_000_000),
 				},
 			},
 			mockFn: func(a args) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: a.in.UserID, Type: sqlentity.Investor}}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when insert loan",
+			fields: fields{
+				store:        store,
+				userStore:    userStore,
+				transactor:   transactor,
+				outbox:       outbox,
+				logger:       logger,
+				snowflakeGen: snowflakeGen,
+			},
+			args: args{
+				ctx: context.Background(),
+				in: usecase.CreateProposedLoanInput{
+					UserID:      1,
+					Amount:      decimal.NewFromInt(1_000_000),
+					TenorMonths: 12,
+				},
+			},
+			mockFn: func(a args) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: a.in.UserID, Type: sqlentity.Borrower}}, nil).Once()
+
 				loanID := 1
 
 				snowflakeGen.EXPECT().Generate().Return(uint64(loanID)).Once()
@@ -58,38 +103,122 @@ func TestCreateProposedLoan_Execute(t *testing.T) {
 					ID:              uint64(loanID),
 					BorrowerID:      a.in.UserID,
 					PrincipalAmount: a.in.Amount,
+					TenorMonths:     sql.NullInt32{Int32: 12, Valid: true},
+					RepaymentMethod: sqlentity.FlatRepayment,
 					InvestedAmount:  decimal.Zero,
 					Status:          sqlentity.Proposed,
 				}).Return(errors.New("any error")).Once()
 			},
 			wantErr: true,
 		},
+		{
+			name: "error when record loan event",
+			fields: fields{
+				store:        store,
+				userStore:    userStore,
+				transactor:   transactor,
+				outbox:       outbox,
+				logger:       logger,
+				snowflakeGen: snowflakeGen,
+			},
+			args: args{
+				ctx: context.Background(),
+				in: usecase.CreateProposedLoanInput{
+					UserID:      1,
+					Amount:      decimal.NewFromInt(1_000_000),
+					TenorMonths: 12,
+				},
+			},
+			mockFn: func(a args) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: a.in.UserID, Type: sqlentity.Borrower}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(1)).Twice()
+
+				store.EXPECT().InsertLoan(a.ctx, mock.Anything).Return(nil).Once()
+
+				outbox.EXPECT().Record(a.ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
 		{
 			name: "success",
 			fields: fields{
 				store:        store,
+				userStore:    userStore,
+				transactor:   transactor,
+				outbox:       outbox,
 				logger:       logger,
 				snowflakeGen: snowflakeGen,
 			},
 			args: args{
 				ctx: context.Background(),
 				in: usecase.CreateProposedLoanInput{
-					UserID: 1,
-					Amount: decimal.NewFromInt(1_000_000),
+					UserID:      1,
+					Amount:      decimal.NewFromInt(1_000_000),
+					TenorMonths: 12,
 				},
 			},
 			mockFn: func(a args) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: a.in.UserID, Type: sqlentity.Borrower}}, nil).Once()
+
 				loanID := 1
 
-				snowflakeGen.EXPECT().Generate().Return(uint64(loanID)).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(loanID)).Twice()
 
 				store.EXPECT().InsertLoan(a.ctx, sqlentity.Loan{
 					ID:              uint64(loanID),
 					BorrowerID:      a.in.UserID,
 					PrincipalAmount: a.in.Amount,
+					TenorMonths:     sql.NullInt32{Int32: 12, Valid: true},
+					RepaymentMethod: sqlentity.FlatRepayment,
+					InvestedAmount:  decimal.Zero,
+					Status:          sqlentity.Proposed,
+				}).Return(nil).Once()
+
+				outbox.EXPECT().Record(a.ctx, mock.MatchedBy(func(e pkgoutbox.Event) bool {
+					return e.Type == event.LoanProposed && e.AggregateType == event.AggregateLoan && e.AggregateID == 1
+				})).Return(nil).Once()
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with annuity repayment",
+			fields: fields{
+				store:        store,
+				userStore:    userStore,
+				transactor:   transactor,
+				outbox:       outbox,
+				logger:       logger,
+				snowflakeGen: snowflakeGen,
+			},
+			args: args{
+				ctx: context.Background(),
+				in: usecase.CreateProposedLoanInput{
+					UserID:          1,
+					Amount:          decimal.NewFromInt(1_000_000),
+					TenorMonths:     6,
+					RepaymentMethod: "ANNUITY",
+				},
+			},
+			mockFn: func(a args) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: a.in.UserID, Type: sqlentity.Borrower}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(1)).Twice()
+
+				store.EXPECT().InsertLoan(a.ctx, sqlentity.Loan{
+					ID:              1,
+					BorrowerID:      a.in.UserID,
+					PrincipalAmount: a.in.Amount,
+					TenorMonths:     sql.NullInt32{Int32: 6, Valid: true},
+					RepaymentMethod: sqlentity.AnnuityRepayment,
 					InvestedAmount:  decimal.Zero,
 					Status:          sqlentity.Proposed,
 				}).Return(nil).Once()
+
+				outbox.EXPECT().Record(a.ctx, mock.Anything).Return(nil).Once()
 			},
 			wantErr: false,
 		},
@@ -100,6 +229,9 @@ func TestCreateProposedLoan_Execute(t *testing.T) {
 
 			c := NewCreateProposedLoan(
 				tt.fields.store,
+				tt.fields.userStore,
+				tt.fields.transactor,
+				tt.fields.outbox,
 				tt.fields.logger,
 				tt.fields.snowflakeGen,
 			)
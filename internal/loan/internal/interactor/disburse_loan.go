@@ -3,14 +3,24 @@ package interactor
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"os"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/schedule"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
@@ -21,26 +31,64 @@ type (
 			in sqlentity.UpdateEntity,
 			opts ...gateway.UpdateLoanOption,
 		) error
+		InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error
 		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		InsertLoanInstallments(ctx context.Context, in sqlentity.LoanInstallments) error
 	}
 
 	DisburseLoan struct {
-		store  DisburseLoanStore
-		logger *zap.SugaredLogger
+		store         DisburseLoanStore
+		userStore     UserStore
+		transactor    pkgsql.Transactor
+		outbox        pkgoutbox.Recorder
+		ledger        ledger.Poster
+		wallets       wallet.Keeper
+		documentStore pkgstorage.DocumentStore
+		stateMachine  *statemachine.LoanStateMachine
+		logger        *zap.SugaredLogger
+		snowflakeGen  pkguid.Snowflake
 	}
 )
 
 func NewDisburseLoan(
 	store DisburseLoanStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
+	ledger ledger.Poster,
+	wallets wallet.Keeper,
+	documentStore pkgstorage.DocumentStore,
+	stateMachine *statemachine.LoanStateMachine,
 	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
 ) *DisburseLoan {
 	return &DisburseLoan{
-		store:  store,
-		logger: logger,
+		store:         store,
+		userStore:     userStore,
+		transactor:    transactor,
+		outbox:        outbox,
+		ledger:        ledger,
+		wallets:       wallets,
+		documentStore: documentStore,
+		stateMachine:  stateMachine,
+		logger:        logger,
+		snowflakeGen:  snowflakeGen,
 	}
 }
 
 func (d *DisburseLoan) Execute(ctx context.Context, in usecase.DisburseLoanInput) error {
+	if err := requireUserType(ctx, d.userStore, in.EmployeeID, sqlentity.Employee, pkgerror.UserNotEmployee); err != nil {
+		d.logger.Errorw("user cannot disburse a loan", "error", err)
+
+		return err
+	}
+
+	return d.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return d.disburse(ctx, in)
+	})
+}
+
+func (d *DisburseLoan) disburse(ctx context.Context, in usecase.DisburseLoanInput) error {
 	loans, err := d.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
 	if err != nil {
 		d.logger.Errorw("failed to get loan", "error", err)
@@ -52,38 +100,155 @@ func (d *DisburseLoan) Execute(ctx context.Context, in usecase.DisburseLoanInput
 	if loan = loans.First(); loans.IsEmpty() {
 		d.logger.Errorw("loan not found")
 
-		return pkgerror.NewBusinessError("loan not found")
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
 	}
 
-	if loan.Status != sqlentity.Invested {
-		d.logger.Errorw("loan not invested")
-
-		return pkgerror.NewBusinessError("loan not invested")
+	if err := d.requireAgreementLetter(ctx, loan, in.AgreementLetterKey); err != nil {
+		return err
 	}
 
-	basePath, err := os.Getwd()
+	// the agreement_letter_document_url column keeps the document key, URLs are signed when the loan is read
+	loan.AgreementLetterDocumentURL = sql.NullString{String: in.AgreementLetterKey, Valid: true}
+
+	disbursedLoan, err := d.stateMachine.Transition(ctx, loan, sqlentity.Disbursed)
 	if err != nil {
-		d.logger.Errorw("failed to get current working directory", "error", err)
+		d.logger.Errorw("loan cannot be disbursed", "error", err)
 
-		return pkgerror.ServerErrorFrom(err)
+		return err
 	}
 
+	now := time.Now()
+
 	if err := d.store.UpdateLoan(
 		ctx,
 		sqlentity.DisburseLoan{
 			DisburesmentDate: sql.NullTime{
-				Time:  time.Now(),
+				Time:  now,
 				Valid: true,
 			},
-			AgreementLetterDocumentURL: sql.NullString{
-				String: fmt.Sprintf("%s/files/agreement-letter/%d/letter-of-agreement-09.pdf", basePath, in.LoanID),
-				Valid:  true,
-			},
+			AgreementLetterDocumentURL: loan.AgreementLetterDocumentURL,
+			Version:                    loan.Version + 1,
 		},
 		gateway.UpdateLoanWithLoanIDFilter(in.LoanID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
 	); err != nil {
 		d.logger.Errorw("failed to update loan", "error", err)
 
+		return updateLoanError(err)
+	}
+
+	// the LoanFullyFunded handler normally captured the reservations already; one that failed must not leave the
+	// money of the investors available in their wallets while the escrow pays the borrower
+	if err := d.wallets.Capture(ctx, loan.ID); err != nil {
+		d.logger.Errorw("failed to capture wallet reservations", "loan_id", loan.ID, "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := d.ledger.Post(ctx, ledger.Disbursement(loan)); err != nil {
+		d.logger.Errorw("failed to post loan disbursement to ledger", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := d.store.InsertLoanStatusHistory(ctx, sqlentity.LoanStatusHistory{
+		ID:          d.snowflakeGen.Generate(),
+		LoanID:      loan.ID,
+		FromStatus:  loan.Status,
+		ToStatus:    disbursedLoan.Status,
+		ActorUserID: in.EmployeeID,
+		Reason:      "loan disbursed to borrower",
+		CreatedAt:   now,
+	}); err != nil {
+		d.logger.Errorw("failed to insert loan status history", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := d.insertSchedule(ctx, loan, now); err != nil {
+		return err
+	}
+
+	if err := recordLoanEvent(ctx, d.outbox, d.snowflakeGen.Generate(), loan.ID, event.LoanDisbursed,
+		event.LoanDisbursedPayload{
+			LoanID:           loan.ID,
+			EmployeeID:       in.EmployeeID,
+			DisbursementDate: now,
+		},
+	); err != nil {
+		d.logger.Errorw("failed to record loan event", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
+
+// insertSchedule stores the installments the borrower repays the loan with, counted from the disbursement date.
+// Loans proposed before the tenor was required have nothing to compute a schedule from and are left without one.
+func (d *DisburseLoan) insertSchedule(ctx context.Context, loan sqlentity.Loan, disbursedAt time.Time) error {
+	if !loan.TenorMonths.Valid {
+		d.logger.Warnw("loan has no tenor, repayment schedule is not generated", "loan_id", loan.ID)
+
+		return nil
+	}
+
+	installments, err := schedule.Generate(schedule.Terms{
+		Principal:   loan.PrincipalAmount,
+		AnnualRate:  loan.InterestRate,
+		TenorMonths: int(loan.TenorMonths.Int32),
+		Method:      loan.RepaymentMethod,
+		StartDate:   disbursedAt,
+	})
+	if err != nil {
+		d.logger.Errorw("failed to generate repayment schedule", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	rows := make(sqlentity.LoanInstallments, 0, len(installments))
+	for _, installment := range installments {
+		rows = append(rows, sqlentity.LoanInstallment{
+			ID:                  d.snowflakeGen.Generate(),
+			LoanID:              loan.ID,
+			InstallmentNumber:   installment.Number,
+			DueDate:             installment.DueDate,
+			PrincipalAmount:     installment.Principal,
+			InterestAmount:      installment.Interest,
+			TotalAmount:         installment.Total,
+			OutstandingBalance:  installment.OutstandingBalance,
+			PaidPrincipalAmount: decimal.Zero,
+			PaidInterestAmount:  decimal.Zero,
+			Status:              sqlentity.InstallmentPending,
+			CreatedAt:           disbursedAt,
+			UpdatedAt:           disbursedAt,
+		})
+	}
+
+	if err := d.store.InsertLoanInstallments(ctx, rows); err != nil {
+		d.logger.Errorw("failed to insert loan installments", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
+
+// requireAgreementLetter checks the key points at an agreement letter uploaded for this loan.
+func (d *DisburseLoan) requireAgreementLetter(ctx context.Context, loan sqlentity.Loan, key string) error {
+	if !strings.HasPrefix(key, agreementLetterKeyPrefix(loan.ID)) {
+		d.logger.Errorw("agreement letter belongs to another loan", "key", key)
+
+		return pkgerror.NewValidationError("agreement letter does not belong to the loan")
+	}
+
+	if _, err := d.documentStore.Stat(ctx, key); err != nil {
+		d.logger.Errorw("failed to stat agreement letter", "error", err)
+
+		if errors.Is(err, pkgstorage.ErrDocumentNotFound) || errors.Is(err, pkgstorage.ErrInvalidKey) {
+			return pkgerror.NewValidationError("agreement letter is not uploaded")
+		}
+
 		return pkgerror.ServerErrorFrom(err)
 	}
 
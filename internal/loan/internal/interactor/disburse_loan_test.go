@@ -0,0 +1,361 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestDisburseLoan_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	employee := sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}
+	investedLoan := sqlentity.Loans{{
+		ID:              1,
+		BorrowerID:      5,
+		PrincipalAmount: decimal.NewFromInt(1_000_000),
+		Status:          sqlentity.Invested,
+		Version:         3,
+	}}
+	scheduledLoan := sqlentity.Loans{{
+		ID:              1,
+		BorrowerID:      5,
+		PrincipalAmount: decimal.NewFromInt(1_200_000),
+		InterestRate:    decimal.NewFromInt(12),
+		TenorMonths:     sql.NullInt32{Int32: 3, Valid: true},
+		RepaymentMethod: sqlentity.FlatRepayment,
+		Status:          sqlentity.Invested,
+		Version:         3,
+	}}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.DisburseLoanInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockDisburseLoanStore,
+			userStore *loanmocks.MockUserStore,
+			documentStore *pkgmocks.MockDocumentStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			a args,
+		)
+		captureErr        error
+		postErr           error
+		wantCapture       bool
+		wantValidationErr bool
+		wantErr           bool
+		wantEntry         string
+		wantEvent         string
+	}{
+		{
+			name: "error agreement letter of another loan",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/2/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(investedLoan, nil).Once()
+			},
+			wantValidationErr: true,
+			wantErr:           true,
+		},
+		{
+			name: "error agreement letter not uploaded",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(investedLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{}, pkgstorage.ErrDocumentNotFound).Once()
+			},
+			wantValidationErr: true,
+			wantErr:           true,
+		},
+		{
+			name: "error when stat agreement letter",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(investedLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{}, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when capture wallet reservations",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(investedLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{Key: a.in.AgreementLetterKey}, nil).Once()
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			captureErr:  errors.New("any error"),
+			wantCapture: true,
+			wantErr:     true,
+		},
+		{
+			name: "error when post to ledger",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(scheduledLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{Key: a.in.AgreementLetterKey}, nil).Once()
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			postErr:     errors.New("any error"),
+			wantCapture: true,
+			wantErr:     true,
+			wantEntry:   "disbursement:1",
+		},
+		{
+			name: "error when insert loan installments",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(scheduledLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{Key: a.in.AgreementLetterKey}, nil).Once()
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Times(4)
+				store.EXPECT().InsertLoanStatusHistory(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanInstallments(a.ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+			wantCapture: true,
+			wantErr:     true,
+			wantEntry:   "disbursement:1",
+		},
+		{
+			name: "success with repayment schedule",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(scheduledLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{Key: a.in.AgreementLetterKey}, nil).Once()
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Times(5)
+				store.EXPECT().InsertLoanStatusHistory(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanInstallments(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.LoanInstallments) bool {
+						last := in[in.Len()-1]
+
+						return in.Len() == 3 &&
+							in.First().LoanID == 1 &&
+							in.First().TotalAmount.Equal(decimal.NewFromInt(412_000)) &&
+							last.InstallmentNumber == 3 &&
+							last.OutstandingBalance.IsZero()
+					}),
+				).Return(nil).Once()
+			},
+			wantCapture: true,
+			wantEntry:   "disbursement:1",
+			wantEvent:   event.LoanDisbursed,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.DisburseLoanInput{
+					LoanID:             1,
+					EmployeeID:         4,
+					AgreementLetterKey: "loan/1/agreement-letter/letter.pdf",
+				},
+			},
+			mockFn: func(
+				store *loanmocks.MockDisburseLoanStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(employee, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(investedLoan, nil).Once()
+				documentStore.EXPECT().Stat(a.ctx, a.in.AgreementLetterKey).
+					Return(pkgstorage.DocumentInfo{Key: a.in.AgreementLetterKey}, nil).Once()
+
+				store.EXPECT().UpdateLoan(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.DisburseLoan) bool {
+						return in.AgreementLetterDocumentURL == sql.NullString{String: a.in.AgreementLetterKey, Valid: true} &&
+							in.Version == 4
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Twice()
+
+				store.EXPECT().InsertLoanStatusHistory(
+					a.ctx,
+					mock.MatchedBy(func(h sqlentity.LoanStatusHistory) bool {
+						return h.FromStatus == sqlentity.Invested && h.ToStatus == sqlentity.Disbursed
+					}),
+				).Return(nil).Once()
+			},
+			wantCapture: true,
+			wantEntry:   "disbursement:1",
+			wantEvent:   event.LoanDisbursed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockDisburseLoanStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			documentStore := pkgmocks.NewMockDocumentStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(tt.args.ctx, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Maybe()
+			outbox := pkgmocks.NewMockRecorder(t)
+			poster := loanmocks.NewMockPoster(t)
+			wallets := loanmocks.NewMockKeeper(t)
+			tt.mockFn(store, userStore, documentStore, snowflakeGen, tt.args)
+			if tt.wantCapture {
+				wallets.EXPECT().Capture(tt.args.ctx, tt.args.in.LoanID).Return(tt.captureErr).Once()
+			}
+			if tt.wantEntry != "" {
+				poster.EXPECT().Post(tt.args.ctx, mock.MatchedBy(func(e ledger.Entry) bool {
+					return e.Reference == tt.wantEntry && e.Validate() == nil
+				})).Return(tt.postErr).Once()
+			}
+			if tt.wantEvent != "" {
+				outbox.EXPECT().Record(tt.args.ctx, mock.MatchedBy(func(e pkgoutbox.Event) bool {
+					return e.Type == tt.wantEvent && e.AggregateID == tt.args.in.LoanID
+				})).Return(nil).Once()
+			}
+
+			d := NewDisburseLoan(
+				store,
+				userStore,
+				transactor,
+				outbox,
+				poster,
+				wallets,
+				documentStore,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				logger,
+				snowflakeGen,
+			)
+			err := d.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DisburseLoan.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.wantValidationErr, pkgerror.IsValidationError(err))
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/distribution"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// PlatformFeeRateFromString parses the percentage of the interest kept by the platform, anything else than a
+// percentage between 0 and 100 means no fee.
+func PlatformFeeRateFromString(s string) decimal.Decimal {
+	rate, err := decimal.NewFromString(s)
+	if err != nil || rate.IsNegative() || rate.GreaterThan(decimal.NewFromInt(100)) {
+		return decimal.Zero
+	}
+
+	return rate
+}
+
+type (
+	DistributeRepaymentStore interface {
+		GetLoanRepayment(
+			ctx context.Context,
+			opts ...gateway.GetLoanRepaymentOption,
+		) (sqlentity.LoanRepayments, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		GetLoanInvestorCredit(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestorCreditOption,
+		) (sqlentity.LoanInvestorCredits, error)
+		InsertLoanInvestorCredits(ctx context.Context, in sqlentity.LoanInvestorCredits) error
+	}
+
+	DistributeRepayment struct {
+		store           DistributeRepaymentStore
+		transactor      pkgsql.Transactor
+		wallets         wallet.Keeper
+		platformFeeRate decimal.Decimal
+		logger          *zap.SugaredLogger
+		snowflakeGen    pkguid.Snowflake
+	}
+)
+
+func NewDistributeRepayment(
+	store DistributeRepaymentStore,
+	transactor pkgsql.Transactor,
+	wallets wallet.Keeper,
+	platformFeeRate decimal.Decimal,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *DistributeRepayment {
+	return &DistributeRepayment{
+		store:           store,
+		transactor:      transactor,
+		wallets:         wallets,
+		platformFeeRate: platformFeeRate,
+		logger:          logger,
+		snowflakeGen:    snowflakeGen,
+	}
+}
+
+func (d *DistributeRepayment) Execute(ctx context.Context, in usecase.DistributeRepaymentInput) error {
+	return d.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return d.distribute(ctx, in)
+	})
+}
+
+// distribute credits the wallets of the investors with the principal and interest of the repayment, the part the
+// borrower overpaid is not theirs and is left out.
+func (d *DistributeRepayment) distribute(ctx context.Context, in usecase.DistributeRepaymentInput) error {
+	credits, err := d.store.GetLoanInvestorCredit(
+		ctx,
+		gateway.GetLoanInvestorCreditWithLoanIDFilter(in.LoanID),
+		gateway.GetLoanInvestorCreditWithRepaymentIDFilter(in.RepaymentID),
+	)
+	if err != nil {
+		d.logger.Errorw("failed to get loan investor credit", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if !credits.IsEmpty() {
+		d.logger.Infow("repayment already distributed", "loan_id", in.LoanID, "repayment_id", in.RepaymentID)
+
+		return nil
+	}
+
+	repayments, err := d.store.GetLoanRepayment(
+		ctx,
+		gateway.GetLoanRepaymentWithIDFilter(in.RepaymentID),
+		gateway.GetLoanRepaymentWithLoanIDFilter(in.LoanID),
+	)
+	if err != nil {
+		d.logger.Errorw("failed to get loan repayment", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	var repayment sqlentity.LoanRepayment
+	if repayment = repayments.First(); repayments.IsEmpty() {
+		d.logger.Errorw("loan repayment not found", "loan_id", in.LoanID, "repayment_id", in.RepaymentID)
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanRepaymentNotFound)
+	}
+
+	investments, err := d.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithLoanIDFilter(repayment.LoanID),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		d.logger.Errorw("failed to get loan investment", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	shares, err := distribution.Distribute(
+		repayment.PrincipalAmount,
+		repayment.InterestAmount,
+		d.platformFeeRate,
+		investmentShares(investments),
+	)
+	if err != nil {
+		d.logger.Errorw("failed to distribute loan repayment", "repayment_id", repayment.ID, "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	credits = make(sqlentity.LoanInvestorCredits, 0, len(shares))
+	for i, share := range shares {
+		credits = append(credits, sqlentity.LoanInvestorCredit{
+			ID:              d.snowflakeGen.Generate(),
+			LoanID:          repayment.LoanID,
+			RepaymentID:     repayment.ID,
+			InvestmentID:    share.InvestmentID,
+			InvestorID:      investments[i].InvestorID,
+			PrincipalAmount: share.Principal,
+			InterestAmount:  share.Interest,
+			FeeAmount:       share.Fee,
+		})
+	}
+
+	if err := d.store.InsertLoanInvestorCredits(ctx, credits); err != nil {
+		d.logger.Errorw("failed to insert loan investor credits", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	for _, credit := range credits {
+		if err := d.wallets.Credit(ctx, credit); err != nil {
+			d.logger.Errorw("failed to credit investor wallet", "investor_id", credit.InvestorID, "error", err)
+
+			return pkgerror.ServerErrorFrom(err)
+		}
+	}
+
+	return nil
+}
+
+// investmentShares is the share of the repayments every investment is owed, in the order of the investments.
+func investmentShares(investments sqlentity.LoanInvestments) []distribution.Share {
+	shares := make([]distribution.Share, 0, investments.Len())
+	for _, investment := range investments {
+		shares = append(shares, distribution.Share{InvestmentID: investment.ID, Amount: investment.Amount})
+	}
+
+	return shares
+}
@@ -0,0 +1,194 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestPlatformFeeRateFromString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: "0"},
+		{in: "abc", want: "0"},
+		{in: "-1", want: "0"},
+		{in: "100.5", want: "0"},
+		{in: "12.5", want: "12.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, PlatformFeeRateFromString(tt.in).String())
+		})
+	}
+}
+
+func TestDistributeRepayment_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	in := usecase.DistributeRepaymentInput{LoanID: 1, RepaymentID: 40}
+	repayment := sqlentity.LoanRepayments{{
+		ID:              40,
+		LoanID:          1,
+		Amount:          decimal.NewFromInt(1_100),
+		PrincipalAmount: decimal.NewFromInt(1_000),
+		InterestAmount:  decimal.RequireFromString("100.05"),
+		OverpaidAmount:  decimal.RequireFromString("0.05"),
+	}}
+	investments := sqlentity.LoanInvestments{
+		{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(2_000)},
+		{ID: 11, LoanID: 1, InvestorID: 4, Amount: decimal.NewFromInt(1_000)},
+	}
+
+	tests := []struct {
+		name        string
+		mockFn      func(store *loanmocks.MockDistributeRepaymentStore, snowflakeGen *pkgmocks.MockSnowflake)
+		creditErr   error
+		wantErr     bool
+		wantCode    pkgerror.Code
+		wantCredits []uint64
+	}{
+		{
+			name: "error when get loan investor credit",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, _ *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success repayment already distributed",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, _ *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestorCredits{{ID: 50, RepaymentID: 40}}, nil).Once()
+			},
+		},
+		{
+			name: "error when get loan repayment",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, _ *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan repayment not found",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, _ *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanRepaymentNotFound,
+		},
+		{
+			name: "error when get loan investment",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, _ *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).Return(repayment, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan without investment",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, _ *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).Return(repayment, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when insert loan investor credits",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, snowflakeGen *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).Return(repayment, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(50).Twice()
+				store.EXPECT().InsertLoanInvestorCredits(mock.Anything, mock.Anything).
+					Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when credit wallet",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, snowflakeGen *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).Return(repayment, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(50).Twice()
+				store.EXPECT().InsertLoanInvestorCredits(mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			creditErr:   errors.New("any error"),
+			wantErr:     true,
+			wantCredits: []uint64{10},
+		},
+		{
+			name: "success",
+			mockFn: func(store *loanmocks.MockDistributeRepaymentStore, snowflakeGen *pkgmocks.MockSnowflake) {
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanRepayment(mock.Anything, mock.Anything, mock.Anything).Return(repayment, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(50).Once()
+				snowflakeGen.EXPECT().Generate().Return(51).Once()
+				// the fee is 10.01 of the 100.05 of interest, the 0.05 overpaid is not distributed
+				store.EXPECT().InsertLoanInvestorCredits(mock.Anything, mock.MatchedBy(
+					func(credits sqlentity.LoanInvestorCredits) bool {
+						return credits.Len() == 2 &&
+							credits[0].ID == 50 && credits[0].InvestmentID == 10 && credits[0].InvestorID == 3 &&
+							credits[0].PrincipalAmount.String() == "666.67" &&
+							credits[0].InterestAmount.String() == "60.03" &&
+							credits[0].FeeAmount.String() == "6.67" &&
+							credits[1].ID == 51 && credits[1].InvestmentID == 11 && credits[1].InvestorID == 4 &&
+							credits[1].PrincipalAmount.String() == "333.33" &&
+							credits[1].InterestAmount.String() == "30.01" &&
+							credits[1].FeeAmount.String() == "3.34"
+					},
+				)).Return(nil).Once()
+			},
+			wantCredits: []uint64{10, 11},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockDistributeRepaymentStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Once()
+			wallets := loanmocks.NewMockKeeper(t)
+			tt.mockFn(store, snowflakeGen)
+			for _, investmentID := range tt.wantCredits {
+				wallets.EXPECT().Credit(mock.Anything, mock.MatchedBy(func(c sqlentity.LoanInvestorCredit) bool {
+					return c.InvestmentID == investmentID
+				})).Return(tt.creditErr).Once()
+			}
+
+			d := NewDistributeRepayment(store, transactor, wallets, decimal.NewFromInt(10), logger, snowflakeGen)
+			err := d.Execute(context.Background(), in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DistributeRepayment.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultExpiryBatchSize uint = 50
+
+	// systemActorID is the actor of the status changes made by the application itself rather than a user.
+	systemActorID uint64 = 0
+)
+
+type (
+	ExpireLoansStore interface {
+		UpdateLoan(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanOption,
+		) error
+		InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		UpdateLoanInvestment(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanInvestmentOption,
+		) error
+	}
+
+	ExpireLoans struct {
+		store        ExpireLoansStore
+		transactor   pkgsql.Transactor
+		outbox       pkgoutbox.Recorder
+		wallets      wallet.Keeper
+		stateMachine *statemachine.LoanStateMachine
+		batchSize    uint
+
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+func NewExpireLoans(
+	store ExpireLoansStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
+	wallets wallet.Keeper,
+	stateMachine *statemachine.LoanStateMachine,
+	batchSize uint,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *ExpireLoans {
+	if batchSize == 0 {
+		batchSize = defaultExpiryBatchSize
+	}
+
+	return &ExpireLoans{
+		store:        store,
+		transactor:   transactor,
+		outbox:       outbox,
+		wallets:      wallets,
+		stateMachine: stateMachine,
+		batchSize:    batchSize,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+// Execute expires every loan of the batch in its own transaction. A loan changed by another request meanwhile, most
+// likely funded by a last investment, is skipped; when still overdue it is picked up again by the next batch.
+func (e *ExpireLoans) Execute(ctx context.Context) (*usecase.ExpireLoansOutput, error) {
+	loans, err := e.store.GetLoan(
+		ctx,
+		gateway.GetLoanWithStatusFilter(sqlentity.Approved),
+		gateway.GetLoanWithFundingDeadlinePassedFilter(time.Now()),
+		gateway.GetLoanWithOrderByID(false),
+		gateway.GetLoanWithLimit(e.batchSize),
+	)
+	if err != nil {
+		e.logger.Errorw("failed to get overdue loans", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.ExpireLoansOutput{}
+
+	var errs error
+	for _, loan := range loans {
+		err := e.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			return e.expire(ctx, loan)
+		})
+		if errors.Is(err, gateway.ErrLoanNotUpdated) {
+			e.logger.Infow("loan changed before it expired", "loan_id", loan.ID)
+
+			continue
+		}
+
+		if err != nil {
+			e.logger.Errorw("failed to expire loan", "loan_id", loan.ID, "error", err)
+			errs = errors.Join(errs, err)
+
+			continue
+		}
+
+		out.Expired++
+	}
+
+	if errs != nil {
+		return nil, pkgerror.ServerErrorFrom(errs)
+	}
+
+	out.HasMore = uint(loans.Len()) == e.batchSize
+
+	return out, nil
+}
+
+// expire moves the loan to EXPIRED, releases the money reserved for its investments, marks them released so they no
+// longer count as invested, and records the event.
+func (e *ExpireLoans) expire(ctx context.Context, loan sqlentity.Loan) error {
+	expiredLoan, err := e.stateMachine.Transition(ctx, loan, sqlentity.Expired)
+	if err != nil {
+		return err
+	}
+
+	if err := e.store.UpdateLoan(
+		ctx,
+		sqlentity.ExpireLoan{Version: loan.Version + 1},
+		gateway.UpdateLoanWithLoanIDFilter(loan.ID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
+	); err != nil {
+		return err
+	}
+
+	if err := e.store.InsertLoanStatusHistory(ctx, sqlentity.LoanStatusHistory{
+		ID:          e.snowflakeGen.Generate(),
+		LoanID:      loan.ID,
+		FromStatus:  loan.Status,
+		ToStatus:    expiredLoan.Status,
+		ActorUserID: systemActorID,
+		Reason:      "loan not fully funded before its funding deadline",
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if err := e.wallets.Release(ctx, loan.ID); err != nil {
+		return err
+	}
+
+	// a loan nobody invested in has no investment to release
+	if err := e.store.UpdateLoanInvestment(
+		ctx,
+		sqlentity.ReleaseLoanInvestment{},
+		gateway.UpdateLoanInvestmentWithLoanIDFilter(loan.ID),
+		gateway.UpdateLoanInvestmentWithStatusFilter(sqlentity.InvestmentActive),
+	); err != nil && !errors.Is(err, gateway.ErrLoanInvestmentNotUpdated) {
+		return err
+	}
+
+	return recordLoanEvent(ctx, e.outbox, e.snowflakeGen.Generate(), loan.ID, event.LoanExpired,
+		event.LoanExpiredPayload{
+			LoanID:          loan.ID,
+			InvestedAmount:  loan.InvestedAmount,
+			FundingDeadline: loan.FundingDeadline.Time,
+		},
+	)
+}
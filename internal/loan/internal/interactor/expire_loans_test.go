@@ -0,0 +1,218 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestExpireLoans_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	ctx := context.Background()
+	overdue := func(id uint64) sqlentity.Loan {
+		return sqlentity.Loan{
+			ID:              id,
+			PrincipalAmount: decimal.NewFromInt(1_000),
+			InvestedAmount:  decimal.NewFromInt(400),
+			Status:          sqlentity.Approved,
+			FundingDeadline: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+			Version:         3,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		mockFn func(
+			store *loanmocks.MockExpireLoansStore,
+			wallets *loanmocks.MockKeeper,
+			outbox *pkgmocks.MockRecorder,
+			snowflakeGen *pkgmocks.MockSnowflake,
+		)
+		want    *usecase.ExpireLoansOutput
+		wantErr bool
+	}{
+		{
+			name: "error get loans",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				_ *loanmocks.MockKeeper,
+				_ *pkgmocks.MockRecorder,
+				_ *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, assert.AnError).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success nothing overdue",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				_ *loanmocks.MockKeeper,
+				_ *pkgmocks.MockRecorder,
+				_ *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).Once()
+			},
+			want: &usecase.ExpireLoansOutput{},
+		},
+		{
+			name: "error release keeps expiring the other loans",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				wallets *loanmocks.MockKeeper,
+				outbox *pkgmocks.MockRecorder,
+				snowflakeGen *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{overdue(1), overdue(2)}, nil).Once()
+				store.EXPECT().UpdateLoan(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+				store.EXPECT().InsertLoanStatusHistory(ctx, mock.Anything).Return(nil).Twice()
+				snowflakeGen.EXPECT().Generate().Return(uint64(9))
+				wallets.EXPECT().Release(ctx, uint64(1)).Return(gateway.ErrWalletReservationNotUpdated).Once()
+				wallets.EXPECT().Release(ctx, uint64(2)).Return(nil).Once()
+				store.EXPECT().UpdateLoanInvestment(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				outbox.EXPECT().Record(ctx, mock.Anything).Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when release investments",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				wallets *loanmocks.MockKeeper,
+				_ *pkgmocks.MockRecorder,
+				snowflakeGen *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{overdue(1)}, nil).Once()
+				store.EXPECT().UpdateLoan(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanStatusHistory(ctx, mock.Anything).Return(nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(9))
+				wallets.EXPECT().Release(ctx, uint64(1)).Return(nil).Once()
+				store.EXPECT().UpdateLoanInvestment(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(assert.AnError).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success expires a loan without investments",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				wallets *loanmocks.MockKeeper,
+				outbox *pkgmocks.MockRecorder,
+				snowflakeGen *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{overdue(1)}, nil).Once()
+				store.EXPECT().UpdateLoan(ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanStatusHistory(ctx, mock.Anything).Return(nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(9))
+				wallets.EXPECT().Release(ctx, uint64(1)).Return(nil).Once()
+				store.EXPECT().UpdateLoanInvestment(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanInvestmentNotUpdated).Once()
+				outbox.EXPECT().Record(ctx, mock.Anything).Return(nil).Once()
+			},
+			want: &usecase.ExpireLoansOutput{Expired: 1},
+		},
+		{
+			name: "success skips a loan changed meanwhile",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				_ *loanmocks.MockKeeper,
+				_ *pkgmocks.MockRecorder,
+				_ *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{overdue(1)}, nil).Once()
+				store.EXPECT().UpdateLoan(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanNotUpdated).Once()
+			},
+			want: &usecase.ExpireLoansOutput{},
+		},
+		{
+			name: "success expires a full batch",
+			mockFn: func(
+				store *loanmocks.MockExpireLoansStore,
+				wallets *loanmocks.MockKeeper,
+				outbox *pkgmocks.MockRecorder,
+				snowflakeGen *pkgmocks.MockSnowflake,
+			) {
+				store.EXPECT().GetLoan(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{overdue(1), overdue(2)}, nil).Once()
+				store.EXPECT().UpdateLoan(
+					ctx,
+					mock.MatchedBy(func(in sqlentity.ExpireLoan) bool {
+						return in.Version == 4
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Twice()
+				store.EXPECT().InsertLoanStatusHistory(ctx, mock.MatchedBy(func(h sqlentity.LoanStatusHistory) bool {
+					return h.FromStatus == sqlentity.Approved &&
+						h.ToStatus == sqlentity.Expired &&
+						h.ActorUserID == systemActorID
+				})).Return(nil).Twice()
+				snowflakeGen.EXPECT().Generate().Return(uint64(9))
+				wallets.EXPECT().Release(ctx, mock.Anything).Return(nil).Twice()
+				// the released investments no longer count as invested, in the portfolio of their investors included
+				store.EXPECT().UpdateLoanInvestment(
+					ctx,
+					sqlentity.ReleaseLoanInvestment{},
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Twice()
+				outbox.EXPECT().Record(ctx, mock.MatchedBy(func(e pkgoutbox.Event) bool {
+					return e.Type == event.LoanExpired
+				})).Return(nil).Twice()
+			},
+			want: &usecase.ExpireLoansOutput{Expired: 2, HasMore: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockExpireLoansStore(t)
+			wallets := loanmocks.NewMockKeeper(t)
+			outbox := pkgmocks.NewMockRecorder(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(ctx, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Maybe()
+			tt.mockFn(store, wallets, outbox, snowflakeGen)
+
+			e := NewExpireLoans(
+				store,
+				transactor,
+				outbox,
+				wallets,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				2,
+				logger,
+				snowflakeGen,
+			)
+			got, err := e.Execute(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpireLoans.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/portfolio"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type (
+	GetInvestorPortfolioStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		GetLoanInvestorCredit(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestorCreditOption,
+		) (sqlentity.LoanInvestorCredits, error)
+	}
+
+	GetInvestorPortfolio struct {
+		store           GetInvestorPortfolioStore
+		userStore       UserStore
+		platformFeeRate decimal.Decimal
+		logger          *zap.SugaredLogger
+	}
+)
+
+func NewGetInvestorPortfolio(
+	store GetInvestorPortfolioStore,
+	userStore UserStore,
+	platformFeeRate decimal.Decimal,
+	logger *zap.SugaredLogger,
+) *GetInvestorPortfolio {
+	return &GetInvestorPortfolio{
+		store:           store,
+		userStore:       userStore,
+		platformFeeRate: platformFeeRate,
+		logger:          logger,
+	}
+}
+
+func (g *GetInvestorPortfolio) Execute(
+	ctx context.Context,
+	in usecase.GetInvestorPortfolioInput,
+) (*usecase.GetInvestorPortfolioOutput, error) {
+	// investors only read their own portfolio, borrowers none
+	if in.Scope.BorrowerID != 0 || (in.Scope.InvestorID != 0 && in.Scope.InvestorID != in.InvestorID) {
+		g.logger.Errorw("caller cannot read investor portfolio", "investor_id", in.InvestorID)
+
+		return nil, pkgerror.NewAuthorizationError("portfolio belongs to another user")
+	}
+
+	err := requireUserType(ctx, g.userStore, in.InvestorID, sqlentity.Investor, pkgerror.UserNotInvestor)
+	if err != nil {
+		g.logger.Errorw("user has no portfolio", "error", err)
+
+		return nil, err
+	}
+
+	investments, err := g.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithInvestorIDFilter(in.InvestorID),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		g.logger.Errorw("failed to get loan investment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	holdings := make([]portfolio.Holding, 0, investments.Len())
+	if !investments.IsEmpty() {
+		holdings, err = g.holdings(ctx, in.InvestorID, investments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	p, err := portfolio.Build(holdings, g.platformFeeRate)
+	if err != nil {
+		g.logger.Errorw("failed to build investor portfolio", "investor_id", in.InvestorID, "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.GetInvestorPortfolioOutput{
+		InvestorID:           in.InvestorID,
+		PlatformFeeRate:      g.platformFeeRate,
+		TotalInvested:        p.TotalInvested,
+		TotalOutstanding:     p.TotalOutstanding,
+		TotalEarned:          p.TotalEarned,
+		TotalExpectedReturn:  p.TotalExpectedReturn,
+		WeightedAverageYield: p.WeightedAverageYield,
+		Investments:          make([]usecase.PortfolioInvestment, 0, len(p.Positions)),
+	}
+
+	for _, position := range p.Positions {
+		out.Investments = append(out.Investments, usecase.PortfolioInvestment{
+			InvestmentID:         position.Investment.ID,
+			LoanID:               position.Loan.ID,
+			LoanStatus:           position.Loan.Status.String(),
+			InterestRate:         position.Loan.InterestRate,
+			TenorMonths:          int(position.Loan.TenorMonths.Int32),
+			InvestedAmount:       position.Investment.Amount,
+			SharePercentage:      position.SharePercentage,
+			ExpectedReturn:       position.ExpectedReturn,
+			RealizedReturn:       position.RealizedReturn,
+			ReceivedPrincipal:    position.ReceivedPrincipal,
+			OutstandingPrincipal: position.OutstandingPrincipal,
+			Yield:                position.Yield,
+		})
+	}
+
+	return out, nil
+}
+
+// holdings pairs every investment with its loan and the credits it received.
+func (g *GetInvestorPortfolio) holdings(
+	ctx context.Context,
+	investorID uint64,
+	investments sqlentity.LoanInvestments,
+) ([]portfolio.Holding, error) {
+	loanIDs := make([]uint64, 0, investments.Len())
+	for _, investment := range investments {
+		loanIDs = append(loanIDs, investment.LoanID)
+	}
+
+	loans, err := g.store.GetLoan(ctx, gateway.GetLoanWithLoanIDsFilter(loanIDs...))
+	if err != nil {
+		g.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	byID := make(map[uint64]sqlentity.Loan, loans.Len())
+	for _, loan := range loans {
+		byID[loan.ID] = loan
+	}
+
+	credits, err := g.store.GetLoanInvestorCredit(ctx, gateway.GetLoanInvestorCreditWithInvestorIDFilter(investorID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan investor credit", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	holdings := make([]portfolio.Holding, 0, investments.Len())
+	for _, investment := range investments {
+		loan, ok := byID[investment.LoanID]
+		if !ok {
+			g.logger.Errorw("loan of investment not found", "investment_id", investment.ID)
+
+			return nil, pkgerror.ServerErrorFrom(fmt.Errorf("loan %d not found", investment.LoanID))
+		}
+
+		holding := portfolio.Holding{Investment: investment, Loan: loan}
+		for _, credit := range credits {
+			if credit.InvestmentID == investment.ID {
+				holding.Credits = append(holding.Credits, credit)
+			}
+		}
+
+		holdings = append(holdings, holding)
+	}
+
+	return holdings, nil
+}
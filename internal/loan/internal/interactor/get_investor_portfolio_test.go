@@ -0,0 +1,211 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestGetInvestorPortfolio_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	investor := sqlentity.Users{{ID: 3, Type: sqlentity.Investor}}
+	investments := sqlentity.LoanInvestments{
+		{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(400_000)},
+	}
+	loans := sqlentity.Loans{{
+		ID:              1,
+		PrincipalAmount: decimal.NewFromInt(1_000_000),
+		InterestRate:    decimal.NewFromInt(12),
+		TenorMonths:     sql.NullInt32{Int32: 3, Valid: true},
+		RepaymentMethod: sqlentity.FlatRepayment,
+		Status:          sqlentity.Disbursed,
+	}}
+	credits := sqlentity.LoanInvestorCredits{{
+		LoanID:          1,
+		InvestmentID:    10,
+		InvestorID:      3,
+		PrincipalAmount: decimal.RequireFromString("133333.33"),
+		InterestAmount:  decimal.NewFromInt(3_600),
+		FeeAmount:       decimal.NewFromInt(400),
+	}}
+
+	tests := []struct {
+		name     string
+		in       usecase.GetInvestorPortfolioInput
+		mockFn   func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore)
+		want     *usecase.GetInvestorPortfolioOutput
+		wantErr  bool
+		wantCode pkgerror.Code
+	}{
+		{
+			name:    "error portfolio of another investor",
+			in:      usecase.GetInvestorPortfolioInput{InvestorID: 3, Scope: usecase.LoanScope{InvestorID: 4}},
+			mockFn:  func(*loanmocks.MockGetInvestorPortfolioStore, *loanmocks.MockUserStore) {},
+			wantErr: true,
+		},
+		{
+			name: "error user not investor",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3},
+			mockFn: func(_ *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).
+					Return(sqlentity.Users{{ID: 3, Type: sqlentity.Borrower}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.UserNotInvestor,
+		},
+		{
+			name: "error when get loan investment",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3},
+			mockFn: func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when get loan",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3},
+			mockFn: func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when get loan investor credit",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3},
+			mockFn: func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(loans, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan of investment not found",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3},
+			mockFn: func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success without investments",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3, Scope: usecase.LoanScope{InvestorID: 3}},
+			mockFn: func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			want: &usecase.GetInvestorPortfolioOutput{
+				InvestorID:           3,
+				PlatformFeeRate:      decimal.NewFromInt(10),
+				TotalInvested:        decimal.Zero,
+				TotalOutstanding:     decimal.Zero,
+				TotalEarned:          decimal.Zero,
+				TotalExpectedReturn:  decimal.Zero,
+				WeightedAverageYield: decimal.Zero,
+				Investments:          []usecase.PortfolioInvestment{},
+			},
+		},
+		{
+			name: "success",
+			in:   usecase.GetInvestorPortfolioInput{InvestorID: 3},
+			mockFn: func(store *loanmocks.MockGetInvestorPortfolioStore, userStore *loanmocks.MockUserStore) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				store.EXPECT().GetLoanInvestment(mock.Anything, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoan(mock.Anything, mock.Anything).Return(loans, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(mock.Anything, mock.Anything).Return(credits, nil).Once()
+			},
+			want: &usecase.GetInvestorPortfolioOutput{
+				InvestorID:           3,
+				PlatformFeeRate:      decimal.NewFromInt(10),
+				TotalInvested:        decimal.NewFromInt(400_000),
+				TotalOutstanding:     decimal.RequireFromString("266666.67"),
+				TotalEarned:          decimal.NewFromInt(3_600),
+				TotalExpectedReturn:  decimal.NewFromInt(10_800),
+				WeightedAverageYield: decimal.RequireFromString("10.8"),
+				Investments: []usecase.PortfolioInvestment{{
+					InvestmentID:         10,
+					LoanID:               1,
+					LoanStatus:           "DISBURSED",
+					InterestRate:         decimal.NewFromInt(12),
+					TenorMonths:          3,
+					InvestedAmount:       decimal.NewFromInt(400_000),
+					SharePercentage:      decimal.NewFromInt(40),
+					ExpectedReturn:       decimal.NewFromInt(10_800),
+					RealizedReturn:       decimal.NewFromInt(3_600),
+					ReceivedPrincipal:    decimal.RequireFromString("133333.33"),
+					OutstandingPrincipal: decimal.RequireFromString("266666.67"),
+					Yield:                decimal.RequireFromString("10.8"),
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockGetInvestorPortfolioStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			tt.mockFn(store, userStore)
+
+			g := NewGetInvestorPortfolio(store, userStore, decimal.NewFromInt(10), logger)
+			got, err := g.Execute(context.Background(), tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetInvestorPortfolio.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			if tt.want == nil {
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.Equal(t, tt.want.InvestorID, got.InvestorID)
+			assert.True(t, tt.want.PlatformFeeRate.Equal(got.PlatformFeeRate))
+			assert.True(t, tt.want.TotalInvested.Equal(got.TotalInvested))
+			assert.True(t, tt.want.TotalOutstanding.Equal(got.TotalOutstanding))
+			assert.True(t, tt.want.TotalEarned.Equal(got.TotalEarned))
+			assert.True(t, tt.want.TotalExpectedReturn.Equal(got.TotalExpectedReturn))
+			assert.True(t, tt.want.WeightedAverageYield.Equal(got.WeightedAverageYield))
+			assert.Len(t, got.Investments, len(tt.want.Investments))
+
+			for i, want := range tt.want.Investments {
+				got := got.Investments[i]
+				assert.Equal(t, want.InvestmentID, got.InvestmentID)
+				assert.Equal(t, want.LoanID, got.LoanID)
+				assert.Equal(t, want.LoanStatus, got.LoanStatus)
+				assert.Equal(t, want.TenorMonths, got.TenorMonths)
+				assert.True(t, want.InterestRate.Equal(got.InterestRate))
+				assert.True(t, want.InvestedAmount.Equal(got.InvestedAmount))
+				assert.True(t, want.SharePercentage.Equal(got.SharePercentage))
+				assert.True(t, want.ExpectedReturn.Equal(got.ExpectedReturn))
+				assert.True(t, want.RealizedReturn.Equal(got.RealizedReturn))
+				assert.True(t, want.ReceivedPrincipal.Equal(got.ReceivedPrincipal))
+				assert.True(t, want.OutstandingPrincipal.Equal(got.OutstandingPrincipal))
+				assert.True(t, want.Yield.Equal(got.Yield))
+			}
+		})
+	}
+}
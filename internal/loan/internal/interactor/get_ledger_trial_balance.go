@@ -0,0 +1,70 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"go.uber.org/zap"
+)
+
+type (
+	GetLedgerTrialBalanceStore interface {
+		GetLedgerTrialBalance(ctx context.Context) (sqlentity.LedgerAccountBalances, error)
+	}
+
+	GetLedgerTrialBalance struct {
+		store  GetLedgerTrialBalanceStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewGetLedgerTrialBalance(
+	store GetLedgerTrialBalanceStore,
+	logger *zap.SugaredLogger,
+) *GetLedgerTrialBalance {
+	return &GetLedgerTrialBalance{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (g *GetLedgerTrialBalance) Execute(
+	ctx context.Context,
+	_ usecase.GetLedgerTrialBalanceInput,
+) (*usecase.GetLedgerTrialBalanceOutput, error) {
+	balances, err := g.store.GetLedgerTrialBalance(ctx)
+	if err != nil {
+		g.logger.Errorw("failed to get ledger trial balance", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	trialBalance := ledger.NewTrialBalance(balances)
+	if !trialBalance.Balanced() {
+		g.logger.Errorw("ledger is not balanced",
+			"total_debit", trialBalance.TotalDebit, "total_credit", trialBalance.TotalCredit)
+	}
+
+	out := &usecase.GetLedgerTrialBalanceOutput{
+		Balanced:    trialBalance.Balanced(),
+		TotalDebit:  trialBalance.TotalDebit,
+		TotalCredit: trialBalance.TotalCredit,
+		Accounts:    make([]usecase.LedgerAccountTotal, 0, len(trialBalance.Accounts)),
+	}
+
+	for _, account := range trialBalance.Accounts {
+		out.Accounts = append(out.Accounts, usecase.LedgerAccountTotal{
+			AccountID: account.AccountID,
+			Type:      account.Account.Type.String(),
+			OwnerID:   account.Account.OwnerID,
+			Debit:     account.Debit,
+			Credit:    account.Credit,
+			Balance:   account.Balance,
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,157 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGetLedgerTrialBalance_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	balances := sqlentity.LedgerAccountBalances{
+		{
+			AccountID:    1,
+			Type:         sqlentity.InvestorWallet,
+			OwnerID:      3,
+			DebitAmount:  decimal.NewFromInt(1_000),
+			CreditAmount: decimal.RequireFromString("1018"),
+		},
+		{
+			AccountID:    2,
+			Type:         sqlentity.PlatformEscrow,
+			DebitAmount:  decimal.RequireFromString("2020"),
+			CreditAmount: decimal.RequireFromString("2020"),
+		},
+		{
+			AccountID:    3,
+			Type:         sqlentity.BorrowerAccount,
+			OwnerID:      5,
+			DebitAmount:  decimal.RequireFromString("1020"),
+			CreditAmount: decimal.NewFromInt(1_000),
+		},
+		{
+			AccountID:    4,
+			Type:         sqlentity.FeeRevenue,
+			DebitAmount:  decimal.Zero,
+			CreditAmount: decimal.NewFromInt(2),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(store *loanmocks.MockGetLedgerTrialBalanceStore)
+		want    *usecase.GetLedgerTrialBalanceOutput
+		wantErr bool
+	}{
+		{
+			name: "error when get ledger trial balance",
+			mockFn: func(store *loanmocks.MockGetLedgerTrialBalanceStore) {
+				store.EXPECT().GetLedgerTrialBalance(context.Background()).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success unbalanced",
+			mockFn: func(store *loanmocks.MockGetLedgerTrialBalanceStore) {
+				store.EXPECT().GetLedgerTrialBalance(context.Background()).Return(balances[:1], nil).Once()
+			},
+			want: &usecase.GetLedgerTrialBalanceOutput{
+				Balanced:    false,
+				TotalDebit:  decimal.NewFromInt(1_000),
+				TotalCredit: decimal.NewFromInt(1_018),
+				Accounts: []usecase.LedgerAccountTotal{
+					{
+						AccountID: 1,
+						Type:      "INVESTOR_WALLET",
+						OwnerID:   3,
+						Debit:     decimal.NewFromInt(1_000),
+						Credit:    decimal.NewFromInt(1_018),
+						Balance:   decimal.NewFromInt(18),
+					},
+				},
+			},
+		},
+		{
+			name: "success",
+			mockFn: func(store *loanmocks.MockGetLedgerTrialBalanceStore) {
+				store.EXPECT().GetLedgerTrialBalance(context.Background()).Return(balances, nil).Once()
+			},
+			want: &usecase.GetLedgerTrialBalanceOutput{
+				Balanced:    true,
+				TotalDebit:  decimal.NewFromInt(4_040),
+				TotalCredit: decimal.NewFromInt(4_040),
+				Accounts: []usecase.LedgerAccountTotal{
+					{
+						AccountID: 1,
+						Type:      "INVESTOR_WALLET",
+						OwnerID:   3,
+						Debit:     decimal.NewFromInt(1_000),
+						Credit:    decimal.NewFromInt(1_018),
+						Balance:   decimal.NewFromInt(18),
+					},
+					{
+						AccountID: 2,
+						Type:      "PLATFORM_ESCROW",
+						Debit:     decimal.NewFromInt(2_020),
+						Credit:    decimal.NewFromInt(2_020),
+						Balance:   decimal.Zero,
+					},
+					{
+						AccountID: 3,
+						Type:      "BORROWER",
+						OwnerID:   5,
+						Debit:     decimal.NewFromInt(1_020),
+						Credit:    decimal.NewFromInt(1_000),
+						Balance:   decimal.NewFromInt(-20),
+					},
+					{
+						AccountID: 4,
+						Type:      "FEE_REVENUE",
+						Debit:     decimal.Zero,
+						Credit:    decimal.NewFromInt(2),
+						Balance:   decimal.NewFromInt(2),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockGetLedgerTrialBalanceStore(t)
+			tt.mockFn(store)
+
+			g := NewGetLedgerTrialBalance(store, logger)
+			got, err := g.Execute(context.Background(), usecase.GetLedgerTrialBalanceInput{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLedgerTrialBalance.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.want == nil {
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.Equal(t, tt.want.Balanced, got.Balanced)
+			assert.True(t, tt.want.TotalDebit.Equal(got.TotalDebit))
+			assert.True(t, tt.want.TotalCredit.Equal(got.TotalCredit))
+			assert.Len(t, got.Accounts, len(tt.want.Accounts))
+
+			for i, account := range tt.want.Accounts {
+				assert.Equal(t, account.AccountID, got.Accounts[i].AccountID)
+				assert.Equal(t, account.Type, got.Accounts[i].Type)
+				assert.Equal(t, account.OwnerID, got.Accounts[i].OwnerID)
+				assert.True(t, account.Debit.Equal(got.Accounts[i].Debit))
+				assert.True(t, account.Credit.Equal(got.Accounts[i].Credit))
+				assert.True(t, account.Balance.Equal(got.Accounts[i].Balance), "balance of account %d", account.AccountID)
+			}
+		})
+	}
+}
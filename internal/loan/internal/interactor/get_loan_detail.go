@@ -0,0 +1,116 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"go.uber.org/zap"
+)
+
+type (
+	GetLoanDetailStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+	}
+
+	GetLoanDetail struct {
+		store         GetLoanDetailStore
+		documentStore pkgstorage.DocumentStore
+		logger        *zap.SugaredLogger
+	}
+)
+
+// agreementLetterURLTTL is how long the signed agreement letter URL of a loan detail stays valid.
+const agreementLetterURLTTL = 15 * time.Minute
+
+func NewGetLoanDetail(
+	store GetLoanDetailStore,
+	documentStore pkgstorage.DocumentStore,
+	logger *zap.SugaredLogger,
+) *GetLoanDetail {
+	return &GetLoanDetail{
+		store:         store,
+		documentStore: documentStore,
+		logger:        logger,
+	}
+}
+
+func (g *GetLoanDetail) Execute(
+	ctx context.Context,
+	in usecase.GetLoanDetailInput,
+) (*usecase.Loan, error) {
+	loans, err := g.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		g.logger.Errorw("loan not found")
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	investments, err := g.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		g.logger.Errorw("failed to get loan investment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if !canReadLoan(in.Scope, loan, investments) {
+		g.logger.Errorw("caller cannot read loan", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewAuthorizationError("loan belongs to another user")
+	}
+
+	out := toLoanOutput(loan, investments)
+
+	if out.Disbursement != nil && out.Disbursement.AgreementLetterDocumentKey != "" {
+		url, err := g.documentStore.SignedURL(ctx, out.Disbursement.AgreementLetterDocumentKey, agreementLetterURLTTL)
+		if err != nil {
+			// loans disbursed before documents had keys keep a file path, which cannot be signed
+			g.logger.Warnw("failed to sign agreement letter url", "loan_id", loan.ID, "error", err)
+		}
+
+		out.Disbursement.AgreementLetterDocumentURL = url
+	}
+
+	for i, investment := range out.Investments {
+		if investment.AgreementLetterDocumentKey == "" || !canReadInvestmentLetter(in.Scope, investment) {
+			continue
+		}
+
+		url, err := g.documentStore.SignedURL(ctx, investment.AgreementLetterDocumentKey, agreementLetterURLTTL)
+		if err != nil {
+			g.logger.Warnw("failed to sign investment agreement letter url", "investment_id", investment.ID, "error", err)
+		}
+
+		out.Investments[i].AgreementLetterDocumentURL = url
+	}
+
+	return &out, nil
+}
+
+// canReadInvestmentLetter keeps an investor's agreement letter to that investor and employees.
+func canReadInvestmentLetter(scope usecase.LoanScope, investment usecase.LoanInvestment) bool {
+	if scope.BorrowerID != 0 {
+		return false
+	}
+
+	return scope.InvestorID == 0 || scope.InvestorID == investment.InvestorID
+}
@@ -0,0 +1,262 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// activeInvestmentsFilter matches the option leaving the cancelled investments out of a loan investment query.
+func activeInvestmentsFilter() any {
+	return mock.MatchedBy(func(opt gateway.GetLoanInvestmentOption) bool {
+		query, _, err := opt(goqu.From("loan_investments")).ToSQL()
+
+		return err == nil && strings.Contains(query, `"status" = 'ACTIVE'`)
+	})
+}
+
+func TestGetLoanDetail_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	disbursedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	investedLoan := sqlentity.Loan{
+		ID:              1,
+		BorrowerID:      5,
+		PrincipalAmount: decimal.NewFromInt(1_000),
+		InvestedAmount:  decimal.NewFromInt(1_000),
+		InterestRate:    decimal.NewFromInt(12),
+		RepaymentMethod: sqlentity.FlatRepayment,
+		Status:          sqlentity.Invested,
+	}
+	disbursedLoan := investedLoan
+	disbursedLoan.Status = sqlentity.Disbursed
+	disbursedLoan.DisbursementDate = sql.NullTime{Time: disbursedAt, Valid: true}
+	disbursedLoan.AgreementLetterDocumentURL = sql.NullString{String: "loan/1/agreement-letter/letter.pdf", Valid: true}
+	investments := sqlentity.LoanInvestments{
+		{
+			ID:                         10,
+			LoanID:                     1,
+			InvestorID:                 3,
+			Amount:                     decimal.NewFromInt(600),
+			AgreementLetterDocumentKey: sql.NullString{String: "loan/1/investment/10/agreement-letter.pdf", Valid: true},
+			Status:                     sqlentity.InvestmentActive,
+		},
+		{
+			ID:                         11,
+			LoanID:                     1,
+			InvestorID:                 4,
+			Amount:                     decimal.NewFromInt(400),
+			AgreementLetterDocumentKey: sql.NullString{String: "loan/1/investment/11/agreement-letter.pdf", Valid: true},
+			Status:                     sqlentity.InvestmentActive,
+		},
+	}
+	loanOutput := func(status string, investments ...usecase.LoanInvestment) *usecase.Loan {
+		return &usecase.Loan{
+			ID:              1,
+			BorrowerID:      5,
+			PrincipalAmount: decimal.NewFromInt(1_000),
+			InvestedAmount:  decimal.NewFromInt(1_000),
+			// fully invested, built the way remainingAmount computes it so the decimals compare equal
+			RemainingAmount: decimal.NewFromInt(1_000).Sub(decimal.NewFromInt(1_000)),
+			InterestRate:    decimal.NewFromInt(12),
+			RepaymentMethod: "FLAT",
+			Status:          status,
+			Investments:     append([]usecase.LoanInvestment{}, investments...),
+		}
+	}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.GetLoanDetailInput
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		mockFn               func(store *loanmocks.MockGetLoanDetailStore, documentStore *pkgmocks.MockDocumentStore, a args)
+		want                 *usecase.Loan
+		wantErr              bool
+		wantCode             pkgerror.Code
+		wantAuthorizationErr bool
+	}{
+		{
+			name: "error when get loan",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDetailInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, _ *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not found",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDetailInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, _ *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanNotFound,
+		},
+		{
+			name: "error when get loan investment",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDetailInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, _ *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error borrower reads the loan of another borrower",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanDetailInput{LoanID: 1, Scope: usecase.LoanScope{BorrowerID: 6}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, _ *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+			},
+			wantErr:              true,
+			wantAuthorizationErr: true,
+		},
+		{
+			name: "error investor without an active investment in the loan",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanDetailInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 7}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, _ *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, activeInvestmentsFilter()).
+					Return(investments, nil).Once()
+			},
+			wantErr:              true,
+			wantAuthorizationErr: true,
+		},
+		{
+			name: "success cancelled investments excluded",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDetailInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, _ *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+				// the cancelled investment 12 of investor 3 is left out by the store
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, activeInvestmentsFilter()).
+					Return(sqlentity.LoanInvestments{{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(600)}}, nil).
+					Once()
+			},
+			want: loanOutput("INVESTED", usecase.LoanInvestment{ID: 10, InvestorID: 3, Amount: decimal.NewFromInt(600)}),
+		},
+		{
+			name: "success investor reads signed urls of the loan and of their own letter",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanDetailInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 3}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, documentStore *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{disbursedLoan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, activeInvestmentsFilter()).
+					Return(investments, nil).Once()
+				documentStore.EXPECT().SignedURL(a.ctx, "loan/1/agreement-letter/letter.pdf", agreementLetterURLTTL).
+					Return("https://documents/letter", nil).Once()
+				documentStore.EXPECT().SignedURL(a.ctx, "loan/1/investment/10/agreement-letter.pdf", agreementLetterURLTTL).
+					Return("https://documents/investment-10", nil).Once()
+			},
+			want: func() *usecase.Loan {
+				out := loanOutput(
+					"DISBURSED",
+					usecase.LoanInvestment{
+						ID:                         10,
+						InvestorID:                 3,
+						Amount:                     decimal.NewFromInt(600),
+						AgreementLetterDocumentKey: "loan/1/investment/10/agreement-letter.pdf",
+						AgreementLetterDocumentURL: "https://documents/investment-10",
+					},
+					usecase.LoanInvestment{
+						ID:                         11,
+						InvestorID:                 4,
+						Amount:                     decimal.NewFromInt(400),
+						AgreementLetterDocumentKey: "loan/1/investment/11/agreement-letter.pdf",
+					},
+				)
+				out.Disbursement = &usecase.LoanDisbursement{
+					Date:                       disbursedAt,
+					AgreementLetterDocumentKey: "loan/1/agreement-letter/letter.pdf",
+					AgreementLetterDocumentURL: "https://documents/letter",
+				}
+
+				return out
+			}(),
+		},
+		{
+			name: "success without urls when document store fails",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanDetailInput{LoanID: 1, Scope: usecase.LoanScope{BorrowerID: 5}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanDetailStore, documentStore *pkgmocks.MockDocumentStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{disbursedLoan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				// the borrower never gets the letters of the investors signed
+				documentStore.EXPECT().SignedURL(a.ctx, "loan/1/agreement-letter/letter.pdf", agreementLetterURLTTL).
+					Return("", errors.New("any error")).Once()
+			},
+			want: func() *usecase.Loan {
+				out := loanOutput(
+					"DISBURSED",
+					usecase.LoanInvestment{
+						ID:                         10,
+						InvestorID:                 3,
+						Amount:                     decimal.NewFromInt(600),
+						AgreementLetterDocumentKey: "loan/1/investment/10/agreement-letter.pdf",
+					},
+					usecase.LoanInvestment{
+						ID:                         11,
+						InvestorID:                 4,
+						Amount:                     decimal.NewFromInt(400),
+						AgreementLetterDocumentKey: "loan/1/investment/11/agreement-letter.pdf",
+					},
+				)
+				out.Disbursement = &usecase.LoanDisbursement{
+					Date:                       disbursedAt,
+					AgreementLetterDocumentKey: "loan/1/agreement-letter/letter.pdf",
+				}
+
+				return out
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockGetLoanDetailStore(t)
+			documentStore := pkgmocks.NewMockDocumentStore(t)
+			tt.mockFn(store, documentStore, tt.args)
+
+			g := NewGetLoanDetail(store, documentStore, logger)
+			got, err := g.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLoanDetail.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			assert.Equal(t, tt.wantAuthorizationErr, pkgerror.IsAuthorizationError(err))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
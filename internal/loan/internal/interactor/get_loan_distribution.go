@@ -0,0 +1,161 @@
+package interactor
+
+import (
+	"context"
+	"slices"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/distribution"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type (
+	GetLoanDistributionStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		GetLoanInstallment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInstallmentOption,
+		) (sqlentity.LoanInstallments, error)
+		GetLoanInvestorCredit(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestorCreditOption,
+		) (sqlentity.LoanInvestorCredits, error)
+	}
+
+	GetLoanDistribution struct {
+		store           GetLoanDistributionStore
+		platformFeeRate decimal.Decimal
+		logger          *zap.SugaredLogger
+	}
+)
+
+func NewGetLoanDistribution(
+	store GetLoanDistributionStore,
+	platformFeeRate decimal.Decimal,
+	logger *zap.SugaredLogger,
+) *GetLoanDistribution {
+	return &GetLoanDistribution{
+		store:           store,
+		platformFeeRate: platformFeeRate,
+		logger:          logger,
+	}
+}
+
+func (g *GetLoanDistribution) Execute(
+	ctx context.Context,
+	in usecase.GetLoanDistributionInput,
+) (*usecase.GetLoanDistributionOutput, error) {
+	loans, err := g.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		g.logger.Errorw("loan not found")
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	investments, err := g.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		g.logger.Errorw("failed to get loan investment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	// unlike the loan itself, the distribution of an approved loan is only readable by its investors
+	if !canReadLoan(in.Scope, loan, investments) || (in.Scope.InvestorID != 0 && !hasInvested(in.Scope, investments)) {
+		g.logger.Errorw("caller cannot read loan distribution", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewAuthorizationError("loan belongs to another user")
+	}
+
+	installments, err := g.store.GetLoanInstallment(ctx, gateway.GetLoanInstallmentWithLoanIDFilter(loan.ID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan installment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	credits, err := g.store.GetLoanInvestorCredit(ctx, gateway.GetLoanInvestorCreditWithLoanIDFilter(loan.ID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan investor credit", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	// the interest still owed is split the way the repayments will be, the fee already taken out
+	unpaidInterest := decimal.Zero
+	for _, installment := range installments {
+		unpaidInterest = unpaidInterest.Add(installment.InterestAmount.Sub(installment.PaidInterestAmount))
+	}
+
+	var owed []distribution.Credit
+	if !investments.IsEmpty() {
+		owed, err = distribution.Distribute(decimal.Zero, unpaidInterest, g.platformFeeRate, investmentShares(investments))
+		if err != nil {
+			g.logger.Errorw("failed to distribute unpaid interest", "loan_id", loan.ID, "error", err)
+
+			return nil, pkgerror.ServerErrorFrom(err)
+		}
+	}
+
+	out := &usecase.GetLoanDistributionOutput{
+		LoanID:          loan.ID,
+		PlatformFeeRate: g.platformFeeRate,
+		Investments:     make([]usecase.LoanInvestmentEarnings, 0, investments.Len()),
+	}
+
+	for i, investment := range investments {
+		if in.Scope.InvestorID != 0 && investment.InvestorID != in.Scope.InvestorID {
+			continue
+		}
+
+		earnings := usecase.LoanInvestmentEarnings{
+			InvestmentID:        investment.ID,
+			InvestorID:          investment.InvestorID,
+			InvestedAmount:      investment.Amount,
+			ReceivedPrincipal:   decimal.Zero,
+			ReceivedInterest:    decimal.Zero,
+			PlatformFee:         decimal.Zero,
+			OutstandingInterest: owed[i].Interest,
+		}
+
+		for _, credit := range credits {
+			if credit.InvestmentID != investment.ID {
+				continue
+			}
+
+			earnings.ReceivedPrincipal = earnings.ReceivedPrincipal.Add(credit.PrincipalAmount)
+			earnings.ReceivedInterest = earnings.ReceivedInterest.Add(credit.InterestAmount)
+			earnings.PlatformFee = earnings.PlatformFee.Add(credit.FeeAmount)
+		}
+
+		earnings.OutstandingPrincipal = investment.Amount.Sub(earnings.ReceivedPrincipal)
+		out.Investments = append(out.Investments, earnings)
+	}
+
+	return out, nil
+}
+
+// hasInvested reports whether the investor limited by scope holds one of the investments.
+func hasInvested(scope usecase.LoanScope, investments sqlentity.LoanInvestments) bool {
+	return slices.ContainsFunc(investments, func(investment sqlentity.LoanInvestment) bool {
+		return investment.InvestorID == scope.InvestorID
+	})
+}
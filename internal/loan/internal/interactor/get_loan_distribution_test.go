@@ -0,0 +1,218 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestGetLoanDistribution_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	disbursedLoan := sqlentity.Loans{{ID: 1, BorrowerID: 5, Status: sqlentity.Disbursed}}
+	investments := sqlentity.LoanInvestments{
+		{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(2_000)},
+		{ID: 11, LoanID: 1, InvestorID: 4, Amount: decimal.NewFromInt(1_000)},
+	}
+	installments := sqlentity.LoanInstallments{
+		{
+			ID:                  20,
+			LoanID:              1,
+			InstallmentNumber:   1,
+			InterestAmount:      decimal.NewFromInt(50),
+			PaidInterestAmount:  decimal.NewFromInt(50),
+			PaidPrincipalAmount: decimal.NewFromInt(1_500),
+		},
+		{
+			ID:                  21,
+			LoanID:              1,
+			InstallmentNumber:   2,
+			InterestAmount:      decimal.NewFromInt(50),
+			PaidInterestAmount:  decimal.Zero,
+			PaidPrincipalAmount: decimal.Zero,
+		},
+	}
+	credits := sqlentity.LoanInvestorCredits{
+		{
+			ID:              50,
+			RepaymentID:     40,
+			InvestmentID:    10,
+			InvestorID:      3,
+			PrincipalAmount: decimal.NewFromInt(1_000),
+			InterestAmount:  decimal.NewFromInt(30),
+			FeeAmount:       decimal.RequireFromString("3.33"),
+		},
+		{
+			ID:              51,
+			RepaymentID:     40,
+			InvestmentID:    11,
+			InvestorID:      4,
+			PrincipalAmount: decimal.NewFromInt(500),
+			InterestAmount:  decimal.NewFromInt(15),
+			FeeAmount:       decimal.RequireFromString("1.67"),
+		},
+	}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.GetLoanDistributionInput
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFn   func(store *loanmocks.MockGetLoanDistributionStore, a args)
+		want     []string
+		wantErr  bool
+		wantCode pkgerror.Code
+	}{
+		{
+			name: "error when get loan",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not found",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanNotFound,
+		},
+		{
+			name: "error when get loan investment",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not invested by investor",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanDistributionInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 2}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when get loan installment",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when get loan investor credit",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(installments, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success loan not funded yet",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			want: []string{},
+		},
+		{
+			name: "success employee sees every investment",
+			args: args{ctx: context.Background(), in: usecase.GetLoanDistributionInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(installments, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(a.ctx, mock.Anything).Return(credits, nil).Once()
+			},
+			// investment, received principal, interest and fee, outstanding principal and interest
+			want: []string{
+				"10 1000 30 3.33 1000 30",
+				"11 500 15 1.67 500 15",
+			},
+		},
+		{
+			name: "success investor sees their own investment",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanDistributionInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 4}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanDistributionStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(investments, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(installments, nil).Once()
+				store.EXPECT().GetLoanInvestorCredit(a.ctx, mock.Anything).Return(credits, nil).Once()
+			},
+			want: []string{"11 500 15 1.67 500 15"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockGetLoanDistributionStore(t)
+			tt.mockFn(store, tt.args)
+
+			g := NewGetLoanDistribution(store, decimal.NewFromInt(10), logger)
+			got, err := g.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLoanDistribution.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			if tt.want == nil {
+				assert.Nil(t, got)
+
+				return
+			}
+
+			earnings := make([]string, 0, len(got.Investments))
+			for _, e := range got.Investments {
+				earnings = append(earnings, strings.Join([]string{
+					strconv.FormatUint(e.InvestmentID, 10),
+					e.ReceivedPrincipal.String(),
+					e.ReceivedInterest.String(),
+					e.PlatformFee.String(),
+					e.OutstandingPrincipal.String(),
+					e.OutstandingInterest.String(),
+				}, " "))
+			}
+
+			assert.Equal(t, tt.want, earnings)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"context"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type (
+	GetLoanScheduleStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		GetLoanInstallment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInstallmentOption,
+		) (sqlentity.LoanInstallments, error)
+	}
+
+	GetLoanSchedule struct {
+		store  GetLoanScheduleStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewGetLoanSchedule(
+	store GetLoanScheduleStore,
+	logger *zap.SugaredLogger,
+) *GetLoanSchedule {
+	return &GetLoanSchedule{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (g *GetLoanSchedule) Execute(
+	ctx context.Context,
+	in usecase.GetLoanScheduleInput,
+) (*usecase.GetLoanScheduleOutput, error) {
+	loans, err := g.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		g.logger.Errorw("loan not found")
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	var investments sqlentity.LoanInvestments
+	if in.Scope.InvestorID != 0 {
+		investments, err = g.store.GetLoanInvestment(
+			ctx,
+			gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+			gateway.GetLoanInvestmentWithActiveFilter(),
+		)
+		if err != nil {
+			g.logger.Errorw("failed to get loan investment", "error", err)
+
+			return nil, pkgerror.ServerErrorFrom(err)
+		}
+	}
+
+	if !canReadLoan(in.Scope, loan, investments) {
+		g.logger.Errorw("caller cannot read loan", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewAuthorizationError("loan belongs to another user")
+	}
+
+	installments, err := g.store.GetLoanInstallment(ctx, gateway.GetLoanInstallmentWithLoanIDFilter(loan.ID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan installment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.GetLoanScheduleOutput{
+		LoanID:          loan.ID,
+		TenorMonths:     int(loan.TenorMonths.Int32),
+		RepaymentMethod: loan.RepaymentMethod.String(),
+		TotalPrincipal:  decimal.Zero,
+		TotalInterest:   decimal.Zero,
+		TotalAmount:     decimal.Zero,
+		Installments:    make([]usecase.LoanInstallment, 0, installments.Len()),
+	}
+
+	for _, installment := range installments {
+		var paidAt *time.Time
+		if installment.PaidAt.Valid {
+			paidAt = &installment.PaidAt.Time
+		}
+
+		out.TotalPrincipal = out.TotalPrincipal.Add(installment.PrincipalAmount)
+		out.TotalInterest = out.TotalInterest.Add(installment.InterestAmount)
+		out.TotalAmount = out.TotalAmount.Add(installment.TotalAmount)
+		out.Installments = append(out.Installments, usecase.LoanInstallment{
+			InstallmentNumber:   installment.InstallmentNumber,
+			DueDate:             installment.DueDate,
+			PrincipalAmount:     installment.PrincipalAmount,
+			InterestAmount:      installment.InterestAmount,
+			TotalAmount:         installment.TotalAmount,
+			OutstandingBalance:  installment.OutstandingBalance,
+			PaidPrincipalAmount: installment.PaidPrincipalAmount,
+			PaidInterestAmount:  installment.PaidInterestAmount,
+			Status:              installment.Status.String(),
+			PaidAt:              paidAt,
+		})
+	}
+
+	return out, nil
+}
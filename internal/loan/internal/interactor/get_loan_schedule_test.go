@@ -0,0 +1,218 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestGetLoanSchedule_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	disbursedLoan := sqlentity.Loans{{
+		ID:              1,
+		BorrowerID:      5,
+		TenorMonths:     sql.NullInt32{Int32: 2, Valid: true},
+		RepaymentMethod: sqlentity.FlatRepayment,
+		Status:          sqlentity.Disbursed,
+	}}
+	firstDue := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	secondDue := time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	paidAt := time.Date(2024, 10, 28, 9, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx context.Context
+		in  usecase.GetLoanScheduleInput
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFn   func(store *loanmocks.MockGetLoanScheduleStore, a args)
+		want     *usecase.GetLoanScheduleOutput
+		wantErr  bool
+		wantCode pkgerror.Code
+	}{
+		{
+			name: "error when get loan",
+			args: args{ctx: context.Background(), in: usecase.GetLoanScheduleInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not found",
+			args: args{ctx: context.Background(), in: usecase.GetLoanScheduleInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanNotFound,
+		},
+		{
+			name: "error loan of another borrower",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanScheduleInput{LoanID: 1, Scope: usecase.LoanScope{BorrowerID: 6}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not invested by investor",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanScheduleInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 2}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{{ID: 1, LoanID: 1, InvestorID: 3}}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error when get loan installment",
+			args: args{ctx: context.Background(), in: usecase.GetLoanScheduleInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success without schedule",
+			args: args{ctx: context.Background(), in: usecase.GetLoanScheduleInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, RepaymentMethod: sqlentity.FlatRepayment}}, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			want: &usecase.GetLoanScheduleOutput{
+				LoanID:          1,
+				RepaymentMethod: "FLAT",
+				TotalPrincipal:  decimal.Zero,
+				TotalInterest:   decimal.Zero,
+				TotalAmount:     decimal.Zero,
+				Installments:    []usecase.LoanInstallment{},
+			},
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanScheduleInput{LoanID: 1, Scope: usecase.LoanScope{BorrowerID: 5}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanScheduleStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything).
+					Return(sqlentity.LoanInstallments{
+						{
+							ID:                  10,
+							LoanID:              1,
+							InstallmentNumber:   1,
+							DueDate:             firstDue,
+							PrincipalAmount:     decimal.NewFromInt(500),
+							InterestAmount:      decimal.NewFromInt(10),
+							TotalAmount:         decimal.NewFromInt(510),
+							OutstandingBalance:  decimal.NewFromInt(500),
+							PaidPrincipalAmount: decimal.NewFromInt(500),
+							PaidInterestAmount:  decimal.NewFromInt(10),
+							Status:              sqlentity.InstallmentPaid,
+							PaidAt:              sql.NullTime{Time: paidAt, Valid: true},
+						},
+						{
+							ID:                  11,
+							LoanID:              1,
+							InstallmentNumber:   2,
+							DueDate:             secondDue,
+							PrincipalAmount:     decimal.NewFromInt(500),
+							InterestAmount:      decimal.NewFromInt(10),
+							TotalAmount:         decimal.NewFromInt(510),
+							OutstandingBalance:  decimal.Zero,
+							PaidPrincipalAmount: decimal.Zero,
+							PaidInterestAmount:  decimal.NewFromInt(4),
+							Status:              sqlentity.InstallmentPartial,
+						},
+					}, nil).Once()
+			},
+			want: &usecase.GetLoanScheduleOutput{
+				LoanID:          1,
+				TenorMonths:     2,
+				RepaymentMethod: "FLAT",
+				TotalPrincipal:  decimal.NewFromInt(1_000),
+				TotalInterest:   decimal.NewFromInt(20),
+				TotalAmount:     decimal.NewFromInt(1_020),
+				Installments: []usecase.LoanInstallment{
+					{
+						InstallmentNumber:   1,
+						DueDate:             firstDue,
+						PrincipalAmount:     decimal.NewFromInt(500),
+						InterestAmount:      decimal.NewFromInt(10),
+						TotalAmount:         decimal.NewFromInt(510),
+						OutstandingBalance:  decimal.NewFromInt(500),
+						PaidPrincipalAmount: decimal.NewFromInt(500),
+						PaidInterestAmount:  decimal.NewFromInt(10),
+						Status:              "PAID",
+						PaidAt:              &paidAt,
+					},
+					{
+						InstallmentNumber:   2,
+						DueDate:             secondDue,
+						PrincipalAmount:     decimal.NewFromInt(500),
+						InterestAmount:      decimal.NewFromInt(10),
+						TotalAmount:         decimal.NewFromInt(510),
+						OutstandingBalance:  decimal.Zero,
+						PaidPrincipalAmount: decimal.Zero,
+						PaidInterestAmount:  decimal.NewFromInt(4),
+						Status:              "PARTIAL",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockGetLoanScheduleStore(t)
+			tt.mockFn(store, tt.args)
+
+			g := NewGetLoanSchedule(store, logger)
+			got, err := g.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLoanSchedule.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			if tt.want == nil {
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.Equal(t, tt.want.LoanID, got.LoanID)
+			assert.Equal(t, tt.want.TenorMonths, got.TenorMonths)
+			assert.Equal(t, tt.want.RepaymentMethod, got.RepaymentMethod)
+			assert.True(t, tt.want.TotalPrincipal.Equal(got.TotalPrincipal))
+			assert.True(t, tt.want.TotalInterest.Equal(got.TotalInterest))
+			assert.True(t, tt.want.TotalAmount.Equal(got.TotalAmount))
+			assert.Equal(t, tt.want.Installments, got.Installments)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"go.uber.org/zap"
+)
+
+type (
+	GetLoanStatusHistoryStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		GetLoanStatusHistory(
+			ctx context.Context,
+			opts ...gateway.GetLoanStatusHistoryOption,
+		) (sqlentity.LoanStatusHistories, error)
+	}
+
+	GetLoanStatusHistory struct {
+		store  GetLoanStatusHistoryStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewGetLoanStatusHistory(
+	store GetLoanStatusHistoryStore,
+	logger *zap.SugaredLogger,
+) *GetLoanStatusHistory {
+	return &GetLoanStatusHistory{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (g *GetLoanStatusHistory) Execute(
+	ctx context.Context,
+	in usecase.GetLoanStatusHistoryInput,
+) (*usecase.GetLoanStatusHistoryOutput, error) {
+	loans, err := g.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		g.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		g.logger.Errorw("loan not found")
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	var investments sqlentity.LoanInvestments
+	if in.Scope.InvestorID != 0 {
+		investments, err = g.store.GetLoanInvestment(
+			ctx,
+			gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+			gateway.GetLoanInvestmentWithActiveFilter(),
+		)
+		if err != nil {
+			g.logger.Errorw("failed to get loan investment", "error", err)
+
+			return nil, pkgerror.ServerErrorFrom(err)
+		}
+	}
+
+	if !canReadLoan(in.Scope, loan, investments) {
+		g.logger.Errorw("caller cannot read loan", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewAuthorizationError("loan belongs to another user")
+	}
+
+	histories, err := g.store.GetLoanStatusHistory(
+		ctx,
+		gateway.GetLoanStatusHistoryWithLoanIDFilter(in.LoanID),
+	)
+	if err != nil {
+		g.logger.Errorw("failed to get loan status history", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.GetLoanStatusHistoryOutput{
+		LoanID:    in.LoanID,
+		Histories: make([]usecase.LoanStatusHistory, 0, histories.Len()),
+	}
+
+	for _, history := range histories {
+		out.Histories = append(out.Histories, usecase.LoanStatusHistory{
+			FromStatus:  history.FromStatus.String(),
+			ToStatus:    history.ToStatus.String(),
+			ActorUserID: history.ActorUserID,
+			Reason:      history.Reason,
+			CreatedAt:   history.CreatedAt,
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,174 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestGetLoanStatusHistory_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	approvedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	investedAt := approvedAt.Add(24 * time.Hour)
+	loan := sqlentity.Loan{ID: 1, BorrowerID: 5, Status: sqlentity.Invested}
+	histories := sqlentity.LoanStatusHistories{
+		{
+			ID:          1,
+			LoanID:      1,
+			FromStatus:  sqlentity.Proposed,
+			ToStatus:    sqlentity.Approved,
+			ActorUserID: 2,
+			Reason:      "field visit done",
+			CreatedAt:   approvedAt,
+		},
+		{ID: 2, LoanID: 1, FromStatus: sqlentity.Approved, ToStatus: sqlentity.Invested, CreatedAt: investedAt},
+	}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.GetLoanStatusHistoryInput
+	}
+	tests := []struct {
+		name                 string
+		args                 args
+		mockFn               func(store *loanmocks.MockGetLoanStatusHistoryStore, a args)
+		want                 *usecase.GetLoanStatusHistoryOutput
+		wantErr              bool
+		wantCode             pkgerror.Code
+		wantAuthorizationErr bool
+	}{
+		{
+			name: "error when get loan",
+			args: args{ctx: context.Background(), in: usecase.GetLoanStatusHistoryInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not found",
+			args: args{ctx: context.Background(), in: usecase.GetLoanStatusHistoryInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanNotFound,
+		},
+		{
+			name: "error when get loan investment",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanStatusHistoryInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 3}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{loan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error borrower reads the loan of another borrower",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanStatusHistoryInput{LoanID: 1, Scope: usecase.LoanScope{BorrowerID: 6}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{loan}, nil).Once()
+			},
+			wantErr:              true,
+			wantAuthorizationErr: true,
+		},
+		{
+			name: "error investor without an active investment in the loan",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanStatusHistoryInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 7}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{loan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, activeInvestmentsFilter()).
+					Return(sqlentity.LoanInvestments{{ID: 10, LoanID: 1, InvestorID: 3}}, nil).Once()
+			},
+			wantErr:              true,
+			wantAuthorizationErr: true,
+		},
+		{
+			name: "error when get loan status history",
+			args: args{ctx: context.Background(), in: usecase.GetLoanStatusHistoryInput{LoanID: 1}},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{loan}, nil).Once()
+				store.EXPECT().GetLoanStatusHistory(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success empty history",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanStatusHistoryInput{LoanID: 1, Scope: usecase.LoanScope{BorrowerID: 5}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{loan}, nil).Once()
+				store.EXPECT().GetLoanStatusHistory(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			want: &usecase.GetLoanStatusHistoryOutput{LoanID: 1, Histories: []usecase.LoanStatusHistory{}},
+		},
+		{
+			name: "success investor reads the history in store order",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.GetLoanStatusHistoryInput{LoanID: 1, Scope: usecase.LoanScope{InvestorID: 3}},
+			},
+			mockFn: func(store *loanmocks.MockGetLoanStatusHistoryStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{loan}, nil).Once()
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, activeInvestmentsFilter()).
+					Return(sqlentity.LoanInvestments{{ID: 10, LoanID: 1, InvestorID: 3}}, nil).Once()
+				store.EXPECT().GetLoanStatusHistory(a.ctx, mock.Anything).Return(histories, nil).Once()
+			},
+			want: &usecase.GetLoanStatusHistoryOutput{
+				LoanID: 1,
+				Histories: []usecase.LoanStatusHistory{
+					{
+						FromStatus:  "PROPOSED",
+						ToStatus:    "APPROVED",
+						ActorUserID: 2,
+						Reason:      "field visit done",
+						CreatedAt:   approvedAt,
+					},
+					{FromStatus: "APPROVED", ToStatus: "INVESTED", CreatedAt: investedAt},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockGetLoanStatusHistoryStore(t)
+			tt.mockFn(store, tt.args)
+
+			g := NewGetLoanStatusHistory(store, logger)
+			got, err := g.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetLoanStatusHistory.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			assert.Equal(t, tt.wantAuthorizationErr, pkgerror.IsAuthorizationError(err))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+type (
+	GetWalletStore interface {
+		GetWallet(ctx context.Context, opts ...gateway.GetWalletOption) (sqlentity.Wallets, error)
+	}
+
+	GetWallet struct {
+		store  GetWalletStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewGetWallet(
+	store GetWalletStore,
+	logger *zap.SugaredLogger,
+) *GetWallet {
+	return &GetWallet{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (g *GetWallet) Execute(ctx context.Context, in usecase.GetWalletInput) (*usecase.Wallet, error) {
+	wallets, err := g.store.GetWallet(ctx, gateway.GetWalletWithInvestorIDFilter(in.InvestorID))
+	if err != nil {
+		g.logger.Errorw("failed to get wallet", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	// the wallet is opened on the first top-up, until then it is empty
+	if wallets.IsEmpty() {
+		return walletOutput(sqlentity.Wallet{
+			InvestorID:     in.InvestorID,
+			Balance:        decimal.Zero,
+			ReservedAmount: decimal.Zero,
+		}), nil
+	}
+
+	return walletOutput(wallets.First()), nil
+}
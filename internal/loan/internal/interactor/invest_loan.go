@@ -2,15 +2,43 @@ package interactor
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
 )
 
+// OverInvestmentPolicy decides what happens to an investment larger than the remaining fundable amount of the loan.
+type OverInvestmentPolicy string
+
+const (
+	// OverInvestmentReject refuses the whole investment.
+	OverInvestmentReject OverInvestmentPolicy = "reject"
+
+	// OverInvestmentCap accepts only the remaining fundable amount.
+	OverInvestmentCap OverInvestmentPolicy = "cap"
+)
+
+func OverInvestmentPolicyFromString(s string) OverInvestmentPolicy {
+	if OverInvestmentPolicy(s) == OverInvestmentCap {
+		return OverInvestmentCap
+	}
+
+	return OverInvestmentReject
+}
+
 type (
 	InvestLoanStore interface {
 		InsertLoanInvestment(ctx context.Context, in sqlentity.LoanInvestment) error
@@ -20,105 +48,267 @@ type (
 			opts ...gateway.UpdateLoanOption,
 		) error
 
+		InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error
+
 		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
 	}
 
 	InvestLoan struct {
 		store        InvestLoanStore
+		userStore    UserStore
+		transactor   pkgsql.Transactor
+		outbox       pkgoutbox.Recorder
+		wallets      wallet.Keeper
+		stateMachine *statemachine.LoanStateMachine
 		logger       *zap.SugaredLogger
 		snowflakeGen pkguid.Snowflake
+
+		overInvestmentPolicy OverInvestmentPolicy
+
+		// an investment losing the optimistic lock of the loan is retried up to maxAttempts times
+		maxAttempts  int
+		retryBackoff time.Duration
 	}
 )
 
 func NewInvestLoan(
 	store InvestLoanStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
+	wallets wallet.Keeper,
+	stateMachine *statemachine.LoanStateMachine,
+	overInvestmentPolicy OverInvestmentPolicy,
 	logger *zap.SugaredLogger,
 	snowflakeGen pkguid.Snowflake,
 ) *InvestLoan {
 	return &InvestLoan{
-		store:        store,
-		logger:       logger,
-		snowflakeGen: snowflakeGen,
+		store:                store,
+		userStore:            userStore,
+		transactor:           transactor,
+		outbox:               outbox,
+		wallets:              wallets,
+		stateMachine:         stateMachine,
+		logger:               logger,
+		snowflakeGen:         snowflakeGen,
+		overInvestmentPolicy: overInvestmentPolicy,
+		maxAttempts:          loanUpdateMaxAttempts,
+		retryBackoff:         loanUpdateRetryBackoff,
 	}
 }
 
-func (i *InvestLoan) Execute(ctx context.Context, in usecase.InvestLoanInput) error {
+func (i *InvestLoan) Execute(ctx context.Context, in usecase.InvestLoanInput) (*usecase.InvestLoanOutput, error) {
+	// the amount is reserved in the wallet of the investor, it must be money a wallet can hold
+	if !wallet.ValidAmount(in.Amount) {
+		i.logger.Errorw("invalid investment amount", "amount", in.Amount)
+
+		return nil, pkgerror.NewValidationError("amount must be positive with at most 2 decimals")
+	}
+
+	if err := requireUserType(ctx, i.userStore, in.InvestorID, sqlentity.Investor, pkgerror.UserNotInvestor); err != nil {
+		i.logger.Errorw("user cannot invest in a loan", "error", err)
+
+		return nil, err
+	}
+
+	var out *usecase.InvestLoanOutput
+	var err error
+
+	for attempt := 1; attempt <= i.maxAttempts; attempt++ {
+		err = i.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+			out, err = i.invest(ctx, in)
+
+			return err
+		})
+		if !errors.Is(err, gateway.ErrLoanNotUpdated) {
+			break
+		}
+
+		i.logger.Warnw("loan was modified concurrently, retrying investment", "loan_id", in.LoanID, "attempt", attempt)
+
+		if waitErr := waitBeforeLoanUpdateRetry(ctx, attempt, i.retryBackoff); waitErr != nil {
+			return nil, pkgerror.ServerErrorFrom(waitErr)
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, gateway.ErrLoanNotUpdated) {
+			return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanConcurrentUpdate)
+		}
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// invest records the investment, and the loan becoming fully funded when it does, together with their events.
+func (i *InvestLoan) invest(ctx context.Context, in usecase.InvestLoanInput) (*usecase.InvestLoanOutput, error) {
 	loans, err := i.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
 	if err != nil {
 		i.logger.Errorw("failed to get loan", "error", err)
 
-		return err
+		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
 	var loan sqlentity.Loan
 	if loan = loans.First(); loans.IsEmpty() {
 		i.logger.Errorw("loan not found")
 
-		return pkgerror.NewBusinessError("loan not found")
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	// investing is only possible while the loan can still become fully funded
+	if err := i.stateMachine.CanTransition(loan.Status, sqlentity.Invested); err != nil {
+		i.logger.Errorw("loan cannot be invested", "error", err)
+
+		return nil, err
+	}
+
+	// an overdue loan is closed for investment even before the expiry worker moved it to EXPIRED
+	if loan.FundingDeadline.Valid && !time.Now().Before(loan.FundingDeadline.Time) {
+		i.logger.Errorw("loan funding deadline has passed", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanFundingClosed)
 	}
 
-	if loan.Status == sqlentity.Invested {
-		i.logger.Errorw("loan already invested")
+	if loan.BorrowerID == in.InvestorID {
+		i.logger.Errorw("borrower cannot invest in their own loan", "loan_id", loan.ID)
 
-		return pkgerror.NewBusinessError("loan already invested")
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanSelfInvestment)
 	}
 
-	if loan.Status != sqlentity.Approved {
-		i.logger.Errorw("loan not approved")
+	amount, err := i.acceptedAmount(loan, in.Amount)
+	if err != nil {
+		i.logger.Errorw("loan investment exceeds remaining amount", "error", err)
 
-		return pkgerror.NewBusinessError("loan not approved")
+		return nil, err
 	}
 
 	loanInvestmentID := i.snowflakeGen.Generate()
 
-	if err := i.store.InsertLoanInvestment(
+	investment := sqlentity.LoanInvestment{
+		ID:         loanInvestmentID,
+		LoanID:     in.LoanID,
+		InvestorID: in.InvestorID,
+		Amount:     amount,
+		Status:     sqlentity.InvestmentActive,
+	}
+
+	if err := i.store.InsertLoanInvestment(ctx, investment); err != nil {
+		i.logger.Errorw("failed to insert loan investment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	// the money stays in the wallet of the investor until the loan is fully funded
+	if err := i.wallets.Reserve(ctx, investment); err != nil {
+		i.logger.Errorw("failed to reserve loan investment in wallet", "error", err)
+
+		return nil, walletError(err)
+	}
+
+	loan.InvestedAmount = loan.InvestedAmount.Add(amount)
+
+	if err := i.store.UpdateLoan(
 		ctx,
-		sqlentity.LoanInvestment{
-			ID:         loanInvestmentID,
-			LoanID:     in.LoanID,
-			InvestorID: in.InvestorID,
-			Amount:     in.Amount,
+		sqlentity.UpdateAmountLoan{
+			Amount:  loan.InvestedAmount,
+			Version: loan.Version + 1,
 		},
+		gateway.UpdateLoanWithLoanIDFilter(in.LoanID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
 	); err != nil {
-		i.logger.Errorw("failed to insert loan investment", "error", err)
+		i.logger.Errorw("failed to update loan", "error", err)
 
-		return err
+		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
-	totalInvestedAmount := loan.InvestedAmount.Add(in.Amount)
+	if err := recordLoanEvent(ctx, i.outbox, i.snowflakeGen.Generate(), loan.ID, event.LoanInvestmentMade,
+		event.LoanInvestmentMadePayload{
+			LoanID:       loan.ID,
+			InvestmentID: loanInvestmentID,
+			InvestorID:   in.InvestorID,
+			Amount:       amount,
+		},
+	); err != nil {
+		i.logger.Errorw("failed to record loan event", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.InvestLoanOutput{
+		InvestmentID:    loanInvestmentID,
+		RequestedAmount: in.Amount,
+		AcceptedAmount:  amount,
+		RemainingAmount: remainingAmount(loan),
+	}
+
+	if loan.InvestedAmount.LessThan(loan.PrincipalAmount) {
+		return out, nil
+	}
+
+	investedLoan, err := i.stateMachine.Transition(ctx, loan, sqlentity.Invested)
+	if err != nil {
+		i.logger.Errorw("loan cannot be invested", "error", err)
+
+		return nil, err
+	}
 
 	if err := i.store.UpdateLoan(
 		ctx,
-		sqlentity.UpdateAmountLoan{
-			Amount: totalInvestedAmount,
+		sqlentity.UpdateLoanStatus{
+			Status: investedLoan.Status,
 		},
 		gateway.UpdateLoanWithLoanIDFilter(in.LoanID),
 	); err != nil {
 		i.logger.Errorw("failed to update loan", "error", err)
 
-		return err
+		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
-	if totalInvestedAmount.GreaterThanOrEqual(loan.PrincipalAmount) {
-		if err := i.store.UpdateLoan(
-			ctx,
-			sqlentity.UpdateLoanStatus{
-				Status: sqlentity.Invested,
-			},
-			gateway.UpdateLoanWithLoanIDFilter(in.LoanID),
-		); err != nil {
-			i.logger.Errorw("failed to update loan", "error", err)
+	if err := i.store.InsertLoanStatusHistory(ctx, sqlentity.LoanStatusHistory{
+		ID:          i.snowflakeGen.Generate(),
+		LoanID:      loan.ID,
+		FromStatus:  loan.Status,
+		ToStatus:    investedLoan.Status,
+		ActorUserID: in.InvestorID,
+		Reason:      "loan fully funded",
+		CreatedAt:   time.Now(),
+	}); err != nil {
+		i.logger.Errorw("failed to insert loan status history", "error", err)
 
-			return err
-		}
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	// the agreement letters are issued and emailed by the handlers of this event
+	if err := recordLoanEvent(ctx, i.outbox, i.snowflakeGen.Generate(), loan.ID, event.LoanFullyFunded,
+		event.LoanFullyFundedPayload{
+			LoanID:         loan.ID,
+			InvestedAmount: loan.InvestedAmount,
+		},
+	); err != nil {
+		i.logger.Errorw("failed to record loan event", "error", err)
 
-		i.sendAgreementLetterToInvestor()
+		return nil, pkgerror.ServerErrorFrom(err)
 	}
 
-	return nil
+	return out, nil
 }
 
-func (i *InvestLoan) sendAgreementLetterToInvestor() {
-	// send email to investor
-	i.logger.Info("send email to investor")
+// acceptedAmount applies the over-investment policy to the requested amount.
+func (i *InvestLoan) acceptedAmount(loan sqlentity.Loan, requested decimal.Decimal) (decimal.Decimal, error) {
+	remaining := remainingAmount(loan)
+	if requested.LessThanOrEqual(remaining) {
+		return requested, nil
+	}
+
+	if i.overInvestmentPolicy == OverInvestmentCap && remaining.IsPositive() {
+		return remaining, nil
+	}
+
+	return decimal.Zero, pkgerror.NewBusinessErrorCodeWithCustomMessage(
+		pkgerror.LoanInvestmentExceedsRemaining,
+		fmt.Sprintf("investment amount %s exceeds the remaining fundable amount %s", requested, remaining),
+	)
 }
@@ -0,0 +1,520 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestInvestLoan_Execute_Concurrent(t *testing.T) {
+	const investors = 20
+
+	store := newFakeInvestLoanStore(sqlentity.Loan{
+		ID:              1,
+		PrincipalAmount: decimal.NewFromInt(1_000_000),
+		InvestedAmount:  decimal.Zero,
+		Status:          sqlentity.Approved,
+	})
+
+	snowflakeGen, err := pkguid.NewSnowflake()
+	assert.NoError(t, err)
+
+	i := NewInvestLoan(
+		store,
+		store,
+		store,
+		store,
+		store,
+		statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+		OverInvestmentReject,
+		zap.NewNop().Sugar(),
+		snowflakeGen,
+	)
+	i.maxAttempts = investors * 2
+	i.retryBackoff = time.Millisecond
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int64
+	start := make(chan struct{})
+
+	for investorID := 1; investorID <= investors; investorID++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := i.Execute(context.Background(), usecase.InvestLoanInput{
+				LoanID:     1,
+				InvestorID: uint64(investorID),
+				Amount:     decimal.NewFromInt(1_000),
+			})
+			if err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	loan, investments := store.committed()
+
+	assert.Equal(t, int64(investors), succeeded.Load())
+	assert.Len(t, investments, investors)
+	assert.True(
+		t,
+		decimal.NewFromInt(1_000*investors).Equal(loan.InvestedAmount),
+		"invested amount %s does not match the %d committed investments", loan.InvestedAmount, investors,
+	)
+	// the loan never becomes fully funded, only the investments are published
+	assert.Equal(t, investors, store.committedEvents(event.LoanInvestmentMade))
+	assert.Zero(t, store.committedEvents(event.LoanFullyFunded))
+}
+
+func TestInvestLoan_Execute_ConcurrentOverFunding(t *testing.T) {
+	const investors = 20
+
+	store := newFakeInvestLoanStore(sqlentity.Loan{
+		ID:              1,
+		PrincipalAmount: decimal.NewFromInt(10_000),
+		InvestedAmount:  decimal.Zero,
+		Status:          sqlentity.Approved,
+	})
+
+	snowflakeGen, err := pkguid.NewSnowflake()
+	assert.NoError(t, err)
+
+	i := NewInvestLoan(
+		store,
+		store,
+		store,
+		store,
+		store,
+		statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+		OverInvestmentReject,
+		zap.NewNop().Sugar(),
+		snowflakeGen,
+	)
+	i.maxAttempts = investors * 2
+	i.retryBackoff = time.Millisecond
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int64
+	start := make(chan struct{})
+
+	for investorID := 1; investorID <= investors; investorID++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			<-start
+
+			_, err := i.Execute(context.Background(), usecase.InvestLoanInput{
+				LoanID:     1,
+				InvestorID: uint64(investorID),
+				Amount:     decimal.NewFromInt(1_000),
+			})
+			if err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	loan, investments := store.committed()
+
+	assert.Equal(t, int64(10), succeeded.Load())
+	assert.Len(t, investments, 10)
+	assert.True(t, loan.PrincipalAmount.Equal(loan.InvestedAmount), "loan invested amount %s", loan.InvestedAmount)
+	assert.Equal(t, sqlentity.Invested, loan.Status)
+	// retried and rejected investments leave no event behind
+	assert.Equal(t, 10, store.committedEvents(event.LoanInvestmentMade))
+	assert.Equal(t, 1, store.committedEvents(event.LoanFullyFunded))
+	// nor any money reserved in the wallets
+	assert.True(t, loan.InvestedAmount.Equal(store.committedReserved()), "wallets reserve %s", store.committedReserved())
+}
+
+func TestInvestLoan_Execute_OverInvestment(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     OverInvestmentPolicy
+		borrower   uint64
+		balance    decimal.Decimal
+		deadline   time.Time
+		invested   decimal.Decimal
+		amount     decimal.Decimal
+		want       *usecase.InvestLoanOutput
+		wantCode   pkgerror.Code
+		wantLoan   sqlentity.Loan
+		wantEvents int
+
+		wantValidationErr bool
+	}{
+		{
+			name:              "reject negative investment",
+			policy:            OverInvestmentReject,
+			invested:          decimal.NewFromInt(700),
+			amount:            decimal.NewFromInt(-1_000),
+			wantLoan:          sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+			wantValidationErr: true,
+		},
+		{
+			name:              "reject zero investment",
+			policy:            OverInvestmentReject,
+			invested:          decimal.NewFromInt(700),
+			amount:            decimal.Zero,
+			wantLoan:          sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+			wantValidationErr: true,
+		},
+		{
+			name:              "reject investment with more than 2 decimals",
+			policy:            OverInvestmentReject,
+			invested:          decimal.NewFromInt(700),
+			amount:            decimal.RequireFromString("10.005"),
+			wantLoan:          sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+			wantValidationErr: true,
+		},
+		{
+			name:     "reject investment above remaining amount",
+			policy:   OverInvestmentReject,
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.NewFromInt(500),
+			wantCode: pkgerror.LoanInvestmentExceedsRemaining,
+			wantLoan: sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+		},
+		{
+			name:     "reject borrower investing in own loan",
+			policy:   OverInvestmentReject,
+			borrower: 2,
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.NewFromInt(100),
+			wantCode: pkgerror.LoanSelfInvestment,
+			wantLoan: sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+		},
+		{
+			name:     "reject investment above wallet balance",
+			policy:   OverInvestmentReject,
+			balance:  decimal.NewFromInt(100),
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.NewFromInt(200),
+			wantCode: pkgerror.WalletInsufficientBalance,
+			wantLoan: sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+		},
+		{
+			name:     "reject investment after funding deadline",
+			policy:   OverInvestmentReject,
+			deadline: time.Now().Add(-time.Hour),
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.NewFromInt(100),
+			wantCode: pkgerror.LoanFundingClosed,
+			wantLoan: sqlentity.Loan{InvestedAmount: decimal.NewFromInt(700), Status: sqlentity.Approved},
+		},
+		{
+			name:     "cap investment to remaining amount",
+			policy:   OverInvestmentCap,
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.NewFromInt(500),
+			want: &usecase.InvestLoanOutput{
+				RequestedAmount: decimal.NewFromInt(500),
+				AcceptedAmount:  decimal.NewFromInt(300),
+				RemainingAmount: decimal.Zero,
+			},
+			wantLoan:   sqlentity.Loan{InvestedAmount: decimal.NewFromInt(1_000), Status: sqlentity.Invested},
+			wantEvents: 2,
+		},
+		{
+			name:     "accept investment below 1",
+			policy:   OverInvestmentReject,
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.RequireFromString("0.50"),
+			want: &usecase.InvestLoanOutput{
+				RequestedAmount: decimal.RequireFromString("0.50"),
+				AcceptedAmount:  decimal.RequireFromString("0.50"),
+				RemainingAmount: decimal.RequireFromString("299.50"),
+			},
+			wantLoan:   sqlentity.Loan{InvestedAmount: decimal.RequireFromString("700.50"), Status: sqlentity.Approved},
+			wantEvents: 1,
+		},
+		{
+			name:     "accept investment within remaining amount",
+			policy:   OverInvestmentReject,
+			invested: decimal.NewFromInt(700),
+			amount:   decimal.NewFromInt(200),
+			want: &usecase.InvestLoanOutput{
+				RequestedAmount: decimal.NewFromInt(200),
+				AcceptedAmount:  decimal.NewFromInt(200),
+				RemainingAmount: decimal.NewFromInt(100),
+			},
+			wantLoan:   sqlentity.Loan{InvestedAmount: decimal.NewFromInt(900), Status: sqlentity.Approved},
+			wantEvents: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeInvestLoanStore(sqlentity.Loan{
+				ID:              1,
+				BorrowerID:      tt.borrower,
+				PrincipalAmount: decimal.NewFromInt(1_000),
+				InvestedAmount:  tt.invested,
+				Status:          sqlentity.Approved,
+				FundingDeadline: sql.NullTime{Time: tt.deadline, Valid: !tt.deadline.IsZero()},
+			})
+			if !tt.balance.IsZero() {
+				store.balance = tt.balance
+			}
+
+			snowflakeGen, err := pkguid.NewSnowflake()
+			assert.NoError(t, err)
+
+			i := NewInvestLoan(
+				store,
+				store,
+				store,
+				store,
+				store,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				tt.policy,
+				zap.NewNop().Sugar(),
+				snowflakeGen,
+			)
+
+			got, err := i.Execute(context.Background(), usecase.InvestLoanInput{
+				LoanID:     1,
+				InvestorID: 2,
+				Amount:     tt.amount,
+			})
+
+			if tt.wantValidationErr {
+				assert.True(t, pkgerror.IsValidationError(err), err)
+				assert.Nil(t, got)
+			} else if tt.wantCode != pkgerror.Generic {
+				businessErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, businessErr.Code)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.NotZero(t, got.InvestmentID)
+				assert.True(t, tt.want.RequestedAmount.Equal(got.RequestedAmount))
+				assert.True(t, tt.want.AcceptedAmount.Equal(got.AcceptedAmount))
+				assert.True(t, tt.want.RemainingAmount.Equal(got.RemainingAmount))
+			}
+
+			loan, _ := store.committed()
+			assert.True(t, tt.wantLoan.InvestedAmount.Equal(loan.InvestedAmount))
+			assert.Equal(t, tt.wantLoan.Status, loan.Status)
+			assert.Equal(
+				t,
+				tt.wantEvents,
+				store.committedEvents(event.LoanInvestmentMade)+store.committedEvents(event.LoanFullyFunded),
+			)
+		})
+	}
+}
+
+// fakeInvestLoanStore mimics InnoDB for a single loan: the first write of a transaction locks the row until commit
+// or rollback, and a versioned update matches no row when another transaction changed the version first. Every
+// investor has the same balance in their wallet.
+type fakeInvestLoanStore struct {
+	wallet.Keeper
+
+	rowLock sync.Mutex
+
+	mu          sync.Mutex
+	loan        sqlentity.Loan
+	investments sqlentity.LoanInvestments
+	histories   sqlentity.LoanStatusHistories
+	events      []pkgoutbox.Event
+	reserved    sqlentity.LoanInvestments
+	balance     decimal.Decimal
+}
+
+type fakeTx struct {
+	locked      bool
+	loan        sqlentity.Loan
+	investments sqlentity.LoanInvestments
+	histories   sqlentity.LoanStatusHistories
+	events      []pkgoutbox.Event
+	reserved    sqlentity.LoanInvestments
+}
+
+type fakeTxKey struct{}
+
+func newFakeInvestLoanStore(loan sqlentity.Loan) *fakeInvestLoanStore {
+	return &fakeInvestLoanStore{loan: loan, balance: decimal.NewFromInt(1_000_000)}
+}
+
+func (f *fakeInvestLoanStore) committed() (sqlentity.Loan, sqlentity.LoanInvestments) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.loan, f.investments
+}
+
+func (f *fakeInvestLoanStore) committedEvents(eventType string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var n int
+	for _, e := range f.events {
+		if e.Type == eventType {
+			n++
+		}
+	}
+
+	return n
+}
+
+// committedReserved is the money the committed reservations hold in the wallets.
+func (f *fakeInvestLoanStore) committedReserved() decimal.Decimal {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	reserved := decimal.Zero
+	for _, investment := range f.reserved {
+		reserved = reserved.Add(investment.Amount)
+	}
+
+	return reserved
+}
+
+func (f *fakeInvestLoanStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx := &fakeTx{}
+
+	err := fn(context.WithValue(ctx, fakeTxKey{}, tx))
+
+	if err == nil && tx.locked {
+		f.mu.Lock()
+		f.loan = tx.loan
+		f.investments = append(f.investments, tx.investments...)
+		f.histories = append(f.histories, tx.histories...)
+		f.events = append(f.events, tx.events...)
+		f.reserved = append(f.reserved, tx.reserved...)
+		f.mu.Unlock()
+	}
+
+	if tx.locked {
+		f.rowLock.Unlock()
+	}
+
+	return err
+}
+
+func (f *fakeInvestLoanStore) tx(ctx context.Context) *fakeTx {
+	tx, _ := ctx.Value(fakeTxKey{}).(*fakeTx)
+
+	return tx
+}
+
+func (f *fakeInvestLoanStore) lock(tx *fakeTx) {
+	if tx.locked {
+		return
+	}
+
+	f.rowLock.Lock()
+	tx.locked = true
+	tx.loan, _ = f.committed()
+}
+
+func (f *fakeInvestLoanStore) GetLoan(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	loan, _ := f.committed()
+
+	return sqlentity.Loans{loan}, nil
+}
+
+func (f *fakeInvestLoanStore) GetUser(_ context.Context, _ ...gateway.GetUserOption) (sqlentity.Users, error) {
+	return sqlentity.Users{{Type: sqlentity.Investor}}, nil
+}
+
+func (f *fakeInvestLoanStore) InsertLoanInvestment(ctx context.Context, in sqlentity.LoanInvestment) error {
+	tx := f.tx(ctx)
+	tx.investments = append(tx.investments, in)
+
+	return nil
+}
+
+func (f *fakeInvestLoanStore) Record(ctx context.Context, events ...pkgoutbox.Event) error {
+	tx := f.tx(ctx)
+	tx.events = append(tx.events, events...)
+
+	return nil
+}
+
+func (f *fakeInvestLoanStore) Reserve(ctx context.Context, investment sqlentity.LoanInvestment) error {
+	tx := f.tx(ctx)
+
+	f.mu.Lock()
+	reserved := investment.Amount
+	for _, r := range append(f.reserved, tx.reserved...) {
+		if r.InvestorID == investment.InvestorID {
+			reserved = reserved.Add(r.Amount)
+		}
+	}
+	f.mu.Unlock()
+
+	if reserved.GreaterThan(f.balance) {
+		return wallet.ErrInsufficientBalance
+	}
+
+	tx.reserved = append(tx.reserved, investment)
+
+	return nil
+}
+
+func (f *fakeInvestLoanStore) InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error {
+	tx := f.tx(ctx)
+	tx.histories = append(tx.histories, in)
+
+	return nil
+}
+
+func (f *fakeInvestLoanStore) UpdateLoan(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	_ ...gateway.UpdateLoanOption,
+) error {
+	tx := f.tx(ctx)
+	f.lock(tx)
+
+	values := in.MappedValues()
+
+	if version, ok := values["version"].(uint64); ok {
+		// the update was filtered on the version it was read with, which is one less than the new version
+		if tx.loan.Version != version-1 {
+			return gateway.ErrLoanNotUpdated
+		}
+
+		tx.loan.Version = version
+	}
+
+	if amount, ok := values["invested_amount"].(decimal.Decimal); ok {
+		tx.loan.InvestedAmount = amount
+	}
+
+	if status, ok := values["status"].(driver.Valuer); ok {
+		v, _ := status.Value()
+		tx.loan.Status = sqlentity.LoanStatusFromString(v.(string))
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/agreement"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"go.uber.org/zap"
+)
+
+type (
+	IssueAgreementLettersStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		UpdateLoanInvestment(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanInvestmentOption,
+		) error
+	}
+
+	AgreementLetterGenerator interface {
+		Generate(data agreement.LetterData) ([]byte, error)
+	}
+
+	IssueAgreementLetters struct {
+		store         IssueAgreementLettersStore
+		userStore     UserStore
+		documentStore pkgstorage.DocumentStore
+		generator     AgreementLetterGenerator
+
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewIssueAgreementLetters(
+	store IssueAgreementLettersStore,
+	userStore UserStore,
+	documentStore pkgstorage.DocumentStore,
+	generator AgreementLetterGenerator,
+	logger *zap.SugaredLogger,
+) *IssueAgreementLetters {
+	return &IssueAgreementLetters{
+		store:         store,
+		userStore:     userStore,
+		documentStore: documentStore,
+		generator:     generator,
+		logger:        logger,
+	}
+}
+
+func (i *IssueAgreementLetters) Execute(ctx context.Context, in usecase.IssueAgreementLettersInput) error {
+	loans, err := i.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		i.logger.Errorw("failed to get loan", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		i.logger.Errorw("loan not found")
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	if loan.Status != sqlentity.Invested {
+		i.logger.Errorw("loan is not fully funded", "loan_id", loan.ID, "status", loan.Status.String())
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanDocumentNotAccepted)
+	}
+
+	investments, err := i.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		i.logger.Errorw("failed to get loan investment", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	names, err := i.userNames(ctx, loan, investments)
+	if err != nil {
+		i.logger.Errorw("failed to get users", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	issuedAt := time.Now()
+
+	// every letter is issued on its own, one failing does not keep the other investors from theirs
+	var errs []error
+	for _, investment := range investments {
+		if investment.AgreementLetterDocumentKey.Valid {
+			continue
+		}
+
+		if err := i.issue(ctx, loan, investment, names, issuedAt); err != nil {
+			i.logger.Errorw("failed to issue agreement letter", "investment_id", investment.ID, "error", err)
+
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
+
+func (i *IssueAgreementLetters) issue(
+	ctx context.Context,
+	loan sqlentity.Loan,
+	investment sqlentity.LoanInvestment,
+	names map[uint64]string,
+	issuedAt time.Time,
+) error {
+	letter, err := i.generator.Generate(agreement.LetterData{
+		LoanID:           loan.ID,
+		BorrowerName:     names[loan.BorrowerID],
+		PrincipalAmount:  loan.PrincipalAmount,
+		InterestRate:     loan.InterestRate,
+		InvestmentID:     investment.ID,
+		InvestorName:     names[investment.InvestorID],
+		InvestmentAmount: investment.Amount,
+		IssuedAt:         issuedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	key := investmentAgreementLetterKey(loan.ID, investment.ID)
+
+	if err := i.documentStore.Put(ctx, key, bytes.NewReader(letter), "application/pdf"); err != nil {
+		return err
+	}
+
+	return i.store.UpdateLoanInvestment(
+		ctx,
+		sqlentity.UpdateLoanInvestmentAgreementLetter{
+			AgreementLetterDocumentKey: sql.NullString{String: key, Valid: true},
+		},
+		gateway.UpdateLoanInvestmentWithIDFilter(investment.ID),
+	)
+}
+
+// userNames maps the borrower and the investors of the loan to their names.
+func (i *IssueAgreementLetters) userNames(
+	ctx context.Context,
+	loan sqlentity.Loan,
+	investments sqlentity.LoanInvestments,
+) (map[uint64]string, error) {
+	userIDs := []uint64{loan.BorrowerID}
+	for _, investment := range investments {
+		userIDs = append(userIDs, investment.InvestorID)
+	}
+
+	users, err := i.userStore.GetUser(ctx, gateway.GetUserWithIDFilter(userIDs...))
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint64]string, users.Len())
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+
+	return names, nil
+}
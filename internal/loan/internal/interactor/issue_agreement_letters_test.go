@@ -0,0 +1,182 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/agreement"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestIssueAgreementLetters_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	investedLoan := sqlentity.Loan{
+		ID:              1,
+		BorrowerID:      2,
+		PrincipalAmount: decimal.NewFromInt(1_000),
+		InvestedAmount:  decimal.NewFromInt(1_000),
+		InterestRate:    decimal.NewFromInt(10),
+		Status:          sqlentity.Invested,
+	}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.IssueAgreementLettersInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockIssueAgreementLettersStore,
+			userStore *loanmocks.MockUserStore,
+			documentStore *pkgmocks.MockDocumentStore,
+			generator *loanmocks.MockAgreementLetterGenerator,
+			a args,
+		)
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error loan is not fully funded",
+			args: args{ctx: context.Background(), in: usecase.IssueAgreementLettersInput{LoanID: 1}},
+			mockFn: func(
+				store *loanmocks.MockIssueAgreementLettersStore,
+				_ *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *loanmocks.MockAgreementLetterGenerator,
+				a args,
+			) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+			},
+			wantCode: pkgerror.LoanDocumentNotAccepted,
+			wantErr:  true,
+		},
+		{
+			name: "error one letter fails, the others are still issued",
+			args: args{ctx: context.Background(), in: usecase.IssueAgreementLettersInput{LoanID: 1}},
+			mockFn: func(
+				store *loanmocks.MockIssueAgreementLettersStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				generator *loanmocks.MockAgreementLetterGenerator,
+				a args,
+			) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(sqlentity.LoanInvestments{
+					{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(400)},
+					{ID: 11, LoanID: 1, InvestorID: 4, Amount: decimal.NewFromInt(600)},
+				}, nil).Once()
+
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(sqlentity.Users{
+					{ID: 2, Name: "borrower"},
+					{ID: 3, Name: "first investor"},
+					{ID: 4, Name: "second investor"},
+				}, nil).Once()
+
+				generator.EXPECT().Generate(mock.MatchedBy(func(d agreement.LetterData) bool {
+					return d.InvestmentID == 10
+				})).Return(nil, errors.New("render failed")).Once()
+
+				generator.EXPECT().Generate(mock.MatchedBy(func(d agreement.LetterData) bool {
+					return d.InvestmentID == 11
+				})).Return([]byte("%PDF-1.3"), nil).Once()
+
+				documentStore.EXPECT().
+					Put(a.ctx, "loan/1/investment/11/agreement-letter.pdf", mock.Anything, "application/pdf").
+					Return(nil).Once()
+
+				store.EXPECT().UpdateLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success skips investments that already have a letter",
+			args: args{ctx: context.Background(), in: usecase.IssueAgreementLettersInput{LoanID: 1}},
+			mockFn: func(
+				store *loanmocks.MockIssueAgreementLettersStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				generator *loanmocks.MockAgreementLetterGenerator,
+				a args,
+			) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(sqlentity.LoanInvestments{
+					{
+						ID:         10,
+						LoanID:     1,
+						InvestorID: 3,
+						Amount:     decimal.NewFromInt(400),
+						AgreementLetterDocumentKey: sql.NullString{
+							String: "loan/1/investment/10/agreement-letter.pdf",
+							Valid:  true,
+						},
+					},
+					{ID: 11, LoanID: 1, InvestorID: 4, Amount: decimal.NewFromInt(600)},
+				}, nil).Once()
+
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(sqlentity.Users{
+					{ID: 2, Name: "borrower"},
+					{ID: 4, Name: "second investor"},
+				}, nil).Once()
+
+				generator.EXPECT().Generate(mock.MatchedBy(func(d agreement.LetterData) bool {
+					return d.InvestmentID == 11 &&
+						d.BorrowerName == "borrower" &&
+						d.InvestorName == "second investor" &&
+						d.InvestmentAmount.Equal(decimal.NewFromInt(600)) &&
+						d.InterestRate.Equal(decimal.NewFromInt(10))
+				})).Return([]byte("%PDF-1.3"), nil).Once()
+
+				documentStore.EXPECT().
+					Put(a.ctx, "loan/1/investment/11/agreement-letter.pdf", mock.Anything, "application/pdf").
+					Return(nil).Once()
+
+				store.EXPECT().UpdateLoanInvestment(
+					a.ctx,
+					sqlentity.UpdateLoanInvestmentAgreementLetter{
+						AgreementLetterDocumentKey: sql.NullString{
+							String: "loan/1/investment/11/agreement-letter.pdf",
+							Valid:  true,
+						},
+					},
+					mock.Anything,
+				).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockIssueAgreementLettersStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			documentStore := pkgmocks.NewMockDocumentStore(t)
+			generator := loanmocks.NewMockAgreementLetterGenerator(t)
+			tt.mockFn(store, userStore, documentStore, generator, tt.args)
+
+			i := NewIssueAgreementLetters(store, userStore, documentStore, generator, logger)
+			err := i.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IssueAgreementLetters.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
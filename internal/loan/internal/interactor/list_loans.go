@@ -0,0 +1,149 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultListLoansLimit uint = 10
+	sortAscending              = "asc"
+)
+
+type (
+	ListLoansStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+	}
+
+	ListLoans struct {
+		store  ListLoansStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewListLoans(
+	store ListLoansStore,
+	logger *zap.SugaredLogger,
+) *ListLoans {
+	return &ListLoans{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (l *ListLoans) Execute(
+	ctx context.Context,
+	in usecase.ListLoansInput,
+) (*usecase.ListLoansOutput, error) {
+	opts, limit, err := l.buildOptions(in)
+	if err != nil {
+		return nil, err
+	}
+
+	loans, err := l.store.GetLoan(ctx, opts...)
+	if err != nil {
+		l.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.ListLoansOutput{
+		Loans: make([]usecase.Loan, 0, loans.Len()),
+	}
+
+	// one extra row was requested to know whether there is a next page
+	if uint(loans.Len()) > limit {
+		loans = loans[:limit]
+		out.NextCursor = loans[len(loans)-1].ID
+	}
+
+	if loans.IsEmpty() {
+		return out, nil
+	}
+
+	loanIDs := make([]uint64, 0, loans.Len())
+	for _, loan := range loans {
+		loanIDs = append(loanIDs, loan.ID)
+	}
+
+	investments, err := l.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithLoanIDFilter(loanIDs...),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		l.logger.Errorw("failed to get loan investment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	investmentsByLoanID := make(map[uint64]sqlentity.LoanInvestments, loans.Len())
+	for _, investment := range investments {
+		investmentsByLoanID[investment.LoanID] = append(investmentsByLoanID[investment.LoanID], investment)
+	}
+
+	for _, loan := range loans {
+		out.Loans = append(out.Loans, toLoanOutput(loan, investmentsByLoanID[loan.ID]))
+	}
+
+	return out, nil
+}
+
+func (l *ListLoans) buildOptions(in usecase.ListLoansInput) ([]gateway.GetLoanOption, uint, error) {
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultListLoansLimit
+	}
+
+	descending := in.Sort != sortAscending
+
+	opts := []gateway.GetLoanOption{
+		gateway.GetLoanWithOrderByID(descending),
+		gateway.GetLoanWithLimit(limit + 1),
+	}
+
+	opts = append(opts, loanScopeOptions(in.Scope)...)
+
+	if in.BorrowerID != 0 {
+		opts = append(opts, gateway.GetLoanWithBorrowerIDFilter(in.BorrowerID))
+	}
+
+	if in.Status != "" {
+		status := sqlentity.LoanStatusFromString(in.Status)
+		if status == sqlentity.UnknownStatus {
+			l.logger.Errorw("invalid loan status filter", "status", in.Status)
+
+			return nil, 0, pkgerror.NewValidationError("invalid loan status")
+		}
+
+		opts = append(opts, gateway.GetLoanWithStatusFilter(status))
+	}
+
+	if !in.CreatedFrom.IsZero() || !in.CreatedTo.IsZero() {
+		createdTo := in.CreatedTo
+		if !createdTo.IsZero() {
+			createdTo = createdTo.AddDate(0, 0, 1)
+		}
+
+		opts = append(opts, gateway.GetLoanWithCreatedAtRangeFilter(in.CreatedFrom, createdTo))
+	}
+
+	if in.Cursor != 0 {
+		if descending {
+			opts = append(opts, gateway.GetLoanWithIDBeforeFilter(in.Cursor))
+		} else {
+			opts = append(opts, gateway.GetLoanWithIDAfterFilter(in.Cursor))
+		}
+	}
+
+	return opts, limit, nil
+}
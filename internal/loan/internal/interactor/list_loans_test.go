@@ -0,0 +1,149 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestListLoans_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.ListLoansInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(store *loanmocks.MockListLoansStore, a args)
+		want    *usecase.ListLoansOutput
+		wantErr bool
+	}{
+		{
+			name: "error invalid status",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.ListLoansInput{Status: "NOPE"},
+			},
+			mockFn:  func(store *loanmocks.MockListLoansStore, a args) {},
+			wantErr: true,
+		},
+		{
+			name: "error when get loan",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.ListLoansInput{},
+			},
+			mockFn: func(store *loanmocks.MockListLoansStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success empty",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.ListLoansInput{Status: "APPROVED"},
+			},
+			mockFn: func(store *loanmocks.MockListLoansStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).Once()
+			},
+			want: &usecase.ListLoansOutput{Loans: []usecase.Loan{}},
+		},
+		{
+			name: "success scoped to borrower",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.ListLoansInput{Scope: usecase.LoanScope{BorrowerID: 5}},
+			},
+			mockFn: func(store *loanmocks.MockListLoansStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, nil).Once()
+			},
+			want: &usecase.ListLoansOutput{Loans: []usecase.Loan{}},
+		},
+		{
+			name: "success with next cursor",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.ListLoansInput{Limit: 2, Cursor: 10},
+			},
+			mockFn: func(store *loanmocks.MockListLoansStore, a args) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(sqlentity.Loans{
+						{
+							ID:              9,
+							PrincipalAmount: decimal.NewFromInt(1_000),
+							InvestedAmount:  decimal.NewFromInt(100),
+							TenorMonths:     sql.NullInt32{Int32: 12, Valid: true},
+							RepaymentMethod: sqlentity.AnnuityRepayment,
+							Status:          sqlentity.Approved,
+						},
+						{
+							ID:              8,
+							PrincipalAmount: decimal.NewFromInt(500),
+							RepaymentMethod: sqlentity.FlatRepayment,
+							Status:          sqlentity.Proposed,
+						},
+						{ID: 7, Status: sqlentity.Proposed},
+					}, nil).Once()
+
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanInvestments{
+						{ID: 1, LoanID: 9, InvestorID: 2, Amount: decimal.NewFromInt(100)},
+					}, nil).Once()
+			},
+			want: &usecase.ListLoansOutput{
+				Loans: []usecase.Loan{
+					{
+						ID:              9,
+						PrincipalAmount: decimal.NewFromInt(1_000),
+						InvestedAmount:  decimal.NewFromInt(100),
+						RemainingAmount: decimal.NewFromInt(900),
+						TenorMonths:     12,
+						RepaymentMethod: "ANNUITY",
+						Status:          "APPROVED",
+						Investments: []usecase.LoanInvestment{
+							{ID: 1, InvestorID: 2, Amount: decimal.NewFromInt(100)},
+						},
+					},
+					{
+						ID:              8,
+						PrincipalAmount: decimal.NewFromInt(500),
+						RemainingAmount: decimal.NewFromInt(500),
+						RepaymentMethod: "FLAT",
+						Status:          "PROPOSED",
+						Investments:     []usecase.LoanInvestment{},
+					},
+				},
+				NextCursor: 8,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockListLoansStore(t)
+			tt.mockFn(store, tt.args)
+
+			l := NewListLoans(store, logger)
+			got, err := l.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ListLoans.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
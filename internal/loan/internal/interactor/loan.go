@@ -0,0 +1,166 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	loanUpdateMaxAttempts  = 5
+	loanUpdateRetryBackoff = 20 * time.Millisecond
+)
+
+func toLoanOutput(loan sqlentity.Loan, investments sqlentity.LoanInvestments) usecase.Loan {
+	out := usecase.Loan{
+		ID:              loan.ID,
+		BorrowerID:      loan.BorrowerID,
+		PrincipalAmount: loan.PrincipalAmount,
+		InvestedAmount:  loan.InvestedAmount,
+		RemainingAmount: remainingAmount(loan),
+		InterestRate:    loan.InterestRate,
+		TenorMonths:     int(loan.TenorMonths.Int32),
+		RepaymentMethod: loan.RepaymentMethod.String(),
+		Status:          loan.Status.String(),
+		Investments:     make([]usecase.LoanInvestment, 0, investments.Len()),
+	}
+
+	if loan.ApprovalDate.Valid {
+		out.Approval = &usecase.LoanApproval{
+			EmployeeID:       uint64(loan.ApprovalEmployeeID.Int64),
+			Date:             loan.ApprovalDate.Time,
+			ProofDocumentKey: loan.ApprovalProofDocumentKey.String,
+		}
+
+		if loan.FundingDeadline.Valid {
+			out.Approval.FundingDeadline = &loan.FundingDeadline.Time
+		}
+	}
+
+	if loan.RejectionDate.Valid {
+		out.Rejection = &usecase.LoanRejection{
+			EmployeeID: uint64(loan.RejectionEmployeeID.Int64),
+			Date:       loan.RejectionDate.Time,
+			Reason:     loan.RejectionReason.String,
+		}
+	}
+
+	if loan.DisbursementDate.Valid {
+		out.Disbursement = &usecase.LoanDisbursement{
+			Date:                       loan.DisbursementDate.Time,
+			AgreementLetterDocumentKey: loan.AgreementLetterDocumentURL.String,
+		}
+	}
+
+	for _, investment := range investments {
+		out.Investments = append(out.Investments, usecase.LoanInvestment{
+			ID:                         investment.ID,
+			InvestorID:                 investment.InvestorID,
+			Amount:                     investment.Amount,
+			AgreementLetterDocumentKey: investment.AgreementLetterDocumentKey.String,
+		})
+	}
+
+	return out
+}
+
+// canReadLoan reports whether a caller limited by scope may read the loan.
+func canReadLoan(scope usecase.LoanScope, loan sqlentity.Loan, investments sqlentity.LoanInvestments) bool {
+	if scope.BorrowerID != 0 && loan.BorrowerID != scope.BorrowerID {
+		return false
+	}
+
+	if scope.InvestorID != 0 && loan.Status != sqlentity.Approved {
+		return slices.ContainsFunc(investments, func(investment sqlentity.LoanInvestment) bool {
+			return investment.InvestorID == scope.InvestorID
+		})
+	}
+
+	return true
+}
+
+// loanScopeOptions narrows a loan query to the loans the caller may read.
+func loanScopeOptions(scope usecase.LoanScope) []gateway.GetLoanOption {
+	var opts []gateway.GetLoanOption
+
+	if scope.BorrowerID != 0 {
+		opts = append(opts, gateway.GetLoanWithBorrowerIDFilter(scope.BorrowerID))
+	}
+
+	if scope.InvestorID != 0 {
+		opts = append(opts, gateway.GetLoanWithInvestorVisibilityFilter(scope.InvestorID))
+	}
+
+	return opts
+}
+
+// agreementLetterKeyPrefix is where the agreement letters of a loan are stored in the document store.
+func agreementLetterKeyPrefix(loanID uint64) string {
+	return fmt.Sprintf("loan/%d/agreement-letter/", loanID)
+}
+
+// investmentAgreementLetterKey is where the agreement letter generated for an investor is stored in the document
+// store. It is outside agreementLetterKeyPrefix, a generated letter cannot stand in for the signed one a disbursement
+// needs.
+func investmentAgreementLetterKey(loanID, investmentID uint64) string {
+	return fmt.Sprintf("loan/%d/investment/%d/agreement-letter.pdf", loanID, investmentID)
+}
+
+// remainingAmount is the part of the principal that can still be invested.
+func remainingAmount(loan sqlentity.Loan) decimal.Decimal {
+	remaining := loan.PrincipalAmount.Sub(loan.InvestedAmount)
+	if remaining.IsNegative() {
+		return decimal.Zero
+	}
+
+	return remaining
+}
+
+// updateLoanError maps a failed versioned loan update to a business error when another request changed the loan
+// first.
+func updateLoanError(err error) error {
+	if errors.Is(err, gateway.ErrLoanNotUpdated) {
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanConcurrentUpdate)
+	}
+
+	return pkgerror.ServerErrorFrom(err)
+}
+
+// waitBeforeLoanUpdateRetry waits before running again a transaction that lost the optimistic lock of the loan. It backs
+// off linearly with jitter so requests competing for the same loan do not retry in lockstep.
+func waitBeforeLoanUpdateRetry(ctx context.Context, attempt int, retryBackoff time.Duration) error {
+	backoff := time.Duration(attempt) * retryBackoff
+	if retryBackoff > 0 {
+		backoff += rand.N(retryBackoff) //nolint:gosec // jitter does not need a secure random source
+	}
+
+	return sleepContext(ctx, backoff)
+}
+
+// recordLoanEvent appends an event of the loan to the outbox, within the transaction carried by ctx.
+func recordLoanEvent(
+	ctx context.Context,
+	outbox pkgoutbox.Recorder,
+	id uint64,
+	loanID uint64,
+	eventType string,
+	payload any,
+) error {
+	e, err := pkgoutbox.NewEvent(id, event.AggregateLoan, loanID, eventType, payload)
+	if err != nil {
+		return err
+	}
+
+	return outbox.Record(ctx, e)
+}
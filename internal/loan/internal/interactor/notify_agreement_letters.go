@@ -0,0 +1,303 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/agreement"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgnotify"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"go.uber.org/zap"
+)
+
+const (
+	// agreementLetterEmailURLTTL keeps the emailed link valid for a week, the longest an S3 presigned URL lives.
+	agreementLetterEmailURLTTL = 7 * 24 * time.Hour
+
+	notifyMaxAttempts  = 3
+	notifyRetryBackoff = time.Second
+)
+
+var errNoEmailAddress = errors.New("investor has no email address")
+
+type (
+	NotifyAgreementLettersStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		GetLoanInvestment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInvestmentOption,
+		) (sqlentity.LoanInvestments, error)
+		GetLoanNotification(
+			ctx context.Context,
+			opts ...gateway.GetLoanNotificationOption,
+		) (sqlentity.LoanNotifications, error)
+		InsertLoanNotification(ctx context.Context, in sqlentity.LoanNotification) error
+		UpdateLoanNotification(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanNotificationOption,
+		) error
+	}
+
+	NotifyAgreementLetters struct {
+		store         NotifyAgreementLettersStore
+		userStore     UserStore
+		documentStore pkgstorage.DocumentStore
+		notifier      pkgnotify.Notifier
+
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+
+		// a transient delivery failure is retried up to maxAttempts times per call
+		maxAttempts  int
+		retryBackoff time.Duration
+	}
+)
+
+func NewNotifyAgreementLetters(
+	store NotifyAgreementLettersStore,
+	userStore UserStore,
+	documentStore pkgstorage.DocumentStore,
+	notifier pkgnotify.Notifier,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *NotifyAgreementLetters {
+	return &NotifyAgreementLetters{
+		store:         store,
+		userStore:     userStore,
+		documentStore: documentStore,
+		notifier:      notifier,
+		logger:        logger,
+		snowflakeGen:  snowflakeGen,
+		maxAttempts:   notifyMaxAttempts,
+		retryBackoff:  notifyRetryBackoff,
+	}
+}
+
+func (n *NotifyAgreementLetters) Execute(ctx context.Context, in usecase.NotifyAgreementLettersInput) error {
+	loans, err := n.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		n.logger.Errorw("failed to get loan", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		n.logger.Errorw("loan not found")
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	if loan.Status != sqlentity.Invested {
+		n.logger.Errorw("loan is not fully funded", "loan_id", loan.ID, "status", loan.Status.String())
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanDocumentNotAccepted)
+	}
+
+	investments, err := n.store.GetLoanInvestment(
+		ctx,
+		gateway.GetLoanInvestmentWithLoanIDFilter(loan.ID),
+		gateway.GetLoanInvestmentWithActiveFilter(),
+	)
+	if err != nil {
+		n.logger.Errorw("failed to get loan investment", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	notifications, err := n.store.GetLoanNotification(
+		ctx,
+		gateway.GetLoanNotificationWithLoanIDFilter(loan.ID),
+		gateway.GetLoanNotificationWithTemplateFilter(sqlentity.AgreementLetterNotification),
+	)
+	if err != nil {
+		n.logger.Errorw("failed to get loan notification", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	delivered := make(map[uint64]sqlentity.LoanNotification, notifications.Len())
+	for _, notification := range notifications {
+		delivered[notification.InvestmentID] = notification
+	}
+
+	userIDs := make([]uint64, 0, investments.Len())
+	for _, investment := range investments {
+		userIDs = append(userIDs, investment.InvestorID)
+	}
+
+	users, err := n.userStore.GetUser(ctx, gateway.GetUserWithIDFilter(userIDs...))
+	if err != nil {
+		n.logger.Errorw("failed to get users", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	investors := make(map[uint64]sqlentity.User, users.Len())
+	for _, user := range users {
+		investors[user.ID] = user
+	}
+
+	// every investor is notified on their own, one failing does not keep the others from their email
+	var errs []error
+	for _, investment := range investments {
+		notification, ok := delivered[investment.ID]
+		if ok && notification.Status == sqlentity.NotificationSent {
+			continue
+		}
+
+		if !investment.AgreementLetterDocumentKey.Valid {
+			n.logger.Warnw("agreement letter is not issued yet", "investment_id", investment.ID)
+
+			errs = append(errs, fmt.Errorf("investment %d has no agreement letter", investment.ID))
+
+			continue
+		}
+
+		if !ok {
+			if notification, err = n.record(ctx, investment, investors[investment.InvestorID]); err != nil {
+				n.logger.Errorw("failed to insert loan notification", "investment_id", investment.ID, "error", err)
+
+				errs = append(errs, err)
+
+				continue
+			}
+		}
+
+		if err := n.deliver(ctx, investment, investors[investment.InvestorID], notification); err != nil {
+			n.logger.Errorw("failed to notify investor", "investment_id", investment.ID, "error", err)
+
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
+
+// record creates the pending delivery record of an investor's agreement letter email.
+func (n *NotifyAgreementLetters) record(
+	ctx context.Context,
+	investment sqlentity.LoanInvestment,
+	investor sqlentity.User,
+) (sqlentity.LoanNotification, error) {
+	now := time.Now()
+	notification := sqlentity.LoanNotification{
+		ID:           n.snowflakeGen.Generate(),
+		LoanID:       investment.LoanID,
+		InvestmentID: investment.ID,
+		UserID:       investment.InvestorID,
+		Template:     sqlentity.AgreementLetterNotification,
+		Recipient:    investor.Email.String,
+		Status:       sqlentity.NotificationPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := n.store.InsertLoanNotification(ctx, notification); err != nil {
+		return sqlentity.LoanNotification{}, err
+	}
+
+	return notification, nil
+}
+
+// deliver sends the email, retrying transient failures, and records the outcome on the notification.
+func (n *NotifyAgreementLetters) deliver(
+	ctx context.Context,
+	investment sqlentity.LoanInvestment,
+	investor sqlentity.User,
+	notification sqlentity.LoanNotification,
+) error {
+	attempts, sendErr := n.send(ctx, investment, investor)
+
+	update := sqlentity.UpdateLoanNotificationDelivery{
+		Status:    sqlentity.NotificationSent,
+		Attempts:  notification.Attempts + attempts,
+		UpdatedAt: time.Now(),
+	}
+
+	if sendErr != nil {
+		update.Status = sqlentity.NotificationFailed
+		update.LastError = sql.NullString{String: sendErr.Error(), Valid: true}
+	} else {
+		update.SentAt = sql.NullTime{Time: update.UpdatedAt, Valid: true}
+	}
+
+	if err := n.store.UpdateLoanNotification(
+		ctx,
+		update,
+		gateway.UpdateLoanNotificationWithIDFilter(notification.ID),
+	); err != nil {
+		return errors.Join(sendErr, err)
+	}
+
+	return sendErr
+}
+
+// send renders the email and hands it to the notifier, it reports how many sends it tried.
+func (n *NotifyAgreementLetters) send(
+	ctx context.Context,
+	investment sqlentity.LoanInvestment,
+	investor sqlentity.User,
+) (int, error) {
+	if !investor.Email.Valid || investor.Email.String == "" {
+		return 0, errNoEmailAddress
+	}
+
+	url, err := n.documentStore.SignedURL(ctx, investment.AgreementLetterDocumentKey.String, agreementLetterEmailURLTTL)
+	if err != nil {
+		return 0, err
+	}
+
+	subject, body, err := agreement.Email(agreement.EmailData{
+		LoanID:           investment.LoanID,
+		InvestorName:     investor.Name,
+		InvestmentAmount: investment.Amount,
+		LetterURL:        url,
+		LinkExpiresAt:    time.Now().Add(agreementLetterEmailURLTTL),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	msg := pkgnotify.Message{To: investor.Email.String, Subject: subject, Body: body}
+
+	var attempt int
+	for attempt = 1; ; attempt++ {
+		err = n.notifier.Notify(ctx, msg)
+		if err == nil || pkgnotify.IsPermanent(err) || attempt >= n.maxAttempts {
+			return attempt, err
+		}
+
+		n.logger.Warnw("failed to send email, retrying", "investment_id", investment.ID, "attempt", attempt, "error", err)
+
+		if waitErr := sleepContext(ctx, time.Duration(attempt)*n.retryBackoff); waitErr != nil {
+			return attempt, errors.Join(err, waitErr)
+		}
+	}
+}
+
+// sleepContext waits for d unless the context is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,208 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgnotify"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestNotifyAgreementLetters_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	snowflakeGen, err := pkguid.NewSnowflake()
+	assert.NoError(t, err)
+
+	investedLoan := sqlentity.Loan{ID: 1, Status: sqlentity.Invested}
+
+	letterKey := func(key string) sql.NullString {
+		return sql.NullString{String: key, Valid: true}
+	}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.NotifyAgreementLettersInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockNotifyAgreementLettersStore,
+			userStore *loanmocks.MockUserStore,
+			documentStore *pkgmocks.MockDocumentStore,
+			notifier *pkgmocks.MockNotifier,
+			a args,
+		)
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error loan is not fully funded",
+			args: args{ctx: context.Background(), in: usecase.NotifyAgreementLettersInput{LoanID: 1}},
+			mockFn: func(
+				store *loanmocks.MockNotifyAgreementLettersStore,
+				_ *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockNotifier,
+				a args,
+			) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+			},
+			wantCode: pkgerror.LoanDocumentNotAccepted,
+			wantErr:  true,
+		},
+		{
+			name: "error investor without email and rejected recipient are recorded as failed",
+			args: args{ctx: context.Background(), in: usecase.NotifyAgreementLettersInput{LoanID: 1}},
+			mockFn: func(
+				store *loanmocks.MockNotifyAgreementLettersStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				notifier *pkgmocks.MockNotifier,
+				a args,
+			) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(sqlentity.LoanInvestments{
+					{
+						ID:                         10,
+						LoanID:                     1,
+						InvestorID:                 3,
+						Amount:                     decimal.NewFromInt(400),
+						AgreementLetterDocumentKey: letterKey("loan/1/investment/10/agreement-letter.pdf"),
+					},
+					{
+						ID:                         11,
+						LoanID:                     1,
+						InvestorID:                 4,
+						Amount:                     decimal.NewFromInt(600),
+						AgreementLetterDocumentKey: letterKey("loan/1/investment/11/agreement-letter.pdf"),
+					},
+				}, nil).Once()
+
+				store.EXPECT().GetLoanNotification(a.ctx, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanNotifications{}, nil).Once()
+
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(sqlentity.Users{
+					{ID: 3, Name: "first investor"},
+					{ID: 4, Name: "second investor", Email: sql.NullString{String: "second@example.com", Valid: true}},
+				}, nil).Once()
+
+				store.EXPECT().InsertLoanNotification(a.ctx, mock.Anything).Return(nil).Twice()
+
+				documentStore.EXPECT().SignedURL(a.ctx, "loan/1/investment/11/agreement-letter.pdf", mock.Anything).
+					Return("https://files.example.com/11", nil).Once()
+
+				// a permanent rejection is not retried
+				notifier.EXPECT().Notify(a.ctx, mock.Anything).
+					Return(&textproto.Error{Code: 550, Msg: "mailbox unavailable"}).Once()
+
+				store.EXPECT().UpdateLoanNotification(
+					a.ctx,
+					mock.MatchedBy(func(u sqlentity.UpdateLoanNotificationDelivery) bool {
+						return u.Status == sqlentity.NotificationFailed && u.LastError.Valid && !u.SentAt.Valid
+					}),
+					mock.Anything,
+				).Return(nil).Twice()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success retries a transient failure and skips investors already notified",
+			args: args{ctx: context.Background(), in: usecase.NotifyAgreementLettersInput{LoanID: 1}},
+			mockFn: func(
+				store *loanmocks.MockNotifyAgreementLettersStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				notifier *pkgmocks.MockNotifier,
+				a args,
+			) {
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{investedLoan}, nil).Once()
+
+				store.EXPECT().GetLoanInvestment(a.ctx, mock.Anything, mock.Anything).Return(sqlentity.LoanInvestments{
+					{
+						ID:                         10,
+						LoanID:                     1,
+						InvestorID:                 3,
+						Amount:                     decimal.NewFromInt(400),
+						AgreementLetterDocumentKey: letterKey("loan/1/investment/10/agreement-letter.pdf"),
+					},
+					{
+						ID:                         11,
+						LoanID:                     1,
+						InvestorID:                 4,
+						Amount:                     decimal.NewFromInt(600),
+						AgreementLetterDocumentKey: letterKey("loan/1/investment/11/agreement-letter.pdf"),
+					},
+				}, nil).Once()
+
+				store.EXPECT().GetLoanNotification(a.ctx, mock.Anything, mock.Anything).
+					Return(sqlentity.LoanNotifications{
+						{ID: 20, LoanID: 1, InvestmentID: 10, Status: sqlentity.NotificationSent, Attempts: 1},
+						{ID: 21, LoanID: 1, InvestmentID: 11, Status: sqlentity.NotificationFailed, Attempts: 3},
+					}, nil).Once()
+
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(sqlentity.Users{
+					{ID: 3, Name: "first investor", Email: sql.NullString{String: "first@example.com", Valid: true}},
+					{ID: 4, Name: "second investor", Email: sql.NullString{String: "second@example.com", Valid: true}},
+				}, nil).Once()
+
+				documentStore.EXPECT().SignedURL(a.ctx, "loan/1/investment/11/agreement-letter.pdf", mock.Anything).
+					Return("https://files.example.com/11", nil).Once()
+
+				notifier.EXPECT().Notify(a.ctx, mock.Anything).Return(errors.New("connection reset")).Once()
+				notifier.EXPECT().Notify(a.ctx, mock.MatchedBy(func(m pkgnotify.Message) bool {
+					return m.To == "second@example.com" &&
+						m.Subject != "" &&
+						strings.Contains(m.Body, "https://files.example.com/11")
+				})).Return(nil).Once()
+
+				store.EXPECT().UpdateLoanNotification(
+					a.ctx,
+					mock.MatchedBy(func(u sqlentity.UpdateLoanNotificationDelivery) bool {
+						return u.Status == sqlentity.NotificationSent && u.Attempts == 5 && u.SentAt.Valid
+					}),
+					mock.Anything,
+				).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockNotifyAgreementLettersStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			documentStore := pkgmocks.NewMockDocumentStore(t)
+			notifier := pkgmocks.NewMockNotifier(t)
+			tt.mockFn(store, userStore, documentStore, notifier, tt.args)
+
+			n := NewNotifyAgreementLetters(store, userStore, documentStore, notifier, logger, snowflakeGen)
+			n.retryBackoff = 0
+
+			err := n.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NotifyAgreementLetters.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"go.uber.org/zap"
+)
+
+type RejectLoan struct {
+	store        UpdateLoanStore
+	userStore    UserStore
+	transactor   pkgsql.Transactor
+	stateMachine *statemachine.LoanStateMachine
+
+	logger       *zap.SugaredLogger
+	snowflakeGen pkguid.Snowflake
+}
+
+func NewRejectLoan(
+	store UpdateLoanStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	stateMachine *statemachine.LoanStateMachine,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *RejectLoan {
+	return &RejectLoan{
+		store:        store,
+		userStore:    userStore,
+		transactor:   transactor,
+		stateMachine: stateMachine,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+func (r *RejectLoan) Execute(ctx context.Context, in usecase.RejectLoanInput) error {
+	if err := requireUserType(ctx, r.userStore, in.EmployeeID, sqlentity.Employee, pkgerror.UserNotEmployee); err != nil {
+		r.logger.Errorw("user cannot reject a loan", "error", err)
+
+		return err
+	}
+
+	return r.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return r.reject(ctx, in)
+	})
+}
+
+func (r *RejectLoan) reject(ctx context.Context, in usecase.RejectLoanInput) error {
+	loans, err := r.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		r.logger.Errorw("failed to get loan", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		r.logger.Errorw("loan not found")
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	loan.RejectionReason = sql.NullString{String: in.Reason, Valid: true}
+
+	rejectedLoan, err := r.stateMachine.Transition(ctx, loan, sqlentity.Rejected)
+	if err != nil {
+		r.logger.Errorw("loan cannot be rejected", "error", err)
+
+		return err
+	}
+
+	now := time.Now()
+
+	if err := r.store.UpdateLoan(
+		ctx,
+		sqlentity.RejectLoan{
+			RejectionDate: sql.NullTime{
+				Valid: true,
+				Time:  now,
+			},
+			RejectionEmployeeID: sql.NullInt64{
+				Valid: true,
+				Int64: int64(in.EmployeeID),
+			},
+			RejectionReason: loan.RejectionReason,
+			Version:         loan.Version + 1,
+		},
+		gateway.UpdateLoanWithLoanIDFilter(in.LoanID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
+	); err != nil {
+		r.logger.Errorw("failed to update loan", "error", err)
+
+		return updateLoanError(err)
+	}
+
+	if err := r.store.InsertLoanStatusHistory(ctx, sqlentity.LoanStatusHistory{
+		ID:          r.snowflakeGen.Generate(),
+		LoanID:      loan.ID,
+		FromStatus:  loan.Status,
+		ToStatus:    rejectedLoan.Status,
+		ActorUserID: in.EmployeeID,
+		Reason:      in.Reason,
+		CreatedAt:   now,
+	}); err != nil {
+		r.logger.Errorw("failed to insert loan status history", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestRejectLoan_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.RejectLoanInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockUpdateLoanStore,
+			userStore *loanmocks.MockUserStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			a args,
+		)
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error user is not an employee",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RejectLoanInput{LoanID: 1, EmployeeID: 2, Reason: "incomplete documents"},
+			},
+			mockFn: func(
+				_ *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 2, Type: sqlentity.Investor}}, nil).Once()
+			},
+			wantCode: pkgerror.UserNotEmployee,
+			wantErr:  true,
+		},
+		{
+			name: "error loan already approved",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RejectLoanInput{LoanID: 1, EmployeeID: 4, Reason: "incomplete documents"},
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+		{
+			name: "error blank reason",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RejectLoanInput{LoanID: 1, EmployeeID: 4, Reason: "  "},
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+			},
+			wantCode: pkgerror.LoanTransitionGuardFailed,
+			wantErr:  true,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RejectLoanInput{LoanID: 1, EmployeeID: 4, Reason: "incomplete documents"},
+			},
+			mockFn: func(
+				store *loanmocks.MockUpdateLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Proposed}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(2)).Once()
+
+				store.EXPECT().UpdateLoan(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.RejectLoan) bool {
+						return in.RejectionReason.String == "incomplete documents" &&
+							in.RejectionEmployeeID.Int64 == 4 &&
+							in.RejectionDate.Valid &&
+							in.Version == 1
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+
+				store.EXPECT().InsertLoanStatusHistory(
+					a.ctx,
+					mock.MatchedBy(func(h sqlentity.LoanStatusHistory) bool {
+						return h.ToStatus == sqlentity.Rejected && h.Reason == "incomplete documents"
+					}),
+				).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockUpdateLoanStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(tt.args.ctx, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Maybe()
+			tt.mockFn(store, userStore, snowflakeGen, tt.args)
+
+			r := NewRejectLoan(
+				store,
+				userStore,
+				transactor,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				logger,
+				snowflakeGen,
+			)
+			err := r.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RejectLoan.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+const repaymentAmountScale = 2
+
+type (
+	RepayLoanStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		UpdateLoan(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanOption,
+		) error
+		InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error
+		GetLoanInstallment(
+			ctx context.Context,
+			opts ...gateway.GetLoanInstallmentOption,
+		) (sqlentity.LoanInstallments, error)
+		UpdateLoanInstallment(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateLoanInstallmentOption,
+		) error
+		InsertLoanRepayment(ctx context.Context, in sqlentity.LoanRepayment) error
+		InsertLoanRepaymentAllocations(ctx context.Context, in sqlentity.LoanRepaymentAllocations) error
+	}
+
+	RepayLoan struct {
+		store        RepayLoanStore
+		userStore    UserStore
+		transactor   pkgsql.Transactor
+		outbox       pkgoutbox.Recorder
+		ledger       ledger.Poster
+		stateMachine *statemachine.LoanStateMachine
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+func NewRepayLoan(
+	store RepayLoanStore,
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	outbox pkgoutbox.Recorder,
+	ledger ledger.Poster,
+	stateMachine *statemachine.LoanStateMachine,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *RepayLoan {
+	return &RepayLoan{
+		store:        store,
+		userStore:    userStore,
+		transactor:   transactor,
+		outbox:       outbox,
+		ledger:       ledger,
+		stateMachine: stateMachine,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+func (r *RepayLoan) Execute(ctx context.Context, in usecase.RepayLoanInput) (*usecase.RepayLoanOutput, error) {
+	if !in.Amount.IsPositive() || !in.Amount.Equal(in.Amount.Round(repaymentAmountScale)) {
+		r.logger.Errorw("invalid repayment amount", "amount", in.Amount)
+
+		return nil, pkgerror.NewValidationError("amount must be positive with at most 2 decimals")
+	}
+
+	if err := requireUserType(ctx, r.userStore, in.BorrowerID, sqlentity.Borrower, pkgerror.UserNotBorrower); err != nil {
+		r.logger.Errorw("user cannot repay a loan", "error", err)
+
+		return nil, err
+	}
+
+	var out *usecase.RepayLoanOutput
+	err := r.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+		out, err = r.repay(ctx, in)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// repay allocates the payment to the oldest installments first, interest before principal, and records the loan as
+// repaid once nothing is outstanding anymore.
+func (r *RepayLoan) repay(ctx context.Context, in usecase.RepayLoanInput) (*usecase.RepayLoanOutput, error) {
+	loans, err := r.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		r.logger.Errorw("failed to get loan", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		r.logger.Errorw("loan not found")
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	if loan.BorrowerID != in.BorrowerID {
+		r.logger.Errorw("borrower cannot repay another borrower's loan", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewAuthorizationError("loan belongs to another user")
+	}
+
+	// repayments are only accepted while the loan can still become repaid
+	if err := r.stateMachine.CanTransition(loan.Status, sqlentity.Repaid); err != nil {
+		r.logger.Errorw("loan cannot be repaid", "error", err)
+
+		return nil, err
+	}
+
+	installments, err := r.store.GetLoanInstallment(
+		ctx,
+		gateway.GetLoanInstallmentWithLoanIDFilter(loan.ID),
+		gateway.GetLoanInstallmentWithUnpaidFilter(),
+	)
+	if err != nil {
+		r.logger.Errorw("failed to get loan installment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if installments.IsEmpty() {
+		r.logger.Errorw("loan has no unpaid installment", "loan_id", loan.ID)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.LoanScheduleNotFound)
+	}
+
+	now := time.Now()
+	repaymentID := r.snowflakeGen.Generate()
+	out := &usecase.RepayLoanOutput{
+		RepaymentID:       repaymentID,
+		Amount:            in.Amount,
+		PrincipalAmount:   decimal.Zero,
+		InterestAmount:    decimal.Zero,
+		OverpaidAmount:    decimal.Zero,
+		OutstandingAmount: decimal.Zero,
+		LoanStatus:        loan.Status.String(),
+		Allocations:       make([]usecase.LoanRepaymentAllocation, 0, installments.Len()),
+	}
+
+	allocations := make(sqlentity.LoanRepaymentAllocations, 0, installments.Len())
+	remaining := in.Amount
+
+	for _, installment := range installments {
+		if !remaining.IsPositive() {
+			out.OutstandingAmount = out.OutstandingAmount.Add(installmentDue(installment))
+
+			continue
+		}
+
+		interest := decimal.Min(remaining, installment.InterestAmount.Sub(installment.PaidInterestAmount))
+		remaining = remaining.Sub(interest)
+		principal := decimal.Min(remaining, installment.PrincipalAmount.Sub(installment.PaidPrincipalAmount))
+		remaining = remaining.Sub(principal)
+
+		installment.PaidInterestAmount = installment.PaidInterestAmount.Add(interest)
+		installment.PaidPrincipalAmount = installment.PaidPrincipalAmount.Add(principal)
+		installment.Status = sqlentity.InstallmentPartial
+
+		var paidAt sql.NullTime
+		if installmentDue(installment).IsZero() {
+			installment.Status = sqlentity.InstallmentPaid
+			paidAt = sql.NullTime{Time: now, Valid: true}
+		}
+
+		if err := r.store.UpdateLoanInstallment(
+			ctx,
+			sqlentity.UpdateLoanInstallmentPayment{
+				PaidPrincipalAmount: installment.PaidPrincipalAmount,
+				PaidInterestAmount:  installment.PaidInterestAmount,
+				Status:              installment.Status,
+				PaidAt:              paidAt,
+				UpdatedAt:           now,
+			},
+			gateway.UpdateLoanInstallmentWithIDFilter(installment.ID),
+		); err != nil {
+			r.logger.Errorw("failed to update loan installment", "error", err)
+
+			return nil, pkgerror.ServerErrorFrom(err)
+		}
+
+		allocations = append(allocations, sqlentity.LoanRepaymentAllocation{
+			ID:              r.snowflakeGen.Generate(),
+			RepaymentID:     repaymentID,
+			InstallmentID:   installment.ID,
+			PrincipalAmount: principal,
+			InterestAmount:  interest,
+		})
+
+		out.PrincipalAmount = out.PrincipalAmount.Add(principal)
+		out.InterestAmount = out.InterestAmount.Add(interest)
+		out.OutstandingAmount = out.OutstandingAmount.Add(installmentDue(installment))
+		out.Allocations = append(out.Allocations, usecase.LoanRepaymentAllocation{
+			InstallmentNumber: installment.InstallmentNumber,
+			PrincipalAmount:   principal,
+			InterestAmount:    interest,
+			Status:            installment.Status.String(),
+		})
+	}
+
+	out.OverpaidAmount = remaining
+
+	repayment := sqlentity.LoanRepayment{
+		ID:              repaymentID,
+		LoanID:          loan.ID,
+		BorrowerID:      in.BorrowerID,
+		Amount:          in.Amount,
+		PrincipalAmount: out.PrincipalAmount,
+		InterestAmount:  out.InterestAmount,
+		OverpaidAmount:  out.OverpaidAmount,
+		PaidAt:          now,
+	}
+
+	if err := r.store.InsertLoanRepayment(ctx, repayment); err != nil {
+		r.logger.Errorw("failed to insert loan repayment", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := r.ledger.Post(ctx, ledger.Repayment(repayment)); err != nil {
+		r.logger.Errorw("failed to post loan repayment to ledger", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := r.store.InsertLoanRepaymentAllocations(ctx, allocations); err != nil {
+		r.logger.Errorw("failed to insert loan repayment allocations", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := r.updateLoan(ctx, in, loan, out, now); err != nil {
+		return nil, err
+	}
+
+	if err := recordLoanEvent(ctx, r.outbox, r.snowflakeGen.Generate(), loan.ID, event.LoanRepaymentMade,
+		event.LoanRepaymentMadePayload{
+			LoanID:            loan.ID,
+			RepaymentID:       repaymentID,
+			BorrowerID:        in.BorrowerID,
+			Amount:            in.Amount,
+			PrincipalAmount:   out.PrincipalAmount,
+			InterestAmount:    out.InterestAmount,
+			OverpaidAmount:    out.OverpaidAmount,
+			OutstandingAmount: out.OutstandingAmount,
+		},
+	); err != nil {
+		r.logger.Errorw("failed to record loan event", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	return out, nil
+}
+
+// updateLoan bumps the version of the loan, so concurrent repayments of the same loan conflict instead of allocating
+// the same installments twice, and moves it to REPAID when the payment settled its last installment.
+func (r *RepayLoan) updateLoan(
+	ctx context.Context,
+	in usecase.RepayLoanInput,
+	loan sqlentity.Loan,
+	out *usecase.RepayLoanOutput,
+	now time.Time,
+) error {
+	repaidLoan := loan
+	if out.OutstandingAmount.IsZero() {
+		var err error
+		if repaidLoan, err = r.stateMachine.Transition(ctx, loan, sqlentity.Repaid); err != nil {
+			r.logger.Errorw("loan cannot be repaid", "error", err)
+
+			return err
+		}
+	}
+
+	if err := r.store.UpdateLoan(
+		ctx,
+		sqlentity.UpdateRepaidLoan{
+			Status:  repaidLoan.Status,
+			Version: loan.Version + 1,
+		},
+		gateway.UpdateLoanWithLoanIDFilter(loan.ID),
+		gateway.UpdateLoanWithVersionFilter(loan.Version),
+	); err != nil {
+		r.logger.Errorw("failed to update loan", "error", err)
+
+		return updateLoanError(err)
+	}
+
+	out.LoanStatus = repaidLoan.Status.String()
+
+	if repaidLoan.Status == loan.Status {
+		return nil
+	}
+
+	if err := r.store.InsertLoanStatusHistory(ctx, sqlentity.LoanStatusHistory{
+		ID:          r.snowflakeGen.Generate(),
+		LoanID:      loan.ID,
+		FromStatus:  loan.Status,
+		ToStatus:    repaidLoan.Status,
+		ActorUserID: in.BorrowerID,
+		Reason:      "loan fully repaid",
+		CreatedAt:   now,
+	}); err != nil {
+		r.logger.Errorw("failed to insert loan status history", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := recordLoanEvent(ctx, r.outbox, r.snowflakeGen.Generate(), loan.ID, event.LoanRepaid,
+		event.LoanRepaidPayload{
+			LoanID:     loan.ID,
+			RepaidDate: now,
+		},
+	); err != nil {
+		r.logger.Errorw("failed to record loan event", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
+
+// installmentDue is what is left to repay of the installment.
+func installmentDue(installment sqlentity.LoanInstallment) decimal.Decimal {
+	return installment.PrincipalAmount.Sub(installment.PaidPrincipalAmount).
+		Add(installment.InterestAmount.Sub(installment.PaidInterestAmount))
+}
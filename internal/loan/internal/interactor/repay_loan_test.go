@@ -0,0 +1,489 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestRepayLoan_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	borrower := sqlentity.Users{{ID: 5, Type: sqlentity.Borrower}}
+	disbursedLoan := sqlentity.Loans{{ID: 1, BorrowerID: 5, Status: sqlentity.Disbursed, Version: 4}}
+	unpaidInstallments := func() sqlentity.LoanInstallments {
+		return sqlentity.LoanInstallments{
+			{
+				ID:                  10,
+				LoanID:              1,
+				InstallmentNumber:   1,
+				PrincipalAmount:     decimal.NewFromInt(500),
+				InterestAmount:      decimal.NewFromInt(10),
+				PaidPrincipalAmount: decimal.Zero,
+				PaidInterestAmount:  decimal.Zero,
+				Status:              sqlentity.InstallmentPending,
+			},
+			{
+				ID:                  11,
+				LoanID:              1,
+				InstallmentNumber:   2,
+				PrincipalAmount:     decimal.NewFromInt(500),
+				InterestAmount:      decimal.NewFromInt(10),
+				PaidPrincipalAmount: decimal.Zero,
+				PaidInterestAmount:  decimal.Zero,
+				Status:              sqlentity.InstallmentPending,
+			},
+		}
+	}
+
+	type want struct {
+		principal   string
+		interest    string
+		overpaid    string
+		outstanding string
+		loanStatus  string
+		allocations []string
+	}
+	type args struct {
+		ctx context.Context
+		in  usecase.RepayLoanInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockRepayLoanStore,
+			userStore *loanmocks.MockUserStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			a args,
+		)
+		postErr           error
+		want              *want
+		wantEntry         string
+		wantEvents        []string
+		wantValidationErr bool
+		wantErr           bool
+		wantCode          pkgerror.Code
+	}{
+		{
+			name: "error amount with more than 2 decimals",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.RequireFromString("10.005")},
+			},
+			mockFn: func(
+				_ *loanmocks.MockRepayLoanStore,
+				_ *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				_ args,
+			) {
+			},
+			wantValidationErr: true,
+			wantErr:           true,
+		},
+		{
+			name: "error when user is not a borrower",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				_ *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 5, Type: sqlentity.Investor}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.UserNotBorrower,
+		},
+		{
+			name: "error loan not found",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanNotFound,
+		},
+		{
+			name: "error loan of another borrower",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, BorrowerID: 6, Status: sqlentity.Disbursed}}, nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan not disbursed",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, BorrowerID: 5, Status: sqlentity.Invested}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+		},
+		{
+			name: "error loan without schedule",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.LoanScheduleNotFound,
+		},
+		{
+			name: "error when update loan installment",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(unpaidInstallments(), nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(20)).Once()
+				store.EXPECT().UpdateLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error loan repaid concurrently",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(unpaidInstallments(), nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(20)).Twice()
+				store.EXPECT().UpdateLoanInstallment(a.ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanRepayment(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanRepaymentAllocations(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().UpdateLoan(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrLoanNotUpdated).Once()
+			},
+			wantErr:   true,
+			wantCode:  pkgerror.LoanConcurrentUpdate,
+			wantEntry: "repayment:20",
+		},
+		{
+			name: "error when post to ledger",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(unpaidInstallments(), nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(20)).Twice()
+				store.EXPECT().UpdateLoanInstallment(a.ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanRepayment(a.ctx, mock.Anything).Return(nil).Once()
+			},
+			postErr:   errors.New("any error"),
+			wantErr:   true,
+			wantEntry: "repayment:20",
+		},
+		{
+			name: "success partial payment",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(300)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(unpaidInstallments(), nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(20)).Times(3)
+				store.EXPECT().UpdateLoanInstallment(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.UpdateLoanInstallmentPayment) bool {
+						return in.PaidInterestAmount.Equal(decimal.NewFromInt(10)) &&
+							in.PaidPrincipalAmount.Equal(decimal.NewFromInt(290)) &&
+							in.Status == sqlentity.InstallmentPartial &&
+							!in.PaidAt.Valid
+					}),
+					mock.Anything,
+				).Return(nil).Once()
+				store.EXPECT().InsertLoanRepayment(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.LoanRepayment) bool {
+						return in.ID == 20 && in.LoanID == 1 && in.BorrowerID == 5 && in.OverpaidAmount.IsZero()
+					}),
+				).Return(nil).Once()
+				store.EXPECT().InsertLoanRepaymentAllocations(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.LoanRepaymentAllocations) bool {
+						return in.Len() == 1 && in[0].RepaymentID == 20 && in[0].InstallmentID == 10
+					}),
+				).Return(nil).Once()
+				store.EXPECT().UpdateLoan(
+					a.ctx,
+					sqlentity.UpdateRepaidLoan{Status: sqlentity.Disbursed, Version: 5},
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+			want: &want{
+				principal:   "290",
+				interest:    "10",
+				overpaid:    "0",
+				outstanding: "720",
+				loanStatus:  "DISBURSED",
+				allocations: []string{"PARTIAL"},
+			},
+			wantEntry:  "repayment:20",
+			wantEvents: []string{event.LoanRepaymentMade},
+		},
+		{
+			name: "success payment spanning installments",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(700)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(unpaidInstallments(), nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(20)).Times(4)
+				store.EXPECT().UpdateLoanInstallment(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.UpdateLoanInstallmentPayment) bool {
+						return in.Status == sqlentity.InstallmentPaid && in.PaidAt.Valid
+					}),
+					mock.Anything,
+				).Return(nil).Once()
+				store.EXPECT().UpdateLoanInstallment(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.UpdateLoanInstallmentPayment) bool {
+						return in.Status == sqlentity.InstallmentPartial &&
+							in.PaidPrincipalAmount.Equal(decimal.NewFromInt(180))
+					}),
+					mock.Anything,
+				).Return(nil).Once()
+				store.EXPECT().InsertLoanRepayment(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLoanRepaymentAllocations(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().UpdateLoan(
+					a.ctx,
+					sqlentity.UpdateRepaidLoan{Status: sqlentity.Disbursed, Version: 5},
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+			want: &want{
+				principal:   "680",
+				interest:    "20",
+				overpaid:    "0",
+				outstanding: "320",
+				loanStatus:  "DISBURSED",
+				allocations: []string{"PAID", "PARTIAL"},
+			},
+			wantEntry:  "repayment:20",
+			wantEvents: []string{event.LoanRepaymentMade},
+		},
+		{
+			name: "success overpayment repays the loan",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.RepayLoanInput{LoanID: 1, BorrowerID: 5, Amount: decimal.NewFromInt(1_100)},
+			},
+			mockFn: func(
+				store *loanmocks.MockRepayLoanStore,
+				userStore *loanmocks.MockUserStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).Return(borrower, nil).Once()
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(disbursedLoan, nil).Once()
+				store.EXPECT().GetLoanInstallment(a.ctx, mock.Anything, mock.Anything).
+					Return(unpaidInstallments(), nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(uint64(20)).Times(6)
+				store.EXPECT().UpdateLoanInstallment(a.ctx, mock.Anything, mock.Anything).Return(nil).Twice()
+				store.EXPECT().InsertLoanRepayment(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.LoanRepayment) bool {
+						return in.OverpaidAmount.Equal(decimal.NewFromInt(80))
+					}),
+				).Return(nil).Once()
+				store.EXPECT().InsertLoanRepaymentAllocations(a.ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().UpdateLoan(
+					a.ctx,
+					sqlentity.UpdateRepaidLoan{Status: sqlentity.Repaid, Version: 5},
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+				store.EXPECT().InsertLoanStatusHistory(
+					a.ctx,
+					mock.MatchedBy(func(h sqlentity.LoanStatusHistory) bool {
+						return h.FromStatus == sqlentity.Disbursed && h.ToStatus == sqlentity.Repaid && h.ActorUserID == 5
+					}),
+				).Return(nil).Once()
+			},
+			want: &want{
+				principal:   "1000",
+				interest:    "20",
+				overpaid:    "80",
+				outstanding: "0",
+				loanStatus:  "REPAID",
+				allocations: []string{"PAID", "PAID"},
+			},
+			wantEntry:  "repayment:20",
+			wantEvents: []string{event.LoanRepaid, event.LoanRepaymentMade},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockRepayLoanStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(tt.args.ctx, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Maybe()
+			outbox := pkgmocks.NewMockRecorder(t)
+			poster := loanmocks.NewMockPoster(t)
+			tt.mockFn(store, userStore, snowflakeGen, tt.args)
+			if tt.wantEntry != "" {
+				poster.EXPECT().Post(tt.args.ctx, mock.MatchedBy(func(e ledger.Entry) bool {
+					return e.Reference == tt.wantEntry && e.Validate() == nil
+				})).Return(tt.postErr).Once()
+			}
+			for _, eventType := range tt.wantEvents {
+				outbox.EXPECT().Record(tt.args.ctx, mock.MatchedBy(func(e pkgoutbox.Event) bool {
+					return e.Type == eventType && e.AggregateID == tt.args.in.LoanID
+				})).Return(nil).Once()
+			}
+
+			r := NewRepayLoan(
+				store,
+				userStore,
+				transactor,
+				outbox,
+				poster,
+				statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...),
+				logger,
+				snowflakeGen,
+			)
+			got, err := r.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RepayLoan.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, tt.wantValidationErr, pkgerror.IsValidationError(err))
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			if tt.want == nil {
+				assert.Nil(t, got)
+
+				return
+			}
+
+			assert.Equal(t, tt.want.principal, got.PrincipalAmount.String())
+			assert.Equal(t, tt.want.interest, got.InterestAmount.String())
+			assert.Equal(t, tt.want.overpaid, got.OverpaidAmount.String())
+			assert.Equal(t, tt.want.outstanding, got.OutstandingAmount.String())
+			assert.Equal(t, tt.want.loanStatus, got.LoanStatus)
+
+			statuses := make([]string, 0, len(got.Allocations))
+			for _, allocation := range got.Allocations {
+				statuses = append(statuses, allocation.Status)
+			}
+
+			assert.Equal(t, tt.want.allocations, statuses)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"go.uber.org/zap"
+)
+
+type TopUpWallet struct {
+	userStore  UserStore
+	transactor pkgsql.Transactor
+	wallets    wallet.Keeper
+	logger     *zap.SugaredLogger
+}
+
+func NewTopUpWallet(
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	wallets wallet.Keeper,
+	logger *zap.SugaredLogger,
+) *TopUpWallet {
+	return &TopUpWallet{
+		userStore:  userStore,
+		transactor: transactor,
+		wallets:    wallets,
+		logger:     logger,
+	}
+}
+
+func (t *TopUpWallet) Execute(ctx context.Context, in usecase.TopUpWalletInput) (*usecase.Wallet, error) {
+	if !wallet.ValidAmount(in.Amount) {
+		t.logger.Errorw("invalid top-up amount", "amount", in.Amount)
+
+		return nil, pkgerror.NewValidationError("amount must be positive with at most 2 decimals")
+	}
+
+	if err := requireUserType(ctx, t.userStore, in.InvestorID, sqlentity.Investor, pkgerror.UserNotInvestor); err != nil {
+		t.logger.Errorw("user cannot top up a wallet", "error", err)
+
+		return nil, err
+	}
+
+	var w sqlentity.Wallet
+	err := t.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+		w, err = t.wallets.TopUp(ctx, in.InvestorID, in.Amount)
+
+		return err
+	})
+	if err != nil {
+		t.logger.Errorw("failed to top up wallet", "error", err)
+
+		return nil, walletError(err)
+	}
+
+	return walletOutput(w), nil
+}
@@ -0,0 +1,140 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"go.uber.org/zap"
+)
+
+// agreementLetterExtensions maps the accepted content types of an agreement letter to the extension it is stored with.
+//
+//nolint:gochecknoglobals // intended to be global
+var agreementLetterExtensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+type (
+	UploadAgreementLetterStore interface {
+		GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error)
+		InsertLoanDocument(ctx context.Context, in sqlentity.LoanDocument) error
+	}
+
+	UploadAgreementLetter struct {
+		store         UploadAgreementLetterStore
+		userStore     UserStore
+		documentStore pkgstorage.DocumentStore
+
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+func NewUploadAgreementLetter(
+	store UploadAgreementLetterStore,
+	userStore UserStore,
+	documentStore pkgstorage.DocumentStore,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *UploadAgreementLetter {
+	return &UploadAgreementLetter{
+		store:         store,
+		userStore:     userStore,
+		documentStore: documentStore,
+		logger:        logger,
+		snowflakeGen:  snowflakeGen,
+	}
+}
+
+// Execute stores the agreement letter of an invested loan under a server generated name and records its checksum.
+// The content type is sniffed from the content, the client supplied file name and content type are never trusted.
+func (u *UploadAgreementLetter) Execute(
+	ctx context.Context,
+	in usecase.UploadAgreementLetterInput,
+) (usecase.UploadAgreementLetterOutput, error) {
+	contentType := http.DetectContentType(in.Content)
+	extension, ok := agreementLetterExtensions[contentType]
+	if !ok {
+		u.logger.Errorw("agreement letter has an unsupported content type", "content_type", contentType)
+
+		return usecase.UploadAgreementLetterOutput{},
+			pkgerror.NewValidationError("agreement letter must be a PDF, JPEG or PNG file")
+	}
+
+	if err := requireUserType(ctx, u.userStore, in.EmployeeID, sqlentity.Employee, pkgerror.UserNotEmployee); err != nil {
+		u.logger.Errorw("user cannot upload an agreement letter", "error", err)
+
+		return usecase.UploadAgreementLetterOutput{}, err
+	}
+
+	loans, err := u.store.GetLoan(ctx, gateway.GetLoanWithLoanIDFilter(in.LoanID))
+	if err != nil {
+		u.logger.Errorw("failed to get loan", "error", err)
+
+		return usecase.UploadAgreementLetterOutput{}, pkgerror.ServerErrorFrom(err)
+	}
+
+	var loan sqlentity.Loan
+	if loan = loans.First(); loans.IsEmpty() {
+		u.logger.Errorw("loan not found")
+
+		return usecase.UploadAgreementLetterOutput{}, pkgerror.NewBusinessErrorCode(pkgerror.LoanNotFound)
+	}
+
+	if loan.Status != sqlentity.Invested {
+		u.logger.Errorw("loan does not accept an agreement letter", "status", loan.Status.String())
+
+		return usecase.UploadAgreementLetterOutput{}, pkgerror.NewBusinessErrorCode(pkgerror.LoanDocumentNotAccepted)
+	}
+
+	checksum := sha256.Sum256(in.Content)
+	document := sqlentity.LoanDocument{
+		ID:             u.snowflakeGen.Generate(),
+		LoanID:         loan.ID,
+		Type:           sqlentity.AgreementLetterDocument,
+		ContentType:    contentType,
+		Size:           int64(len(in.Content)),
+		ChecksumSHA256: hex.EncodeToString(checksum[:]),
+		UploadedBy:     in.EmployeeID,
+		CreatedAt:      time.Now(),
+	}
+	document.DocumentKey = agreementLetterKeyPrefix(loan.ID) + fmt.Sprintf("%d%s", document.ID, extension)
+
+	if err := u.documentStore.Put(ctx, document.DocumentKey, bytes.NewReader(in.Content), contentType); err != nil {
+		u.logger.Errorw("failed to store agreement letter", "error", err)
+
+		return usecase.UploadAgreementLetterOutput{}, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := u.store.InsertLoanDocument(ctx, document); err != nil {
+		u.logger.Errorw("failed to insert loan document", "error", err)
+
+		// the stored file is unreachable without its record, failing to remove it only leaves an orphan behind
+		if err := u.documentStore.Delete(ctx, document.DocumentKey); err != nil {
+			u.logger.Warnw("failed to delete orphaned agreement letter", "key", document.DocumentKey, "error", err)
+		}
+
+		return usecase.UploadAgreementLetterOutput{}, pkgerror.ServerErrorFrom(err)
+	}
+
+	return usecase.UploadAgreementLetterOutput{
+		DocumentID:         document.ID,
+		AgreementLetterKey: document.DocumentKey,
+		ContentType:        document.ContentType,
+		Size:               document.Size,
+		ChecksumSHA256:     document.ChecksumSHA256,
+	}, nil
+}
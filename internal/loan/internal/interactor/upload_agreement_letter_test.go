@@ -0,0 +1,222 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// pdfAgreementLetter starts with the PDF signature, which is all content sniffing looks at.
+var pdfAgreementLetter = []byte("%PDF-1.7\n")
+
+func TestUploadAgreementLetter_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.UploadAgreementLetterInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *loanmocks.MockUploadAgreementLetterStore,
+			userStore *loanmocks.MockUserStore,
+			documentStore *pkgmocks.MockDocumentStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			a args,
+		)
+		want     usecase.UploadAgreementLetterOutput
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error unsupported content type",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.UploadAgreementLetterInput{
+					LoanID:     1,
+					EmployeeID: 4,
+					Content:    []byte("#!/bin/sh\nrm -rf /\n"),
+				},
+			},
+			mockFn: func(
+				_ *loanmocks.MockUploadAgreementLetterStore,
+				_ *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				_ args,
+			) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error user is not an employee",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.UploadAgreementLetterInput{LoanID: 1, EmployeeID: 2, Content: pdfAgreementLetter},
+			},
+			mockFn: func(
+				_ *loanmocks.MockUploadAgreementLetterStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 2, Type: sqlentity.Investor}}, nil).Once()
+			},
+			wantCode: pkgerror.UserNotEmployee,
+			wantErr:  true,
+		},
+		{
+			name: "error loan not found",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.UploadAgreementLetterInput{LoanID: 1, EmployeeID: 4, Content: pdfAgreementLetter},
+			},
+			mockFn: func(
+				store *loanmocks.MockUploadAgreementLetterStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).Return(sqlentity.Loans{}, nil).Once()
+			},
+			wantCode: pkgerror.LoanNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error loan is not invested",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.UploadAgreementLetterInput{LoanID: 1, EmployeeID: 4, Content: pdfAgreementLetter},
+			},
+			mockFn: func(
+				store *loanmocks.MockUploadAgreementLetterStore,
+				userStore *loanmocks.MockUserStore,
+				_ *pkgmocks.MockDocumentStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Approved}}, nil).Once()
+			},
+			wantCode: pkgerror.LoanDocumentNotAccepted,
+			wantErr:  true,
+		},
+		{
+			name: "error insert document removes the stored file",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.UploadAgreementLetterInput{LoanID: 1, EmployeeID: 4, Content: pdfAgreementLetter},
+			},
+			mockFn: func(
+				store *loanmocks.MockUploadAgreementLetterStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Invested}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(9)).Once()
+
+				documentStore.EXPECT().
+					Put(a.ctx, "loan/1/agreement-letter/9.pdf", mock.Anything, "application/pdf").
+					Return(nil).Once()
+
+				store.EXPECT().InsertLoanDocument(a.ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+				documentStore.EXPECT().Delete(a.ctx, "loan/1/agreement-letter/9.pdf").Return(nil).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.UploadAgreementLetterInput{LoanID: 1, EmployeeID: 4, Content: pdfAgreementLetter},
+			},
+			mockFn: func(
+				store *loanmocks.MockUploadAgreementLetterStore,
+				userStore *loanmocks.MockUserStore,
+				documentStore *pkgmocks.MockDocumentStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				userStore.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 4, Type: sqlentity.Employee}}, nil).Once()
+
+				store.EXPECT().GetLoan(a.ctx, mock.Anything).
+					Return(sqlentity.Loans{{ID: 1, Status: sqlentity.Invested}}, nil).Once()
+
+				snowflakeGen.EXPECT().Generate().Return(uint64(9)).Once()
+
+				documentStore.EXPECT().
+					Put(a.ctx, "loan/1/agreement-letter/9.pdf", mock.Anything, "application/pdf").
+					Return(nil).Once()
+
+				store.EXPECT().InsertLoanDocument(
+					a.ctx,
+					mock.MatchedBy(func(d sqlentity.LoanDocument) bool {
+						return d.ID == 9 &&
+							d.LoanID == 1 &&
+							d.Type == sqlentity.AgreementLetterDocument &&
+							d.UploadedBy == 4
+					}),
+				).Return(nil).Once()
+			},
+			want: usecase.UploadAgreementLetterOutput{
+				DocumentID:         9,
+				AgreementLetterKey: "loan/1/agreement-letter/9.pdf",
+				ContentType:        "application/pdf",
+				Size:               int64(len(pdfAgreementLetter)),
+				ChecksumSHA256:     "0716f9264c9fe19f5d7455276107f3ddcc1d3497f63d60689a73558ae8a1bf5e",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockUploadAgreementLetterStore(t)
+			userStore := loanmocks.NewMockUserStore(t)
+			documentStore := pkgmocks.NewMockDocumentStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			tt.mockFn(store, userStore, documentStore, snowflakeGen, tt.args)
+
+			u := NewUploadAgreementLetter(store, userStore, documentStore, logger, snowflakeGen)
+			got, err := u.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UploadAgreementLetter.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+)
+
+type UserStore interface {
+	GetUser(ctx context.Context, opts ...gateway.GetUserOption) (sqlentity.Users, error)
+}
+
+// requireUserType fails with UserNotFound when the user does not exist, with UserDeactivated when it can no longer
+// act and with code when it has another type.
+func requireUserType(
+	ctx context.Context,
+	store UserStore,
+	userID uint64,
+	userType sqlentity.UserType,
+	code pkgerror.Code,
+) error {
+	users, err := store.GetUser(ctx, gateway.GetUserWithIDFilter(userID))
+	if err != nil {
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if users.IsEmpty() {
+		return pkgerror.NewBusinessErrorCode(pkgerror.UserNotFound)
+	}
+
+	if users.First().DeactivatedAt.Valid {
+		return pkgerror.NewBusinessErrorCode(pkgerror.UserDeactivated)
+	}
+
+	if users.First().Type != userType {
+		return pkgerror.NewBusinessErrorCode(code)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package interactor
+
+import (
+	"errors"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+)
+
+// walletError maps a wallet lacking the money to take from it to a business error, and an amount it cannot move to a
+// validation error.
+func walletError(err error) error {
+	if errors.Is(err, wallet.ErrInvalidAmount) {
+		return pkgerror.NewValidationError("amount must be positive with at most 2 decimals")
+	}
+
+	if errors.Is(err, wallet.ErrInsufficientBalance) {
+		return pkgerror.NewBusinessErrorCode(pkgerror.WalletInsufficientBalance)
+	}
+
+	return pkgerror.ServerErrorFrom(err)
+}
+
+func walletOutput(w sqlentity.Wallet) *usecase.Wallet {
+	return &usecase.Wallet{
+		InvestorID:      w.InvestorID,
+		Balance:         w.Balance,
+		ReservedAmount:  w.ReservedAmount,
+		AvailableAmount: w.AvailableAmount(),
+	}
+}
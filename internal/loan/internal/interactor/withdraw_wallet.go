@@ -0,0 +1,61 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"go.uber.org/zap"
+)
+
+type WithdrawWallet struct {
+	userStore  UserStore
+	transactor pkgsql.Transactor
+	wallets    wallet.Keeper
+	logger     *zap.SugaredLogger
+}
+
+func NewWithdrawWallet(
+	userStore UserStore,
+	transactor pkgsql.Transactor,
+	wallets wallet.Keeper,
+	logger *zap.SugaredLogger,
+) *WithdrawWallet {
+	return &WithdrawWallet{
+		userStore:  userStore,
+		transactor: transactor,
+		wallets:    wallets,
+		logger:     logger,
+	}
+}
+
+func (w *WithdrawWallet) Execute(ctx context.Context, in usecase.WithdrawWalletInput) (*usecase.Wallet, error) {
+	if !wallet.ValidAmount(in.Amount) {
+		w.logger.Errorw("invalid withdrawal amount", "amount", in.Amount)
+
+		return nil, pkgerror.NewValidationError("amount must be positive with at most 2 decimals")
+	}
+
+	if err := requireUserType(ctx, w.userStore, in.InvestorID, sqlentity.Investor, pkgerror.UserNotInvestor); err != nil {
+		w.logger.Errorw("user cannot withdraw from a wallet", "error", err)
+
+		return nil, err
+	}
+
+	var out sqlentity.Wallet
+	err := w.transactor.WithinTx(ctx, func(ctx context.Context) (err error) {
+		out, err = w.wallets.Withdraw(ctx, in.InvestorID, in.Amount)
+
+		return err
+	})
+	if err != nil {
+		w.logger.Errorw("failed to withdraw from wallet", "error", err)
+
+		return nil, walletError(err)
+	}
+
+	return walletOutput(out), nil
+}
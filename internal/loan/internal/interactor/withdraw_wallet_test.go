@@ -0,0 +1,121 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestWithdrawWallet_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	investor := sqlentity.Users{{ID: 3, Type: sqlentity.Investor}}
+
+	tests := []struct {
+		name     string
+		in       usecase.WithdrawWalletInput
+		mockFn   func(userStore *loanmocks.MockUserStore, wallets *loanmocks.MockKeeper)
+		want     *usecase.Wallet
+		wantErr  bool
+		wantCode pkgerror.Code
+	}{
+		{
+			name:    "error amount not positive",
+			in:      usecase.WithdrawWalletInput{InvestorID: 3, Amount: decimal.Zero},
+			mockFn:  func(*loanmocks.MockUserStore, *loanmocks.MockKeeper) {},
+			wantErr: true,
+		},
+		{
+			name:    "error amount with more than 2 decimals",
+			in:      usecase.WithdrawWalletInput{InvestorID: 3, Amount: decimal.RequireFromString("10.001")},
+			mockFn:  func(*loanmocks.MockUserStore, *loanmocks.MockKeeper) {},
+			wantErr: true,
+		},
+		{
+			name: "error user not investor",
+			in:   usecase.WithdrawWalletInput{InvestorID: 3, Amount: decimal.NewFromInt(100)},
+			mockFn: func(userStore *loanmocks.MockUserStore, _ *loanmocks.MockKeeper) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).
+					Return(sqlentity.Users{{ID: 3, Type: sqlentity.Borrower}}, nil).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.UserNotInvestor,
+		},
+		{
+			name: "error when withdraw",
+			in:   usecase.WithdrawWalletInput{InvestorID: 3, Amount: decimal.NewFromInt(100)},
+			mockFn: func(userStore *loanmocks.MockUserStore, wallets *loanmocks.MockKeeper) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				wallets.EXPECT().Withdraw(mock.Anything, uint64(3), decimal.NewFromInt(100)).
+					Return(sqlentity.Wallet{}, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error insufficient balance",
+			in:   usecase.WithdrawWalletInput{InvestorID: 3, Amount: decimal.NewFromInt(100)},
+			mockFn: func(userStore *loanmocks.MockUserStore, wallets *loanmocks.MockKeeper) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				wallets.EXPECT().Withdraw(mock.Anything, uint64(3), decimal.NewFromInt(100)).
+					Return(sqlentity.Wallet{}, wallet.ErrInsufficientBalance).Once()
+			},
+			wantErr:  true,
+			wantCode: pkgerror.WalletInsufficientBalance,
+		},
+		{
+			name: "success",
+			in:   usecase.WithdrawWalletInput{InvestorID: 3, Amount: decimal.NewFromInt(100)},
+			mockFn: func(userStore *loanmocks.MockUserStore, wallets *loanmocks.MockKeeper) {
+				userStore.EXPECT().GetUser(mock.Anything, mock.Anything).Return(investor, nil).Once()
+				wallets.EXPECT().Withdraw(mock.Anything, uint64(3), decimal.NewFromInt(100)).
+					Return(sqlentity.Wallet{
+						InvestorID:     3,
+						Balance:        decimal.NewFromInt(250),
+						ReservedAmount: decimal.NewFromInt(50),
+					}, nil).Once()
+			},
+			want: &usecase.Wallet{
+				InvestorID:      3,
+				Balance:         decimal.NewFromInt(250),
+				ReservedAmount:  decimal.NewFromInt(50),
+				AvailableAmount: decimal.NewFromInt(200),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStore := loanmocks.NewMockUserStore(t)
+			wallets := loanmocks.NewMockKeeper(t)
+			transactor := pkgmocks.NewMockTransactor(t)
+			transactor.EXPECT().WithinTx(mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				}).Maybe()
+			tt.mockFn(userStore, wallets)
+
+			w := NewWithdrawWallet(userStore, transactor, wallets, logger)
+			got, err := w.Execute(context.Background(), tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WithdrawWallet.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
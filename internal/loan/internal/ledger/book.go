@@ -0,0 +1,136 @@
+package ledger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+)
+
+type (
+	// Poster posts journal entries within the transaction carried by ctx.
+	Poster interface {
+		Post(ctx context.Context, entry Entry) error
+	}
+
+	BookStore interface {
+		GetLedgerAccount(
+			ctx context.Context,
+			opts ...gateway.GetLedgerAccountOption,
+		) (sqlentity.LedgerAccounts, error)
+		InsertLedgerAccounts(ctx context.Context, in sqlentity.LedgerAccounts) error
+		InsertLedgerEntry(ctx context.Context, in sqlentity.LedgerEntry) error
+		InsertLedgerLines(ctx context.Context, in sqlentity.LedgerLines) error
+	}
+
+	// Book posts the entries to the ledger tables, opening the accounts an entry is the first to post to.
+	Book struct {
+		store        BookStore
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+func NewBook(store BookStore, snowflakeGen pkguid.Snowflake) *Book {
+	return &Book{
+		store:        store,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+func (b *Book) Post(ctx context.Context, entry Entry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	accountIDs, err := b.accountIDs(ctx, entry.Accounts())
+	if err != nil {
+		return err
+	}
+
+	entryID := b.snowflakeGen.Generate()
+
+	if err := b.store.InsertLedgerEntry(ctx, sqlentity.LedgerEntry{
+		ID:          entryID,
+		Reference:   entry.Reference,
+		LoanID:      entry.LoanID,
+		Description: entry.Description,
+		PostedAt:    time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	lines := make(sqlentity.LedgerLines, 0, len(entry.Lines))
+	for _, line := range entry.Lines {
+		lines = append(lines, sqlentity.LedgerLine{
+			ID:           b.snowflakeGen.Generate(),
+			EntryID:      entryID,
+			AccountID:    accountIDs[line.Account],
+			DebitAmount:  line.Debit,
+			CreditAmount: line.Credit,
+		})
+	}
+
+	return b.store.InsertLedgerLines(ctx, lines)
+}
+
+// accountIDs finds the ids of the accounts, opening the ones that do not exist yet.
+func (b *Book) accountIDs(ctx context.Context, accounts []Account) (map[Account]uint64, error) {
+	keys := make(sqlentity.LedgerAccounts, 0, len(accounts))
+	for _, account := range accounts {
+		keys = append(keys, sqlentity.LedgerAccount{Type: account.Type, OwnerID: account.OwnerID})
+	}
+
+	found, err := b.store.GetLedgerAccount(ctx, gateway.GetLedgerAccountWithKeysFilter(keys...))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[Account]uint64, len(accounts))
+	for _, account := range found {
+		ids[Account{Type: account.Type, OwnerID: account.OwnerID}] = account.ID
+	}
+
+	var missing sqlentity.LedgerAccounts
+	for _, account := range accounts {
+		if _, ok := ids[account]; !ok {
+			missing = append(missing, sqlentity.LedgerAccount{
+				ID:      b.snowflakeGen.Generate(),
+				Type:    account.Type,
+				OwnerID: account.OwnerID,
+			})
+		}
+	}
+
+	if missing.IsEmpty() {
+		return ids, nil
+	}
+
+	// another transaction may open the same accounts at once, the insert skips them and the locking read sees them
+	if err := b.store.InsertLedgerAccounts(ctx, missing); err != nil {
+		return nil, err
+	}
+
+	found, err = b.store.GetLedgerAccount(
+		ctx,
+		gateway.GetLedgerAccountWithKeysFilter(missing...),
+		gateway.GetLedgerAccountWithLock(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range found {
+		ids[Account{Type: account.Type, OwnerID: account.OwnerID}] = account.ID
+	}
+
+	for _, account := range missing {
+		if _, ok := ids[Account{Type: account.Type, OwnerID: account.OwnerID}]; !ok {
+			return nil, fmt.Errorf("ledger account %s of %d not opened", account.Type, account.OwnerID)
+		}
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,242 @@
+// Package ledger keeps the books of the money moved by the loans in double entry.
+//
+// Every money movement is posted as one immutable journal entry whose lines move money between accounts: a debit
+// takes money out of an account, a credit puts it in, and the debits of an entry always equal its credits. The
+// balance of an account is its credits minus its debits. Investors pay their investment from their wallet into the
+// platform escrow, the escrow pays the principal out to the borrower on disbursement, the borrower repays into the
+// escrow and the escrow passes the repayment on to the wallets of the investors and to the fee revenue. The money
+// topped up to and withdrawn from the wallets comes from and goes to the settlement account.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shopspring/decimal"
+)
+
+// amountScale is the number of decimals of the stored amounts.
+const amountScale = 2
+
+// ErrUnbalancedEntry is returned for an entry whose debits do not equal its credits or with an invalid line.
+var ErrUnbalancedEntry = errors.New("unbalanced ledger entry")
+
+// Account identifies a ledger account by its type and owner, the accounts of the platform have no owner.
+type Account struct {
+	Type    sqlentity.LedgerAccountType
+	OwnerID uint64
+}
+
+func InvestorWallet(investorID uint64) Account {
+	return Account{Type: sqlentity.InvestorWallet, OwnerID: investorID}
+}
+
+func Borrower(borrowerID uint64) Account {
+	return Account{Type: sqlentity.BorrowerAccount, OwnerID: borrowerID}
+}
+
+func PlatformEscrow() Account {
+	return Account{Type: sqlentity.PlatformEscrow}
+}
+
+func FeeRevenue() Account {
+	return Account{Type: sqlentity.FeeRevenue}
+}
+
+func Settlement() Account {
+	return Account{Type: sqlentity.Settlement}
+}
+
+// Line moves money out of the account by Debit or into it by Credit, only one of them is set.
+type Line struct {
+	Account Account
+	Debit   decimal.Decimal
+	Credit  decimal.Decimal
+}
+
+func Debit(account Account, amount decimal.Decimal) Line {
+	return Line{Account: account, Debit: amount, Credit: decimal.Zero}
+}
+
+func Credit(account Account, amount decimal.Decimal) Line {
+	return Line{Account: account, Debit: decimal.Zero, Credit: amount}
+}
+
+// Entry is a journal entry. Reference names the money movement it records, a movement is only posted once.
+type Entry struct {
+	Reference   string
+	LoanID      uint64
+	Description string
+	Lines       []Line
+}
+
+// Validate checks the entry has at least two lines, each either a debit or a credit of at most 2 decimals, and that
+// its debits equal its credits.
+func (e Entry) Validate() error {
+	if e.Reference == "" || len(e.Lines) < 2 {
+		return ErrUnbalancedEntry
+	}
+
+	debits, credits := decimal.Zero, decimal.Zero
+	for _, line := range e.Lines {
+		if line.Debit.IsNegative() || line.Credit.IsNegative() || line.Debit.IsPositive() == line.Credit.IsPositive() {
+			return ErrUnbalancedEntry
+		}
+
+		if !line.Debit.Equal(line.Debit.Round(amountScale)) || !line.Credit.Equal(line.Credit.Round(amountScale)) {
+			return ErrUnbalancedEntry
+		}
+
+		debits = debits.Add(line.Debit)
+		credits = credits.Add(line.Credit)
+	}
+
+	if !debits.Equal(credits) {
+		return ErrUnbalancedEntry
+	}
+
+	return nil
+}
+
+// Accounts lists the accounts the entry posts to, once each in the order of the lines.
+func (e Entry) Accounts() []Account {
+	accounts := make([]Account, 0, len(e.Lines))
+	seen := make(map[Account]bool, len(e.Lines))
+
+	for _, line := range e.Lines {
+		if !seen[line.Account] {
+			seen[line.Account] = true
+			accounts = append(accounts, line.Account)
+		}
+	}
+
+	return accounts
+}
+
+// TopUp moves money from outside the platform into the wallet of the investor.
+func TopUp(id, investorID uint64, amount decimal.Decimal) Entry {
+	return Entry{
+		Reference:   fmt.Sprintf("top-up:%d", id),
+		Description: "wallet top-up",
+		Lines: []Line{
+			Debit(Settlement(), amount),
+			Credit(InvestorWallet(investorID), amount),
+		},
+	}
+}
+
+// Withdrawal moves money out of the wallet of the investor to outside the platform.
+func Withdrawal(id, investorID uint64, amount decimal.Decimal) Entry {
+	return Entry{
+		Reference:   fmt.Sprintf("withdrawal:%d", id),
+		Description: "wallet withdrawal",
+		Lines: []Line{
+			Debit(InvestorWallet(investorID), amount),
+			Credit(Settlement(), amount),
+		},
+	}
+}
+
+// Investment moves the money of an investment from the wallet of the investor to the escrow.
+func Investment(investment sqlentity.LoanInvestment) Entry {
+	return Entry{
+		Reference:   fmt.Sprintf("investment:%d", investment.ID),
+		LoanID:      investment.LoanID,
+		Description: "investment in loan",
+		Lines: []Line{
+			Debit(InvestorWallet(investment.InvestorID), investment.Amount),
+			Credit(PlatformEscrow(), investment.Amount),
+		},
+	}
+}
+
+// Disbursement pays the principal of the loan out of the escrow to the borrower.
+func Disbursement(loan sqlentity.Loan) Entry {
+	return Entry{
+		Reference:   fmt.Sprintf("disbursement:%d", loan.ID),
+		LoanID:      loan.ID,
+		Description: "loan disbursed to borrower",
+		Lines: []Line{
+			Debit(PlatformEscrow(), loan.PrincipalAmount),
+			Credit(Borrower(loan.BorrowerID), loan.PrincipalAmount),
+		},
+	}
+}
+
+// Repayment moves the whole payment of the borrower into the escrow, the overpaid part included.
+func Repayment(repayment sqlentity.LoanRepayment) Entry {
+	return Entry{
+		Reference:   fmt.Sprintf("repayment:%d", repayment.ID),
+		LoanID:      repayment.LoanID,
+		Description: "loan repayment from borrower",
+		Lines: []Line{
+			Debit(Borrower(repayment.BorrowerID), repayment.Amount),
+			Credit(PlatformEscrow(), repayment.Amount),
+		},
+	}
+}
+
+// Distribution passes the share of an investment in a repayment on from the escrow to the wallet of its investor,
+// and the platform fee taken from it to the fee revenue.
+func Distribution(credit sqlentity.LoanInvestorCredit) Entry {
+	amount := credit.PrincipalAmount.Add(credit.InterestAmount)
+	lines := []Line{Debit(PlatformEscrow(), amount.Add(credit.FeeAmount))}
+
+	if amount.IsPositive() {
+		lines = append(lines, Credit(InvestorWallet(credit.InvestorID), amount))
+	}
+
+	if credit.FeeAmount.IsPositive() {
+		lines = append(lines, Credit(FeeRevenue(), credit.FeeAmount))
+	}
+
+	return Entry{
+		Reference:   fmt.Sprintf("distribution:%d:%d", credit.RepaymentID, credit.InvestmentID),
+		LoanID:      credit.LoanID,
+		Description: "loan repayment distributed to investor",
+		Lines:       lines,
+	}
+}
+
+// AccountBalance is what was posted to an account, Balance being its credits minus its debits.
+type AccountBalance struct {
+	AccountID uint64
+	Account   Account
+	Debit     decimal.Decimal
+	Credit    decimal.Decimal
+	Balance   decimal.Decimal
+}
+
+// TrialBalance lists the balance of every account, the books balance when the debits equal the credits.
+type TrialBalance struct {
+	Accounts    []AccountBalance
+	TotalDebit  decimal.Decimal
+	TotalCredit decimal.Decimal
+}
+
+func NewTrialBalance(balances sqlentity.LedgerAccountBalances) TrialBalance {
+	trialBalance := TrialBalance{
+		Accounts:    make([]AccountBalance, 0, balances.Len()),
+		TotalDebit:  decimal.Zero,
+		TotalCredit: decimal.Zero,
+	}
+
+	for _, balance := range balances {
+		trialBalance.TotalDebit = trialBalance.TotalDebit.Add(balance.DebitAmount)
+		trialBalance.TotalCredit = trialBalance.TotalCredit.Add(balance.CreditAmount)
+		trialBalance.Accounts = append(trialBalance.Accounts, AccountBalance{
+			AccountID: balance.AccountID,
+			Account:   Account{Type: balance.Type, OwnerID: balance.OwnerID},
+			Debit:     balance.DebitAmount,
+			Credit:    balance.CreditAmount,
+			Balance:   balance.CreditAmount.Sub(balance.DebitAmount),
+		})
+	}
+
+	return trialBalance
+}
+
+func (t TrialBalance) Balanced() bool {
+	return t.TotalDebit.Equal(t.TotalCredit)
+}
@@ -0,0 +1,315 @@
+package ledger_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/distribution"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryBookStore keeps the ledger tables in memory, it ignores the filters and returns every account.
+type memoryBookStore struct {
+	accounts sqlentity.LedgerAccounts
+	entries  []sqlentity.LedgerEntry
+	lines    sqlentity.LedgerLines
+}
+
+func (m *memoryBookStore) GetLedgerAccount(
+	context.Context,
+	...gateway.GetLedgerAccountOption,
+) (sqlentity.LedgerAccounts, error) {
+	return m.accounts, nil
+}
+
+func (m *memoryBookStore) InsertLedgerAccounts(_ context.Context, in sqlentity.LedgerAccounts) error {
+	m.accounts = append(m.accounts, in...)
+
+	return nil
+}
+
+func (m *memoryBookStore) InsertLedgerEntry(_ context.Context, in sqlentity.LedgerEntry) error {
+	for _, entry := range m.entries {
+		if entry.Reference == in.Reference {
+			return errors.New("duplicate ledger entry reference")
+		}
+	}
+
+	m.entries = append(m.entries, in)
+
+	return nil
+}
+
+func (m *memoryBookStore) InsertLedgerLines(_ context.Context, in sqlentity.LedgerLines) error {
+	m.lines = append(m.lines, in...)
+
+	return nil
+}
+
+// trialBalance totals the lines of every account the way the trial balance query does.
+func (m *memoryBookStore) trialBalance() ledger.TrialBalance {
+	balances := make(sqlentity.LedgerAccountBalances, 0, m.accounts.Len())
+	for _, account := range m.accounts {
+		balance := sqlentity.LedgerAccountBalance{
+			AccountID:    account.ID,
+			Type:         account.Type,
+			OwnerID:      account.OwnerID,
+			DebitAmount:  decimal.Zero,
+			CreditAmount: decimal.Zero,
+		}
+
+		for _, line := range m.lines {
+			if line.AccountID == account.ID {
+				balance.DebitAmount = balance.DebitAmount.Add(line.DebitAmount)
+				balance.CreditAmount = balance.CreditAmount.Add(line.CreditAmount)
+			}
+		}
+
+		balances = append(balances, balance)
+	}
+
+	return ledger.NewTrialBalance(balances)
+}
+
+func (m *memoryBookStore) balance(t *testing.T, account ledger.Account) string {
+	t.Helper()
+
+	for _, balance := range m.trialBalance().Accounts {
+		if balance.Account == account {
+			return balance.Balance.String()
+		}
+	}
+
+	t.Fatalf("no ledger account %s of %d", account.Type, account.OwnerID)
+
+	return ""
+}
+
+func newSnowflake(t *testing.T) *pkgmocks.MockSnowflake {
+	t.Helper()
+
+	var id uint64
+	snowflakeGen := pkgmocks.NewMockSnowflake(t)
+	snowflakeGen.EXPECT().Generate().RunAndReturn(func() uint64 {
+		id++
+
+		return id
+	}).Maybe()
+
+	return snowflakeGen
+}
+
+func TestEntry_Validate(t *testing.T) {
+	escrow, wallet := ledger.PlatformEscrow(), ledger.InvestorWallet(3)
+	hundred := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name    string
+		entry   ledger.Entry
+		wantErr bool
+	}{
+		{
+			name:    "error without reference",
+			entry:   ledger.Entry{Lines: []ledger.Line{ledger.Debit(wallet, hundred), ledger.Credit(escrow, hundred)}},
+			wantErr: true,
+		},
+		{
+			name:    "error single line",
+			entry:   ledger.Entry{Reference: "test:1", Lines: []ledger.Line{ledger.Debit(wallet, decimal.Zero)}},
+			wantErr: true,
+		},
+		{
+			name: "error debits differ from credits",
+			entry: ledger.Entry{Reference: "test:1", Lines: []ledger.Line{
+				ledger.Debit(wallet, hundred),
+				ledger.Credit(escrow, decimal.NewFromInt(99)),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "error line both debit and credit",
+			entry: ledger.Entry{Reference: "test:1", Lines: []ledger.Line{
+				{Account: wallet, Debit: hundred, Credit: hundred},
+				ledger.Credit(escrow, decimal.Zero),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "error negative line",
+			entry: ledger.Entry{Reference: "test:1", Lines: []ledger.Line{
+				ledger.Debit(wallet, hundred.Neg()),
+				ledger.Credit(escrow, hundred.Neg()),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "error more than 2 decimals",
+			entry: ledger.Entry{Reference: "test:1", Lines: []ledger.Line{
+				ledger.Debit(wallet, decimal.RequireFromString("0.001")),
+				ledger.Credit(escrow, decimal.RequireFromString("0.001")),
+			}},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			entry: ledger.Investment(sqlentity.LoanInvestment{
+				ID:         10,
+				LoanID:     1,
+				InvestorID: 3,
+				Amount:     hundred,
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.entry.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Entry.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestBook_Post follows the money of a loan from its investments to the distribution of its repayment, the books
+// must balance after every entry.
+func TestBook_Post(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryBookStore{}
+	book := ledger.NewBook(store, newSnowflake(t))
+
+	loan := sqlentity.Loan{ID: 1, BorrowerID: 5, PrincipalAmount: decimal.NewFromInt(1_000)}
+	investments := sqlentity.LoanInvestments{
+		{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(600)},
+		{ID: 11, LoanID: 1, InvestorID: 4, Amount: decimal.NewFromInt(400)},
+	}
+	repayment := sqlentity.LoanRepayment{
+		ID:              40,
+		LoanID:          1,
+		BorrowerID:      5,
+		Amount:          decimal.RequireFromString("1025.05"),
+		PrincipalAmount: decimal.NewFromInt(1_000),
+		InterestAmount:  decimal.NewFromInt(25),
+		OverpaidAmount:  decimal.RequireFromString("0.05"),
+	}
+
+	shares, err := distribution.Distribute(
+		repayment.PrincipalAmount,
+		repayment.InterestAmount,
+		decimal.NewFromInt(10),
+		[]distribution.Share{
+			{InvestmentID: 10, Amount: investments[0].Amount},
+			{InvestmentID: 11, Amount: investments[1].Amount},
+		},
+	)
+	assert.NoError(t, err)
+
+	credits := make(sqlentity.LoanInvestorCredits, 0, len(shares))
+	for i, share := range shares {
+		credits = append(credits, sqlentity.LoanInvestorCredit{
+			LoanID:          repayment.LoanID,
+			RepaymentID:     repayment.ID,
+			InvestmentID:    share.InvestmentID,
+			InvestorID:      investments[i].InvestorID,
+			PrincipalAmount: share.Principal,
+			InterestAmount:  share.Interest,
+			FeeAmount:       share.Fee,
+		})
+	}
+
+	entries := []ledger.Entry{
+		ledger.Investment(investments[0]),
+		ledger.Investment(investments[1]),
+		ledger.Disbursement(loan),
+		ledger.Repayment(repayment),
+	}
+	for _, credit := range credits {
+		entries = append(entries, ledger.Distribution(credit))
+	}
+	for _, entry := range entries {
+		assert.NoError(t, book.Post(ctx, entry))
+		assert.True(t, store.trialBalance().Balanced(), "books do not balance after %s", entry.Reference)
+	}
+
+	assert.ErrorIs(t, book.Post(ctx, ledger.Entry{Reference: "test:1"}), ledger.ErrUnbalancedEntry)
+	assert.Error(t, book.Post(ctx, ledger.Repayment(repayment)), "a movement is only posted once")
+
+	trialBalance := store.trialBalance()
+	assert.Len(t, trialBalance.Accounts, 5)
+	assert.Equal(t, "4050.05", trialBalance.TotalDebit.String())
+	assert.Equal(t, "4050.05", trialBalance.TotalCredit.String())
+
+	// the investors earned the interest net of the 2.5 fee, the escrow keeps the overpaid part to refund
+	assert.Equal(t, "13.5", store.balance(t, ledger.InvestorWallet(3)))
+	assert.Equal(t, "9", store.balance(t, ledger.InvestorWallet(4)))
+	assert.Equal(t, "-25.05", store.balance(t, ledger.Borrower(5)))
+	assert.Equal(t, "0.05", store.balance(t, ledger.PlatformEscrow()))
+	assert.Equal(t, "2.5", store.balance(t, ledger.FeeRevenue()))
+}
+
+func TestBook_Post_Error(t *testing.T) {
+	ctx := context.Background()
+	entry := ledger.Investment(sqlentity.LoanInvestment{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(100)})
+	accounts := sqlentity.LedgerAccounts{
+		{ID: 1, Type: sqlentity.InvestorWallet, OwnerID: 3},
+		{ID: 2, Type: sqlentity.PlatformEscrow},
+	}
+
+	tests := []struct {
+		name   string
+		mockFn func(store *loanmocks.MockBookStore)
+	}{
+		{
+			name: "error when get ledger account",
+			mockFn: func(store *loanmocks.MockBookStore) {
+				store.EXPECT().GetLedgerAccount(ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when insert ledger accounts",
+			mockFn: func(store *loanmocks.MockBookStore) {
+				store.EXPECT().GetLedgerAccount(ctx, mock.Anything).Return(nil, nil).Once()
+				store.EXPECT().InsertLedgerAccounts(ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error account not opened",
+			mockFn: func(store *loanmocks.MockBookStore) {
+				store.EXPECT().GetLedgerAccount(ctx, mock.Anything).Return(accounts[1:], nil).Once()
+				store.EXPECT().InsertLedgerAccounts(ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().GetLedgerAccount(ctx, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "error when insert ledger entry",
+			mockFn: func(store *loanmocks.MockBookStore) {
+				store.EXPECT().GetLedgerAccount(ctx, mock.Anything).Return(accounts, nil).Once()
+				store.EXPECT().InsertLedgerEntry(ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when insert ledger lines",
+			mockFn: func(store *loanmocks.MockBookStore) {
+				store.EXPECT().GetLedgerAccount(ctx, mock.Anything).Return(accounts, nil).Once()
+				store.EXPECT().InsertLedgerEntry(ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().InsertLedgerLines(ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockBookStore(t)
+			tt.mockFn(store)
+
+			err := ledger.NewBook(store, newSnowflake(t)).Post(ctx, entry)
+			assert.Error(t, err)
+		})
+	}
+}
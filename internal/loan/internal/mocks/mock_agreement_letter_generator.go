@@ -0,0 +1,94 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	agreement "github.com/shandysiswandi/test-amartha/internal/loan/internal/agreement"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockAgreementLetterGenerator is an autogenerated mock type for the AgreementLetterGenerator type
+type MockAgreementLetterGenerator struct {
+	mock.Mock
+}
+
+type MockAgreementLetterGenerator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAgreementLetterGenerator) EXPECT() *MockAgreementLetterGenerator_Expecter {
+	return &MockAgreementLetterGenerator_Expecter{mock: &_m.Mock}
+}
+
+// Generate provides a mock function with given fields: data
+func (_m *MockAgreementLetterGenerator) Generate(data agreement.LetterData) ([]byte, error) {
+	ret := _m.Called(data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Generate")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(agreement.LetterData) ([]byte, error)); ok {
+		return rf(data)
+	}
+	if rf, ok := ret.Get(0).(func(agreement.LetterData) []byte); ok {
+		r0 = rf(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(agreement.LetterData) error); ok {
+		r1 = rf(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAgreementLetterGenerator_Generate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Generate'
+type MockAgreementLetterGenerator_Generate_Call struct {
+	*mock.Call
+}
+
+// Generate is a helper method to define mock.On call
+//   - data agreement.LetterData
+func (_e *MockAgreementLetterGenerator_Expecter) Generate(data interface{}) *MockAgreementLetterGenerator_Generate_Call {
+	return &MockAgreementLetterGenerator_Generate_Call{Call: _e.mock.On("Generate", data)}
+}
+
+func (_c *MockAgreementLetterGenerator_Generate_Call) Run(run func(data agreement.LetterData)) *MockAgreementLetterGenerator_Generate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(agreement.LetterData))
+	})
+	return _c
+}
+
+func (_c *MockAgreementLetterGenerator_Generate_Call) Return(_a0 []byte, _a1 error) *MockAgreementLetterGenerator_Generate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAgreementLetterGenerator_Generate_Call) RunAndReturn(run func(agreement.LetterData) ([]byte, error)) *MockAgreementLetterGenerator_Generate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAgreementLetterGenerator creates a new instance of MockAgreementLetterGenerator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAgreementLetterGenerator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAgreementLetterGenerator {
+	mock := &MockAgreementLetterGenerator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,254 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockBookStore is an autogenerated mock type for the BookStore type
+type MockBookStore struct {
+	mock.Mock
+}
+
+type MockBookStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBookStore) EXPECT() *MockBookStore_Expecter {
+	return &MockBookStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLedgerAccount provides a mock function with given fields: ctx, opts
+func (_m *MockBookStore) GetLedgerAccount(ctx context.Context, opts ...gateway.GetLedgerAccountOption) (sqlentity.LedgerAccounts, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerAccount")
+	}
+
+	var r0 sqlentity.LedgerAccounts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLedgerAccountOption) (sqlentity.LedgerAccounts, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLedgerAccountOption) sqlentity.LedgerAccounts); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LedgerAccounts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLedgerAccountOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBookStore_GetLedgerAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLedgerAccount'
+type MockBookStore_GetLedgerAccount_Call struct {
+	*mock.Call
+}
+
+// GetLedgerAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLedgerAccountOption
+func (_e *MockBookStore_Expecter) GetLedgerAccount(ctx interface{}, opts ...interface{}) *MockBookStore_GetLedgerAccount_Call {
+	return &MockBookStore_GetLedgerAccount_Call{Call: _e.mock.On("GetLedgerAccount",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockBookStore_GetLedgerAccount_Call) Run(run func(ctx context.Context, opts ...gateway.GetLedgerAccountOption)) *MockBookStore_GetLedgerAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLedgerAccountOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLedgerAccountOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockBookStore_GetLedgerAccount_Call) Return(_a0 sqlentity.LedgerAccounts, _a1 error) *MockBookStore_GetLedgerAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBookStore_GetLedgerAccount_Call) RunAndReturn(run func(context.Context, ...gateway.GetLedgerAccountOption) (sqlentity.LedgerAccounts, error)) *MockBookStore_GetLedgerAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLedgerAccounts provides a mock function with given fields: ctx, in
+func (_m *MockBookStore) InsertLedgerAccounts(ctx context.Context, in sqlentity.LedgerAccounts) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLedgerAccounts")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LedgerAccounts) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBookStore_InsertLedgerAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLedgerAccounts'
+type MockBookStore_InsertLedgerAccounts_Call struct {
+	*mock.Call
+}
+
+// InsertLedgerAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LedgerAccounts
+func (_e *MockBookStore_Expecter) InsertLedgerAccounts(ctx interface{}, in interface{}) *MockBookStore_InsertLedgerAccounts_Call {
+	return &MockBookStore_InsertLedgerAccounts_Call{Call: _e.mock.On("InsertLedgerAccounts", ctx, in)}
+}
+
+func (_c *MockBookStore_InsertLedgerAccounts_Call) Run(run func(ctx context.Context, in sqlentity.LedgerAccounts)) *MockBookStore_InsertLedgerAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LedgerAccounts))
+	})
+	return _c
+}
+
+func (_c *MockBookStore_InsertLedgerAccounts_Call) Return(_a0 error) *MockBookStore_InsertLedgerAccounts_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookStore_InsertLedgerAccounts_Call) RunAndReturn(run func(context.Context, sqlentity.LedgerAccounts) error) *MockBookStore_InsertLedgerAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLedgerEntry provides a mock function with given fields: ctx, in
+func (_m *MockBookStore) InsertLedgerEntry(ctx context.Context, in sqlentity.LedgerEntry) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLedgerEntry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LedgerEntry) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBookStore_InsertLedgerEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLedgerEntry'
+type MockBookStore_InsertLedgerEntry_Call struct {
+	*mock.Call
+}
+
+// InsertLedgerEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LedgerEntry
+func (_e *MockBookStore_Expecter) InsertLedgerEntry(ctx interface{}, in interface{}) *MockBookStore_InsertLedgerEntry_Call {
+	return &MockBookStore_InsertLedgerEntry_Call{Call: _e.mock.On("InsertLedgerEntry", ctx, in)}
+}
+
+func (_c *MockBookStore_InsertLedgerEntry_Call) Run(run func(ctx context.Context, in sqlentity.LedgerEntry)) *MockBookStore_InsertLedgerEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LedgerEntry))
+	})
+	return _c
+}
+
+func (_c *MockBookStore_InsertLedgerEntry_Call) Return(_a0 error) *MockBookStore_InsertLedgerEntry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookStore_InsertLedgerEntry_Call) RunAndReturn(run func(context.Context, sqlentity.LedgerEntry) error) *MockBookStore_InsertLedgerEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLedgerLines provides a mock function with given fields: ctx, in
+func (_m *MockBookStore) InsertLedgerLines(ctx context.Context, in sqlentity.LedgerLines) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLedgerLines")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LedgerLines) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockBookStore_InsertLedgerLines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLedgerLines'
+type MockBookStore_InsertLedgerLines_Call struct {
+	*mock.Call
+}
+
+// InsertLedgerLines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LedgerLines
+func (_e *MockBookStore_Expecter) InsertLedgerLines(ctx interface{}, in interface{}) *MockBookStore_InsertLedgerLines_Call {
+	return &MockBookStore_InsertLedgerLines_Call{Call: _e.mock.On("InsertLedgerLines", ctx, in)}
+}
+
+func (_c *MockBookStore_InsertLedgerLines_Call) Run(run func(ctx context.Context, in sqlentity.LedgerLines)) *MockBookStore_InsertLedgerLines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LedgerLines))
+	})
+	return _c
+}
+
+func (_c *MockBookStore_InsertLedgerLines_Call) Return(_a0 error) *MockBookStore_InsertLedgerLines_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockBookStore_InsertLedgerLines_Call) RunAndReturn(run func(context.Context, sqlentity.LedgerLines) error) *MockBookStore_InsertLedgerLines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBookStore creates a new instance of MockBookStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBookStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBookStore {
+	mock := &MockBookStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCancelLoanInvestment is an autogenerated mock type for the CancelLoanInvestment type
+type MockCancelLoanInvestment struct {
+	mock.Mock
+}
+
+type MockCancelLoanInvestment_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCancelLoanInvestment) EXPECT() *MockCancelLoanInvestment_Expecter {
+	return &MockCancelLoanInvestment_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockCancelLoanInvestment) Execute(ctx context.Context, in usecase.CancelLoanInvestmentInput) (*usecase.CancelLoanInvestmentOutput, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 *usecase.CancelLoanInvestmentOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.CancelLoanInvestmentInput) (*usecase.CancelLoanInvestmentOutput, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.CancelLoanInvestmentInput) *usecase.CancelLoanInvestmentOutput); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.CancelLoanInvestmentOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.CancelLoanInvestmentInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCancelLoanInvestment_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockCancelLoanInvestment_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.CancelLoanInvestmentInput
+func (_e *MockCancelLoanInvestment_Expecter) Execute(ctx interface{}, in interface{}) *MockCancelLoanInvestment_Execute_Call {
+	return &MockCancelLoanInvestment_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockCancelLoanInvestment_Execute_Call) Run(run func(ctx context.Context, in usecase.CancelLoanInvestmentInput)) *MockCancelLoanInvestment_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.CancelLoanInvestmentInput))
+	})
+	return _c
+}
+
+func (_c *MockCancelLoanInvestment_Execute_Call) Return(_a0 *usecase.CancelLoanInvestmentOutput, _a1 error) *MockCancelLoanInvestment_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCancelLoanInvestment_Execute_Call) RunAndReturn(run func(context.Context, usecase.CancelLoanInvestmentInput) (*usecase.CancelLoanInvestmentOutput, error)) *MockCancelLoanInvestment_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCancelLoanInvestment creates a new instance of MockCancelLoanInvestment. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCancelLoanInvestment(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCancelLoanInvestment {
+	mock := &MockCancelLoanInvestment{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,310 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockCancelLoanInvestmentStore is an autogenerated mock type for the CancelLoanInvestmentStore type
+type MockCancelLoanInvestmentStore struct {
+	mock.Mock
+}
+
+type MockCancelLoanInvestmentStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCancelLoanInvestmentStore) EXPECT() *MockCancelLoanInvestmentStore_Expecter {
+	return &MockCancelLoanInvestmentStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockCancelLoanInvestmentStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCancelLoanInvestmentStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockCancelLoanInvestmentStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockCancelLoanInvestmentStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockCancelLoanInvestmentStore_GetLoan_Call {
+	return &MockCancelLoanInvestmentStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockCancelLoanInvestmentStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockCancelLoanInvestmentStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockCancelLoanInvestmentStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockCancelLoanInvestmentStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockCancelLoanInvestmentStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCancelLoanInvestmentStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockCancelLoanInvestmentStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockCancelLoanInvestmentStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockCancelLoanInvestmentStore_GetLoanInvestment_Call {
+	return &MockCancelLoanInvestmentStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockCancelLoanInvestmentStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockCancelLoanInvestmentStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockCancelLoanInvestmentStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockCancelLoanInvestmentStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoan provides a mock function with given fields: ctx, in, opts
+func (_m *MockCancelLoanInvestmentStore) UpdateLoan(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCancelLoanInvestmentStore_UpdateLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoan'
+type MockCancelLoanInvestmentStore_UpdateLoan_Call struct {
+	*mock.Call
+}
+
+// UpdateLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanOption
+func (_e *MockCancelLoanInvestmentStore_Expecter) UpdateLoan(ctx interface{}, in interface{}, opts ...interface{}) *MockCancelLoanInvestmentStore_UpdateLoan_Call {
+	return &MockCancelLoanInvestmentStore_UpdateLoan_Call{Call: _e.mock.On("UpdateLoan",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockCancelLoanInvestmentStore_UpdateLoan_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption)) *MockCancelLoanInvestmentStore_UpdateLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_UpdateLoan_Call) Return(_a0 error) *MockCancelLoanInvestmentStore_UpdateLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_UpdateLoan_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error) *MockCancelLoanInvestmentStore_UpdateLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoanInvestment provides a mock function with given fields: ctx, in, opts
+func (_m *MockCancelLoanInvestmentStore) UpdateLoanInvestment(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInvestmentOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoanInvestment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInvestmentOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoanInvestment'
+type MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// UpdateLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanInvestmentOption
+func (_e *MockCancelLoanInvestmentStore_Expecter) UpdateLoanInvestment(ctx interface{}, in interface{}, opts ...interface{}) *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call {
+	return &MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call{Call: _e.mock.On("UpdateLoanInvestment",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInvestmentOption)) *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanInvestmentOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call) Return(_a0 error) *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInvestmentOption) error) *MockCancelLoanInvestmentStore_UpdateLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCancelLoanInvestmentStore creates a new instance of MockCancelLoanInvestmentStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCancelLoanInvestmentStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCancelLoanInvestmentStore {
+	mock := &MockCancelLoanInvestmentStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCaptureWalletReservations is an autogenerated mock type for the CaptureWalletReservations type
+type MockCaptureWalletReservations struct {
+	mock.Mock
+}
+
+type MockCaptureWalletReservations_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCaptureWalletReservations) EXPECT() *MockCaptureWalletReservations_Expecter {
+	return &MockCaptureWalletReservations_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockCaptureWalletReservations) Execute(ctx context.Context, in usecase.CaptureWalletReservationsInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.CaptureWalletReservationsInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCaptureWalletReservations_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockCaptureWalletReservations_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.CaptureWalletReservationsInput
+func (_e *MockCaptureWalletReservations_Expecter) Execute(ctx interface{}, in interface{}) *MockCaptureWalletReservations_Execute_Call {
+	return &MockCaptureWalletReservations_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockCaptureWalletReservations_Execute_Call) Run(run func(ctx context.Context, in usecase.CaptureWalletReservationsInput)) *MockCaptureWalletReservations_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.CaptureWalletReservationsInput))
+	})
+	return _c
+}
+
+func (_c *MockCaptureWalletReservations_Execute_Call) Return(_a0 error) *MockCaptureWalletReservations_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCaptureWalletReservations_Execute_Call) RunAndReturn(run func(context.Context, usecase.CaptureWalletReservationsInput) error) *MockCaptureWalletReservations_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCaptureWalletReservations creates a new instance of MockCaptureWalletReservations. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCaptureWalletReservations(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCaptureWalletReservations {
+	mock := &MockCaptureWalletReservations{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
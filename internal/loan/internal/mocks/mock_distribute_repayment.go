@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockDistributeRepayment is an autogenerated mock type for the DistributeRepayment type
+type MockDistributeRepayment struct {
+	mock.Mock
+}
+
+type MockDistributeRepayment_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDistributeRepayment) EXPECT() *MockDistributeRepayment_Expecter {
+	return &MockDistributeRepayment_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockDistributeRepayment) Execute(ctx context.Context, in usecase.DistributeRepaymentInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.DistributeRepaymentInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDistributeRepayment_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockDistributeRepayment_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.DistributeRepaymentInput
+func (_e *MockDistributeRepayment_Expecter) Execute(ctx interface{}, in interface{}) *MockDistributeRepayment_Execute_Call {
+	return &MockDistributeRepayment_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockDistributeRepayment_Execute_Call) Run(run func(ctx context.Context, in usecase.DistributeRepaymentInput)) *MockDistributeRepayment_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.DistributeRepaymentInput))
+	})
+	return _c
+}
+
+func (_c *MockDistributeRepayment_Execute_Call) Return(_a0 error) *MockDistributeRepayment_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDistributeRepayment_Execute_Call) RunAndReturn(run func(context.Context, usecase.DistributeRepaymentInput) error) *MockDistributeRepayment_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDistributeRepayment creates a new instance of MockDistributeRepayment. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDistributeRepayment(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDistributeRepayment {
+	mock := &MockDistributeRepayment{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,306 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockDistributeRepaymentStore is an autogenerated mock type for the DistributeRepaymentStore type
+type MockDistributeRepaymentStore struct {
+	mock.Mock
+}
+
+type MockDistributeRepaymentStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDistributeRepaymentStore) EXPECT() *MockDistributeRepaymentStore_Expecter {
+	return &MockDistributeRepaymentStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockDistributeRepaymentStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDistributeRepaymentStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockDistributeRepaymentStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockDistributeRepaymentStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockDistributeRepaymentStore_GetLoanInvestment_Call {
+	return &MockDistributeRepaymentStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockDistributeRepaymentStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockDistributeRepaymentStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockDistributeRepaymentStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestorCredit provides a mock function with given fields: ctx, opts
+func (_m *MockDistributeRepaymentStore) GetLoanInvestorCredit(ctx context.Context, opts ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestorCredit")
+	}
+
+	var r0 sqlentity.LoanInvestorCredits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) sqlentity.LoanInvestorCredits); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestorCredits)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDistributeRepaymentStore_GetLoanInvestorCredit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestorCredit'
+type MockDistributeRepaymentStore_GetLoanInvestorCredit_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestorCredit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestorCreditOption
+func (_e *MockDistributeRepaymentStore_Expecter) GetLoanInvestorCredit(ctx interface{}, opts ...interface{}) *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call {
+	return &MockDistributeRepaymentStore_GetLoanInvestorCredit_Call{Call: _e.mock.On("GetLoanInvestorCredit",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestorCreditOption)) *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestorCreditOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestorCreditOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call) Return(_a0 sqlentity.LoanInvestorCredits, _a1 error) *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error)) *MockDistributeRepaymentStore_GetLoanInvestorCredit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanRepayment provides a mock function with given fields: ctx, opts
+func (_m *MockDistributeRepaymentStore) GetLoanRepayment(ctx context.Context, opts ...gateway.GetLoanRepaymentOption) (sqlentity.LoanRepayments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanRepayment")
+	}
+
+	var r0 sqlentity.LoanRepayments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanRepaymentOption) (sqlentity.LoanRepayments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanRepaymentOption) sqlentity.LoanRepayments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanRepayments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanRepaymentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDistributeRepaymentStore_GetLoanRepayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanRepayment'
+type MockDistributeRepaymentStore_GetLoanRepayment_Call struct {
+	*mock.Call
+}
+
+// GetLoanRepayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanRepaymentOption
+func (_e *MockDistributeRepaymentStore_Expecter) GetLoanRepayment(ctx interface{}, opts ...interface{}) *MockDistributeRepaymentStore_GetLoanRepayment_Call {
+	return &MockDistributeRepaymentStore_GetLoanRepayment_Call{Call: _e.mock.On("GetLoanRepayment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanRepayment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanRepaymentOption)) *MockDistributeRepaymentStore_GetLoanRepayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanRepaymentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanRepaymentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanRepayment_Call) Return(_a0 sqlentity.LoanRepayments, _a1 error) *MockDistributeRepaymentStore_GetLoanRepayment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_GetLoanRepayment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanRepaymentOption) (sqlentity.LoanRepayments, error)) *MockDistributeRepaymentStore_GetLoanRepayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanInvestorCredits provides a mock function with given fields: ctx, in
+func (_m *MockDistributeRepaymentStore) InsertLoanInvestorCredits(ctx context.Context, in sqlentity.LoanInvestorCredits) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanInvestorCredits")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanInvestorCredits) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanInvestorCredits'
+type MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call struct {
+	*mock.Call
+}
+
+// InsertLoanInvestorCredits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanInvestorCredits
+func (_e *MockDistributeRepaymentStore_Expecter) InsertLoanInvestorCredits(ctx interface{}, in interface{}) *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call {
+	return &MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call{Call: _e.mock.On("InsertLoanInvestorCredits", ctx, in)}
+}
+
+func (_c *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call) Run(run func(ctx context.Context, in sqlentity.LoanInvestorCredits)) *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanInvestorCredits))
+	})
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call) Return(_a0 error) *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call) RunAndReturn(run func(context.Context, sqlentity.LoanInvestorCredits) error) *MockDistributeRepaymentStore_InsertLoanInvestorCredits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDistributeRepaymentStore creates a new instance of MockDistributeRepaymentStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDistributeRepaymentStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDistributeRepaymentStore {
+	mock := &MockDistributeRepaymentStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockExpireLoans is an autogenerated mock type for the ExpireLoans type
+type MockExpireLoans struct {
+	mock.Mock
+}
+
+type MockExpireLoans_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExpireLoans) EXPECT() *MockExpireLoans_Expecter {
+	return &MockExpireLoans_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx
+func (_m *MockExpireLoans) Execute(ctx context.Context) (*usecase.ExpireLoansOutput, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 *usecase.ExpireLoansOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*usecase.ExpireLoansOutput, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *usecase.ExpireLoansOutput); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.ExpireLoansOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExpireLoans_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockExpireLoans_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockExpireLoans_Expecter) Execute(ctx interface{}) *MockExpireLoans_Execute_Call {
+	return &MockExpireLoans_Execute_Call{Call: _e.mock.On("Execute", ctx)}
+}
+
+func (_c *MockExpireLoans_Execute_Call) Run(run func(ctx context.Context)) *MockExpireLoans_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockExpireLoans_Execute_Call) Return(_a0 *usecase.ExpireLoansOutput, _a1 error) *MockExpireLoans_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExpireLoans_Execute_Call) RunAndReturn(run func(context.Context) (*usecase.ExpireLoansOutput, error)) *MockExpireLoans_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExpireLoans creates a new instance of MockExpireLoans. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExpireLoans(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExpireLoans {
+	mock := &MockExpireLoans{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,284 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockExpireLoansStore is an autogenerated mock type for the ExpireLoansStore type
+type MockExpireLoansStore struct {
+	mock.Mock
+}
+
+type MockExpireLoansStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExpireLoansStore) EXPECT() *MockExpireLoansStore_Expecter {
+	return &MockExpireLoansStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockExpireLoansStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockExpireLoansStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockExpireLoansStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockExpireLoansStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockExpireLoansStore_GetLoan_Call {
+	return &MockExpireLoansStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockExpireLoansStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockExpireLoansStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockExpireLoansStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockExpireLoansStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockExpireLoansStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockExpireLoansStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanStatusHistory provides a mock function with given fields: ctx, in
+func (_m *MockExpireLoansStore) InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanStatusHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanStatusHistory) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExpireLoansStore_InsertLoanStatusHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanStatusHistory'
+type MockExpireLoansStore_InsertLoanStatusHistory_Call struct {
+	*mock.Call
+}
+
+// InsertLoanStatusHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanStatusHistory
+func (_e *MockExpireLoansStore_Expecter) InsertLoanStatusHistory(ctx interface{}, in interface{}) *MockExpireLoansStore_InsertLoanStatusHistory_Call {
+	return &MockExpireLoansStore_InsertLoanStatusHistory_Call{Call: _e.mock.On("InsertLoanStatusHistory", ctx, in)}
+}
+
+func (_c *MockExpireLoansStore_InsertLoanStatusHistory_Call) Run(run func(ctx context.Context, in sqlentity.LoanStatusHistory)) *MockExpireLoansStore_InsertLoanStatusHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanStatusHistory))
+	})
+	return _c
+}
+
+func (_c *MockExpireLoansStore_InsertLoanStatusHistory_Call) Return(_a0 error) *MockExpireLoansStore_InsertLoanStatusHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExpireLoansStore_InsertLoanStatusHistory_Call) RunAndReturn(run func(context.Context, sqlentity.LoanStatusHistory) error) *MockExpireLoansStore_InsertLoanStatusHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoan provides a mock function with given fields: ctx, in, opts
+func (_m *MockExpireLoansStore) UpdateLoan(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExpireLoansStore_UpdateLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoan'
+type MockExpireLoansStore_UpdateLoan_Call struct {
+	*mock.Call
+}
+
+// UpdateLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanOption
+func (_e *MockExpireLoansStore_Expecter) UpdateLoan(ctx interface{}, in interface{}, opts ...interface{}) *MockExpireLoansStore_UpdateLoan_Call {
+	return &MockExpireLoansStore_UpdateLoan_Call{Call: _e.mock.On("UpdateLoan",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockExpireLoansStore_UpdateLoan_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption)) *MockExpireLoansStore_UpdateLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockExpireLoansStore_UpdateLoan_Call) Return(_a0 error) *MockExpireLoansStore_UpdateLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExpireLoansStore_UpdateLoan_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error) *MockExpireLoansStore_UpdateLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoanInvestment provides a mock function with given fields: ctx, in, opts
+func (_m *MockExpireLoansStore) UpdateLoanInvestment(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInvestmentOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoanInvestment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInvestmentOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockExpireLoansStore_UpdateLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoanInvestment'
+type MockExpireLoansStore_UpdateLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// UpdateLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanInvestmentOption
+func (_e *MockExpireLoansStore_Expecter) UpdateLoanInvestment(ctx interface{}, in interface{}, opts ...interface{}) *MockExpireLoansStore_UpdateLoanInvestment_Call {
+	return &MockExpireLoansStore_UpdateLoanInvestment_Call{Call: _e.mock.On("UpdateLoanInvestment",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockExpireLoansStore_UpdateLoanInvestment_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInvestmentOption)) *MockExpireLoansStore_UpdateLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanInvestmentOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockExpireLoansStore_UpdateLoanInvestment_Call) Return(_a0 error) *MockExpireLoansStore_UpdateLoanInvestment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockExpireLoansStore_UpdateLoanInvestment_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInvestmentOption) error) *MockExpireLoansStore_UpdateLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockExpireLoansStore creates a new instance of MockExpireLoansStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExpireLoansStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExpireLoansStore {
+	mock := &MockExpireLoansStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
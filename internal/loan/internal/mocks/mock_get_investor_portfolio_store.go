@@ -0,0 +1,259 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockGetInvestorPortfolioStore is an autogenerated mock type for the GetInvestorPortfolioStore type
+type MockGetInvestorPortfolioStore struct {
+	mock.Mock
+}
+
+type MockGetInvestorPortfolioStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetInvestorPortfolioStore) EXPECT() *MockGetInvestorPortfolioStore_Expecter {
+	return &MockGetInvestorPortfolioStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockGetInvestorPortfolioStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetInvestorPortfolioStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockGetInvestorPortfolioStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockGetInvestorPortfolioStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockGetInvestorPortfolioStore_GetLoan_Call {
+	return &MockGetInvestorPortfolioStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockGetInvestorPortfolioStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockGetInvestorPortfolioStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockGetInvestorPortfolioStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockGetInvestorPortfolioStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetInvestorPortfolioStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockGetInvestorPortfolioStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockGetInvestorPortfolioStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockGetInvestorPortfolioStore_GetLoanInvestment_Call {
+	return &MockGetInvestorPortfolioStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockGetInvestorPortfolioStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockGetInvestorPortfolioStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockGetInvestorPortfolioStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestorCredit provides a mock function with given fields: ctx, opts
+func (_m *MockGetInvestorPortfolioStore) GetLoanInvestorCredit(ctx context.Context, opts ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestorCredit")
+	}
+
+	var r0 sqlentity.LoanInvestorCredits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) sqlentity.LoanInvestorCredits); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestorCredits)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestorCredit'
+type MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestorCredit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestorCreditOption
+func (_e *MockGetInvestorPortfolioStore_Expecter) GetLoanInvestorCredit(ctx interface{}, opts ...interface{}) *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call {
+	return &MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call{Call: _e.mock.On("GetLoanInvestorCredit",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestorCreditOption)) *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestorCreditOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestorCreditOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call) Return(_a0 sqlentity.LoanInvestorCredits, _a1 error) *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error)) *MockGetInvestorPortfolioStore_GetLoanInvestorCredit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetInvestorPortfolioStore creates a new instance of MockGetInvestorPortfolioStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetInvestorPortfolioStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetInvestorPortfolioStore {
+	mock := &MockGetInvestorPortfolioStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetLedgerTrialBalance is an autogenerated mock type for the GetLedgerTrialBalance type
+type MockGetLedgerTrialBalance struct {
+	mock.Mock
+}
+
+type MockGetLedgerTrialBalance_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetLedgerTrialBalance) EXPECT() *MockGetLedgerTrialBalance_Expecter {
+	return &MockGetLedgerTrialBalance_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockGetLedgerTrialBalance) Execute(ctx context.Context, in usecase.GetLedgerTrialBalanceInput) (*usecase.GetLedgerTrialBalanceOutput, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 *usecase.GetLedgerTrialBalanceOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.GetLedgerTrialBalanceInput) (*usecase.GetLedgerTrialBalanceOutput, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.GetLedgerTrialBalanceInput) *usecase.GetLedgerTrialBalanceOutput); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.GetLedgerTrialBalanceOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.GetLedgerTrialBalanceInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLedgerTrialBalance_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockGetLedgerTrialBalance_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.GetLedgerTrialBalanceInput
+func (_e *MockGetLedgerTrialBalance_Expecter) Execute(ctx interface{}, in interface{}) *MockGetLedgerTrialBalance_Execute_Call {
+	return &MockGetLedgerTrialBalance_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockGetLedgerTrialBalance_Execute_Call) Run(run func(ctx context.Context, in usecase.GetLedgerTrialBalanceInput)) *MockGetLedgerTrialBalance_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.GetLedgerTrialBalanceInput))
+	})
+	return _c
+}
+
+func (_c *MockGetLedgerTrialBalance_Execute_Call) Return(_a0 *usecase.GetLedgerTrialBalanceOutput, _a1 error) *MockGetLedgerTrialBalance_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLedgerTrialBalance_Execute_Call) RunAndReturn(run func(context.Context, usecase.GetLedgerTrialBalanceInput) (*usecase.GetLedgerTrialBalanceOutput, error)) *MockGetLedgerTrialBalance_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetLedgerTrialBalance creates a new instance of MockGetLedgerTrialBalance. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetLedgerTrialBalance(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetLedgerTrialBalance {
+	mock := &MockGetLedgerTrialBalance{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockGetLedgerTrialBalanceStore is an autogenerated mock type for the GetLedgerTrialBalanceStore type
+type MockGetLedgerTrialBalanceStore struct {
+	mock.Mock
+}
+
+type MockGetLedgerTrialBalanceStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetLedgerTrialBalanceStore) EXPECT() *MockGetLedgerTrialBalanceStore_Expecter {
+	return &MockGetLedgerTrialBalanceStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLedgerTrialBalance provides a mock function with given fields: ctx
+func (_m *MockGetLedgerTrialBalanceStore) GetLedgerTrialBalance(ctx context.Context) (sqlentity.LedgerAccountBalances, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLedgerTrialBalance")
+	}
+
+	var r0 sqlentity.LedgerAccountBalances
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (sqlentity.LedgerAccountBalances, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) sqlentity.LedgerAccountBalances); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LedgerAccountBalances)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLedgerTrialBalance'
+type MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call struct {
+	*mock.Call
+}
+
+// GetLedgerTrialBalance is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockGetLedgerTrialBalanceStore_Expecter) GetLedgerTrialBalance(ctx interface{}) *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call {
+	return &MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call{Call: _e.mock.On("GetLedgerTrialBalance", ctx)}
+}
+
+func (_c *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call) Run(run func(ctx context.Context)) *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call) Return(_a0 sqlentity.LedgerAccountBalances, _a1 error) *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call) RunAndReturn(run func(context.Context) (sqlentity.LedgerAccountBalances, error)) *MockGetLedgerTrialBalanceStore_GetLedgerTrialBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetLedgerTrialBalanceStore creates a new instance of MockGetLedgerTrialBalanceStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetLedgerTrialBalanceStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetLedgerTrialBalanceStore {
+	mock := &MockGetLedgerTrialBalanceStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
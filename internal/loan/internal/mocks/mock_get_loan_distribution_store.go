@@ -0,0 +1,332 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockGetLoanDistributionStore is an autogenerated mock type for the GetLoanDistributionStore type
+type MockGetLoanDistributionStore struct {
+	mock.Mock
+}
+
+type MockGetLoanDistributionStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetLoanDistributionStore) EXPECT() *MockGetLoanDistributionStore_Expecter {
+	return &MockGetLoanDistributionStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanDistributionStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanDistributionStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockGetLoanDistributionStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockGetLoanDistributionStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockGetLoanDistributionStore_GetLoan_Call {
+	return &MockGetLoanDistributionStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockGetLoanDistributionStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockGetLoanDistributionStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockGetLoanDistributionStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInstallment provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanDistributionStore) GetLoanInstallment(ctx context.Context, opts ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInstallment")
+	}
+
+	var r0 sqlentity.LoanInstallments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInstallmentOption) sqlentity.LoanInstallments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInstallments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInstallmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanDistributionStore_GetLoanInstallment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInstallment'
+type MockGetLoanDistributionStore_GetLoanInstallment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInstallment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInstallmentOption
+func (_e *MockGetLoanDistributionStore_Expecter) GetLoanInstallment(ctx interface{}, opts ...interface{}) *MockGetLoanDistributionStore_GetLoanInstallment_Call {
+	return &MockGetLoanDistributionStore_GetLoanInstallment_Call{Call: _e.mock.On("GetLoanInstallment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInstallment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInstallmentOption)) *MockGetLoanDistributionStore_GetLoanInstallment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInstallmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInstallmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInstallment_Call) Return(_a0 sqlentity.LoanInstallments, _a1 error) *MockGetLoanDistributionStore_GetLoanInstallment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInstallment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error)) *MockGetLoanDistributionStore_GetLoanInstallment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanDistributionStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanDistributionStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockGetLoanDistributionStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockGetLoanDistributionStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockGetLoanDistributionStore_GetLoanInvestment_Call {
+	return &MockGetLoanDistributionStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockGetLoanDistributionStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockGetLoanDistributionStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockGetLoanDistributionStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestorCredit provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanDistributionStore) GetLoanInvestorCredit(ctx context.Context, opts ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestorCredit")
+	}
+
+	var r0 sqlentity.LoanInvestorCredits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) sqlentity.LoanInvestorCredits); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestorCredits)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestorCreditOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanDistributionStore_GetLoanInvestorCredit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestorCredit'
+type MockGetLoanDistributionStore_GetLoanInvestorCredit_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestorCredit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestorCreditOption
+func (_e *MockGetLoanDistributionStore_Expecter) GetLoanInvestorCredit(ctx interface{}, opts ...interface{}) *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call {
+	return &MockGetLoanDistributionStore_GetLoanInvestorCredit_Call{Call: _e.mock.On("GetLoanInvestorCredit",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestorCreditOption)) *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestorCreditOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestorCreditOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call) Return(_a0 sqlentity.LoanInvestorCredits, _a1 error) *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestorCreditOption) (sqlentity.LoanInvestorCredits, error)) *MockGetLoanDistributionStore_GetLoanInvestorCredit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetLoanDistributionStore creates a new instance of MockGetLoanDistributionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetLoanDistributionStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetLoanDistributionStore {
+	mock := &MockGetLoanDistributionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
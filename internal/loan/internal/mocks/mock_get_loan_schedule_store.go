@@ -0,0 +1,259 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockGetLoanScheduleStore is an autogenerated mock type for the GetLoanScheduleStore type
+type MockGetLoanScheduleStore struct {
+	mock.Mock
+}
+
+type MockGetLoanScheduleStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetLoanScheduleStore) EXPECT() *MockGetLoanScheduleStore_Expecter {
+	return &MockGetLoanScheduleStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanScheduleStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanScheduleStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockGetLoanScheduleStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockGetLoanScheduleStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockGetLoanScheduleStore_GetLoan_Call {
+	return &MockGetLoanScheduleStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockGetLoanScheduleStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockGetLoanScheduleStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockGetLoanScheduleStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInstallment provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanScheduleStore) GetLoanInstallment(ctx context.Context, opts ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInstallment")
+	}
+
+	var r0 sqlentity.LoanInstallments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInstallmentOption) sqlentity.LoanInstallments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInstallments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInstallmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanScheduleStore_GetLoanInstallment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInstallment'
+type MockGetLoanScheduleStore_GetLoanInstallment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInstallment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInstallmentOption
+func (_e *MockGetLoanScheduleStore_Expecter) GetLoanInstallment(ctx interface{}, opts ...interface{}) *MockGetLoanScheduleStore_GetLoanInstallment_Call {
+	return &MockGetLoanScheduleStore_GetLoanInstallment_Call{Call: _e.mock.On("GetLoanInstallment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoanInstallment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInstallmentOption)) *MockGetLoanScheduleStore_GetLoanInstallment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInstallmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInstallmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoanInstallment_Call) Return(_a0 sqlentity.LoanInstallments, _a1 error) *MockGetLoanScheduleStore_GetLoanInstallment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoanInstallment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error)) *MockGetLoanScheduleStore_GetLoanInstallment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanScheduleStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanScheduleStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockGetLoanScheduleStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockGetLoanScheduleStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockGetLoanScheduleStore_GetLoanInvestment_Call {
+	return &MockGetLoanScheduleStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockGetLoanScheduleStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockGetLoanScheduleStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanScheduleStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockGetLoanScheduleStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetLoanScheduleStore creates a new instance of MockGetLoanScheduleStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetLoanScheduleStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetLoanScheduleStore {
+	mock := &MockGetLoanScheduleStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
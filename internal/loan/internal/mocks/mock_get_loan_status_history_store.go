@@ -0,0 +1,259 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockGetLoanStatusHistoryStore is an autogenerated mock type for the GetLoanStatusHistoryStore type
+type MockGetLoanStatusHistoryStore struct {
+	mock.Mock
+}
+
+type MockGetLoanStatusHistoryStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetLoanStatusHistoryStore) EXPECT() *MockGetLoanStatusHistoryStore_Expecter {
+	return &MockGetLoanStatusHistoryStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanStatusHistoryStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanStatusHistoryStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockGetLoanStatusHistoryStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockGetLoanStatusHistoryStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockGetLoanStatusHistoryStore_GetLoan_Call {
+	return &MockGetLoanStatusHistoryStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockGetLoanStatusHistoryStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockGetLoanStatusHistoryStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockGetLoanStatusHistoryStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanStatusHistoryStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanStatusHistoryStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockGetLoanStatusHistoryStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockGetLoanStatusHistoryStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call {
+	return &MockGetLoanStatusHistoryStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockGetLoanStatusHistoryStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanStatusHistory provides a mock function with given fields: ctx, opts
+func (_m *MockGetLoanStatusHistoryStore) GetLoanStatusHistory(ctx context.Context, opts ...gateway.GetLoanStatusHistoryOption) (sqlentity.LoanStatusHistories, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanStatusHistory")
+	}
+
+	var r0 sqlentity.LoanStatusHistories
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanStatusHistoryOption) (sqlentity.LoanStatusHistories, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanStatusHistoryOption) sqlentity.LoanStatusHistories); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanStatusHistories)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanStatusHistoryOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanStatusHistory'
+type MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call struct {
+	*mock.Call
+}
+
+// GetLoanStatusHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanStatusHistoryOption
+func (_e *MockGetLoanStatusHistoryStore_Expecter) GetLoanStatusHistory(ctx interface{}, opts ...interface{}) *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call {
+	return &MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call{Call: _e.mock.On("GetLoanStatusHistory",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanStatusHistoryOption)) *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanStatusHistoryOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanStatusHistoryOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call) Return(_a0 sqlentity.LoanStatusHistories, _a1 error) *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanStatusHistoryOption) (sqlentity.LoanStatusHistories, error)) *MockGetLoanStatusHistoryStore_GetLoanStatusHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetLoanStatusHistoryStore creates a new instance of MockGetLoanStatusHistoryStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetLoanStatusHistoryStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetLoanStatusHistoryStore {
+	mock := &MockGetLoanStatusHistoryStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
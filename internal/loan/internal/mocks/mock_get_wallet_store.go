@@ -0,0 +1,113 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockGetWalletStore is an autogenerated mock type for the GetWalletStore type
+type MockGetWalletStore struct {
+	mock.Mock
+}
+
+type MockGetWalletStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetWalletStore) EXPECT() *MockGetWalletStore_Expecter {
+	return &MockGetWalletStore_Expecter{mock: &_m.Mock}
+}
+
+// GetWallet provides a mock function with given fields: ctx, opts
+func (_m *MockGetWalletStore) GetWallet(ctx context.Context, opts ...gateway.GetWalletOption) (sqlentity.Wallets, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWallet")
+	}
+
+	var r0 sqlentity.Wallets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWalletOption) (sqlentity.Wallets, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWalletOption) sqlentity.Wallets); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Wallets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWalletOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetWalletStore_GetWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWallet'
+type MockGetWalletStore_GetWallet_Call struct {
+	*mock.Call
+}
+
+// GetWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWalletOption
+func (_e *MockGetWalletStore_Expecter) GetWallet(ctx interface{}, opts ...interface{}) *MockGetWalletStore_GetWallet_Call {
+	return &MockGetWalletStore_GetWallet_Call{Call: _e.mock.On("GetWallet",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockGetWalletStore_GetWallet_Call) Run(run func(ctx context.Context, opts ...gateway.GetWalletOption)) *MockGetWalletStore_GetWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWalletOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWalletOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockGetWalletStore_GetWallet_Call) Return(_a0 sqlentity.Wallets, _a1 error) *MockGetWalletStore_GetWallet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetWalletStore_GetWallet_Call) RunAndReturn(run func(context.Context, ...gateway.GetWalletOption) (sqlentity.Wallets, error)) *MockGetWalletStore_GetWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetWalletStore creates a new instance of MockGetWalletStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetWalletStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetWalletStore {
+	mock := &MockGetWalletStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIssueAgreementLetters is an autogenerated mock type for the IssueAgreementLetters type
+type MockIssueAgreementLetters struct {
+	mock.Mock
+}
+
+type MockIssueAgreementLetters_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIssueAgreementLetters) EXPECT() *MockIssueAgreementLetters_Expecter {
+	return &MockIssueAgreementLetters_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockIssueAgreementLetters) Execute(ctx context.Context, in usecase.IssueAgreementLettersInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.IssueAgreementLettersInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIssueAgreementLetters_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockIssueAgreementLetters_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.IssueAgreementLettersInput
+func (_e *MockIssueAgreementLetters_Expecter) Execute(ctx interface{}, in interface{}) *MockIssueAgreementLetters_Execute_Call {
+	return &MockIssueAgreementLetters_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockIssueAgreementLetters_Execute_Call) Run(run func(ctx context.Context, in usecase.IssueAgreementLettersInput)) *MockIssueAgreementLetters_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.IssueAgreementLettersInput))
+	})
+	return _c
+}
+
+func (_c *MockIssueAgreementLetters_Execute_Call) Return(_a0 error) *MockIssueAgreementLetters_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIssueAgreementLetters_Execute_Call) RunAndReturn(run func(context.Context, usecase.IssueAgreementLettersInput) error) *MockIssueAgreementLetters_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIssueAgreementLetters creates a new instance of MockIssueAgreementLetters. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIssueAgreementLetters(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIssueAgreementLetters {
+	mock := &MockIssueAgreementLetters{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,248 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockIssueAgreementLettersStore is an autogenerated mock type for the IssueAgreementLettersStore type
+type MockIssueAgreementLettersStore struct {
+	mock.Mock
+}
+
+type MockIssueAgreementLettersStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIssueAgreementLettersStore) EXPECT() *MockIssueAgreementLettersStore_Expecter {
+	return &MockIssueAgreementLettersStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockIssueAgreementLettersStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIssueAgreementLettersStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockIssueAgreementLettersStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockIssueAgreementLettersStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockIssueAgreementLettersStore_GetLoan_Call {
+	return &MockIssueAgreementLettersStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockIssueAgreementLettersStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockIssueAgreementLettersStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockIssueAgreementLettersStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockIssueAgreementLettersStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIssueAgreementLettersStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockIssueAgreementLettersStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockIssueAgreementLettersStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIssueAgreementLettersStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockIssueAgreementLettersStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockIssueAgreementLettersStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockIssueAgreementLettersStore_GetLoanInvestment_Call {
+	return &MockIssueAgreementLettersStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockIssueAgreementLettersStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockIssueAgreementLettersStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockIssueAgreementLettersStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockIssueAgreementLettersStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIssueAgreementLettersStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockIssueAgreementLettersStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoanInvestment provides a mock function with given fields: ctx, in, opts
+func (_m *MockIssueAgreementLettersStore) UpdateLoanInvestment(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInvestmentOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoanInvestment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInvestmentOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIssueAgreementLettersStore_UpdateLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoanInvestment'
+type MockIssueAgreementLettersStore_UpdateLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// UpdateLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanInvestmentOption
+func (_e *MockIssueAgreementLettersStore_Expecter) UpdateLoanInvestment(ctx interface{}, in interface{}, opts ...interface{}) *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call {
+	return &MockIssueAgreementLettersStore_UpdateLoanInvestment_Call{Call: _e.mock.On("UpdateLoanInvestment",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInvestmentOption)) *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanInvestmentOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call) Return(_a0 error) *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInvestmentOption) error) *MockIssueAgreementLettersStore_UpdateLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIssueAgreementLettersStore creates a new instance of MockIssueAgreementLettersStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIssueAgreementLettersStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIssueAgreementLettersStore {
+	mock := &MockIssueAgreementLettersStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,390 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	decimal "github.com/shopspring/decimal"
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockKeeper is an autogenerated mock type for the Keeper type
+type MockKeeper struct {
+	mock.Mock
+}
+
+type MockKeeper_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockKeeper) EXPECT() *MockKeeper_Expecter {
+	return &MockKeeper_Expecter{mock: &_m.Mock}
+}
+
+// Capture provides a mock function with given fields: ctx, loanID
+func (_m *MockKeeper) Capture(ctx context.Context, loanID uint64) error {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Capture")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKeeper_Capture_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Capture'
+type MockKeeper_Capture_Call struct {
+	*mock.Call
+}
+
+// Capture is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID uint64
+func (_e *MockKeeper_Expecter) Capture(ctx interface{}, loanID interface{}) *MockKeeper_Capture_Call {
+	return &MockKeeper_Capture_Call{Call: _e.mock.On("Capture", ctx, loanID)}
+}
+
+func (_c *MockKeeper_Capture_Call) Run(run func(ctx context.Context, loanID uint64)) *MockKeeper_Capture_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_Capture_Call) Return(_a0 error) *MockKeeper_Capture_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKeeper_Capture_Call) RunAndReturn(run func(context.Context, uint64) error) *MockKeeper_Capture_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Credit provides a mock function with given fields: ctx, credit
+func (_m *MockKeeper) Credit(ctx context.Context, credit sqlentity.LoanInvestorCredit) error {
+	ret := _m.Called(ctx, credit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Credit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanInvestorCredit) error); ok {
+		r0 = rf(ctx, credit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKeeper_Credit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Credit'
+type MockKeeper_Credit_Call struct {
+	*mock.Call
+}
+
+// Credit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credit sqlentity.LoanInvestorCredit
+func (_e *MockKeeper_Expecter) Credit(ctx interface{}, credit interface{}) *MockKeeper_Credit_Call {
+	return &MockKeeper_Credit_Call{Call: _e.mock.On("Credit", ctx, credit)}
+}
+
+func (_c *MockKeeper_Credit_Call) Run(run func(ctx context.Context, credit sqlentity.LoanInvestorCredit)) *MockKeeper_Credit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanInvestorCredit))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_Credit_Call) Return(_a0 error) *MockKeeper_Credit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKeeper_Credit_Call) RunAndReturn(run func(context.Context, sqlentity.LoanInvestorCredit) error) *MockKeeper_Credit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, loanID
+func (_m *MockKeeper) Release(ctx context.Context, loanID uint64) error {
+	ret := _m.Called(ctx, loanID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, loanID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKeeper_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockKeeper_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - loanID uint64
+func (_e *MockKeeper_Expecter) Release(ctx interface{}, loanID interface{}) *MockKeeper_Release_Call {
+	return &MockKeeper_Release_Call{Call: _e.mock.On("Release", ctx, loanID)}
+}
+
+func (_c *MockKeeper_Release_Call) Run(run func(ctx context.Context, loanID uint64)) *MockKeeper_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_Release_Call) Return(_a0 error) *MockKeeper_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKeeper_Release_Call) RunAndReturn(run func(context.Context, uint64) error) *MockKeeper_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseInvestment provides a mock function with given fields: ctx, investmentID
+func (_m *MockKeeper) ReleaseInvestment(ctx context.Context, investmentID uint64) error {
+	ret := _m.Called(ctx, investmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseInvestment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, investmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKeeper_ReleaseInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseInvestment'
+type MockKeeper_ReleaseInvestment_Call struct {
+	*mock.Call
+}
+
+// ReleaseInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - investmentID uint64
+func (_e *MockKeeper_Expecter) ReleaseInvestment(ctx interface{}, investmentID interface{}) *MockKeeper_ReleaseInvestment_Call {
+	return &MockKeeper_ReleaseInvestment_Call{Call: _e.mock.On("ReleaseInvestment", ctx, investmentID)}
+}
+
+func (_c *MockKeeper_ReleaseInvestment_Call) Run(run func(ctx context.Context, investmentID uint64)) *MockKeeper_ReleaseInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_ReleaseInvestment_Call) Return(_a0 error) *MockKeeper_ReleaseInvestment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKeeper_ReleaseInvestment_Call) RunAndReturn(run func(context.Context, uint64) error) *MockKeeper_ReleaseInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, investment
+func (_m *MockKeeper) Reserve(ctx context.Context, investment sqlentity.LoanInvestment) error {
+	ret := _m.Called(ctx, investment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanInvestment) error); ok {
+		r0 = rf(ctx, investment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockKeeper_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type MockKeeper_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - investment sqlentity.LoanInvestment
+func (_e *MockKeeper_Expecter) Reserve(ctx interface{}, investment interface{}) *MockKeeper_Reserve_Call {
+	return &MockKeeper_Reserve_Call{Call: _e.mock.On("Reserve", ctx, investment)}
+}
+
+func (_c *MockKeeper_Reserve_Call) Run(run func(ctx context.Context, investment sqlentity.LoanInvestment)) *MockKeeper_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanInvestment))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_Reserve_Call) Return(_a0 error) *MockKeeper_Reserve_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockKeeper_Reserve_Call) RunAndReturn(run func(context.Context, sqlentity.LoanInvestment) error) *MockKeeper_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TopUp provides a mock function with given fields: ctx, investorID, amount
+func (_m *MockKeeper) TopUp(ctx context.Context, investorID uint64, amount decimal.Decimal) (sqlentity.Wallet, error) {
+	ret := _m.Called(ctx, investorID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TopUp")
+	}
+
+	var r0 sqlentity.Wallet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, decimal.Decimal) (sqlentity.Wallet, error)); ok {
+		return rf(ctx, investorID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, decimal.Decimal) sqlentity.Wallet); ok {
+		r0 = rf(ctx, investorID, amount)
+	} else {
+		r0 = ret.Get(0).(sqlentity.Wallet)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, decimal.Decimal) error); ok {
+		r1 = rf(ctx, investorID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeeper_TopUp_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TopUp'
+type MockKeeper_TopUp_Call struct {
+	*mock.Call
+}
+
+// TopUp is a helper method to define mock.On call
+//   - ctx context.Context
+//   - investorID uint64
+//   - amount decimal.Decimal
+func (_e *MockKeeper_Expecter) TopUp(ctx interface{}, investorID interface{}, amount interface{}) *MockKeeper_TopUp_Call {
+	return &MockKeeper_TopUp_Call{Call: _e.mock.On("TopUp", ctx, investorID, amount)}
+}
+
+func (_c *MockKeeper_TopUp_Call) Run(run func(ctx context.Context, investorID uint64, amount decimal.Decimal)) *MockKeeper_TopUp_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(decimal.Decimal))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_TopUp_Call) Return(_a0 sqlentity.Wallet, _a1 error) *MockKeeper_TopUp_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeeper_TopUp_Call) RunAndReturn(run func(context.Context, uint64, decimal.Decimal) (sqlentity.Wallet, error)) *MockKeeper_TopUp_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Withdraw provides a mock function with given fields: ctx, investorID, amount
+func (_m *MockKeeper) Withdraw(ctx context.Context, investorID uint64, amount decimal.Decimal) (sqlentity.Wallet, error) {
+	ret := _m.Called(ctx, investorID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Withdraw")
+	}
+
+	var r0 sqlentity.Wallet
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, decimal.Decimal) (sqlentity.Wallet, error)); ok {
+		return rf(ctx, investorID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, decimal.Decimal) sqlentity.Wallet); ok {
+		r0 = rf(ctx, investorID, amount)
+	} else {
+		r0 = ret.Get(0).(sqlentity.Wallet)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, decimal.Decimal) error); ok {
+		r1 = rf(ctx, investorID, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeeper_Withdraw_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Withdraw'
+type MockKeeper_Withdraw_Call struct {
+	*mock.Call
+}
+
+// Withdraw is a helper method to define mock.On call
+//   - ctx context.Context
+//   - investorID uint64
+//   - amount decimal.Decimal
+func (_e *MockKeeper_Expecter) Withdraw(ctx interface{}, investorID interface{}, amount interface{}) *MockKeeper_Withdraw_Call {
+	return &MockKeeper_Withdraw_Call{Call: _e.mock.On("Withdraw", ctx, investorID, amount)}
+}
+
+func (_c *MockKeeper_Withdraw_Call) Run(run func(ctx context.Context, investorID uint64, amount decimal.Decimal)) *MockKeeper_Withdraw_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(decimal.Decimal))
+	})
+	return _c
+}
+
+func (_c *MockKeeper_Withdraw_Call) Return(_a0 sqlentity.Wallet, _a1 error) *MockKeeper_Withdraw_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeeper_Withdraw_Call) RunAndReturn(run func(context.Context, uint64, decimal.Decimal) (sqlentity.Wallet, error)) *MockKeeper_Withdraw_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockKeeper creates a new instance of MockKeeper. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockKeeper(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockKeeper {
+	mock := &MockKeeper{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
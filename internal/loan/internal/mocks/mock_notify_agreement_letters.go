@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/loan/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockNotifyAgreementLetters is an autogenerated mock type for the NotifyAgreementLetters type
+type MockNotifyAgreementLetters struct {
+	mock.Mock
+}
+
+type MockNotifyAgreementLetters_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifyAgreementLetters) EXPECT() *MockNotifyAgreementLetters_Expecter {
+	return &MockNotifyAgreementLetters_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockNotifyAgreementLetters) Execute(ctx context.Context, in usecase.NotifyAgreementLettersInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.NotifyAgreementLettersInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifyAgreementLetters_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockNotifyAgreementLetters_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.NotifyAgreementLettersInput
+func (_e *MockNotifyAgreementLetters_Expecter) Execute(ctx interface{}, in interface{}) *MockNotifyAgreementLetters_Execute_Call {
+	return &MockNotifyAgreementLetters_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockNotifyAgreementLetters_Execute_Call) Run(run func(ctx context.Context, in usecase.NotifyAgreementLettersInput)) *MockNotifyAgreementLetters_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.NotifyAgreementLettersInput))
+	})
+	return _c
+}
+
+func (_c *MockNotifyAgreementLetters_Execute_Call) Return(_a0 error) *MockNotifyAgreementLetters_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifyAgreementLetters_Execute_Call) RunAndReturn(run func(context.Context, usecase.NotifyAgreementLettersInput) error) *MockNotifyAgreementLetters_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotifyAgreementLetters creates a new instance of MockNotifyAgreementLetters. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifyAgreementLetters(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifyAgreementLetters {
+	mock := &MockNotifyAgreementLetters{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,368 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockNotifyAgreementLettersStore is an autogenerated mock type for the NotifyAgreementLettersStore type
+type MockNotifyAgreementLettersStore struct {
+	mock.Mock
+}
+
+type MockNotifyAgreementLettersStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifyAgreementLettersStore) EXPECT() *MockNotifyAgreementLettersStore_Expecter {
+	return &MockNotifyAgreementLettersStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockNotifyAgreementLettersStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotifyAgreementLettersStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockNotifyAgreementLettersStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockNotifyAgreementLettersStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockNotifyAgreementLettersStore_GetLoan_Call {
+	return &MockNotifyAgreementLettersStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockNotifyAgreementLettersStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockNotifyAgreementLettersStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockNotifyAgreementLettersStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInvestment provides a mock function with given fields: ctx, opts
+func (_m *MockNotifyAgreementLettersStore) GetLoanInvestment(ctx context.Context, opts ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInvestment")
+	}
+
+	var r0 sqlentity.LoanInvestments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInvestmentOption) sqlentity.LoanInvestments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInvestments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInvestmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotifyAgreementLettersStore_GetLoanInvestment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInvestment'
+type MockNotifyAgreementLettersStore_GetLoanInvestment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInvestment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInvestmentOption
+func (_e *MockNotifyAgreementLettersStore_Expecter) GetLoanInvestment(ctx interface{}, opts ...interface{}) *MockNotifyAgreementLettersStore_GetLoanInvestment_Call {
+	return &MockNotifyAgreementLettersStore_GetLoanInvestment_Call{Call: _e.mock.On("GetLoanInvestment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoanInvestment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInvestmentOption)) *MockNotifyAgreementLettersStore_GetLoanInvestment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInvestmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInvestmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoanInvestment_Call) Return(_a0 sqlentity.LoanInvestments, _a1 error) *MockNotifyAgreementLettersStore_GetLoanInvestment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoanInvestment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInvestmentOption) (sqlentity.LoanInvestments, error)) *MockNotifyAgreementLettersStore_GetLoanInvestment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanNotification provides a mock function with given fields: ctx, opts
+func (_m *MockNotifyAgreementLettersStore) GetLoanNotification(ctx context.Context, opts ...gateway.GetLoanNotificationOption) (sqlentity.LoanNotifications, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanNotification")
+	}
+
+	var r0 sqlentity.LoanNotifications
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanNotificationOption) (sqlentity.LoanNotifications, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanNotificationOption) sqlentity.LoanNotifications); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanNotifications)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanNotificationOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockNotifyAgreementLettersStore_GetLoanNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanNotification'
+type MockNotifyAgreementLettersStore_GetLoanNotification_Call struct {
+	*mock.Call
+}
+
+// GetLoanNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanNotificationOption
+func (_e *MockNotifyAgreementLettersStore_Expecter) GetLoanNotification(ctx interface{}, opts ...interface{}) *MockNotifyAgreementLettersStore_GetLoanNotification_Call {
+	return &MockNotifyAgreementLettersStore_GetLoanNotification_Call{Call: _e.mock.On("GetLoanNotification",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoanNotification_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanNotificationOption)) *MockNotifyAgreementLettersStore_GetLoanNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanNotificationOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanNotificationOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoanNotification_Call) Return(_a0 sqlentity.LoanNotifications, _a1 error) *MockNotifyAgreementLettersStore_GetLoanNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_GetLoanNotification_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanNotificationOption) (sqlentity.LoanNotifications, error)) *MockNotifyAgreementLettersStore_GetLoanNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanNotification provides a mock function with given fields: ctx, in
+func (_m *MockNotifyAgreementLettersStore) InsertLoanNotification(ctx context.Context, in sqlentity.LoanNotification) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanNotification) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifyAgreementLettersStore_InsertLoanNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanNotification'
+type MockNotifyAgreementLettersStore_InsertLoanNotification_Call struct {
+	*mock.Call
+}
+
+// InsertLoanNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanNotification
+func (_e *MockNotifyAgreementLettersStore_Expecter) InsertLoanNotification(ctx interface{}, in interface{}) *MockNotifyAgreementLettersStore_InsertLoanNotification_Call {
+	return &MockNotifyAgreementLettersStore_InsertLoanNotification_Call{Call: _e.mock.On("InsertLoanNotification", ctx, in)}
+}
+
+func (_c *MockNotifyAgreementLettersStore_InsertLoanNotification_Call) Run(run func(ctx context.Context, in sqlentity.LoanNotification)) *MockNotifyAgreementLettersStore_InsertLoanNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanNotification))
+	})
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_InsertLoanNotification_Call) Return(_a0 error) *MockNotifyAgreementLettersStore_InsertLoanNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_InsertLoanNotification_Call) RunAndReturn(run func(context.Context, sqlentity.LoanNotification) error) *MockNotifyAgreementLettersStore_InsertLoanNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoanNotification provides a mock function with given fields: ctx, in, opts
+func (_m *MockNotifyAgreementLettersStore) UpdateLoanNotification(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanNotificationOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoanNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanNotificationOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockNotifyAgreementLettersStore_UpdateLoanNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoanNotification'
+type MockNotifyAgreementLettersStore_UpdateLoanNotification_Call struct {
+	*mock.Call
+}
+
+// UpdateLoanNotification is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanNotificationOption
+func (_e *MockNotifyAgreementLettersStore_Expecter) UpdateLoanNotification(ctx interface{}, in interface{}, opts ...interface{}) *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call {
+	return &MockNotifyAgreementLettersStore_UpdateLoanNotification_Call{Call: _e.mock.On("UpdateLoanNotification",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanNotificationOption)) *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanNotificationOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanNotificationOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call) Return(_a0 error) *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanNotificationOption) error) *MockNotifyAgreementLettersStore_UpdateLoanNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotifyAgreementLettersStore creates a new instance of MockNotifyAgreementLettersStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifyAgreementLettersStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifyAgreementLettersStore {
+	mock := &MockNotifyAgreementLettersStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	ledger "github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPoster is an autogenerated mock type for the Poster type
+type MockPoster struct {
+	mock.Mock
+}
+
+type MockPoster_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPoster) EXPECT() *MockPoster_Expecter {
+	return &MockPoster_Expecter{mock: &_m.Mock}
+}
+
+// Post provides a mock function with given fields: ctx, entry
+func (_m *MockPoster) Post(ctx context.Context, entry ledger.Entry) error {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Post")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ledger.Entry) error); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPoster_Post_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Post'
+type MockPoster_Post_Call struct {
+	*mock.Call
+}
+
+// Post is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry ledger.Entry
+func (_e *MockPoster_Expecter) Post(ctx interface{}, entry interface{}) *MockPoster_Post_Call {
+	return &MockPoster_Post_Call{Call: _e.mock.On("Post", ctx, entry)}
+}
+
+func (_c *MockPoster_Post_Call) Run(run func(ctx context.Context, entry ledger.Entry)) *MockPoster_Post_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(ledger.Entry))
+	})
+	return _c
+}
+
+func (_c *MockPoster_Post_Call) Return(_a0 error) *MockPoster_Post_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPoster_Post_Call) RunAndReturn(run func(context.Context, ledger.Entry) error) *MockPoster_Post_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPoster creates a new instance of MockPoster. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPoster(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPoster {
+	mock := &MockPoster{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,451 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockRepayLoanStore is an autogenerated mock type for the RepayLoanStore type
+type MockRepayLoanStore struct {
+	mock.Mock
+}
+
+type MockRepayLoanStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepayLoanStore) EXPECT() *MockRepayLoanStore_Expecter {
+	return &MockRepayLoanStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockRepayLoanStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepayLoanStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockRepayLoanStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockRepayLoanStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockRepayLoanStore_GetLoan_Call {
+	return &MockRepayLoanStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockRepayLoanStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockRepayLoanStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockRepayLoanStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockRepayLoanStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLoanInstallment provides a mock function with given fields: ctx, opts
+func (_m *MockRepayLoanStore) GetLoanInstallment(ctx context.Context, opts ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoanInstallment")
+	}
+
+	var r0 sqlentity.LoanInstallments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanInstallmentOption) sqlentity.LoanInstallments); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.LoanInstallments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanInstallmentOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRepayLoanStore_GetLoanInstallment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoanInstallment'
+type MockRepayLoanStore_GetLoanInstallment_Call struct {
+	*mock.Call
+}
+
+// GetLoanInstallment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanInstallmentOption
+func (_e *MockRepayLoanStore_Expecter) GetLoanInstallment(ctx interface{}, opts ...interface{}) *MockRepayLoanStore_GetLoanInstallment_Call {
+	return &MockRepayLoanStore_GetLoanInstallment_Call{Call: _e.mock.On("GetLoanInstallment",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockRepayLoanStore_GetLoanInstallment_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanInstallmentOption)) *MockRepayLoanStore_GetLoanInstallment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanInstallmentOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanInstallmentOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_GetLoanInstallment_Call) Return(_a0 sqlentity.LoanInstallments, _a1 error) *MockRepayLoanStore_GetLoanInstallment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_GetLoanInstallment_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanInstallmentOption) (sqlentity.LoanInstallments, error)) *MockRepayLoanStore_GetLoanInstallment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanRepayment provides a mock function with given fields: ctx, in
+func (_m *MockRepayLoanStore) InsertLoanRepayment(ctx context.Context, in sqlentity.LoanRepayment) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanRepayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanRepayment) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepayLoanStore_InsertLoanRepayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanRepayment'
+type MockRepayLoanStore_InsertLoanRepayment_Call struct {
+	*mock.Call
+}
+
+// InsertLoanRepayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanRepayment
+func (_e *MockRepayLoanStore_Expecter) InsertLoanRepayment(ctx interface{}, in interface{}) *MockRepayLoanStore_InsertLoanRepayment_Call {
+	return &MockRepayLoanStore_InsertLoanRepayment_Call{Call: _e.mock.On("InsertLoanRepayment", ctx, in)}
+}
+
+func (_c *MockRepayLoanStore_InsertLoanRepayment_Call) Run(run func(ctx context.Context, in sqlentity.LoanRepayment)) *MockRepayLoanStore_InsertLoanRepayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanRepayment))
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_InsertLoanRepayment_Call) Return(_a0 error) *MockRepayLoanStore_InsertLoanRepayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_InsertLoanRepayment_Call) RunAndReturn(run func(context.Context, sqlentity.LoanRepayment) error) *MockRepayLoanStore_InsertLoanRepayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanRepaymentAllocations provides a mock function with given fields: ctx, in
+func (_m *MockRepayLoanStore) InsertLoanRepaymentAllocations(ctx context.Context, in sqlentity.LoanRepaymentAllocations) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanRepaymentAllocations")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanRepaymentAllocations) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepayLoanStore_InsertLoanRepaymentAllocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanRepaymentAllocations'
+type MockRepayLoanStore_InsertLoanRepaymentAllocations_Call struct {
+	*mock.Call
+}
+
+// InsertLoanRepaymentAllocations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanRepaymentAllocations
+func (_e *MockRepayLoanStore_Expecter) InsertLoanRepaymentAllocations(ctx interface{}, in interface{}) *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call {
+	return &MockRepayLoanStore_InsertLoanRepaymentAllocations_Call{Call: _e.mock.On("InsertLoanRepaymentAllocations", ctx, in)}
+}
+
+func (_c *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call) Run(run func(ctx context.Context, in sqlentity.LoanRepaymentAllocations)) *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanRepaymentAllocations))
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call) Return(_a0 error) *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call) RunAndReturn(run func(context.Context, sqlentity.LoanRepaymentAllocations) error) *MockRepayLoanStore_InsertLoanRepaymentAllocations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanStatusHistory provides a mock function with given fields: ctx, in
+func (_m *MockRepayLoanStore) InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanStatusHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanStatusHistory) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepayLoanStore_InsertLoanStatusHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanStatusHistory'
+type MockRepayLoanStore_InsertLoanStatusHistory_Call struct {
+	*mock.Call
+}
+
+// InsertLoanStatusHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanStatusHistory
+func (_e *MockRepayLoanStore_Expecter) InsertLoanStatusHistory(ctx interface{}, in interface{}) *MockRepayLoanStore_InsertLoanStatusHistory_Call {
+	return &MockRepayLoanStore_InsertLoanStatusHistory_Call{Call: _e.mock.On("InsertLoanStatusHistory", ctx, in)}
+}
+
+func (_c *MockRepayLoanStore_InsertLoanStatusHistory_Call) Run(run func(ctx context.Context, in sqlentity.LoanStatusHistory)) *MockRepayLoanStore_InsertLoanStatusHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanStatusHistory))
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_InsertLoanStatusHistory_Call) Return(_a0 error) *MockRepayLoanStore_InsertLoanStatusHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_InsertLoanStatusHistory_Call) RunAndReturn(run func(context.Context, sqlentity.LoanStatusHistory) error) *MockRepayLoanStore_InsertLoanStatusHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoan provides a mock function with given fields: ctx, in, opts
+func (_m *MockRepayLoanStore) UpdateLoan(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepayLoanStore_UpdateLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoan'
+type MockRepayLoanStore_UpdateLoan_Call struct {
+	*mock.Call
+}
+
+// UpdateLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanOption
+func (_e *MockRepayLoanStore_Expecter) UpdateLoan(ctx interface{}, in interface{}, opts ...interface{}) *MockRepayLoanStore_UpdateLoan_Call {
+	return &MockRepayLoanStore_UpdateLoan_Call{Call: _e.mock.On("UpdateLoan",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRepayLoanStore_UpdateLoan_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption)) *MockRepayLoanStore_UpdateLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_UpdateLoan_Call) Return(_a0 error) *MockRepayLoanStore_UpdateLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_UpdateLoan_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error) *MockRepayLoanStore_UpdateLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoanInstallment provides a mock function with given fields: ctx, in, opts
+func (_m *MockRepayLoanStore) UpdateLoanInstallment(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInstallmentOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoanInstallment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInstallmentOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRepayLoanStore_UpdateLoanInstallment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoanInstallment'
+type MockRepayLoanStore_UpdateLoanInstallment_Call struct {
+	*mock.Call
+}
+
+// UpdateLoanInstallment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanInstallmentOption
+func (_e *MockRepayLoanStore_Expecter) UpdateLoanInstallment(ctx interface{}, in interface{}, opts ...interface{}) *MockRepayLoanStore_UpdateLoanInstallment_Call {
+	return &MockRepayLoanStore_UpdateLoanInstallment_Call{Call: _e.mock.On("UpdateLoanInstallment",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockRepayLoanStore_UpdateLoanInstallment_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanInstallmentOption)) *MockRepayLoanStore_UpdateLoanInstallment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanInstallmentOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanInstallmentOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRepayLoanStore_UpdateLoanInstallment_Call) Return(_a0 error) *MockRepayLoanStore_UpdateLoanInstallment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRepayLoanStore_UpdateLoanInstallment_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanInstallmentOption) error) *MockRepayLoanStore_UpdateLoanInstallment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRepayLoanStore creates a new instance of MockRepayLoanStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepayLoanStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepayLoanStore {
+	mock := &MockRepayLoanStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,222 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockUpdateLoanStore is an autogenerated mock type for the UpdateLoanStore type
+type MockUpdateLoanStore struct {
+	mock.Mock
+}
+
+type MockUpdateLoanStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUpdateLoanStore) EXPECT() *MockUpdateLoanStore_Expecter {
+	return &MockUpdateLoanStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockUpdateLoanStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUpdateLoanStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockUpdateLoanStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockUpdateLoanStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockUpdateLoanStore_GetLoan_Call {
+	return &MockUpdateLoanStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockUpdateLoanStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockUpdateLoanStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockUpdateLoanStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockUpdateLoanStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUpdateLoanStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockUpdateLoanStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanStatusHistory provides a mock function with given fields: ctx, in
+func (_m *MockUpdateLoanStore) InsertLoanStatusHistory(ctx context.Context, in sqlentity.LoanStatusHistory) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanStatusHistory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanStatusHistory) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUpdateLoanStore_InsertLoanStatusHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanStatusHistory'
+type MockUpdateLoanStore_InsertLoanStatusHistory_Call struct {
+	*mock.Call
+}
+
+// InsertLoanStatusHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanStatusHistory
+func (_e *MockUpdateLoanStore_Expecter) InsertLoanStatusHistory(ctx interface{}, in interface{}) *MockUpdateLoanStore_InsertLoanStatusHistory_Call {
+	return &MockUpdateLoanStore_InsertLoanStatusHistory_Call{Call: _e.mock.On("InsertLoanStatusHistory", ctx, in)}
+}
+
+func (_c *MockUpdateLoanStore_InsertLoanStatusHistory_Call) Run(run func(ctx context.Context, in sqlentity.LoanStatusHistory)) *MockUpdateLoanStore_InsertLoanStatusHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanStatusHistory))
+	})
+	return _c
+}
+
+func (_c *MockUpdateLoanStore_InsertLoanStatusHistory_Call) Return(_a0 error) *MockUpdateLoanStore_InsertLoanStatusHistory_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUpdateLoanStore_InsertLoanStatusHistory_Call) RunAndReturn(run func(context.Context, sqlentity.LoanStatusHistory) error) *MockUpdateLoanStore_InsertLoanStatusHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLoan provides a mock function with given fields: ctx, in, opts
+func (_m *MockUpdateLoanStore) UpdateLoan(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLoan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUpdateLoanStore_UpdateLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLoan'
+type MockUpdateLoanStore_UpdateLoan_Call struct {
+	*mock.Call
+}
+
+// UpdateLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateLoanOption
+func (_e *MockUpdateLoanStore_Expecter) UpdateLoan(ctx interface{}, in interface{}, opts ...interface{}) *MockUpdateLoanStore_UpdateLoan_Call {
+	return &MockUpdateLoanStore_UpdateLoan_Call{Call: _e.mock.On("UpdateLoan",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockUpdateLoanStore_UpdateLoan_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateLoanOption)) *MockUpdateLoanStore_UpdateLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateLoanOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateLoanOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockUpdateLoanStore_UpdateLoan_Call) Return(_a0 error) *MockUpdateLoanStore_UpdateLoan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUpdateLoanStore_UpdateLoan_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateLoanOption) error) *MockUpdateLoanStore_UpdateLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUpdateLoanStore creates a new instance of MockUpdateLoanStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUpdateLoanStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUpdateLoanStore {
+	mock := &MockUpdateLoanStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,160 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockUploadAgreementLetterStore is an autogenerated mock type for the UploadAgreementLetterStore type
+type MockUploadAgreementLetterStore struct {
+	mock.Mock
+}
+
+type MockUploadAgreementLetterStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUploadAgreementLetterStore) EXPECT() *MockUploadAgreementLetterStore_Expecter {
+	return &MockUploadAgreementLetterStore_Expecter{mock: &_m.Mock}
+}
+
+// GetLoan provides a mock function with given fields: ctx, opts
+func (_m *MockUploadAgreementLetterStore) GetLoan(ctx context.Context, opts ...gateway.GetLoanOption) (sqlentity.Loans, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLoan")
+	}
+
+	var r0 sqlentity.Loans
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetLoanOption) sqlentity.Loans); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Loans)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetLoanOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUploadAgreementLetterStore_GetLoan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLoan'
+type MockUploadAgreementLetterStore_GetLoan_Call struct {
+	*mock.Call
+}
+
+// GetLoan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetLoanOption
+func (_e *MockUploadAgreementLetterStore_Expecter) GetLoan(ctx interface{}, opts ...interface{}) *MockUploadAgreementLetterStore_GetLoan_Call {
+	return &MockUploadAgreementLetterStore_GetLoan_Call{Call: _e.mock.On("GetLoan",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockUploadAgreementLetterStore_GetLoan_Call) Run(run func(ctx context.Context, opts ...gateway.GetLoanOption)) *MockUploadAgreementLetterStore_GetLoan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetLoanOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetLoanOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockUploadAgreementLetterStore_GetLoan_Call) Return(_a0 sqlentity.Loans, _a1 error) *MockUploadAgreementLetterStore_GetLoan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUploadAgreementLetterStore_GetLoan_Call) RunAndReturn(run func(context.Context, ...gateway.GetLoanOption) (sqlentity.Loans, error)) *MockUploadAgreementLetterStore_GetLoan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertLoanDocument provides a mock function with given fields: ctx, in
+func (_m *MockUploadAgreementLetterStore) InsertLoanDocument(ctx context.Context, in sqlentity.LoanDocument) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertLoanDocument")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.LoanDocument) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockUploadAgreementLetterStore_InsertLoanDocument_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertLoanDocument'
+type MockUploadAgreementLetterStore_InsertLoanDocument_Call struct {
+	*mock.Call
+}
+
+// InsertLoanDocument is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.LoanDocument
+func (_e *MockUploadAgreementLetterStore_Expecter) InsertLoanDocument(ctx interface{}, in interface{}) *MockUploadAgreementLetterStore_InsertLoanDocument_Call {
+	return &MockUploadAgreementLetterStore_InsertLoanDocument_Call{Call: _e.mock.On("InsertLoanDocument", ctx, in)}
+}
+
+func (_c *MockUploadAgreementLetterStore_InsertLoanDocument_Call) Run(run func(ctx context.Context, in sqlentity.LoanDocument)) *MockUploadAgreementLetterStore_InsertLoanDocument_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.LoanDocument))
+	})
+	return _c
+}
+
+func (_c *MockUploadAgreementLetterStore_InsertLoanDocument_Call) Return(_a0 error) *MockUploadAgreementLetterStore_InsertLoanDocument_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockUploadAgreementLetterStore_InsertLoanDocument_Call) RunAndReturn(run func(context.Context, sqlentity.LoanDocument) error) *MockUploadAgreementLetterStore_InsertLoanDocument_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUploadAgreementLetterStore creates a new instance of MockUploadAgreementLetterStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUploadAgreementLetterStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUploadAgreementLetterStore {
+	mock := &MockUploadAgreementLetterStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
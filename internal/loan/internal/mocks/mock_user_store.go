@@ -0,0 +1,113 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockUserStore is an autogenerated mock type for the UserStore type
+type MockUserStore struct {
+	mock.Mock
+}
+
+type MockUserStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserStore) EXPECT() *MockUserStore_Expecter {
+	return &MockUserStore_Expecter{mock: &_m.Mock}
+}
+
+// GetUser provides a mock function with given fields: ctx, opts
+func (_m *MockUserStore) GetUser(ctx context.Context, opts ...gateway.GetUserOption) (sqlentity.Users, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 sqlentity.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetUserOption) (sqlentity.Users, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetUserOption) sqlentity.Users); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Users)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetUserOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockUserStore_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUserStore_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetUserOption
+func (_e *MockUserStore_Expecter) GetUser(ctx interface{}, opts ...interface{}) *MockUserStore_GetUser_Call {
+	return &MockUserStore_GetUser_Call{Call: _e.mock.On("GetUser",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockUserStore_GetUser_Call) Run(run func(ctx context.Context, opts ...gateway.GetUserOption)) *MockUserStore_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetUserOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetUserOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockUserStore_GetUser_Call) Return(_a0 sqlentity.Users, _a1 error) *MockUserStore_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockUserStore_GetUser_Call) RunAndReturn(run func(context.Context, ...gateway.GetUserOption) (sqlentity.Users, error)) *MockUserStore_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserStore creates a new instance of MockUserStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserStore {
+	mock := &MockUserStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
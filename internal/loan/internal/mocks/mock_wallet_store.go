@@ -0,0 +1,403 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package loanmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+// MockWalletStore is an autogenerated mock type for the WalletStore type
+type MockWalletStore struct {
+	mock.Mock
+}
+
+type MockWalletStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWalletStore) EXPECT() *MockWalletStore_Expecter {
+	return &MockWalletStore_Expecter{mock: &_m.Mock}
+}
+
+// GetWallet provides a mock function with given fields: ctx, opts
+func (_m *MockWalletStore) GetWallet(ctx context.Context, opts ...gateway.GetWalletOption) (sqlentity.Wallets, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWallet")
+	}
+
+	var r0 sqlentity.Wallets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWalletOption) (sqlentity.Wallets, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWalletOption) sqlentity.Wallets); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Wallets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWalletOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWalletStore_GetWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWallet'
+type MockWalletStore_GetWallet_Call struct {
+	*mock.Call
+}
+
+// GetWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWalletOption
+func (_e *MockWalletStore_Expecter) GetWallet(ctx interface{}, opts ...interface{}) *MockWalletStore_GetWallet_Call {
+	return &MockWalletStore_GetWallet_Call{Call: _e.mock.On("GetWallet",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockWalletStore_GetWallet_Call) Run(run func(ctx context.Context, opts ...gateway.GetWalletOption)) *MockWalletStore_GetWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWalletOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWalletOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockWalletStore_GetWallet_Call) Return(_a0 sqlentity.Wallets, _a1 error) *MockWalletStore_GetWallet_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWalletStore_GetWallet_Call) RunAndReturn(run func(context.Context, ...gateway.GetWalletOption) (sqlentity.Wallets, error)) *MockWalletStore_GetWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWalletReservation provides a mock function with given fields: ctx, opts
+func (_m *MockWalletStore) GetWalletReservation(ctx context.Context, opts ...gateway.GetWalletReservationOption) (sqlentity.WalletReservations, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWalletReservation")
+	}
+
+	var r0 sqlentity.WalletReservations
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWalletReservationOption) (sqlentity.WalletReservations, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWalletReservationOption) sqlentity.WalletReservations); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WalletReservations)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWalletReservationOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWalletStore_GetWalletReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWalletReservation'
+type MockWalletStore_GetWalletReservation_Call struct {
+	*mock.Call
+}
+
+// GetWalletReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWalletReservationOption
+func (_e *MockWalletStore_Expecter) GetWalletReservation(ctx interface{}, opts ...interface{}) *MockWalletStore_GetWalletReservation_Call {
+	return &MockWalletStore_GetWalletReservation_Call{Call: _e.mock.On("GetWalletReservation",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockWalletStore_GetWalletReservation_Call) Run(run func(ctx context.Context, opts ...gateway.GetWalletReservationOption)) *MockWalletStore_GetWalletReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWalletReservationOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWalletReservationOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockWalletStore_GetWalletReservation_Call) Return(_a0 sqlentity.WalletReservations, _a1 error) *MockWalletStore_GetWalletReservation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWalletStore_GetWalletReservation_Call) RunAndReturn(run func(context.Context, ...gateway.GetWalletReservationOption) (sqlentity.WalletReservations, error)) *MockWalletStore_GetWalletReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWallet provides a mock function with given fields: ctx, in
+func (_m *MockWalletStore) InsertWallet(ctx context.Context, in sqlentity.Wallet) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWallet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.Wallet) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWalletStore_InsertWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWallet'
+type MockWalletStore_InsertWallet_Call struct {
+	*mock.Call
+}
+
+// InsertWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.Wallet
+func (_e *MockWalletStore_Expecter) InsertWallet(ctx interface{}, in interface{}) *MockWalletStore_InsertWallet_Call {
+	return &MockWalletStore_InsertWallet_Call{Call: _e.mock.On("InsertWallet", ctx, in)}
+}
+
+func (_c *MockWalletStore_InsertWallet_Call) Run(run func(ctx context.Context, in sqlentity.Wallet)) *MockWalletStore_InsertWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.Wallet))
+	})
+	return _c
+}
+
+func (_c *MockWalletStore_InsertWallet_Call) Return(_a0 error) *MockWalletStore_InsertWallet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWalletStore_InsertWallet_Call) RunAndReturn(run func(context.Context, sqlentity.Wallet) error) *MockWalletStore_InsertWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWalletReservation provides a mock function with given fields: ctx, in
+func (_m *MockWalletStore) InsertWalletReservation(ctx context.Context, in sqlentity.WalletReservation) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWalletReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.WalletReservation) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWalletStore_InsertWalletReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWalletReservation'
+type MockWalletStore_InsertWalletReservation_Call struct {
+	*mock.Call
+}
+
+// InsertWalletReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.WalletReservation
+func (_e *MockWalletStore_Expecter) InsertWalletReservation(ctx interface{}, in interface{}) *MockWalletStore_InsertWalletReservation_Call {
+	return &MockWalletStore_InsertWalletReservation_Call{Call: _e.mock.On("InsertWalletReservation", ctx, in)}
+}
+
+func (_c *MockWalletStore_InsertWalletReservation_Call) Run(run func(ctx context.Context, in sqlentity.WalletReservation)) *MockWalletStore_InsertWalletReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.WalletReservation))
+	})
+	return _c
+}
+
+func (_c *MockWalletStore_InsertWalletReservation_Call) Return(_a0 error) *MockWalletStore_InsertWalletReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWalletStore_InsertWalletReservation_Call) RunAndReturn(run func(context.Context, sqlentity.WalletReservation) error) *MockWalletStore_InsertWalletReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWallet provides a mock function with given fields: ctx, in, opts
+func (_m *MockWalletStore) UpdateWallet(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWalletOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWallet")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWalletOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWalletStore_UpdateWallet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWallet'
+type MockWalletStore_UpdateWallet_Call struct {
+	*mock.Call
+}
+
+// UpdateWallet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateWalletOption
+func (_e *MockWalletStore_Expecter) UpdateWallet(ctx interface{}, in interface{}, opts ...interface{}) *MockWalletStore_UpdateWallet_Call {
+	return &MockWalletStore_UpdateWallet_Call{Call: _e.mock.On("UpdateWallet",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockWalletStore_UpdateWallet_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWalletOption)) *MockWalletStore_UpdateWallet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateWalletOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateWalletOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockWalletStore_UpdateWallet_Call) Return(_a0 error) *MockWalletStore_UpdateWallet_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWalletStore_UpdateWallet_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWalletOption) error) *MockWalletStore_UpdateWallet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWalletReservation provides a mock function with given fields: ctx, in, opts
+func (_m *MockWalletStore) UpdateWalletReservation(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWalletReservationOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWalletReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWalletReservationOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockWalletStore_UpdateWalletReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWalletReservation'
+type MockWalletStore_UpdateWalletReservation_Call struct {
+	*mock.Call
+}
+
+// UpdateWalletReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateWalletReservationOption
+func (_e *MockWalletStore_Expecter) UpdateWalletReservation(ctx interface{}, in interface{}, opts ...interface{}) *MockWalletStore_UpdateWalletReservation_Call {
+	return &MockWalletStore_UpdateWalletReservation_Call{Call: _e.mock.On("UpdateWalletReservation",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockWalletStore_UpdateWalletReservation_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWalletReservationOption)) *MockWalletStore_UpdateWalletReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateWalletReservationOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateWalletReservationOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockWalletStore_UpdateWalletReservation_Call) Return(_a0 error) *MockWalletStore_UpdateWalletReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockWalletStore_UpdateWalletReservation_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWalletReservationOption) error) *MockWalletStore_UpdateWalletReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWalletStore creates a new instance of MockWalletStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWalletStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWalletStore {
+	mock := &MockWalletStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,153 @@
+// Package portfolio computes what the investments of an investor return.
+//
+// The expected return of an investment is its share of the interest the schedule of the loan charges over the whole
+// tenor, net of the platform fee and split the way the repayments are. The realized return is the interest the
+// investor was credited so far, net of the fee as well. The yield of an investment is the yearly interest rate of its
+// loan net of the platform fee, the yield of a portfolio is the yield of its investments weighted by their amount.
+// Percentages are rounded half away from zero to two decimals.
+package portfolio
+
+import (
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/distribution"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/schedule"
+	"github.com/shopspring/decimal"
+)
+
+// percentScale is the number of decimals of the percentages.
+const percentScale = 2
+
+//nolint:gochecknoglobals // intended to be global
+var hundred = decimal.NewFromInt(100)
+
+// Holding is an investment with its loan and the credits it received of the repayments.
+type Holding struct {
+	Investment sqlentity.LoanInvestment
+	Loan       sqlentity.Loan
+	Credits    sqlentity.LoanInvestorCredits
+}
+
+// Position is what an investment returns. SharePercentage is the part of the loan principal it funds.
+type Position struct {
+	Investment           sqlentity.LoanInvestment
+	Loan                 sqlentity.Loan
+	SharePercentage      decimal.Decimal
+	ExpectedReturn       decimal.Decimal
+	RealizedReturn       decimal.Decimal
+	ReceivedPrincipal    decimal.Decimal
+	OutstandingPrincipal decimal.Decimal
+	Yield                decimal.Decimal
+}
+
+// Portfolio is the positions of an investor and their totals. TotalOutstanding is the principal not repaid yet and
+// TotalEarned the realized return.
+type Portfolio struct {
+	Positions            []Position
+	TotalInvested        decimal.Decimal
+	TotalOutstanding     decimal.Decimal
+	TotalEarned          decimal.Decimal
+	TotalExpectedReturn  decimal.Decimal
+	WeightedAverageYield decimal.Decimal
+}
+
+// Build computes the position of every holding, in the order of the holdings, and the totals of the portfolio.
+func Build(holdings []Holding, feeRate decimal.Decimal) (Portfolio, error) {
+	portfolio := Portfolio{
+		Positions:            make([]Position, 0, len(holdings)),
+		TotalInvested:        decimal.Zero,
+		TotalOutstanding:     decimal.Zero,
+		TotalEarned:          decimal.Zero,
+		TotalExpectedReturn:  decimal.Zero,
+		WeightedAverageYield: decimal.Zero,
+	}
+
+	weightedYield := decimal.Zero
+	for _, holding := range holdings {
+		position, err := NewPosition(holding, feeRate)
+		if err != nil {
+			return Portfolio{}, err
+		}
+
+		amount := holding.Investment.Amount
+		portfolio.TotalInvested = portfolio.TotalInvested.Add(amount)
+		portfolio.TotalOutstanding = portfolio.TotalOutstanding.Add(position.OutstandingPrincipal)
+		portfolio.TotalEarned = portfolio.TotalEarned.Add(position.RealizedReturn)
+		portfolio.TotalExpectedReturn = portfolio.TotalExpectedReturn.Add(position.ExpectedReturn)
+		weightedYield = weightedYield.Add(position.Yield.Mul(amount))
+
+		portfolio.Positions = append(portfolio.Positions, position)
+	}
+
+	if portfolio.TotalInvested.IsPositive() {
+		portfolio.WeightedAverageYield = weightedYield.Div(portfolio.TotalInvested).Round(percentScale)
+	}
+
+	return portfolio, nil
+}
+
+// NewPosition computes what the investment of the holding returns.
+func NewPosition(holding Holding, feeRate decimal.Decimal) (Position, error) {
+	investment, loan := holding.Investment, holding.Loan
+
+	expected, err := ExpectedReturn(loan, investment.Amount, feeRate)
+	if err != nil {
+		return Position{}, err
+	}
+
+	position := Position{
+		Investment:        investment,
+		Loan:              loan,
+		SharePercentage:   decimal.Zero,
+		ExpectedReturn:    expected,
+		RealizedReturn:    decimal.Zero,
+		ReceivedPrincipal: decimal.Zero,
+		Yield:             loan.InterestRate.Mul(hundred.Sub(feeRate)).Div(hundred).Round(percentScale),
+	}
+
+	if loan.PrincipalAmount.IsPositive() {
+		position.SharePercentage = investment.Amount.Mul(hundred).Div(loan.PrincipalAmount).Round(percentScale)
+	}
+
+	for _, credit := range holding.Credits {
+		position.ReceivedPrincipal = position.ReceivedPrincipal.Add(credit.PrincipalAmount)
+		position.RealizedReturn = position.RealizedReturn.Add(credit.InterestAmount)
+	}
+
+	position.OutstandingPrincipal = investment.Amount.Sub(position.ReceivedPrincipal)
+
+	return position, nil
+}
+
+// ExpectedReturn is the interest, net of the platform fee of feeRate percent, that amount invested in the loan earns
+// once the loan is repaid. A loan without a tenor has no schedule and earns nothing expected.
+func ExpectedReturn(loan sqlentity.Loan, amount, feeRate decimal.Decimal) (decimal.Decimal, error) {
+	if !loan.TenorMonths.Valid {
+		return decimal.Zero, nil
+	}
+
+	installments, err := schedule.Generate(schedule.Terms{
+		Principal:   loan.PrincipalAmount,
+		AnnualRate:  loan.InterestRate,
+		TenorMonths: int(loan.TenorMonths.Int32),
+		Method:      loan.RepaymentMethod,
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	interest := decimal.Zero
+	for _, installment := range installments {
+		interest = interest.Add(installment.Interest)
+	}
+
+	// the rest of the principal is funded by the other investments, whoever makes them
+	credits, err := distribution.Distribute(decimal.Zero, interest, feeRate, []distribution.Share{
+		{InvestmentID: 1, Amount: amount},
+		{InvestmentID: 2, Amount: loan.PrincipalAmount.Sub(amount)},
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return credits[0].Interest, nil
+}
@@ -0,0 +1,167 @@
+package portfolio
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpectedReturn(t *testing.T) {
+	flat := sqlentity.Loan{
+		PrincipalAmount: decimal.NewFromInt(1_000_000),
+		InterestRate:    decimal.NewFromInt(12),
+		TenorMonths:     sql.NullInt32{Int32: 3, Valid: true},
+		RepaymentMethod: sqlentity.FlatRepayment,
+	}
+
+	tests := []struct {
+		name    string
+		loan    sqlentity.Loan
+		amount  string
+		feeRate string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "error unknown repayment method",
+			loan:    sqlentity.Loan{PrincipalAmount: flat.PrincipalAmount, TenorMonths: flat.TenorMonths},
+			amount:  "400000",
+			feeRate: "10",
+			wantErr: true,
+		},
+		{
+			name:    "success loan without tenor",
+			loan:    sqlentity.Loan{PrincipalAmount: flat.PrincipalAmount, InterestRate: flat.InterestRate},
+			amount:  "400000",
+			feeRate: "10",
+			want:    "0",
+		},
+		{
+			name:    "success share of the interest net of the fee",
+			loan:    flat,
+			amount:  "400000",
+			feeRate: "10",
+			want:    "10800",
+		},
+		{
+			name:    "success whole loan without fee",
+			loan:    flat,
+			amount:  "1000000",
+			feeRate: "0",
+			want:    "30000",
+		},
+		{
+			name:    "success odd share rounds to the cent",
+			loan:    flat,
+			amount:  "333333.33",
+			feeRate: "10",
+			want:    "9000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpectedReturn(
+				tt.loan,
+				decimal.RequireFromString(tt.amount),
+				decimal.RequireFromString(tt.feeRate),
+			)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ExpectedReturn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got.String())
+			}
+		})
+	}
+}
+
+func TestBuild(t *testing.T) {
+	holdings := []Holding{
+		{
+			Investment: sqlentity.LoanInvestment{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(400_000)},
+			Loan: sqlentity.Loan{
+				ID:              1,
+				PrincipalAmount: decimal.NewFromInt(1_000_000),
+				InterestRate:    decimal.NewFromInt(12),
+				TenorMonths:     sql.NullInt32{Int32: 3, Valid: true},
+				RepaymentMethod: sqlentity.FlatRepayment,
+				Status:          sqlentity.Approved,
+			},
+		},
+		{
+			Investment: sqlentity.LoanInvestment{ID: 11, LoanID: 2, InvestorID: 3, Amount: decimal.NewFromInt(500_000)},
+			Loan: sqlentity.Loan{
+				ID:              2,
+				PrincipalAmount: decimal.NewFromInt(500_000),
+				InterestRate:    decimal.NewFromInt(18),
+				TenorMonths:     sql.NullInt32{Int32: 6, Valid: true},
+				RepaymentMethod: sqlentity.FlatRepayment,
+				Status:          sqlentity.Disbursed,
+			},
+			Credits: sqlentity.LoanInvestorCredits{
+				{
+					InvestmentID:    11,
+					PrincipalAmount: decimal.RequireFromString("83333.33"),
+					InterestAmount:  decimal.NewFromInt(6_750),
+					FeeAmount:       decimal.NewFromInt(750),
+				},
+				{
+					InvestmentID:    11,
+					PrincipalAmount: decimal.RequireFromString("83333.33"),
+					InterestAmount:  decimal.NewFromInt(6_750),
+					FeeAmount:       decimal.NewFromInt(750),
+				},
+			},
+		},
+		{
+			Investment: sqlentity.LoanInvestment{ID: 12, LoanID: 3, InvestorID: 3, Amount: decimal.NewFromInt(100_000)},
+			Loan: sqlentity.Loan{
+				ID:              3,
+				PrincipalAmount: decimal.NewFromInt(1_000_000),
+				InterestRate:    decimal.NewFromInt(10),
+				Status:          sqlentity.Approved,
+			},
+		},
+	}
+
+	got, err := Build(holdings, decimal.NewFromInt(10))
+	assert.NoError(t, err)
+
+	type row struct {
+		share, expected, realized, received, outstanding, yield string
+	}
+	want := []row{
+		{"40", "10800", "0", "0", "400000", "10.8"},
+		{"100", "40500", "13500", "166666.66", "333333.34", "16.2"},
+		{"10", "0", "0", "0", "100000", "9"},
+	}
+	assert.Len(t, got.Positions, len(want))
+	for i, position := range got.Positions {
+		assert.Equal(t, want[i], row{
+			position.SharePercentage.String(),
+			position.ExpectedReturn.String(),
+			position.RealizedReturn.String(),
+			position.ReceivedPrincipal.String(),
+			position.OutstandingPrincipal.String(),
+			position.Yield.String(),
+		}, "position %d", i)
+	}
+
+	assert.Equal(t, "1000000", got.TotalInvested.String())
+	assert.Equal(t, "833333.34", got.TotalOutstanding.String())
+	assert.Equal(t, "13500", got.TotalEarned.String())
+	assert.Equal(t, "51300", got.TotalExpectedReturn.String())
+	assert.Equal(t, "13.32", got.WeightedAverageYield.String())
+}
+
+func TestBuild_Empty(t *testing.T) {
+	got, err := Build(nil, decimal.NewFromInt(10))
+	assert.NoError(t, err)
+	assert.Empty(t, got.Positions)
+	assert.True(t, got.TotalInvested.IsZero())
+	assert.True(t, got.WeightedAverageYield.IsZero())
+}
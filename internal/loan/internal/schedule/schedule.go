@@ -0,0 +1,139 @@
+// Package schedule computes the monthly installments a borrower repays a disbursed loan with.
+//
+// Amounts are rounded half away from zero to the two decimals the loan tables store. The monthly rate is the yearly
+// rate divided by 12, kept at 16 decimals, only the amounts derived from it are rounded to cents. Rounding every
+// installment leaves a few cents off the principal, the last installment absorbs that difference so the principal is
+// always repaid exactly and the outstanding balance ends at zero.
+package schedule
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// amountScale is the number of decimals of the stored amounts.
+	amountScale = 2
+	// rateScale is the precision the monthly rate and the annuity factor are computed with.
+	rateScale = 16
+)
+
+// ErrInvalidTerms is returned for terms no schedule can be computed from.
+var ErrInvalidTerms = errors.New("invalid loan terms")
+
+//nolint:gochecknoglobals // intended to be global
+var (
+	hundred       = decimal.NewFromInt(100)
+	monthsPerYear = decimal.NewFromInt(12)
+)
+
+// Terms are what a schedule is computed from. AnnualRate is a percentage and StartDate the disbursement date, the
+// first installment is due a month after it.
+type Terms struct {
+	Principal   decimal.Decimal
+	AnnualRate  decimal.Decimal
+	TenorMonths int
+	Method      sqlentity.RepaymentMethod
+	StartDate   time.Time
+}
+
+// Installment is one monthly repayment. OutstandingBalance is the principal left once it is repaid.
+type Installment struct {
+	Number             int
+	DueDate            time.Time
+	Principal          decimal.Decimal
+	Interest           decimal.Decimal
+	Total              decimal.Decimal
+	OutstandingBalance decimal.Decimal
+}
+
+// Generate computes the installments of the terms, ordered by due date.
+func Generate(terms Terms) ([]Installment, error) {
+	if terms.TenorMonths <= 0 || !terms.Principal.IsPositive() || terms.AnnualRate.IsNegative() {
+		return nil, ErrInvalidTerms
+	}
+
+	monthlyRate := terms.AnnualRate.DivRound(hundred, rateScale).DivRound(monthsPerYear, rateScale)
+
+	switch terms.Method {
+	case sqlentity.FlatRepayment:
+		return flat(terms, monthlyRate), nil
+	case sqlentity.AnnuityRepayment:
+		return annuity(terms, monthlyRate), nil
+	default:
+		return nil, ErrInvalidTerms
+	}
+}
+
+// flat repays the same principal every month with interest charged on the original principal.
+func flat(terms Terms, monthlyRate decimal.Decimal) []Installment {
+	n := decimal.NewFromInt(int64(terms.TenorMonths))
+	principal := terms.Principal.Div(n).Round(amountScale)
+	interest := terms.Principal.Mul(monthlyRate).Round(amountScale)
+
+	return build(terms, func(_ decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+		return principal, interest
+	})
+}
+
+// annuity repays the same total every month, interest is charged on the outstanding balance so the principal part
+// grows as the balance shrinks.
+func annuity(terms Terms, monthlyRate decimal.Decimal) []Installment {
+	n := int64(terms.TenorMonths)
+
+	var payment decimal.Decimal
+	if monthlyRate.IsZero() {
+		payment = terms.Principal.Div(decimal.NewFromInt(n)).Round(amountScale)
+	} else {
+		// payment = P * r / (1 - (1 + r)^-n)
+		growth := decimal.NewFromInt(1).Add(monthlyRate).Pow(decimal.NewFromInt(n))
+		discount := decimal.NewFromInt(1).Sub(decimal.NewFromInt(1).DivRound(growth, rateScale))
+		payment = terms.Principal.Mul(monthlyRate).DivRound(discount, rateScale).Round(amountScale)
+	}
+
+	return build(terms, func(balance decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+		interest := balance.Mul(monthlyRate).Round(amountScale)
+
+		return payment.Sub(interest), interest
+	})
+}
+
+// build lays out the installments with the principal and interest split of each month, the last installment repays
+// whatever principal is left.
+func build(terms Terms, split func(balance decimal.Decimal) (principal, interest decimal.Decimal)) []Installment {
+	installments := make([]Installment, 0, terms.TenorMonths)
+	balance := terms.Principal
+
+	for number := 1; number <= terms.TenorMonths; number++ {
+		principal, interest := split(balance)
+		if number == terms.TenorMonths || principal.GreaterThan(balance) {
+			principal = balance
+		}
+
+		balance = balance.Sub(principal)
+
+		installments = append(installments, Installment{
+			Number:             number,
+			DueDate:            DueDate(terms.StartDate, number),
+			Principal:          principal,
+			Interest:           interest,
+			Total:              principal.Add(interest),
+			OutstandingBalance: balance,
+		})
+	}
+
+	return installments
+}
+
+// DueDate is the date the installment number falls due, the day of the month of start that many months later. The
+// day is clamped to the end of shorter months, a loan disbursed on January 31st is due on February 28th or 29th.
+func DueDate(start time.Time, number int) time.Time {
+	year, month, day := start.Date()
+	firstOfMonth := time.Date(year, month+time.Month(number), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), min(day, lastDay), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,140 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	start := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	type row struct {
+		dueDate, principal, interest, total, balance string
+	}
+	tests := []struct {
+		name    string
+		terms   Terms
+		want    []row
+		wantErr bool
+	}{
+		{
+			name:    "error no tenor",
+			terms:   Terms{Principal: decimal.NewFromInt(1000), Method: sqlentity.FlatRepayment},
+			wantErr: true,
+		},
+		{
+			name: "error unknown method",
+			terms: Terms{
+				Principal:   decimal.NewFromInt(1000),
+				AnnualRate:  decimal.NewFromInt(12),
+				TenorMonths: 3,
+				StartDate:   start,
+			},
+			wantErr: true,
+		},
+		{
+			name: "success flat, the last installment absorbs the rounding",
+			terms: Terms{
+				Principal:   decimal.NewFromInt(1_000_000),
+				AnnualRate:  decimal.NewFromInt(12),
+				TenorMonths: 3,
+				Method:      sqlentity.FlatRepayment,
+				StartDate:   start,
+			},
+			want: []row{
+				{"2024-02-29", "333333.33", "10000.00", "343333.33", "666666.67"},
+				{"2024-03-31", "333333.33", "10000.00", "343333.33", "333333.34"},
+				{"2024-04-30", "333333.34", "10000.00", "343333.34", "0.00"},
+			},
+		},
+		{
+			name: "success annuity",
+			terms: Terms{
+				Principal:   decimal.NewFromInt(1_000_000),
+				AnnualRate:  decimal.NewFromInt(12),
+				TenorMonths: 3,
+				Method:      sqlentity.AnnuityRepayment,
+				StartDate:   start,
+			},
+			want: []row{
+				{"2024-02-29", "330022.11", "10000.00", "340022.11", "669977.89"},
+				{"2024-03-31", "333322.33", "6699.78", "340022.11", "336655.56"},
+				{"2024-04-30", "336655.56", "3366.56", "340022.12", "0.00"},
+			},
+		},
+		{
+			name: "success annuity without interest",
+			terms: Terms{
+				Principal:   decimal.NewFromInt(100),
+				TenorMonths: 3,
+				Method:      sqlentity.AnnuityRepayment,
+				StartDate:   start,
+			},
+			want: []row{
+				{"2024-02-29", "33.33", "0.00", "33.33", "66.67"},
+				{"2024-03-31", "33.33", "0.00", "33.33", "33.34"},
+				{"2024-04-30", "33.34", "0.00", "33.34", "0.00"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Generate(tt.terms)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			rows := make([]row, 0, len(got))
+			for _, installment := range got {
+				rows = append(rows, row{
+					installment.DueDate.Format(time.DateOnly),
+					installment.Principal.StringFixed(2),
+					installment.Interest.StringFixed(2),
+					installment.Total.StringFixed(2),
+					installment.OutstandingBalance.StringFixed(2),
+				})
+			}
+
+			if tt.wantErr {
+				assert.Empty(t, rows)
+
+				return
+			}
+
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
+
+func TestGenerate_repaysThePrincipal(t *testing.T) {
+	for _, method := range []sqlentity.RepaymentMethod{sqlentity.FlatRepayment, sqlentity.AnnuityRepayment} {
+		got, err := Generate(Terms{
+			Principal:   decimal.RequireFromString("7654321.09"),
+			AnnualRate:  decimal.RequireFromString("17.5"),
+			TenorMonths: 36,
+			Method:      method,
+			StartDate:   time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, got, 36)
+
+		repaid := decimal.Zero
+		for _, installment := range got {
+			repaid = repaid.Add(installment.Principal)
+		}
+
+		assert.Equal(t, "7654321.09", repaid.StringFixed(2), method.String())
+		assert.True(t, got[35].OutstandingBalance.IsZero(), method.String())
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	start := time.Date(2024, 1, 31, 23, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "2024-02-29", DueDate(start, 1).Format(time.DateOnly))
+	assert.Equal(t, "2024-03-31", DueDate(start, 2).Format(time.DateOnly))
+	assert.Equal(t, "2025-01-31", DueDate(start, 12).Format(time.DateOnly))
+	assert.Equal(t, "2024-06-15", DueDate(time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), 1).Format(time.DateOnly))
+}
@@ -0,0 +1,50 @@
+package statemachine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+)
+
+func HasApprovalProof(_ context.Context, loan sqlentity.Loan) error {
+	if !loan.ApprovalProofDocumentKey.Valid || loan.ApprovalProofDocumentKey.String == "" {
+		return errors.New("loan has no field validator proof of visit")
+	}
+
+	return nil
+}
+
+func HasRejectionReason(_ context.Context, loan sqlentity.Loan) error {
+	if !loan.RejectionReason.Valid || strings.TrimSpace(loan.RejectionReason.String) == "" {
+		return errors.New("loan rejection requires a reason")
+	}
+
+	return nil
+}
+
+func FullyFunded(_ context.Context, loan sqlentity.Loan) error {
+	if loan.InvestedAmount.LessThan(loan.PrincipalAmount) {
+		return errors.New("loan is not fully funded")
+	}
+
+	return nil
+}
+
+func FundingDeadlinePassed(_ context.Context, loan sqlentity.Loan) error {
+	if !loan.FundingDeadline.Valid || time.Now().Before(loan.FundingDeadline.Time) {
+		return errors.New("loan funding deadline has not passed")
+	}
+
+	return nil
+}
+
+func HasAgreementLetter(_ context.Context, loan sqlentity.Loan) error {
+	if !loan.AgreementLetterDocumentURL.Valid || loan.AgreementLetterDocumentURL.String == "" {
+		return errors.New("loan has no agreement letter")
+	}
+
+	return nil
+}
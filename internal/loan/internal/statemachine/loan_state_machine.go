@@ -0,0 +1,125 @@
+// Package statemachine declares the loan status lifecycle. Interactors ask the LoanStateMachine whether a status
+// change is legal instead of comparing statuses themselves, so adding a status only requires a new transition here.
+package statemachine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+)
+
+type (
+	// Guard decides whether the loan satisfies a requirement of a transition. A non nil error rejects it.
+	Guard func(ctx context.Context, loan sqlentity.Loan) error
+
+	// Hook is invoked when a loan leaves or enters a status. A non nil error aborts the transition.
+	Hook func(ctx context.Context, loan sqlentity.Loan, from, to sqlentity.LoanStatus) error
+
+	Transition struct {
+		From   sqlentity.LoanStatus
+		To     sqlentity.LoanStatus
+		Guards []Guard
+	}
+
+	LoanStateMachine struct {
+		transitions map[sqlentity.LoanStatus]map[sqlentity.LoanStatus][]Guard
+		onEnter     map[sqlentity.LoanStatus][]Hook
+		onExit      map[sqlentity.LoanStatus][]Hook
+	}
+)
+
+func NewLoanStateMachine(transitions ...Transition) *LoanStateMachine {
+	sm := &LoanStateMachine{
+		transitions: make(map[sqlentity.LoanStatus]map[sqlentity.LoanStatus][]Guard),
+		onEnter:     make(map[sqlentity.LoanStatus][]Hook),
+		onExit:      make(map[sqlentity.LoanStatus][]Hook),
+	}
+
+	for _, t := range transitions {
+		if sm.transitions[t.From] == nil {
+			sm.transitions[t.From] = make(map[sqlentity.LoanStatus][]Guard)
+		}
+
+		sm.transitions[t.From][t.To] = append(sm.transitions[t.From][t.To], t.Guards...)
+	}
+
+	return sm
+}
+
+// DefaultTransitions is the loan lifecycle: PROPOSED → APPROVED → INVESTED → DISBURSED → REPAID, where a proposal can
+// also end as REJECTED and an approved loan not fully funded by its funding deadline as EXPIRED. A disbursed loan behind
+// on its installments is LATE, from where it is either REPAID or ends as DEFAULTED.
+func DefaultTransitions() []Transition {
+	return []Transition{
+		{From: sqlentity.Proposed, To: sqlentity.Approved, Guards: []Guard{HasApprovalProof}},
+		{From: sqlentity.Proposed, To: sqlentity.Rejected, Guards: []Guard{HasRejectionReason}},
+		{From: sqlentity.Approved, To: sqlentity.Invested, Guards: []Guard{FullyFunded}},
+		{From: sqlentity.Approved, To: sqlentity.Expired, Guards: []Guard{FundingDeadlinePassed}},
+		{From: sqlentity.Invested, To: sqlentity.Disbursed, Guards: []Guard{HasAgreementLetter}},
+		{From: sqlentity.Disbursed, To: sqlentity.Repaid},
+		{From: sqlentity.Disbursed, To: sqlentity.Late},
+		{From: sqlentity.Late, To: sqlentity.Repaid},
+		{From: sqlentity.Late, To: sqlentity.Defaulted},
+	}
+}
+
+func (sm *LoanStateMachine) OnEnter(status sqlentity.LoanStatus, hooks ...Hook) {
+	sm.onEnter[status] = append(sm.onEnter[status], hooks...)
+}
+
+func (sm *LoanStateMachine) OnExit(status sqlentity.LoanStatus, hooks ...Hook) {
+	sm.onExit[status] = append(sm.onExit[status], hooks...)
+}
+
+// CanTransition reports whether the transition is declared, without evaluating its guards.
+func (sm *LoanStateMachine) CanTransition(from, to sqlentity.LoanStatus) error {
+	if _, ok := sm.transitions[from][to]; !ok {
+		return pkgerror.NewBusinessErrorCodeWithCustomMessage(
+			pkgerror.LoanInvalidStatusTransition,
+			fmt.Sprintf("loan with status %s cannot move to %s", from, to),
+		)
+	}
+
+	return nil
+}
+
+// Transition evaluates the guards and hooks of moving the loan to the given status and returns the loan with its
+// new status. The caller is responsible for persisting it.
+func (sm *LoanStateMachine) Transition(
+	ctx context.Context,
+	loan sqlentity.Loan,
+	to sqlentity.LoanStatus,
+) (sqlentity.Loan, error) {
+	from := loan.Status
+
+	if err := sm.CanTransition(from, to); err != nil {
+		return loan, err
+	}
+
+	for _, guard := range sm.transitions[from][to] {
+		if err := guard(ctx, loan); err != nil {
+			return loan, pkgerror.NewBusinessErrorCodeWithCustomMessage(
+				pkgerror.LoanTransitionGuardFailed,
+				err.Error(),
+			)
+		}
+	}
+
+	for _, hook := range sm.onExit[from] {
+		if err := hook(ctx, loan, from, to); err != nil {
+			return loan, err
+		}
+	}
+
+	loan.Status = to
+
+	for _, hook := range sm.onEnter[to] {
+		if err := hook(ctx, loan, from, to); err != nil {
+			return loan, err
+		}
+	}
+
+	return loan, nil
+}
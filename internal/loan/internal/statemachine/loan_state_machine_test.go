@@ -0,0 +1,252 @@
+package statemachine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoanStateMachine_Transition(t *testing.T) {
+	type args struct {
+		loan sqlentity.Loan
+		to   sqlentity.LoanStatus
+	}
+	tests := []struct {
+		name     string
+		args     args
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "proposed to approved without proof",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Proposed},
+				to:   sqlentity.Approved,
+			},
+			wantCode: pkgerror.LoanTransitionGuardFailed,
+			wantErr:  true,
+		},
+		{
+			name: "proposed to approved",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:                   sqlentity.Proposed,
+					ApprovalProofDocumentKey: sql.NullString{String: "loan/1/approval-proof/1.png", Valid: true},
+				},
+				to: sqlentity.Approved,
+			},
+		},
+		{
+			name: "proposed to rejected without reason",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:          sqlentity.Proposed,
+					RejectionReason: sql.NullString{String: " ", Valid: true},
+				},
+				to: sqlentity.Rejected,
+			},
+			wantCode: pkgerror.LoanTransitionGuardFailed,
+			wantErr:  true,
+		},
+		{
+			name: "proposed to rejected",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:          sqlentity.Proposed,
+					RejectionReason: sql.NullString{String: "borrower address cannot be verified", Valid: true},
+				},
+				to: sqlentity.Rejected,
+			},
+		},
+		{
+			name: "rejected is terminal",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Rejected},
+				to:   sqlentity.Approved,
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+		{
+			name: "undeclared transition",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Proposed},
+				to:   sqlentity.Disbursed,
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+		{
+			name: "approved to invested not fully funded",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:          sqlentity.Approved,
+					PrincipalAmount: decimal.NewFromInt(100),
+					InvestedAmount:  decimal.NewFromInt(99),
+				},
+				to: sqlentity.Invested,
+			},
+			wantCode: pkgerror.LoanTransitionGuardFailed,
+			wantErr:  true,
+		},
+		{
+			name: "approved to invested fully funded",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:          sqlentity.Approved,
+					PrincipalAmount: decimal.NewFromInt(100),
+					InvestedAmount:  decimal.NewFromInt(100),
+				},
+				to: sqlentity.Invested,
+			},
+		},
+		{
+			name: "approved to expired before the funding deadline",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:          sqlentity.Approved,
+					FundingDeadline: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+				},
+				to: sqlentity.Expired,
+			},
+			wantCode: pkgerror.LoanTransitionGuardFailed,
+			wantErr:  true,
+		},
+		{
+			name: "approved to expired after the funding deadline",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:          sqlentity.Approved,
+					FundingDeadline: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+				},
+				to: sqlentity.Expired,
+			},
+		},
+		{
+			name: "expired is terminal",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Expired},
+				to:   sqlentity.Invested,
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+		{
+			name: "invested to disbursed without agreement letter",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Invested},
+				to:   sqlentity.Disbursed,
+			},
+			wantCode: pkgerror.LoanTransitionGuardFailed,
+			wantErr:  true,
+		},
+		{
+			name: "invested to disbursed with agreement letter",
+			args: args{
+				loan: sqlentity.Loan{
+					Status:                     sqlentity.Invested,
+					AgreementLetterDocumentURL: sql.NullString{String: "letter.pdf", Valid: true},
+				},
+				to: sqlentity.Disbursed,
+			},
+		},
+		{
+			name: "disbursed to repaid",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Disbursed},
+				to:   sqlentity.Repaid,
+			},
+		},
+		{
+			name: "late to repaid",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Late},
+				to:   sqlentity.Repaid,
+			},
+		},
+		{
+			name: "late to defaulted",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Late},
+				to:   sqlentity.Defaulted,
+			},
+		},
+		{
+			name: "repaid is terminal",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Repaid},
+				to:   sqlentity.Late,
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+		{
+			name: "disbursed cannot default before being late",
+			args: args{
+				loan: sqlentity.Loan{Status: sqlentity.Disbursed},
+				to:   sqlentity.Defaulted,
+			},
+			wantCode: pkgerror.LoanInvalidStatusTransition,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := NewLoanStateMachine(DefaultTransitions()...)
+
+			got, err := sm.Transition(context.Background(), tt.args.loan, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoanStateMachine.Transition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+				assert.Equal(t, tt.args.loan.Status, got.Status)
+
+				return
+			}
+
+			assert.Equal(t, tt.args.to, got.Status)
+		})
+	}
+}
+
+func TestLoanStateMachine_Hooks(t *testing.T) {
+	sm := NewLoanStateMachine(DefaultTransitions()...)
+	loan := sqlentity.Loan{
+		Status:                   sqlentity.Proposed,
+		ApprovalProofDocumentKey: sql.NullString{String: "loan/1/approval-proof/1.png", Valid: true},
+	}
+
+	var calls []string
+	sm.OnExit(sqlentity.Proposed, func(_ context.Context, loan sqlentity.Loan, from, to sqlentity.LoanStatus) error {
+		calls = append(calls, "exit "+loan.Status.String())
+
+		return nil
+	})
+	sm.OnEnter(sqlentity.Approved, func(_ context.Context, loan sqlentity.Loan, from, to sqlentity.LoanStatus) error {
+		calls = append(calls, "enter "+loan.Status.String())
+
+		return nil
+	})
+
+	_, err := sm.Transition(context.Background(), loan, sqlentity.Approved)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"exit PROPOSED", "enter APPROVED"}, calls)
+
+	sm.OnEnter(sqlentity.Approved, func(context.Context, sqlentity.Loan, sqlentity.LoanStatus, sqlentity.LoanStatus) error {
+		return errors.New("hook error")
+	})
+
+	_, err = sm.Transition(context.Background(), loan, sqlentity.Approved)
+	assert.Error(t, err)
+}
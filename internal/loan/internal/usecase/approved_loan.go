@@ -1,14 +1,21 @@
 package usecase
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type (
 	ApprovedLoan interface {
 		Execute(ctx context.Context, in ApprovedLoanInput) error
 	}
 
+	// ApprovedLoanInput carries the field validator's photo proof of visiting the borrower. ApprovalDate is the day
+	// the loan was approved and cannot be in the future.
 	ApprovedLoanInput struct {
-		LoanID     uint64 `json:"loan_id"     validate:"required"`
-		EmployeeID uint64 `json:"employee_id" validate:"required"`
+		LoanID       uint64    `json:"loan_id"       validate:"required"`
+		EmployeeID   uint64    `json:"-"             validate:"required"`
+		ApprovalDate time.Time `json:"approval_date" validate:"required"`
+		ProofPhoto   []byte    `json:"-"             validate:"required"`
 	}
 )
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// CancelLoanInvestment withdraws an investment while its loan is still open for investment, the money reserved for
+	// it goes back to the wallet of the investor.
+	CancelLoanInvestment interface {
+		Execute(ctx context.Context, in CancelLoanInvestmentInput) (*CancelLoanInvestmentOutput, error)
+	}
+
+	CancelLoanInvestmentInput struct {
+		LoanID       uint64 `json:"loan_id"       validate:"required"`
+		InvestmentID uint64 `json:"investment_id" validate:"required"`
+		InvestorID   uint64 `json:"-"             validate:"required"`
+	}
+
+	CancelLoanInvestmentOutput struct {
+		InvestmentID    uint64          `json:"investment_id"`
+		CancelledAmount decimal.Decimal `json:"cancelled_amount"`
+		InvestedAmount  decimal.Decimal `json:"invested_amount"`
+		RemainingAmount decimal.Decimal `json:"remaining_amount"`
+	}
+)
@@ -0,0 +1,15 @@
+package usecase
+
+import "context"
+
+type (
+	// CaptureWalletReservations moves the money held in the wallets for the investments in a fully funded loan to the
+	// escrow. Reservations already captured are skipped, so it can be retried.
+	CaptureWalletReservations interface {
+		Execute(ctx context.Context, in CaptureWalletReservationsInput) error
+	}
+
+	CaptureWalletReservationsInput struct {
+		LoanID uint64 `json:"loan_id" validate:"required"`
+	}
+)
@@ -12,8 +12,10 @@ type (
 	}
 
 	CreateProposedLoanInput struct {
-		UserID       uint64          `json:"user_id"       validate:"required"` // UserID should get from authorization
-		InterestRate decimal.Decimal `json:"interest_rate" validate:"required"`
-		Amount       decimal.Decimal `json:"amount"        validate:"required"`
+		UserID          uint64          `json:"-"                validate:"required"`
+		InterestRate    decimal.Decimal `json:"interest_rate"    validate:"required"`
+		Amount          decimal.Decimal `json:"amount"           validate:"required"`
+		TenorMonths     int             `json:"tenor_months"     validate:"required,min=1,max=60"`
+		RepaymentMethod string          `json:"repayment_method" validate:"omitempty,oneof=FLAT ANNUITY"`
 	}
 )
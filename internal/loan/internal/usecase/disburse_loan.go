@@ -7,7 +7,10 @@ type (
 		Execute(ctx context.Context, in DisburseLoanInput) error
 	}
 
+	// DisburseLoanInput references the agreement letter returned by the upload of the loan.
 	DisburseLoanInput struct {
-		LoanID uint64 `json:"loan_id"`
+		LoanID             uint64 `json:"loan_id"`
+		EmployeeID         uint64 `json:"-"                    validate:"required"`
+		AgreementLetterKey string `json:"agreement_letter_key" validate:"required"`
 	}
 )
@@ -0,0 +1,16 @@
+package usecase
+
+import "context"
+
+type (
+	// DistributeRepayment credits every investor of the loan with their share of a repayment. A repayment already
+	// distributed is skipped, so it can be retried.
+	DistributeRepayment interface {
+		Execute(ctx context.Context, in DistributeRepaymentInput) error
+	}
+
+	DistributeRepaymentInput struct {
+		LoanID      uint64 `json:"loan_id" validate:"required"`
+		RepaymentID uint64 `json:"repayment_id" validate:"required"`
+	}
+)
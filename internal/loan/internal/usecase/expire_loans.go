@@ -0,0 +1,18 @@
+package usecase
+
+import "context"
+
+type (
+	// ExpireLoans ends a batch of approved loans not fully funded by their funding deadline, the money reserved for
+	// their investments goes back to the wallets.
+	ExpireLoans interface {
+		Execute(ctx context.Context) (*ExpireLoansOutput, error)
+	}
+
+	// ExpireLoansOutput counts the loans expired in one batch. HasMore reports a full batch, more loans are probably
+	// overdue.
+	ExpireLoansOutput struct {
+		Expired int
+		HasMore bool
+	}
+)
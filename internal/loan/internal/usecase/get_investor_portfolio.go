@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	GetInvestorPortfolio interface {
+		Execute(ctx context.Context, in GetInvestorPortfolioInput) (*GetInvestorPortfolioOutput, error)
+	}
+
+	GetInvestorPortfolioInput struct {
+		InvestorID uint64    `json:"investor_id" validate:"required"`
+		Scope      LoanScope `json:"-"`
+	}
+
+	// GetInvestorPortfolioOutput lists the investments of the investor with what they return. Returns are interest net
+	// of the platform fee, TotalEarned is the realized return and TotalOutstanding the principal not repaid yet. The
+	// yields are yearly percentages, WeightedAverageYield weighs every investment by its amount.
+	GetInvestorPortfolioOutput struct {
+		InvestorID           uint64                `json:"investor_id"`
+		PlatformFeeRate      decimal.Decimal       `json:"platform_fee_rate"`
+		TotalInvested        decimal.Decimal       `json:"total_invested"`
+		TotalOutstanding     decimal.Decimal       `json:"total_outstanding"`
+		TotalEarned          decimal.Decimal       `json:"total_earned"`
+		TotalExpectedReturn  decimal.Decimal       `json:"total_expected_return"`
+		WeightedAverageYield decimal.Decimal       `json:"weighted_average_yield"`
+		Investments          []PortfolioInvestment `json:"investments"`
+	}
+
+	// PortfolioInvestment is one investment of the portfolio. SharePercentage is the part of the loan principal it
+	// funds and ExpectedReturn the interest it earns once the loan is repaid.
+	PortfolioInvestment struct {
+		InvestmentID         uint64          `json:"investment_id"`
+		LoanID               uint64          `json:"loan_id"`
+		LoanStatus           string          `json:"loan_status"`
+		InterestRate         decimal.Decimal `json:"interest_rate"`
+		TenorMonths          int             `json:"tenor_months,omitempty"`
+		InvestedAmount       decimal.Decimal `json:"invested_amount"`
+		SharePercentage      decimal.Decimal `json:"share_percentage"`
+		ExpectedReturn       decimal.Decimal `json:"expected_return"`
+		RealizedReturn       decimal.Decimal `json:"realized_return"`
+		ReceivedPrincipal    decimal.Decimal `json:"received_principal"`
+		OutstandingPrincipal decimal.Decimal `json:"outstanding_principal"`
+		Yield                decimal.Decimal `json:"yield"`
+	}
+)
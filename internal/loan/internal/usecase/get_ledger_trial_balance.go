@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	GetLedgerTrialBalance interface {
+		Execute(ctx context.Context, in GetLedgerTrialBalanceInput) (*GetLedgerTrialBalanceOutput, error)
+	}
+
+	GetLedgerTrialBalanceInput struct{}
+
+	// GetLedgerTrialBalanceOutput lists what was posted to every ledger account, the books are balanced when the
+	// total debit equals the total credit.
+	GetLedgerTrialBalanceOutput struct {
+		Balanced    bool                 `json:"balanced"`
+		TotalDebit  decimal.Decimal      `json:"total_debit"`
+		TotalCredit decimal.Decimal      `json:"total_credit"`
+		Accounts    []LedgerAccountTotal `json:"accounts"`
+	}
+
+	// LedgerAccountTotal is what was posted to one account, Balance being its credits minus its debits. The accounts
+	// of the platform have no owner.
+	LedgerAccountTotal struct {
+		AccountID uint64          `json:"account_id"`
+		Type      string          `json:"type"`
+		OwnerID   uint64          `json:"owner_id,omitempty"`
+		Debit     decimal.Decimal `json:"debit"`
+		Credit    decimal.Decimal `json:"credit"`
+		Balance   decimal.Decimal `json:"balance"`
+	}
+)
@@ -0,0 +1,14 @@
+package usecase
+
+import "context"
+
+type (
+	GetLoanDetail interface {
+		Execute(ctx context.Context, in GetLoanDetailInput) (*Loan, error)
+	}
+
+	GetLoanDetailInput struct {
+		LoanID uint64    `json:"loan_id" validate:"required"`
+		Scope  LoanScope `json:"-"`
+	}
+)
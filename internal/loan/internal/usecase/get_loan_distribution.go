@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	GetLoanDistribution interface {
+		Execute(ctx context.Context, in GetLoanDistributionInput) (*GetLoanDistributionOutput, error)
+	}
+
+	GetLoanDistributionInput struct {
+		LoanID uint64    `json:"loan_id" validate:"required"`
+		Scope  LoanScope `json:"-"`
+	}
+
+	// GetLoanDistributionOutput lists what every investment of the loan received of the repayments and what is still
+	// owed to it, an investor only sees their own investments.
+	GetLoanDistributionOutput struct {
+		LoanID          uint64                   `json:"loan_id"`
+		PlatformFeeRate decimal.Decimal          `json:"platform_fee_rate"`
+		Investments     []LoanInvestmentEarnings `json:"investments"`
+	}
+
+	// LoanInvestmentEarnings is the repayment position of one investment. Interest amounts are net of the platform
+	// fee, OutstandingInterest is the share of the interest the borrower has not repaid yet.
+	LoanInvestmentEarnings struct {
+		InvestmentID         uint64          `json:"investment_id"`
+		InvestorID           uint64          `json:"investor_id"`
+		InvestedAmount       decimal.Decimal `json:"invested_amount"`
+		ReceivedPrincipal    decimal.Decimal `json:"received_principal"`
+		ReceivedInterest     decimal.Decimal `json:"received_interest"`
+		PlatformFee          decimal.Decimal `json:"platform_fee"`
+		OutstandingPrincipal decimal.Decimal `json:"outstanding_principal"`
+		OutstandingInterest  decimal.Decimal `json:"outstanding_interest"`
+	}
+)
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	GetLoanSchedule interface {
+		Execute(ctx context.Context, in GetLoanScheduleInput) (*GetLoanScheduleOutput, error)
+	}
+
+	GetLoanScheduleInput struct {
+		LoanID uint64    `json:"loan_id" validate:"required"`
+		Scope  LoanScope `json:"-"`
+	}
+
+	// GetLoanScheduleOutput lists the installments of a disbursed loan, it is empty until the loan is disbursed.
+	GetLoanScheduleOutput struct {
+		LoanID          uint64            `json:"loan_id"`
+		TenorMonths     int               `json:"tenor_months"`
+		RepaymentMethod string            `json:"repayment_method"`
+		TotalPrincipal  decimal.Decimal   `json:"total_principal"`
+		TotalInterest   decimal.Decimal   `json:"total_interest"`
+		TotalAmount     decimal.Decimal   `json:"total_amount"`
+		Installments    []LoanInstallment `json:"installments"`
+	}
+
+	LoanInstallment struct {
+		InstallmentNumber   int             `json:"installment_number"`
+		DueDate             time.Time       `json:"due_date"`
+		PrincipalAmount     decimal.Decimal `json:"principal_amount"`
+		InterestAmount      decimal.Decimal `json:"interest_amount"`
+		TotalAmount         decimal.Decimal `json:"total_amount"`
+		OutstandingBalance  decimal.Decimal `json:"outstanding_balance"`
+		PaidPrincipalAmount decimal.Decimal `json:"paid_principal_amount"`
+		PaidInterestAmount  decimal.Decimal `json:"paid_interest_amount"`
+		Status              string          `json:"status"`
+		PaidAt              *time.Time      `json:"paid_at,omitempty"`
+	}
+)
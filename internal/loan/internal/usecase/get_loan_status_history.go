@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	GetLoanStatusHistory interface {
+		Execute(ctx context.Context, in GetLoanStatusHistoryInput) (*GetLoanStatusHistoryOutput, error)
+	}
+
+	GetLoanStatusHistoryInput struct {
+		LoanID uint64    `json:"loan_id" validate:"required"`
+		Scope  LoanScope `json:"-"`
+	}
+
+	GetLoanStatusHistoryOutput struct {
+		LoanID    uint64              `json:"loan_id"`
+		Histories []LoanStatusHistory `json:"histories"`
+	}
+
+	LoanStatusHistory struct {
+		FromStatus  string    `json:"from_status"`
+		ToStatus    string    `json:"to_status"`
+		ActorUserID uint64    `json:"actor_user_id"`
+		Reason      string    `json:"reason"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+)
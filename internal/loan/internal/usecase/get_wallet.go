@@ -0,0 +1,13 @@
+package usecase
+
+import "context"
+
+type (
+	GetWallet interface {
+		Execute(ctx context.Context, in GetWalletInput) (*Wallet, error)
+	}
+
+	GetWalletInput struct {
+		InvestorID uint64 `json:"-" validate:"required"`
+	}
+)
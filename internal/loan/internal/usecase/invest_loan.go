@@ -8,12 +8,19 @@ import (
 
 type (
 	InvestLoan interface {
-		Execute(ctx context.Context, in InvestLoanInput) error
+		Execute(ctx context.Context, in InvestLoanInput) (*InvestLoanOutput, error)
 	}
 
 	InvestLoanInput struct {
 		LoanID     uint64          `json:"loan_id"`
-		InvestorID uint64          `json:"investor_id" validate:"required"`
+		InvestorID uint64          `json:"-"           validate:"required"`
 		Amount     decimal.Decimal `json:"amount"      validate:"required"`
 	}
+
+	InvestLoanOutput struct {
+		InvestmentID    uint64          `json:"investment_id"`
+		RequestedAmount decimal.Decimal `json:"requested_amount"`
+		AcceptedAmount  decimal.Decimal `json:"accepted_amount"`
+		RemainingAmount decimal.Decimal `json:"remaining_amount"`
+	}
 )
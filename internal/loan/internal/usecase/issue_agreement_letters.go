@@ -0,0 +1,15 @@
+package usecase
+
+import "context"
+
+type (
+	// IssueAgreementLetters generates the agreement letter of every investment of a fully funded loan that does not
+	// have one yet, so it can be retried after a partial failure.
+	IssueAgreementLetters interface {
+		Execute(ctx context.Context, in IssueAgreementLettersInput) error
+	}
+
+	IssueAgreementLettersInput struct {
+		LoanID uint64 `json:"loan_id" validate:"required"`
+	}
+)
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	ListLoans interface {
+		Execute(ctx context.Context, in ListLoansInput) (*ListLoansOutput, error)
+	}
+
+	// ListLoansInput filters loans by borrower, status and creation date. CreatedFrom and CreatedTo are inclusive
+	// dates, Cursor is the last loan ID of the previous page. Scope narrows the result to the loans of the caller.
+	ListLoansInput struct {
+		BorrowerID  uint64    `json:"borrower_id"`
+		Status      string    `json:"status"`
+		CreatedFrom time.Time `json:"created_from"`
+		CreatedTo   time.Time `json:"created_to"   validate:"omitempty,gtefield=CreatedFrom"`
+		Cursor      uint64    `json:"cursor"`
+		Limit       uint      `json:"limit"        validate:"omitempty,max=100"`
+		Sort        string    `json:"sort"         validate:"omitempty,oneof=asc desc"`
+		Scope       LoanScope `json:"-"`
+	}
+
+	ListLoansOutput struct {
+		Loans      []Loan `json:"loans"`
+		NextCursor uint64 `json:"next_cursor,omitempty"`
+	}
+)
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	// LoanScope restricts the loans a caller may read. Borrowers are scoped to the loans they proposed and investors
+	// to the loans they invested in or that are still open for investment. The zero value reads every loan.
+	LoanScope struct {
+		BorrowerID uint64
+		InvestorID uint64
+	}
+
+	Loan struct {
+		ID              uint64            `json:"id"`
+		BorrowerID      uint64            `json:"borrower_id"`
+		PrincipalAmount decimal.Decimal   `json:"principal_amount"`
+		InvestedAmount  decimal.Decimal   `json:"invested_amount"`
+		RemainingAmount decimal.Decimal   `json:"remaining_amount"`
+		InterestRate    decimal.Decimal   `json:"interest_rate"`
+		TenorMonths     int               `json:"tenor_months,omitempty"`
+		RepaymentMethod string            `json:"repayment_method"`
+		Status          string            `json:"status"`
+		Approval        *LoanApproval     `json:"approval,omitempty"`
+		Rejection       *LoanRejection    `json:"rejection,omitempty"`
+		Disbursement    *LoanDisbursement `json:"disbursement,omitempty"`
+		Investments     []LoanInvestment  `json:"investments"`
+	}
+
+	LoanApproval struct {
+		EmployeeID       uint64    `json:"employee_id"`
+		Date             time.Time `json:"date"`
+		ProofDocumentKey string    `json:"proof_document_key,omitempty"`
+		// FundingDeadline is when the loan expires unless it is fully funded, it is empty for loans approved before
+		// funding windows existed.
+		FundingDeadline *time.Time `json:"funding_deadline,omitempty"`
+	}
+
+	LoanRejection struct {
+		EmployeeID uint64    `json:"employee_id"`
+		Date       time.Time `json:"date"`
+		Reason     string    `json:"reason"`
+	}
+
+	// LoanDisbursement links the agreement letter by its key. AgreementLetterDocumentURL is a short-lived signed URL,
+	// only set when reading a single loan.
+	LoanDisbursement struct {
+		Date                       time.Time `json:"date"`
+		AgreementLetterDocumentKey string    `json:"agreement_letter_document_key,omitempty"`
+		AgreementLetterDocumentURL string    `json:"agreement_letter_document_url,omitempty"`
+	}
+
+	// LoanInvestment links the investor's generated agreement letter by its key. AgreementLetterDocumentURL is a
+	// short-lived signed URL, only set for the investor and employees when reading a single loan.
+	LoanInvestment struct {
+		ID                         uint64          `json:"id"`
+		InvestorID                 uint64          `json:"investor_id"`
+		Amount                     decimal.Decimal `json:"amount"`
+		AgreementLetterDocumentKey string          `json:"agreement_letter_document_key,omitempty"`
+		AgreementLetterDocumentURL string          `json:"agreement_letter_document_url,omitempty"`
+	}
+)
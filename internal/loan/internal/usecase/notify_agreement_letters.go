@@ -0,0 +1,15 @@
+package usecase
+
+import "context"
+
+type (
+	// NotifyAgreementLetters emails every investor of a fully funded loan a link to their agreement letter. Investors
+	// already notified are skipped, so it can be retried after a partial failure.
+	NotifyAgreementLetters interface {
+		Execute(ctx context.Context, in NotifyAgreementLettersInput) error
+	}
+
+	NotifyAgreementLettersInput struct {
+		LoanID uint64 `json:"loan_id" validate:"required"`
+	}
+)
@@ -0,0 +1,15 @@
+package usecase
+
+import "context"
+
+type (
+	RejectLoan interface {
+		Execute(ctx context.Context, in RejectLoanInput) error
+	}
+
+	RejectLoanInput struct {
+		LoanID     uint64 `json:"loan_id"`
+		EmployeeID uint64 `json:"-"       validate:"required"`
+		Reason     string `json:"reason"  validate:"required,max=1000"`
+	}
+)
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	RepayLoan interface {
+		Execute(ctx context.Context, in RepayLoanInput) (*RepayLoanOutput, error)
+	}
+
+	RepayLoanInput struct {
+		LoanID     uint64          `json:"loan_id"`
+		BorrowerID uint64          `json:"-"       validate:"required"`
+		Amount     decimal.Decimal `json:"amount"  validate:"required"`
+	}
+
+	// RepayLoanOutput tells how the payment was allocated. OverpaidAmount is what was left of it once every
+	// installment was paid, OutstandingAmount what the borrower still owes.
+	RepayLoanOutput struct {
+		RepaymentID       uint64                    `json:"repayment_id"`
+		Amount            decimal.Decimal           `json:"amount"`
+		PrincipalAmount   decimal.Decimal           `json:"principal_amount"`
+		InterestAmount    decimal.Decimal           `json:"interest_amount"`
+		OverpaidAmount    decimal.Decimal           `json:"overpaid_amount"`
+		OutstandingAmount decimal.Decimal           `json:"outstanding_amount"`
+		LoanStatus        string                    `json:"loan_status"`
+		Allocations       []LoanRepaymentAllocation `json:"allocations"`
+	}
+
+	LoanRepaymentAllocation struct {
+		InstallmentNumber int             `json:"installment_number"`
+		PrincipalAmount   decimal.Decimal `json:"principal_amount"`
+		InterestAmount    decimal.Decimal `json:"interest_amount"`
+		Status            string          `json:"status"`
+	}
+)
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	TopUpWallet interface {
+		Execute(ctx context.Context, in TopUpWalletInput) (*Wallet, error)
+	}
+
+	TopUpWalletInput struct {
+		InvestorID uint64          `json:"-"      validate:"required"`
+		Amount     decimal.Decimal `json:"amount" validate:"required"`
+	}
+)
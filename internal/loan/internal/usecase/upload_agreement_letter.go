@@ -0,0 +1,23 @@
+package usecase
+
+import "context"
+
+type (
+	UploadAgreementLetter interface {
+		Execute(ctx context.Context, in UploadAgreementLetterInput) (UploadAgreementLetterOutput, error)
+	}
+
+	UploadAgreementLetterInput struct {
+		LoanID     uint64 `json:"loan_id"`
+		EmployeeID uint64 `json:"-"       validate:"required"`
+		Content    []byte `json:"-"       validate:"required"`
+	}
+
+	UploadAgreementLetterOutput struct {
+		DocumentID         uint64 `json:"document_id"`
+		AgreementLetterKey string `json:"agreement_letter_key"`
+		ContentType        string `json:"content_type"`
+		Size               int64  `json:"size"`
+		ChecksumSHA256     string `json:"checksum_sha256"`
+	}
+)
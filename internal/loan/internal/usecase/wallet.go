@@ -0,0 +1,12 @@
+package usecase
+
+import "github.com/shopspring/decimal"
+
+// Wallet is the money of an investor. ReservedAmount is held for their investments in loans not fully funded yet,
+// AvailableAmount is what they can still invest or withdraw.
+type Wallet struct {
+	InvestorID      uint64          `json:"investor_id"`
+	Balance         decimal.Decimal `json:"balance"`
+	ReservedAmount  decimal.Decimal `json:"reserved_amount"`
+	AvailableAmount decimal.Decimal `json:"available_amount"`
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+type (
+	WithdrawWallet interface {
+		Execute(ctx context.Context, in WithdrawWalletInput) (*Wallet, error)
+	}
+
+	WithdrawWalletInput struct {
+		InvestorID uint64          `json:"-"      validate:"required"`
+		Amount     decimal.Decimal `json:"amount" validate:"required"`
+	}
+)
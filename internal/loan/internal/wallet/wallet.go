@@ -0,0 +1,324 @@
+// Package wallet keeps the money of the investors.
+//
+// Investors top their wallet up from outside the platform and withdraw from it. Investing in a loan does not take the
+// money out of the wallet yet, it reserves it: the reserved money cannot be withdrawn nor invested again, and it is
+// only moved to the escrow once the loan is fully funded. A reservation is released, giving the money back, when the
+// investment will not be funded. The repayments distributed to the investors are credited to their wallets. Every
+// change of a wallet locks it until the end of the transaction, and the money it moves is posted to the ledger in the
+// same transaction.
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shopspring/decimal"
+)
+
+// AmountScale is the number of decimals of the money moved in and out of the wallets.
+const AmountScale = 2
+
+var (
+	// ErrInsufficientBalance is returned when the money available in a wallet is less than the amount to take from it.
+	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+
+	// ErrInvalidAmount is returned for an amount that is not positive or has more than AmountScale decimals.
+	ErrInvalidAmount = errors.New("invalid wallet amount")
+)
+
+type (
+	// Keeper moves the money of the wallets within the transaction carried by ctx.
+	Keeper interface {
+		TopUp(ctx context.Context, investorID uint64, amount decimal.Decimal) (sqlentity.Wallet, error)
+		Withdraw(ctx context.Context, investorID uint64, amount decimal.Decimal) (sqlentity.Wallet, error)
+		Reserve(ctx context.Context, investment sqlentity.LoanInvestment) error
+		Capture(ctx context.Context, loanID uint64) error
+		Release(ctx context.Context, loanID uint64) error
+		ReleaseInvestment(ctx context.Context, investmentID uint64) error
+		Credit(ctx context.Context, credit sqlentity.LoanInvestorCredit) error
+	}
+
+	WalletStore interface {
+		GetWallet(ctx context.Context, opts ...gateway.GetWalletOption) (sqlentity.Wallets, error)
+		InsertWallet(ctx context.Context, in sqlentity.Wallet) error
+		UpdateWallet(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWalletOption) error
+		GetWalletReservation(
+			ctx context.Context,
+			opts ...gateway.GetWalletReservationOption,
+		) (sqlentity.WalletReservations, error)
+		InsertWalletReservation(ctx context.Context, in sqlentity.WalletReservation) error
+		UpdateWalletReservation(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateWalletReservationOption,
+		) error
+	}
+
+	Wallets struct {
+		store        WalletStore
+		ledger       ledger.Poster
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+// ValidAmount reports whether amount can be moved in or out of a wallet: positive with at most AmountScale decimals.
+func ValidAmount(amount decimal.Decimal) bool {
+	return amount.IsPositive() && amount.Equal(amount.Round(AmountScale))
+}
+
+func NewWallets(store WalletStore, ledger ledger.Poster, snowflakeGen pkguid.Snowflake) *Wallets {
+	return &Wallets{
+		store:        store,
+		ledger:       ledger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+// TopUp adds money to the wallet of the investor, opening it on their first top-up.
+func (w *Wallets) TopUp(ctx context.Context, investorID uint64, amount decimal.Decimal) (sqlentity.Wallet, error) {
+	// another transaction may open the same wallet at once, the insert skips it and the locking read waits for it
+	if err := w.store.InsertWallet(ctx, sqlentity.Wallet{
+		InvestorID:     investorID,
+		Balance:        decimal.Zero,
+		ReservedAmount: decimal.Zero,
+	}); err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	wallet, err := w.lock(ctx, investorID)
+	if err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	wallet.Balance = wallet.Balance.Add(amount)
+
+	if err := w.update(ctx, wallet); err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	if err := w.ledger.Post(ctx, ledger.TopUp(w.snowflakeGen.Generate(), investorID, amount)); err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	return wallet, nil
+}
+
+// Withdraw takes money out of the wallet of the investor, the reserved money cannot be withdrawn.
+func (w *Wallets) Withdraw(ctx context.Context, investorID uint64, amount decimal.Decimal) (sqlentity.Wallet, error) {
+	wallet, err := w.lock(ctx, investorID)
+	if err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	if wallet.AvailableAmount().LessThan(amount) {
+		return sqlentity.Wallet{}, ErrInsufficientBalance
+	}
+
+	wallet.Balance = wallet.Balance.Sub(amount)
+
+	if err := w.update(ctx, wallet); err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	if err := w.ledger.Post(ctx, ledger.Withdrawal(w.snowflakeGen.Generate(), investorID, amount)); err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	return wallet, nil
+}
+
+// Reserve holds the money of the investment in the wallet of the investor until the loan is fully funded.
+func (w *Wallets) Reserve(ctx context.Context, investment sqlentity.LoanInvestment) error {
+	// a negative reservation would hand the investor money that was never put in the wallet
+	if !ValidAmount(investment.Amount) {
+		return ErrInvalidAmount
+	}
+
+	wallet, err := w.lock(ctx, investment.InvestorID)
+	if err != nil {
+		return err
+	}
+
+	if wallet.AvailableAmount().LessThan(investment.Amount) {
+		return ErrInsufficientBalance
+	}
+
+	wallet.ReservedAmount = wallet.ReservedAmount.Add(investment.Amount)
+
+	if err := w.update(ctx, wallet); err != nil {
+		return err
+	}
+
+	return w.store.InsertWalletReservation(ctx, sqlentity.WalletReservation{
+		ID:           w.snowflakeGen.Generate(),
+		InvestorID:   investment.InvestorID,
+		LoanID:       investment.LoanID,
+		InvestmentID: investment.ID,
+		Amount:       investment.Amount,
+		Status:       sqlentity.ReservationHeld,
+	})
+}
+
+// Capture moves the money held for the investments in the loan out of the wallets and into the escrow.
+func (w *Wallets) Capture(ctx context.Context, loanID uint64) error {
+	return w.settle(ctx, sqlentity.ReservationCaptured, gateway.GetWalletReservationWithLoanIDFilter(loanID))
+}
+
+// Release gives the money held for the investments in the loan back to the wallets.
+func (w *Wallets) Release(ctx context.Context, loanID uint64) error {
+	return w.settle(ctx, sqlentity.ReservationReleased, gateway.GetWalletReservationWithLoanIDFilter(loanID))
+}
+
+// ReleaseInvestment gives the money held for a single investment back to the wallet of its investor.
+func (w *Wallets) ReleaseInvestment(ctx context.Context, investmentID uint64) error {
+	return w.settle(
+		ctx,
+		sqlentity.ReservationReleased,
+		gateway.GetWalletReservationWithInvestmentIDFilter(investmentID),
+	)
+}
+
+// Credit adds the share of a repayment owed to an investment to the wallet of its investor, the platform fee taken
+// from it goes to the fee revenue.
+func (w *Wallets) Credit(ctx context.Context, credit sqlentity.LoanInvestorCredit) error {
+	amount := credit.PrincipalAmount.Add(credit.InterestAmount)
+	if amount.IsZero() && credit.FeeAmount.IsZero() {
+		return nil
+	}
+
+	// investments made before the wallets existed have no wallet to credit yet
+	if err := w.store.InsertWallet(ctx, sqlentity.Wallet{
+		InvestorID:     credit.InvestorID,
+		Balance:        decimal.Zero,
+		ReservedAmount: decimal.Zero,
+	}); err != nil {
+		return err
+	}
+
+	wallet, err := w.lock(ctx, credit.InvestorID)
+	if err != nil {
+		return err
+	}
+
+	wallet.Balance = wallet.Balance.Add(amount)
+
+	if err := w.update(ctx, wallet); err != nil {
+		return err
+	}
+
+	return w.ledger.Post(ctx, ledger.Distribution(credit))
+}
+
+// settle ends the reservations still held among the ones selected by opts. A reservation settled by another
+// transaction since it was read fails the settlement with gateway.ErrWalletReservationNotUpdated.
+func (w *Wallets) settle(
+	ctx context.Context,
+	status sqlentity.WalletReservationStatus,
+	opts ...gateway.GetWalletReservationOption,
+) error {
+	reservations, err := w.store.GetWalletReservation(
+		ctx,
+		append(opts, gateway.GetWalletReservationWithStatusFilter(sqlentity.ReservationHeld))...,
+	)
+	if err != nil {
+		return err
+	}
+
+	if reservations.IsEmpty() {
+		return nil
+	}
+
+	investorIDs := make([]uint64, 0, reservations.Len())
+	for _, reservation := range reservations {
+		investorIDs = append(investorIDs, reservation.InvestorID)
+	}
+
+	wallets, err := w.store.GetWallet(
+		ctx,
+		gateway.GetWalletWithInvestorIDFilter(investorIDs...),
+		gateway.GetWalletWithLock(),
+	)
+	if err != nil {
+		return err
+	}
+
+	byInvestor := make(map[uint64]sqlentity.Wallet, wallets.Len())
+	for _, wallet := range wallets {
+		byInvestor[wallet.InvestorID] = wallet
+	}
+
+	for _, reservation := range reservations {
+		wallet, ok := byInvestor[reservation.InvestorID]
+		if !ok {
+			return fmt.Errorf("wallet of investor %d not found", reservation.InvestorID)
+		}
+
+		if err := w.store.UpdateWalletReservation(
+			ctx,
+			sqlentity.UpdateWalletReservationStatus{Status: status},
+			gateway.UpdateWalletReservationWithIDFilter(reservation.ID),
+			gateway.UpdateWalletReservationWithStatusFilter(sqlentity.ReservationHeld),
+		); err != nil {
+			return err
+		}
+
+		wallet.ReservedAmount = wallet.ReservedAmount.Sub(reservation.Amount)
+
+		if status == sqlentity.ReservationCaptured {
+			wallet.Balance = wallet.Balance.Sub(reservation.Amount)
+
+			if err := w.ledger.Post(ctx, ledger.Investment(sqlentity.LoanInvestment{
+				ID:         reservation.InvestmentID,
+				LoanID:     reservation.LoanID,
+				InvestorID: reservation.InvestorID,
+				Amount:     reservation.Amount,
+			})); err != nil {
+				return err
+			}
+		}
+
+		byInvestor[reservation.InvestorID] = wallet
+	}
+
+	for _, wallet := range wallets {
+		if err := w.update(ctx, byInvestor[wallet.InvestorID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lock reads the wallet of the investor and locks it, an investor without a wallet has an empty one.
+func (w *Wallets) lock(ctx context.Context, investorID uint64) (sqlentity.Wallet, error) {
+	wallets, err := w.store.GetWallet(
+		ctx,
+		gateway.GetWalletWithInvestorIDFilter(investorID),
+		gateway.GetWalletWithLock(),
+	)
+	if err != nil {
+		return sqlentity.Wallet{}, err
+	}
+
+	if wallets.IsEmpty() {
+		return sqlentity.Wallet{InvestorID: investorID, Balance: decimal.Zero, ReservedAmount: decimal.Zero}, nil
+	}
+
+	return wallets.First(), nil
+}
+
+func (w *Wallets) update(ctx context.Context, wallet sqlentity.Wallet) error {
+	return w.store.UpdateWallet(
+		ctx,
+		sqlentity.UpdateWalletBalance{
+			Balance:        wallet.Balance,
+			ReservedAmount: wallet.ReservedAmount,
+		},
+		gateway.UpdateWalletWithInvestorIDFilter(wallet.InvestorID),
+	)
+}
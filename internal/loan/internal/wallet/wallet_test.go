@@ -0,0 +1,369 @@
+package wallet_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	loanmocks "github.com/shandysiswandi/test-amartha/internal/loan/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryWalletStore keeps the wallet of a single investor in memory. It ignores the filters, except that only the held
+// reservations are read and updated, so at most one loan may hold a reservation at a time.
+type memoryWalletStore struct {
+	wallets      sqlentity.Wallets
+	reservations sqlentity.WalletReservations
+}
+
+func (m *memoryWalletStore) GetWallet(context.Context, ...gateway.GetWalletOption) (sqlentity.Wallets, error) {
+	return m.wallets, nil
+}
+
+func (m *memoryWalletStore) InsertWallet(_ context.Context, in sqlentity.Wallet) error {
+	if m.wallets.IsEmpty() {
+		m.wallets = append(m.wallets, in)
+	}
+
+	return nil
+}
+
+func (m *memoryWalletStore) UpdateWallet(
+	_ context.Context,
+	in sqlentity.UpdateEntity,
+	_ ...gateway.UpdateWalletOption,
+) error {
+	update, _ := in.(sqlentity.UpdateWalletBalance)
+	if update.ReservedAmount.IsNegative() || update.ReservedAmount.GreaterThan(update.Balance) {
+		return errors.New("check constraint violated")
+	}
+
+	m.wallets[0].Balance = update.Balance
+	m.wallets[0].ReservedAmount = update.ReservedAmount
+
+	return nil
+}
+
+func (m *memoryWalletStore) GetWalletReservation(
+	context.Context,
+	...gateway.GetWalletReservationOption,
+) (sqlentity.WalletReservations, error) {
+	var held sqlentity.WalletReservations
+	for _, reservation := range m.reservations {
+		if reservation.Status == sqlentity.ReservationHeld {
+			held = append(held, reservation)
+		}
+	}
+
+	return held, nil
+}
+
+func (m *memoryWalletStore) InsertWalletReservation(_ context.Context, in sqlentity.WalletReservation) error {
+	m.reservations = append(m.reservations, in)
+
+	return nil
+}
+
+func (m *memoryWalletStore) UpdateWalletReservation(
+	_ context.Context,
+	in sqlentity.UpdateEntity,
+	_ ...gateway.UpdateWalletReservationOption,
+) error {
+	update, _ := in.(sqlentity.UpdateWalletReservationStatus)
+	for i := range m.reservations {
+		if m.reservations[i].Status == sqlentity.ReservationHeld {
+			m.reservations[i].Status = update.Status
+		}
+	}
+
+	return nil
+}
+
+// memoryPoster keeps the posted entries in memory.
+type memoryPoster struct {
+	entries []ledger.Entry
+}
+
+func (m *memoryPoster) Post(_ context.Context, entry ledger.Entry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	m.entries = append(m.entries, entry)
+
+	return nil
+}
+
+// balance is the credits minus the debits posted to the account.
+func (m *memoryPoster) balance(account ledger.Account) decimal.Decimal {
+	balance := decimal.Zero
+	for _, entry := range m.entries {
+		for _, line := range entry.Lines {
+			if line.Account == account {
+				balance = balance.Add(line.Credit).Sub(line.Debit)
+			}
+		}
+	}
+
+	return balance
+}
+
+func newSnowflake(t *testing.T) *pkgmocks.MockSnowflake {
+	t.Helper()
+
+	var id uint64
+	snowflakeGen := pkgmocks.NewMockSnowflake(t)
+	snowflakeGen.EXPECT().Generate().RunAndReturn(func() uint64 {
+		id++
+
+		return id
+	}).Maybe()
+
+	return snowflakeGen
+}
+
+// TestWallets follows the wallet of an investor through an investment funded and repaid and others released, the
+// balance of the wallet must always match the balance of its ledger account.
+func TestWallets(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryWalletStore{}
+	poster := &memoryPoster{}
+	wallets := wallet.NewWallets(store, poster, newSnowflake(t))
+
+	assertWallet := func(balance, reserved int64) {
+		t.Helper()
+
+		got := store.wallets.First()
+		assert.True(t, decimal.NewFromInt(balance).Equal(got.Balance), "balance %s", got.Balance)
+		assert.True(t, decimal.NewFromInt(reserved).Equal(got.ReservedAmount), "reserved %s", got.ReservedAmount)
+		assert.True(t, got.Balance.Equal(poster.balance(ledger.InvestorWallet(3))), "ledger does not match wallet")
+	}
+
+	got, err := wallets.TopUp(ctx, 3, decimal.NewFromInt(1_000))
+	assert.NoError(t, err)
+	assert.True(t, decimal.NewFromInt(1_000).Equal(got.AvailableAmount()))
+	assertWallet(1_000, 0)
+
+	first := sqlentity.LoanInvestment{ID: 10, LoanID: 1, InvestorID: 3, Amount: decimal.NewFromInt(600)}
+	assert.NoError(t, wallets.Reserve(ctx, first))
+	assertWallet(1_000, 600)
+
+	// a reservation must be positive with at most 2 decimals, a negative one would make money out of nothing
+	for _, amount := range []string{"-1000", "0", "10.005"} {
+		invalid := sqlentity.LoanInvestment{ID: 12, LoanID: 2, InvestorID: 3, Amount: decimal.RequireFromString(amount)}
+		assert.ErrorIs(t, wallets.Reserve(ctx, invalid), wallet.ErrInvalidAmount, amount)
+	}
+	assertWallet(1_000, 600)
+
+	// the reserved money can neither be invested again nor withdrawn
+	second := sqlentity.LoanInvestment{ID: 11, LoanID: 2, InvestorID: 3, Amount: decimal.NewFromInt(500)}
+	assert.ErrorIs(t, wallets.Reserve(ctx, second), wallet.ErrInsufficientBalance)
+	_, err = wallets.Withdraw(ctx, 3, decimal.NewFromInt(500))
+	assert.ErrorIs(t, err, wallet.ErrInsufficientBalance)
+	assertWallet(1_000, 600)
+
+	assert.NoError(t, wallets.Capture(ctx, 1))
+	assertWallet(400, 0)
+	assert.True(t, decimal.NewFromInt(600).Equal(poster.balance(ledger.PlatformEscrow())))
+
+	// a redelivered capture finds nothing held anymore
+	assert.NoError(t, wallets.Capture(ctx, 1))
+	assertWallet(400, 0)
+
+	second.Amount = decimal.NewFromInt(300)
+	assert.NoError(t, wallets.Reserve(ctx, second))
+	assertWallet(400, 300)
+
+	assert.NoError(t, wallets.Release(ctx, 2))
+	assertWallet(400, 0)
+	assert.True(t, decimal.NewFromInt(600).Equal(poster.balance(ledger.PlatformEscrow())))
+
+	// a cancelled investment gives its money back on its own
+	third := sqlentity.LoanInvestment{ID: 12, LoanID: 4, InvestorID: 3, Amount: decimal.NewFromInt(150)}
+	assert.NoError(t, wallets.Reserve(ctx, third))
+	assertWallet(400, 150)
+	assert.NoError(t, wallets.ReleaseInvestment(ctx, third.ID))
+	assertWallet(400, 0)
+
+	// a repayment of the funded loan is credited to the wallet net of the fee and can be withdrawn
+	assert.NoError(t, wallets.Credit(ctx, sqlentity.LoanInvestorCredit{
+		LoanID:          1,
+		RepaymentID:     40,
+		InvestmentID:    first.ID,
+		InvestorID:      3,
+		PrincipalAmount: decimal.NewFromInt(300),
+		InterestAmount:  decimal.NewFromInt(27),
+		FeeAmount:       decimal.NewFromInt(3),
+	}))
+	assertWallet(727, 0)
+	assert.True(t, decimal.NewFromInt(270).Equal(poster.balance(ledger.PlatformEscrow())))
+	assert.True(t, decimal.NewFromInt(3).Equal(poster.balance(ledger.FeeRevenue())))
+
+	// a share too small to get a cent moves nothing
+	assert.NoError(t, wallets.Credit(ctx, sqlentity.LoanInvestorCredit{
+		LoanID:          1,
+		RepaymentID:     41,
+		InvestmentID:    first.ID,
+		InvestorID:      3,
+		PrincipalAmount: decimal.Zero,
+		InterestAmount:  decimal.Zero,
+		FeeAmount:       decimal.Zero,
+	}))
+	assertWallet(727, 0)
+
+	got, err = wallets.Withdraw(ctx, 3, decimal.NewFromInt(727))
+	assert.NoError(t, err)
+	assert.True(t, got.Balance.IsZero())
+	assertWallet(0, 0)
+
+	// the money that came into the platform and stayed is in the escrow and the fee revenue
+	assert.True(t, poster.balance(ledger.Settlement()).Neg().Equal(
+		poster.balance(ledger.PlatformEscrow()).Add(poster.balance(ledger.FeeRevenue())),
+	))
+}
+
+func TestWallets_Capture_Error(t *testing.T) {
+	ctx := context.Background()
+	reservations := sqlentity.WalletReservations{
+		{ID: 20, InvestorID: 3, LoanID: 1, InvestmentID: 10, Amount: decimal.NewFromInt(600)},
+	}
+	wallets := sqlentity.Wallets{
+		{InvestorID: 3, Balance: decimal.NewFromInt(1_000), ReservedAmount: decimal.NewFromInt(600)},
+	}
+
+	tests := []struct {
+		name   string
+		mockFn func(store *loanmocks.MockWalletStore, poster *loanmocks.MockPoster)
+	}{
+		{
+			name: "error when get wallet reservation",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().GetWalletReservation(ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when get wallet",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().GetWalletReservation(ctx, mock.Anything, mock.Anything).Return(reservations, nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error wallet not found",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().GetWalletReservation(ctx, mock.Anything, mock.Anything).Return(reservations, nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "error reservation settled by another transaction",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().GetWalletReservation(ctx, mock.Anything, mock.Anything).Return(reservations, nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(wallets, nil).Once()
+				store.EXPECT().UpdateWalletReservation(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrWalletReservationNotUpdated).Once()
+			},
+		},
+		{
+			name: "error when post investment",
+			mockFn: func(store *loanmocks.MockWalletStore, poster *loanmocks.MockPoster) {
+				store.EXPECT().GetWalletReservation(ctx, mock.Anything, mock.Anything).Return(reservations, nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(wallets, nil).Once()
+				store.EXPECT().UpdateWalletReservation(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+				poster.EXPECT().Post(ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when update wallet",
+			mockFn: func(store *loanmocks.MockWalletStore, poster *loanmocks.MockPoster) {
+				store.EXPECT().GetWalletReservation(ctx, mock.Anything, mock.Anything).Return(reservations, nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(wallets, nil).Once()
+				store.EXPECT().UpdateWalletReservation(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+				poster.EXPECT().Post(ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().UpdateWallet(ctx, mock.Anything, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockWalletStore(t)
+			poster := loanmocks.NewMockPoster(t)
+			tt.mockFn(store, poster)
+
+			err := wallet.NewWallets(store, poster, newSnowflake(t)).Capture(ctx, 1)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWallets_Credit_Error(t *testing.T) {
+	ctx := context.Background()
+	credit := sqlentity.LoanInvestorCredit{
+		LoanID:          1,
+		RepaymentID:     40,
+		InvestmentID:    10,
+		InvestorID:      3,
+		PrincipalAmount: decimal.NewFromInt(300),
+		InterestAmount:  decimal.NewFromInt(27),
+		FeeAmount:       decimal.NewFromInt(3),
+	}
+	wallets := sqlentity.Wallets{{InvestorID: 3, Balance: decimal.NewFromInt(400), ReservedAmount: decimal.Zero}}
+
+	tests := []struct {
+		name   string
+		mockFn func(store *loanmocks.MockWalletStore, poster *loanmocks.MockPoster)
+	}{
+		{
+			name: "error when insert wallet",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().InsertWallet(ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when get wallet",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().InsertWallet(ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when update wallet",
+			mockFn: func(store *loanmocks.MockWalletStore, _ *loanmocks.MockPoster) {
+				store.EXPECT().InsertWallet(ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(wallets, nil).Once()
+				store.EXPECT().UpdateWallet(ctx, mock.Anything, mock.Anything).Return(errors.New("any error")).Once()
+			},
+		},
+		{
+			name: "error when post distribution",
+			mockFn: func(store *loanmocks.MockWalletStore, poster *loanmocks.MockPoster) {
+				store.EXPECT().InsertWallet(ctx, mock.Anything).Return(nil).Once()
+				store.EXPECT().GetWallet(ctx, mock.Anything, mock.Anything).Return(wallets, nil).Once()
+				store.EXPECT().UpdateWallet(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+				poster.EXPECT().Post(ctx, mock.MatchedBy(func(e ledger.Entry) bool {
+					return e.Reference == "distribution:40:10" && e.Validate() == nil
+				})).Return(errors.New("any error")).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := loanmocks.NewMockWalletStore(t)
+			poster := loanmocks.NewMockPoster(t)
+			tt.mockFn(store, poster)
+
+			err := wallet.NewWallets(store, poster, newSnowflake(t)).Credit(ctx, credit)
+			assert.Error(t, err)
+		})
+	}
+}
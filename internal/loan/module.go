@@ -5,61 +5,296 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/julienschmidt/httprouter"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/agreement"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/event"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/gateway"
 	"github.com/shandysiswandi/test-amartha/internal/loan/internal/interactor"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/ledger"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/statemachine"
+	"github.com/shandysiswandi/test-amartha/internal/loan/internal/wallet"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgnotify"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
 	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgworker"
+	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
+// Exposed lets other modules react to the loan events published through the outbox, and carries the background workers
+// of the module the application starts with its HTTP server.
 type Exposed struct {
+	EventTypes []string
+	Workers    []*pkgworker.Periodic
 }
 
 type Dependencies struct {
-	DB           *sql.DB
-	Logger       *zap.SugaredLogger
-	QueryBuilder pkgsql.GoquBuilder
-	SnowflakeGen pkguid.Snowflake
-	HttpRouter   *httprouter.Router
-	Validator    *validator.Validate
+	Config        *viper.Viper
+	DB            *sql.DB
+	Logger        *zap.SugaredLogger
+	QueryBuilder  pkgsql.GoquBuilder
+	SnowflakeGen  pkguid.Snowflake
+	HttpRouter    *httprouter.Router
+	Validator     *validator.Validate
+	TokenVerifier pkgauth.TokenVerifier
+	DocumentStore pkgstorage.DocumentStore
+	Notifier      pkgnotify.Notifier
+	Outbox        pkgoutbox.Recorder
+	OutboxEvents  pkgoutbox.Registry
+	// WorkerLease lets a single instance of the application run the background workers.
+	WorkerLease pkgworker.Lease
 }
 
 func New(deps Dependencies) *Exposed {
 	loanSQLstore := gateway.NewLoanSQLGateway(deps.DB, deps.Logger, deps.QueryBuilder)
+	loanStateMachine := statemachine.NewLoanStateMachine(statemachine.DefaultTransitions()...)
+	loanLedger := ledger.NewBook(loanSQLstore, deps.SnowflakeGen)
+	loanWallets := wallet.NewWallets(loanSQLstore, loanLedger, deps.SnowflakeGen)
 
 	createProposedLoanUsecase := interactor.NewCreateProposedLoan(
 		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
 		deps.Logger,
 		deps.SnowflakeGen,
 	)
 
 	approveLoanUsecase := interactor.NewApproveLoan(
 		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
+		deps.DocumentStore,
+		loanStateMachine,
+		deps.Config.GetDuration("loan.funding.window"),
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	rejectLoanUsecase := interactor.NewRejectLoan(
+		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		loanStateMachine,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	issueAgreementLettersUsecase := interactor.NewIssueAgreementLetters(
+		loanSQLstore,
+		loanSQLstore,
+		deps.DocumentStore,
+		agreement.NewGenerator(),
+		deps.Logger,
+	)
+
+	notifyAgreementLettersUsecase := interactor.NewNotifyAgreementLetters(
+		loanSQLstore,
+		loanSQLstore,
+		deps.DocumentStore,
+		deps.Notifier,
 		deps.Logger,
+		deps.SnowflakeGen,
 	)
 
 	investLoanUsecase := interactor.NewInvestLoan(
 		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
+		loanWallets,
+		loanStateMachine,
+		interactor.OverInvestmentPolicyFromString(deps.Config.GetString("loan.over_investment.policy")),
 		deps.Logger,
 		deps.SnowflakeGen,
 	)
 
 	disburseLoanUsecase := interactor.NewDisburseLoan(
+		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
+		loanLedger,
+		loanWallets,
+		deps.DocumentStore,
+		loanStateMachine,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	repayLoanUsecase := interactor.NewRepayLoan(
+		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
+		loanLedger,
+		loanStateMachine,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	platformFeeRate := interactor.PlatformFeeRateFromString(
+		deps.Config.GetString("loan.distribution.platform_fee_rate"),
+	)
+
+	distributeRepaymentUsecase := interactor.NewDistributeRepayment(
+		loanSQLstore,
+		loanSQLstore,
+		loanWallets,
+		platformFeeRate,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	getLoanDetailUsecase := interactor.NewGetLoanDetail(
+		loanSQLstore,
+		deps.DocumentStore,
+		deps.Logger,
+	)
+
+	listLoansUsecase := interactor.NewListLoans(
 		loanSQLstore,
 		deps.Logger,
 	)
 
+	getLoanStatusHistoryUsecase := interactor.NewGetLoanStatusHistory(
+		loanSQLstore,
+		deps.Logger,
+	)
+
+	getLoanScheduleUsecase := interactor.NewGetLoanSchedule(
+		loanSQLstore,
+		deps.Logger,
+	)
+
+	getLoanDistributionUsecase := interactor.NewGetLoanDistribution(
+		loanSQLstore,
+		platformFeeRate,
+		deps.Logger,
+	)
+
+	getInvestorPortfolioUsecase := interactor.NewGetInvestorPortfolio(
+		loanSQLstore,
+		loanSQLstore,
+		platformFeeRate,
+		deps.Logger,
+	)
+
+	getLedgerTrialBalanceUsecase := interactor.NewGetLedgerTrialBalance(
+		loanSQLstore,
+		deps.Logger,
+	)
+
+	getWalletUsecase := interactor.NewGetWallet(
+		loanSQLstore,
+		deps.Logger,
+	)
+
+	topUpWalletUsecase := interactor.NewTopUpWallet(
+		loanSQLstore,
+		loanSQLstore,
+		loanWallets,
+		deps.Logger,
+	)
+
+	withdrawWalletUsecase := interactor.NewWithdrawWallet(
+		loanSQLstore,
+		loanSQLstore,
+		loanWallets,
+		deps.Logger,
+	)
+
+	cancelLoanInvestmentUsecase := interactor.NewCancelLoanInvestment(
+		loanSQLstore,
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
+		loanWallets,
+		loanStateMachine,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	captureWalletReservationsUsecase := interactor.NewCaptureWalletReservations(
+		loanSQLstore,
+		loanWallets,
+		deps.Logger,
+	)
+
+	expireLoansUsecase := interactor.NewExpireLoans(
+		loanSQLstore,
+		loanSQLstore,
+		deps.Outbox,
+		loanWallets,
+		loanStateMachine,
+		deps.Config.GetUint("loan.expiry.batch_size"),
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	uploadAgreementLetterUsecase := interactor.NewUploadAgreementLetter(
+		loanSQLstore,
+		loanSQLstore,
+		deps.DocumentStore,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
 	loanHTTPEndpoint := gateway.NewLoanHTTPEndpoint(
 		createProposedLoanUsecase,
 		approveLoanUsecase,
+		rejectLoanUsecase,
 		investLoanUsecase,
 		disburseLoanUsecase,
+		repayLoanUsecase,
+		getLoanDetailUsecase,
+		listLoansUsecase,
+		getLoanStatusHistoryUsecase,
+		getLoanScheduleUsecase,
+		getLoanDistributionUsecase,
+		getInvestorPortfolioUsecase,
+		getLedgerTrialBalanceUsecase,
+		getWalletUsecase,
+		topUpWalletUsecase,
+		withdrawWalletUsecase,
+		cancelLoanInvestmentUsecase,
+		uploadAgreementLetterUsecase,
+
+		deps.Logger,
+		deps.Validator,
+	)
 
+	gateway.NewLoanHTTPGateway(
+		deps.HttpRouter,
 		deps.Logger,
+		loanHTTPEndpoint,
 		deps.Validator,
+		deps.TokenVerifier,
+	)
+
+	loanOutboxHandler := gateway.NewLoanOutboxHandler(
+		issueAgreementLettersUsecase,
+		notifyAgreementLettersUsecase,
+		distributeRepaymentUsecase,
+		captureWalletReservationsUsecase,
+		deps.Logger,
 	)
 
-	gateway.NewLoanHTTPGateway(deps.HttpRouter, deps.Logger, loanHTTPEndpoint, deps.Validator)
+	gateway.NewLoanOutboxGateway(deps.OutboxEvents, loanOutboxHandler)
+
+	loanExpiryWorker := gateway.NewLoanExpiryWorkerGateway(
+		deps.Config.GetDuration("loan.expiry.poll_interval"),
+		deps.WorkerLease,
+		deps.Config.GetDuration("loan.expiry.lease"),
+		expireLoansUsecase,
+		deps.Logger,
+	)
 
-	return &Exposed{}
+	return &Exposed{
+		EventTypes: event.Types(),
+		Workers:    []*pkgworker.Periodic{loanExpiryWorker},
+	}
 }
@@ -0,0 +1,42 @@
+// Package pkgauth identifies the caller of a request. A Principal is derived from a verified bearer token and carried
+// by the request context.
+package pkgauth
+
+import "context"
+
+type principalContextKey struct{}
+
+type Role string
+
+const (
+	RoleBorrower Role = "borrower"
+	RoleInvestor Role = "investor"
+	RoleEmployee Role = "employee"
+	// RoleAdmin operates the platform itself, such as the integrations of partners. It is not tied to a user type.
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleBorrower, RoleInvestor, RoleEmployee, RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	UserID uint64
+	Role   Role
+}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(Principal)
+
+	return principal, ok
+}
@@ -0,0 +1,144 @@
+package pkgauth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACToken_Verify(t *testing.T) {
+	now := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	newToken := func(key []byte, issuer string) *HMACToken {
+		h, err := NewHMACToken(key, issuer)
+		assert.NoError(t, err)
+
+		h.now = func() time.Time { return now }
+
+		return h
+	}
+
+	signClaims := func(method jwt.SigningMethod, key any, c claims) string {
+		token, err := jwt.NewWithClaims(method, c).SignedString(key)
+		assert.NoError(t, err)
+
+		return token
+	}
+
+	validClaims := func() claims {
+		return claims{
+			Role: RoleInvestor,
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    "amartha",
+				Subject:   "2",
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		token   func() string
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name: "success",
+			token: func() string {
+				token, err := newToken([]byte("secret"), "amartha").
+					Sign(Principal{UserID: 2, Role: RoleInvestor}, time.Hour)
+				assert.NoError(t, err)
+
+				return token
+			},
+			want: Principal{UserID: 2, Role: RoleInvestor},
+		},
+		{
+			name: "error malformed token",
+			token: func() string {
+				return "not-a-token"
+			},
+			wantErr: true,
+		},
+		{
+			name: "error signed with another key",
+			token: func() string {
+				return signClaims(jwt.SigningMethodHS256, []byte("another secret"), validClaims())
+			},
+			wantErr: true,
+		},
+		{
+			name: "error unsigned token",
+			token: func() string {
+				return signClaims(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, validClaims())
+			},
+			wantErr: true,
+		},
+		{
+			name: "error expired token",
+			token: func() string {
+				c := validClaims()
+				c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Second))
+
+				return signClaims(jwt.SigningMethodHS256, []byte("secret"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error token without expiry",
+			token: func() string {
+				c := validClaims()
+				c.ExpiresAt = nil
+
+				return signClaims(jwt.SigningMethodHS256, []byte("secret"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error another issuer",
+			token: func() string {
+				c := validClaims()
+				c.Issuer = "someone else"
+
+				return signClaims(jwt.SigningMethodHS256, []byte("secret"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error subject is not a user id",
+			token: func() string {
+				c := validClaims()
+				c.Subject = "investor"
+
+				return signClaims(jwt.SigningMethodHS256, []byte("secret"), c)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error unknown role",
+			token: func() string {
+				c := validClaims()
+				c.Role = "superuser"
+
+				return signClaims(jwt.SigningMethodHS256, []byte("secret"), c)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newToken([]byte("secret"), "amartha").Verify(tt.token())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("HMACToken.Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
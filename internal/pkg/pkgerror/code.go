@@ -5,11 +5,57 @@ type Code int
 
 const (
 	Generic Code = iota
+	LoanNotFound
+	LoanInvalidStatusTransition
+	LoanTransitionGuardFailed
+	LoanConcurrentUpdate
+	LoanInvestmentExceedsRemaining
+	LoanSelfInvestment
+	LoanDocumentNotAccepted
+	UserNotFound
+	UserNotBorrower
+	UserNotEmployee
+	UserNotInvestor
+	UserDeactivated
+	UserAlreadyDeactivated
+	WebhookSubscriptionNotFound
+	WebhookDeliveryNotFound
+	WebhookDeliveryNotReplayable
+	LoanScheduleNotFound
+	LoanRepaymentNotFound
+	WalletInsufficientBalance
+	LoanFundingClosed
+	LoanInvestmentNotFound
+	LoanInvestmentNotCancellable
+	LoanInvestmentAlreadyCancelled
 )
 
 func codeMessage() map[Code]string {
 	return map[Code]string{
-		Generic: "Error",
+		Generic:                        "Error",
+		LoanNotFound:                   "Loan not found",
+		LoanInvalidStatusTransition:    "Loan status transition is not allowed",
+		LoanTransitionGuardFailed:      "Loan status transition requirement is not met",
+		LoanConcurrentUpdate:           "Loan was modified by another request, please retry",
+		LoanInvestmentExceedsRemaining: "Investment amount exceeds the remaining fundable amount of the loan",
+		LoanSelfInvestment:             "Borrower cannot invest in their own loan",
+		LoanDocumentNotAccepted:        "Loan does not accept this document in its current status",
+		UserNotFound:                   "User not found",
+		UserNotBorrower:                "Only borrowers can perform this action",
+		UserNotEmployee:                "Only employees can perform this action",
+		UserNotInvestor:                "Only investors can perform this action",
+		UserDeactivated:                "User is deactivated",
+		UserAlreadyDeactivated:         "User is already deactivated",
+		WebhookSubscriptionNotFound:    "Webhook subscription not found",
+		WebhookDeliveryNotFound:        "Webhook delivery not found",
+		WebhookDeliveryNotReplayable:   "Webhook delivery is still pending and cannot be replayed",
+		LoanScheduleNotFound:           "Loan has no repayment schedule",
+		LoanRepaymentNotFound:          "Loan repayment not found",
+		WalletInsufficientBalance:      "Insufficient wallet balance",
+		LoanFundingClosed:              "Loan funding deadline has passed",
+		LoanInvestmentNotFound:         "Loan investment not found",
+		LoanInvestmentNotCancellable:   "Investment can only be cancelled while the loan is open for investment",
+		LoanInvestmentAlreadyCancelled: "Loan investment is already cancelled",
 	}
 }
 
@@ -20,6 +20,12 @@ const (
 
 	// PartnerError should represent an error related to error from partner side
 	PartnerError
+
+	// AuthenticationError should represent a request whose caller could not be identified.
+	AuthenticationError
+
+	// AuthorizationError should represent an identified caller that is not allowed to perform the request.
+	AuthorizationError
 )
 
 // Error represents wrapped errors which can be differentiated from the error type whether it's validation,
@@ -91,6 +97,14 @@ func (err *Error) isPartnerError() bool {
 	return err.ErrorType == PartnerError
 }
 
+func (err *Error) isAuthenticationError() bool {
+	return err.ErrorType == AuthenticationError
+}
+
+func (err *Error) isAuthorizationError() bool {
+	return err.ErrorType == AuthorizationError
+}
+
 func NewValidationError(message string) error {
 	return &Error{
 		Message:   message,
@@ -190,6 +204,56 @@ func NewPartnerError(responseCode string, message string) *Error {
 	}
 }
 
+func NewAuthenticationError(message string) *Error {
+	return &Error{
+		Message:   message,
+		ErrorType: AuthenticationError,
+	}
+}
+
+func AuthenticationErrorFrom(err error) *Error {
+	return &Error{
+		Message:   "",
+		Original:  err,
+		ErrorType: AuthenticationError,
+	}
+}
+
+func IsAuthenticationError(err error) bool {
+	var eval *Error
+
+	if errors.As(err, &eval) {
+		return eval.isAuthenticationError()
+	}
+
+	return false
+}
+
+func NewAuthorizationError(message string) *Error {
+	return &Error{
+		Message:   message,
+		ErrorType: AuthorizationError,
+	}
+}
+
+func AuthorizationErrorFrom(err error) *Error {
+	return &Error{
+		Message:   "",
+		Original:  err,
+		ErrorType: AuthorizationError,
+	}
+}
+
+func IsAuthorizationError(err error) bool {
+	var eval *Error
+
+	if errors.As(err, &eval) {
+		return eval.isAuthorizationError()
+	}
+
+	return false
+}
+
 func AsValidationError(err error) (*Error, bool) {
 	var eval *Error
 
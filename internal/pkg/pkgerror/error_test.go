@@ -91,6 +91,28 @@ func Test_ErrorFrom(t *testing.T) {
 			expectedBool:    false,
 			expectedMessage: "some error",
 		},
+		{
+			name: "authentication error",
+			errProvider: func() error {
+				return AuthenticationErrorFrom(errors.New("some error"))
+			},
+			matcherFunc: func(err error) bool {
+				return IsAuthenticationError(err)
+			},
+			expectedBool:    true,
+			expectedMessage: "some error",
+		},
+		{
+			name: "false authentication error",
+			errProvider: func() error {
+				return ValidationErrorFrom(errors.New("some error"))
+			},
+			matcherFunc: func(err error) bool {
+				return IsAuthenticationError(err)
+			},
+			expectedBool:    false,
+			expectedMessage: "some error",
+		},
 		{
 			name: "business error",
 			errProvider: func() error {
@@ -181,6 +203,60 @@ func Test_ErrorFrom(t *testing.T) {
 	}
 }
 
+func Test_AuthorizationErrorFrom(t *testing.T) {
+	tests := []struct {
+		name            string
+		errProvider     func() error
+		matcherFunc     func(error) bool
+		expectedBool    bool
+		expectedMessage string
+	}{
+		{
+			name: "authorization error",
+			errProvider: func() error {
+				return AuthorizationErrorFrom(errors.New("some error"))
+			},
+			matcherFunc: func(err error) bool {
+				return IsAuthorizationError(err)
+			},
+			expectedBool:    true,
+			expectedMessage: "some error",
+		},
+		{
+			name: "false authorization error",
+			errProvider: func() error {
+				return AuthenticationErrorFrom(errors.New("some error"))
+			},
+			matcherFunc: func(err error) bool {
+				return IsAuthorizationError(err)
+			},
+			expectedBool:    false,
+			expectedMessage: "some error",
+		},
+		{
+			name: "false authorization error from plain error",
+			errProvider: func() error {
+				return errors.New("some error")
+			},
+			matcherFunc: func(err error) bool {
+				return IsAuthorizationError(err)
+			},
+			expectedBool:    false,
+			expectedMessage: "some error",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.errProvider()
+			isMatch := test.matcherFunc(err)
+
+			assert.Equal(t, test.expectedBool, isMatch)
+			assert.Equal(t, test.expectedMessage, err.Error())
+		})
+	}
+}
+
 func Test_TypeError_Unwrap(t *testing.T) {
 	err := &Error{
 		Message:   "",
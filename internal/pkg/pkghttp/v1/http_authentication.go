@@ -0,0 +1,43 @@
+package pkghttp
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+)
+
+const bearerScheme = "Bearer "
+
+// WithAuthentication verifies the bearer token populated by WithPopulateContextFromHeader and puts the resulting
+// pkgauth.Principal into the context. Requests without a valid token fail with an authentication error.
+func WithAuthentication(verifier pkgauth.TokenVerifier) PreRequestMiddleware {
+	return func(next EndpointHandler) EndpointHandler {
+		return func(ctx context.Context, r Request) (any, error) {
+			authorization, _ := ctx.Value(ContextKeyAuthorization).(string)
+
+			token, ok := bearerToken(authorization)
+			if !ok {
+				return nil, pkgerror.NewAuthenticationError("missing bearer token")
+			}
+
+			principal, err := verifier.Verify(token)
+			if err != nil {
+				return nil, pkgerror.AuthenticationErrorFrom(err)
+			}
+
+			return next(pkgauth.ContextWithPrincipal(ctx, principal), r)
+		}
+	}
+}
+
+func bearerToken(authorization string) (string, bool) {
+	if len(authorization) < len(bearerScheme) || !strings.EqualFold(authorization[:len(bearerScheme)], bearerScheme) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(authorization[len(bearerScheme):])
+
+	return token, token != ""
+}
@@ -0,0 +1,95 @@
+package pkghttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubTokenVerifier map[string]pkgauth.Principal
+
+func (s stubTokenVerifier) Verify(token string) (pkgauth.Principal, error) {
+	principal, ok := s[token]
+	if !ok {
+		return pkgauth.Principal{}, errors.New("unknown token")
+	}
+
+	return principal, nil
+}
+
+func Test_WithAuthentication(t *testing.T) {
+	verifier := stubTokenVerifier{
+		"valid-token": {UserID: 2, Role: pkgauth.RoleInvestor},
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantPrincipal pkgauth.Principal
+	}{
+		{
+			name:       "error missing authorization header",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "error not a bearer token",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "error empty bearer token",
+			authorization: "Bearer ",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "error invalid token",
+			authorization: "Bearer invalid-token",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "success",
+			authorization: "bearer valid-token",
+			wantStatus:    http.StatusOK,
+			wantPrincipal: pkgauth.Principal{UserID: 2, Role: pkgauth.RoleInvestor},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPrincipal pkgauth.Principal
+
+			s := NewServer(
+				WithResponseEncoder(CodeMessageResponseEncoder),
+				WithErrorResponseEncoder(CodeMessageErrorEncoder),
+				WithRequestDecoders(WithPopulateContextFromHeader),
+				WithPreRequestMiddlewares(WithAuthentication(verifier)),
+			)
+
+			e := s.Serve(func(ctx context.Context, _ Request) (any, error) {
+				gotPrincipal, _ = pkgauth.PrincipalFromContext(ctx)
+
+				return nil, nil
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/example", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			e.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Equal(t, tt.wantPrincipal, gotPrincipal)
+
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Contains(t, rr.Body.String(), RequestAuthenticationFailed.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package pkghttp
+
+import (
+	"context"
+	"slices"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+)
+
+// Policy decides whether the authenticated principal may call an endpoint. It returns false to deny the request.
+type Policy func(ctx context.Context, principal pkgauth.Principal, r Request) bool
+
+// WithPolicy guards an endpoint with policies that must all allow the request, otherwise it fails with an
+// authorization error. Policies run after the server middlewares, so WithAuthentication has already put the
+// principal into the context.
+func WithPolicy(policies ...Policy) EndpointOption {
+	return func(endpoint *Endpoint) {
+		// middlewares are wrapped in order, so the first one is the innermost and runs after the server middlewares
+		endpoint.middlewares = slices.Insert(endpoint.middlewares, 0, authorize(policies))
+	}
+}
+
+// AllowRoles allows principals having one of the given roles.
+func AllowRoles(roles ...pkgauth.Role) Policy {
+	return func(_ context.Context, principal pkgauth.Principal, _ Request) bool {
+		return slices.Contains(roles, principal.Role)
+	}
+}
+
+func authorize(policies []Policy) PreRequestMiddleware {
+	return func(next EndpointHandler) EndpointHandler {
+		return func(ctx context.Context, r Request) (any, error) {
+			principal, ok := pkgauth.PrincipalFromContext(ctx)
+			if !ok {
+				return nil, pkgerror.NewAuthenticationError("request is not authenticated")
+			}
+
+			for _, policy := range policies {
+				if !policy(ctx, principal, r) {
+					return nil, pkgerror.NewAuthorizationError("caller is not allowed to perform this request")
+				}
+			}
+
+			return next(ctx, r)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package pkghttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WithPolicy(t *testing.T) {
+	verifier := stubTokenVerifier{
+		"investor-token": {UserID: 2, Role: pkgauth.RoleInvestor},
+		"employee-token": {UserID: 3, Role: pkgauth.RoleEmployee},
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		withAuth      bool
+		policies      []Policy
+		wantStatus    int
+		wantCode      string
+	}{
+		{
+			name:          "error unauthenticated request is checked before policies",
+			authorization: "Bearer unknown-token",
+			withAuth:      true,
+			policies:      []Policy{AllowRoles(pkgauth.RoleEmployee)},
+			wantStatus:    http.StatusUnauthorized,
+			wantCode:      RequestAuthenticationFailed.Code,
+		},
+		{
+			name:       "error no principal in context",
+			policies:   []Policy{AllowRoles(pkgauth.RoleEmployee)},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   RequestAuthenticationFailed.Code,
+		},
+		{
+			name:          "error role not allowed",
+			authorization: "Bearer investor-token",
+			withAuth:      true,
+			policies:      []Policy{AllowRoles(pkgauth.RoleEmployee)},
+			wantStatus:    http.StatusForbidden,
+			wantCode:      RequestForbidden.Code,
+		},
+		{
+			name:          "error one of the policies denies",
+			authorization: "Bearer employee-token",
+			withAuth:      true,
+			policies: []Policy{
+				AllowRoles(pkgauth.RoleEmployee),
+				func(context.Context, pkgauth.Principal, Request) bool { return false },
+			},
+			wantStatus: http.StatusForbidden,
+			wantCode:   RequestForbidden.Code,
+		},
+		{
+			name:          "success role allowed",
+			authorization: "Bearer employee-token",
+			withAuth:      true,
+			policies:      []Policy{AllowRoles(pkgauth.RoleBorrower, pkgauth.RoleEmployee)},
+			wantStatus:    http.StatusOK,
+			wantCode:      RequestSuccess.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := []ServerOption{
+				WithResponseEncoder(CodeMessageResponseEncoder),
+				WithErrorResponseEncoder(CodeMessageErrorEncoder),
+				WithRequestDecoders(WithPopulateContextFromHeader),
+			}
+			if tt.withAuth {
+				options = append(options, WithPreRequestMiddlewares(WithAuthentication(verifier)))
+			}
+
+			e := NewServer(options...).Serve(func(context.Context, Request) (any, error) {
+				return "ok", nil
+			}, WithPolicy(tt.policies...))
+
+			req := httptest.NewRequest(http.MethodGet, "/example", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			e.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"code":"`+tt.wantCode+`"`)
+		})
+	}
+}
@@ -24,6 +24,7 @@ var (
 	RequestAuthenticationFailed = CodeMessage{"1403", "Authentication failed"}
 	RequestValidationFailed     = CodeMessage{"1404", "Validation failed"}
 	RequestInvalid              = CodeMessage{"1405", "Invalid request"}
+	RequestForbidden            = CodeMessage{"1406", "Access to the resource is forbidden"}
 
 	RequestGenericError = CodeMessage{"1500", "Unexpected error. Please contact support"}
 )
@@ -101,6 +102,22 @@ func CodeMessageErrorEncoder(_ context.Context, err error, w http.ResponseWriter
 		}
 	}
 
+	if pkgerror.IsAuthenticationError(err) {
+		statusCode = http.StatusUnauthorized
+		response = CodeMessageResponse{
+			CodeMessage: RequestAuthenticationFailed,
+			Data:        err.Error(),
+		}
+	}
+
+	if pkgerror.IsAuthorizationError(err) {
+		statusCode = http.StatusForbidden
+		response = CodeMessageResponse{
+			CodeMessage: RequestForbidden,
+			Data:        err.Error(),
+		}
+	}
+
 	if pkgerror.IsBusinessError(err) {
 		statusCode = http.StatusBadRequest
 		response = CodeMessageResponse{
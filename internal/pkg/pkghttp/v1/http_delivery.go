@@ -1,8 +1,11 @@
 package pkghttp
 
+import "slices"
+
 type Server struct {
 	responseEncoder      ResponseEncoder
 	errorResponseEncoder ErrorResponseEncoder
+	requestDecoders      []RequestDecoder
 	middlewares          []PreRequestMiddleware
 }
 
@@ -23,7 +26,8 @@ func (s *Server) Serve(handler EndpointHandler, options ...EndpointOption) *Endp
 		handler:              handler,
 		responseEncoder:      s.responseEncoder,
 		errorResponseEncoder: s.errorResponseEncoder,
-		middlewares:          s.middlewares,
+		requestDecoders:      slices.Clone(s.requestDecoders),
+		middlewares:          slices.Clone(s.middlewares),
 	}
 
 	for _, option := range options {
@@ -65,3 +69,9 @@ func WithPreRequestMiddlewares(middlewares ...PreRequestMiddleware) ServerOption
 		s.middlewares = append(s.middlewares, middlewares...)
 	})
 }
+
+func WithRequestDecoders(decoders ...RequestDecoder) ServerOption {
+	return ServerOptionFunc(func(s *Server) {
+		s.requestDecoders = append(s.requestDecoders, decoders...)
+	})
+}
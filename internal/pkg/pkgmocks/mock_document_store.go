@@ -0,0 +1,311 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package pkgmocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pkgstorage "github.com/shandysiswandi/test-amartha/internal/pkg/pkgstorage"
+
+	time "time"
+)
+
+// MockDocumentStore is an autogenerated mock type for the DocumentStore type
+type MockDocumentStore struct {
+	mock.Mock
+}
+
+type MockDocumentStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDocumentStore) EXPECT() *MockDocumentStore_Expecter {
+	return &MockDocumentStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *MockDocumentStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDocumentStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockDocumentStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockDocumentStore_Expecter) Delete(ctx interface{}, key interface{}) *MockDocumentStore_Delete_Call {
+	return &MockDocumentStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockDocumentStore_Delete_Call) Run(run func(ctx context.Context, key string)) *MockDocumentStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDocumentStore_Delete_Call) Return(_a0 error) *MockDocumentStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDocumentStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockDocumentStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *MockDocumentStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDocumentStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDocumentStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockDocumentStore_Expecter) Get(ctx interface{}, key interface{}) *MockDocumentStore_Get_Call {
+	return &MockDocumentStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockDocumentStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockDocumentStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDocumentStore_Get_Call) Return(_a0 io.ReadCloser, _a1 error) *MockDocumentStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDocumentStore_Get_Call) RunAndReturn(run func(context.Context, string) (io.ReadCloser, error)) *MockDocumentStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, key, content, contentType
+func (_m *MockDocumentStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	ret := _m.Called(ctx, key, content, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, string) error); ok {
+		r0 = rf(ctx, key, content, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDocumentStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type MockDocumentStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - content io.Reader
+//   - contentType string
+func (_e *MockDocumentStore_Expecter) Put(ctx interface{}, key interface{}, content interface{}, contentType interface{}) *MockDocumentStore_Put_Call {
+	return &MockDocumentStore_Put_Call{Call: _e.mock.On("Put", ctx, key, content, contentType)}
+}
+
+func (_c *MockDocumentStore_Put_Call) Run(run func(ctx context.Context, key string, content io.Reader, contentType string)) *MockDocumentStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockDocumentStore_Put_Call) Return(_a0 error) *MockDocumentStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDocumentStore_Put_Call) RunAndReturn(run func(context.Context, string, io.Reader, string) error) *MockDocumentStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SignedURL provides a mock function with given fields: ctx, key, ttl
+func (_m *MockDocumentStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	ret := _m.Called(ctx, key, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignedURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (string, error)); ok {
+		return rf(ctx, key, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) string); ok {
+		r0 = rf(ctx, key, ttl)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDocumentStore_SignedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignedURL'
+type MockDocumentStore_SignedURL_Call struct {
+	*mock.Call
+}
+
+// SignedURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl time.Duration
+func (_e *MockDocumentStore_Expecter) SignedURL(ctx interface{}, key interface{}, ttl interface{}) *MockDocumentStore_SignedURL_Call {
+	return &MockDocumentStore_SignedURL_Call{Call: _e.mock.On("SignedURL", ctx, key, ttl)}
+}
+
+func (_c *MockDocumentStore_SignedURL_Call) Run(run func(ctx context.Context, key string, ttl time.Duration)) *MockDocumentStore_SignedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockDocumentStore_SignedURL_Call) Return(_a0 string, _a1 error) *MockDocumentStore_SignedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDocumentStore_SignedURL_Call) RunAndReturn(run func(context.Context, string, time.Duration) (string, error)) *MockDocumentStore_SignedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stat provides a mock function with given fields: ctx, key
+func (_m *MockDocumentStore) Stat(ctx context.Context, key string) (pkgstorage.DocumentInfo, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stat")
+	}
+
+	var r0 pkgstorage.DocumentInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (pkgstorage.DocumentInfo, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) pkgstorage.DocumentInfo); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(pkgstorage.DocumentInfo)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDocumentStore_Stat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stat'
+type MockDocumentStore_Stat_Call struct {
+	*mock.Call
+}
+
+// Stat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockDocumentStore_Expecter) Stat(ctx interface{}, key interface{}) *MockDocumentStore_Stat_Call {
+	return &MockDocumentStore_Stat_Call{Call: _e.mock.On("Stat", ctx, key)}
+}
+
+func (_c *MockDocumentStore_Stat_Call) Run(run func(ctx context.Context, key string)) *MockDocumentStore_Stat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockDocumentStore_Stat_Call) Return(_a0 pkgstorage.DocumentInfo, _a1 error) *MockDocumentStore_Stat_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDocumentStore_Stat_Call) RunAndReturn(run func(context.Context, string) (pkgstorage.DocumentInfo, error)) *MockDocumentStore_Stat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDocumentStore creates a new instance of MockDocumentStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDocumentStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDocumentStore {
+	mock := &MockDocumentStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package pkgmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockLease is an autogenerated mock type for the Lease type
+type MockLease struct {
+	mock.Mock
+}
+
+type MockLease_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLease) EXPECT() *MockLease_Expecter {
+	return &MockLease_Expecter{mock: &_m.Mock}
+}
+
+// Acquire provides a mock function with given fields: ctx, name, now, ttl
+func (_m *MockLease) Acquire(ctx context.Context, name string, now time.Time, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, name, now, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) (bool, error)); ok {
+		return rf(ctx, name, now, ttl)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Duration) bool); ok {
+		r0 = rf(ctx, name, now, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time, time.Duration) error); ok {
+		r1 = rf(ctx, name, now, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLease_Acquire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acquire'
+type MockLease_Acquire_Call struct {
+	*mock.Call
+}
+
+// Acquire is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - now time.Time
+//   - ttl time.Duration
+func (_e *MockLease_Expecter) Acquire(ctx interface{}, name interface{}, now interface{}, ttl interface{}) *MockLease_Acquire_Call {
+	return &MockLease_Acquire_Call{Call: _e.mock.On("Acquire", ctx, name, now, ttl)}
+}
+
+func (_c *MockLease_Acquire_Call) Run(run func(ctx context.Context, name string, now time.Time, ttl time.Duration)) *MockLease_Acquire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockLease_Acquire_Call) Return(_a0 bool, _a1 error) *MockLease_Acquire_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLease_Acquire_Call) RunAndReturn(run func(context.Context, string, time.Time, time.Duration) (bool, error)) *MockLease_Acquire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLease creates a new instance of MockLease. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLease(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLease {
+	mock := &MockLease{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,98 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package pkgmocks
+
+import (
+	context "context"
+
+	pkgoutbox "github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRecorder is an autogenerated mock type for the Recorder type
+type MockRecorder struct {
+	mock.Mock
+}
+
+type MockRecorder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRecorder) EXPECT() *MockRecorder_Expecter {
+	return &MockRecorder_Expecter{mock: &_m.Mock}
+}
+
+// Record provides a mock function with given fields: ctx, events
+func (_m *MockRecorder) Record(ctx context.Context, events ...pkgoutbox.Event) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...pkgoutbox.Event) error); ok {
+		r0 = rf(ctx, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRecorder_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockRecorder_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events ...pkgoutbox.Event
+func (_e *MockRecorder_Expecter) Record(ctx interface{}, events ...interface{}) *MockRecorder_Record_Call {
+	return &MockRecorder_Record_Call{Call: _e.mock.On("Record",
+		append([]interface{}{ctx}, events...)...)}
+}
+
+func (_c *MockRecorder_Record_Call) Run(run func(ctx context.Context, events ...pkgoutbox.Event)) *MockRecorder_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]pkgoutbox.Event, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(pkgoutbox.Event)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockRecorder_Record_Call) Return(_a0 error) *MockRecorder_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRecorder_Record_Call) RunAndReturn(run func(context.Context, ...pkgoutbox.Event) error) *MockRecorder_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRecorder creates a new instance of MockRecorder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRecorder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRecorder {
+	mock := &MockRecorder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,69 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package pkgmocks
+
+import (
+	pkgoutbox "github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRegistry is an autogenerated mock type for the Registry type
+type MockRegistry struct {
+	mock.Mock
+}
+
+type MockRegistry_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRegistry) EXPECT() *MockRegistry_Expecter {
+	return &MockRegistry_Expecter{mock: &_m.Mock}
+}
+
+// Register provides a mock function with given fields: eventType, handler
+func (_m *MockRegistry) Register(eventType string, handler pkgoutbox.Handler) {
+	_m.Called(eventType, handler)
+}
+
+// MockRegistry_Register_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Register'
+type MockRegistry_Register_Call struct {
+	*mock.Call
+}
+
+// Register is a helper method to define mock.On call
+//   - eventType string
+//   - handler pkgoutbox.Handler
+func (_e *MockRegistry_Expecter) Register(eventType interface{}, handler interface{}) *MockRegistry_Register_Call {
+	return &MockRegistry_Register_Call{Call: _e.mock.On("Register", eventType, handler)}
+}
+
+func (_c *MockRegistry_Register_Call) Run(run func(eventType string, handler pkgoutbox.Handler)) *MockRegistry_Register_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(pkgoutbox.Handler))
+	})
+	return _c
+}
+
+func (_c *MockRegistry_Register_Call) Return() *MockRegistry_Register_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRegistry_Register_Call) RunAndReturn(run func(string, pkgoutbox.Handler)) *MockRegistry_Register_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewMockRegistry creates a new instance of MockRegistry. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRegistry(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRegistry {
+	mock := &MockRegistry{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
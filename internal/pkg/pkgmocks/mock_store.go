@@ -0,0 +1,306 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package pkgmocks
+
+import (
+	context "context"
+
+	pkgoutbox "github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockStore is an autogenerated mock type for the Store type
+type MockStore struct {
+	mock.Mock
+}
+
+type MockStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockStore) EXPECT() *MockStore_Expecter {
+	return &MockStore_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function with given fields: ctx, now, limit, lease
+func (_m *MockStore) Claim(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]pkgoutbox.Event, error) {
+	ret := _m.Called(ctx, now, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 []pkgoutbox.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, time.Duration) ([]pkgoutbox.Event, error)); ok {
+		return rf(ctx, now, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int, time.Duration) []pkgoutbox.Event); ok {
+		r0 = rf(ctx, now, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkgoutbox.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int, time.Duration) error); ok {
+		r1 = rf(ctx, now, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockStore_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockStore_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+//   - lease time.Duration
+func (_e *MockStore_Expecter) Claim(ctx interface{}, now interface{}, limit interface{}, lease interface{}) *MockStore_Claim_Call {
+	return &MockStore_Claim_Call{Call: _e.mock.On("Claim", ctx, now, limit, lease)}
+}
+
+func (_c *MockStore_Claim_Call) Run(run func(ctx context.Context, now time.Time, limit int, lease time.Duration)) *MockStore_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockStore_Claim_Call) Return(_a0 []pkgoutbox.Event, _a1 error) *MockStore_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockStore_Claim_Call) RunAndReturn(run func(context.Context, time.Time, int, time.Duration) ([]pkgoutbox.Event, error)) *MockStore_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDispatched provides a mock function with given fields: ctx, id, at
+func (_m *MockStore) MarkDispatched(ctx context.Context, id uint64, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDispatched")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_MarkDispatched_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDispatched'
+type MockStore_MarkDispatched_Call struct {
+	*mock.Call
+}
+
+// MarkDispatched is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - at time.Time
+func (_e *MockStore_Expecter) MarkDispatched(ctx interface{}, id interface{}, at interface{}) *MockStore_MarkDispatched_Call {
+	return &MockStore_MarkDispatched_Call{Call: _e.mock.On("MarkDispatched", ctx, id, at)}
+}
+
+func (_c *MockStore_MarkDispatched_Call) Run(run func(ctx context.Context, id uint64, at time.Time)) *MockStore_MarkDispatched_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_MarkDispatched_Call) Return(_a0 error) *MockStore_MarkDispatched_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_MarkDispatched_Call) RunAndReturn(run func(context.Context, uint64, time.Time) error) *MockStore_MarkDispatched_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function with given fields: ctx, id, lastErr
+func (_m *MockStore) MarkFailed(ctx context.Context, id uint64, lastErr string) error {
+	ret := _m.Called(ctx, id, lastErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string) error); ok {
+		r0 = rf(ctx, id, lastErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockStore_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - lastErr string
+func (_e *MockStore_Expecter) MarkFailed(ctx interface{}, id interface{}, lastErr interface{}) *MockStore_MarkFailed_Call {
+	return &MockStore_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, id, lastErr)}
+}
+
+func (_c *MockStore_MarkFailed_Call) Run(run func(ctx context.Context, id uint64, lastErr string)) *MockStore_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockStore_MarkFailed_Call) Return(_a0 error) *MockStore_MarkFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_MarkFailed_Call) RunAndReturn(run func(context.Context, uint64, string) error) *MockStore_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Record provides a mock function with given fields: ctx, events
+func (_m *MockStore) Record(ctx context.Context, events ...pkgoutbox.Event) error {
+	_va := make([]interface{}, len(events))
+	for _i := range events {
+		_va[_i] = events[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...pkgoutbox.Event) error); ok {
+		r0 = rf(ctx, events...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Record_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Record'
+type MockStore_Record_Call struct {
+	*mock.Call
+}
+
+// Record is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events ...pkgoutbox.Event
+func (_e *MockStore_Expecter) Record(ctx interface{}, events ...interface{}) *MockStore_Record_Call {
+	return &MockStore_Record_Call{Call: _e.mock.On("Record",
+		append([]interface{}{ctx}, events...)...)}
+}
+
+func (_c *MockStore_Record_Call) Run(run func(ctx context.Context, events ...pkgoutbox.Event)) *MockStore_Record_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]pkgoutbox.Event, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(pkgoutbox.Event)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockStore_Record_Call) Return(_a0 error) *MockStore_Record_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Record_Call) RunAndReturn(run func(context.Context, ...pkgoutbox.Event) error) *MockStore_Record_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Retry provides a mock function with given fields: ctx, id, lastErr, retryAt
+func (_m *MockStore) Retry(ctx context.Context, id uint64, lastErr string, retryAt time.Time) error {
+	ret := _m.Called(ctx, id, lastErr, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, string, time.Time) error); ok {
+		r0 = rf(ctx, id, lastErr, retryAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockStore_Retry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Retry'
+type MockStore_Retry_Call struct {
+	*mock.Call
+}
+
+// Retry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - lastErr string
+//   - retryAt time.Time
+func (_e *MockStore_Expecter) Retry(ctx interface{}, id interface{}, lastErr interface{}, retryAt interface{}) *MockStore_Retry_Call {
+	return &MockStore_Retry_Call{Call: _e.mock.On("Retry", ctx, id, lastErr, retryAt)}
+}
+
+func (_c *MockStore_Retry_Call) Run(run func(ctx context.Context, id uint64, lastErr string, retryAt time.Time)) *MockStore_Retry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint64), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockStore_Retry_Call) Return(_a0 error) *MockStore_Retry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockStore_Retry_Call) RunAndReturn(run func(context.Context, uint64, string, time.Time) error) *MockStore_Retry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockStore creates a new instance of MockStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockStore {
+	mock := &MockStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
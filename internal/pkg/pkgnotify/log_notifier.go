@@ -0,0 +1,22 @@
+package pkgnotify
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogNotifier only logs the messages it is given, for environments without a mail server.
+type LogNotifier struct {
+	logger *zap.SugaredLogger
+}
+
+func NewLogNotifier(logger *zap.SugaredLogger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, msg Message) error {
+	n.logger.Infow("notification", "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+
+	return nil
+}
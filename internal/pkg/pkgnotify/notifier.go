@@ -0,0 +1,39 @@
+// Package pkgnotify delivers messages such as emails to users. Callers build a Message and never deal with the
+// transport a Notifier uses.
+package pkgnotify
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+)
+
+var ErrInvalidMessage = errors.New("invalid message")
+
+type (
+	Notifier interface {
+		Notify(ctx context.Context, msg Message) error
+	}
+
+	// Message is a plain text message for a single recipient.
+	Message struct {
+		To      string
+		Subject string
+		Body    string
+	}
+)
+
+// IsPermanent reports whether sending failed in a way that retrying the same message cannot fix, such as an invalid
+// message or a recipient the mail server rejects.
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrInvalidMessage) {
+		return true
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code >= 500
+	}
+
+	return false
+}
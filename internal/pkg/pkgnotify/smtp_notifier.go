@@ -0,0 +1,164 @@
+package pkgnotify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultSMTPTimeout bounds a delivery when the context has no deadline of its own.
+const defaultSMTPTimeout = 30 * time.Second
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	Timeout  time.Duration
+}
+
+// SMTPNotifier sends every message as a plain text email over its own SMTP connection. STARTTLS is used whenever the
+// server offers it and credentials are only sent when a username is configured.
+type SMTPNotifier struct {
+	config SMTPConfig
+	from   *mail.Address
+	now    func() time.Time
+}
+
+func NewSMTPNotifier(config SMTPConfig) (*SMTPNotifier, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("smtp notifier requires a host")
+	}
+
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid smtp from address: %w", err)
+	}
+
+	if config.Port == 0 {
+		config.Port = 587
+	}
+
+	if config.Timeout == 0 {
+		config.Timeout = defaultSMTPTimeout
+	}
+
+	return &SMTPNotifier{config: config, from: from, now: time.Now}, nil
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("%w: recipient: %w", ErrInvalidMessage, err)
+	}
+
+	// a line break in the subject would let the message add headers of its own
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("%w: subject must be a single line", ErrInvalidMessage)
+	}
+
+	data, err := n.compose(to, msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.config.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: n.config.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(n.from.Address); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// compose renders the headers and the quoted-printable body of the message.
+func (n *SMTPNotifier) compose(to *mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	headers := [][2]string{
+		{"From", n.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", msg.Subject)},
+		{"Date", n.now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,234 @@
+package pkgnotify
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPServer speaks just enough SMTP for net/smtp to deliver a message. Recipients listed in reject get a 550.
+type fakeSMTPServer struct {
+	listener net.Listener
+	reject   map[string]bool
+
+	mu       sync.Mutex
+	messages []fakeSMTPMessage
+}
+
+type fakeSMTPMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTPServer(t *testing.T, reject ...string) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	s := &fakeSMTPServer{listener: listener, reject: make(map[string]bool)}
+	for _, addr := range reject {
+		s.reject[addr] = true
+	}
+
+	go s.serve()
+	t.Cleanup(func() { listener.Close() })
+
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) received() []fakeSMTPMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]fakeSMTPMessage(nil), s.messages...)
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	var msg fakeSMTPMessage
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		cmd := strings.TrimRight(line, "\r\n")
+		switch verb := strings.ToUpper(strings.SplitN(cmd, " ", 2)[0]); verb {
+		case "EHLO", "HELO":
+			reply("250 fake")
+		case "MAIL":
+			msg = fakeSMTPMessage{from: addressOf(cmd)}
+			reply("250 OK")
+		case "RCPT":
+			to := addressOf(cmd)
+			if s.reject[to] {
+				reply("550 no such user")
+
+				continue
+			}
+
+			msg.to = append(msg.to, to)
+			reply("250 OK")
+		case "DATA":
+			reply("354 end with .")
+
+			var data strings.Builder
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+
+				if line == ".\r\n" {
+					break
+				}
+
+				data.WriteString(line)
+			}
+
+			msg.data = data.String()
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func addressOf(cmd string) string {
+	start, end := strings.Index(cmd, "<"), strings.Index(cmd, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+
+	return cmd[start+1 : end]
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	server := newFakeSMTPServer(t, "unknown@example.com")
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{
+		Host: "127.0.0.1",
+		Port: server.port(),
+		From: "Loans <loans@example.com>",
+	})
+	assert.NoError(t, err)
+
+	notifier.now = func() time.Time { return time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC) }
+
+	err = notifier.Notify(context.Background(), Message{
+		To:      "Budi <budi@example.com>",
+		Subject: "Your agreement letter for loan 1",
+		Body:    "Hello Budi,\nyour letter is ready.\n",
+	})
+	assert.NoError(t, err)
+
+	messages := server.received()
+	if assert.Len(t, messages, 1) {
+		assert.Equal(t, "loans@example.com", messages[0].from)
+		assert.Equal(t, []string{"budi@example.com"}, messages[0].to)
+
+		parsed, err := mail.ReadMessage(strings.NewReader(messages[0].data))
+		assert.NoError(t, err)
+		assert.Equal(t, "Your agreement letter for loan 1", parsed.Header.Get("Subject"))
+		assert.Equal(t, "\"Budi\" <budi@example.com>", parsed.Header.Get("To"))
+
+		body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello Budi,\r\nyour letter is ready.\r\n", string(body))
+	}
+
+	t.Run("rejected recipient is permanent", func(t *testing.T) {
+		err := notifier.Notify(context.Background(), Message{To: "unknown@example.com", Subject: "hi"})
+		assert.Error(t, err)
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("invalid recipient is permanent", func(t *testing.T) {
+		err := notifier.Notify(context.Background(), Message{To: "not an address", Subject: "hi"})
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+		assert.True(t, IsPermanent(err))
+	})
+
+	t.Run("subject cannot inject headers", func(t *testing.T) {
+		err := notifier.Notify(context.Background(), Message{
+			To:      "budi@example.com",
+			Subject: "hi\r\nBcc: everyone@example.com",
+		})
+		assert.ErrorIs(t, err, ErrInvalidMessage)
+	})
+}
+
+func TestSMTPNotifier_Notify_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: "127.0.0.1", Port: port, From: "loans@example.com"})
+	assert.NoError(t, err)
+
+	err = notifier.Notify(context.Background(), Message{To: "budi@example.com", Subject: "hi"})
+	assert.Error(t, err)
+	assert.False(t, IsPermanent(err), "a connection failure is worth retrying")
+}
+
+func TestNewSMTPNotifier(t *testing.T) {
+	_, err := NewSMTPNotifier(SMTPConfig{From: "loans@example.com"})
+	assert.Error(t, err)
+
+	_, err = NewSMTPNotifier(SMTPConfig{Host: "localhost", From: "not an address"})
+	assert.Error(t, err)
+
+	notifier, err := NewSMTPNotifier(SMTPConfig{Host: "localhost", From: "loans@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, 587, notifier.config.Port)
+}
+
+func TestIsPermanent(t *testing.T) {
+	assert.False(t, IsPermanent(errors.New("connection reset")))
+	assert.False(t, IsPermanent(nil))
+	assert.True(t, IsPermanent(ErrInvalidMessage))
+}
@@ -0,0 +1,200 @@
+package pkgoutbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type DispatcherConfig struct {
+	// PollInterval is how long the dispatcher sleeps after finding no due event.
+	PollInterval time.Duration
+	BatchSize    int
+	// Lease is how long a claimed event is hidden from other dispatchers while its handlers run.
+	Lease time.Duration
+	// MaxAttempts is how many times an event is tried before it is marked FAILED.
+	MaxAttempts int
+	// RetryBackoff delays the next attempt of a failed event, growing linearly with its attempts.
+	RetryBackoff time.Duration
+}
+
+// Dispatcher polls the outbox and publishes every due event to the handlers registered for its type. Handlers are
+// registered before Start, an event without handlers is marked dispatched right away.
+type Dispatcher struct {
+	store  Store
+	logger *zap.SugaredLogger
+	config DispatcherConfig
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewDispatcher(store Store, logger *zap.SugaredLogger, config DispatcherConfig) *Dispatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+
+	if config.Lease <= 0 {
+		config.Lease = time.Minute
+	}
+
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 10
+	}
+
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 10 * time.Second
+	}
+
+	return &Dispatcher{
+		store:    store,
+		logger:   logger,
+		config:   config,
+		handlers: make(map[string][]Handler),
+	}
+}
+
+func (d *Dispatcher) Register(eventType string, handler Handler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// Start runs the dispatch loop in the background until Stop is called.
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done = make(chan struct{})
+
+	go d.loop(ctx)
+}
+
+// Stop ends the dispatch loop and waits for the batch in progress, or until ctx is done. Events of an interrupted
+// batch are dispatched again once their lease ends.
+func (d *Dispatcher) Stop(ctx context.Context) error {
+	if d.cancel == nil {
+		return nil
+	}
+
+	d.cancel()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stop outbox dispatcher: %w", ctx.Err())
+	}
+}
+
+func (d *Dispatcher) loop(ctx context.Context) {
+	defer close(d.done)
+
+	for {
+		n, err := d.DispatchBatch(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			d.logger.Errorw("failed to dispatch outbox events", "error", err)
+		}
+
+		// a full batch means more events are probably due, keep going without waiting
+		if err == nil && n == d.config.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+
+			continue
+		}
+
+		timer := time.NewTimer(d.config.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// DispatchBatch claims one batch of due events and publishes them, it reports how many events it claimed.
+func (d *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	events, err := d.store.Claim(ctx, time.Now(), d.config.BatchSize, d.config.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return len(events), ctx.Err()
+		}
+
+		if err := d.dispatch(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return len(events), errors.Join(errs...)
+}
+
+// dispatch publishes the event to every handler and records the outcome. A failing handler makes the whole event
+// retried, including the handlers that already succeeded.
+func (d *Dispatcher) dispatch(ctx context.Context, event Event) error {
+	d.mu.RLock()
+	handlers := d.handlers[event.Type]
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := d.handle(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	now := time.Now()
+	handleErr := errors.Join(errs...)
+
+	if handleErr == nil {
+		return d.store.MarkDispatched(ctx, event.ID, now)
+	}
+
+	d.logger.Warnw(
+		"outbox event handler failed",
+		"event_id", event.ID,
+		"event_type", event.Type,
+		"attempt", event.Attempts+1,
+		"error", handleErr,
+	)
+
+	if event.Attempts+1 >= d.config.MaxAttempts {
+		d.logger.Errorw("outbox event failed too many times", "event_id", event.ID, "event_type", event.Type)
+
+		return d.store.MarkFailed(ctx, event.ID, handleErr.Error())
+	}
+
+	retryAt := now.Add(time.Duration(event.Attempts+1) * d.config.RetryBackoff)
+
+	return d.store.Retry(ctx, event.ID, handleErr.Error(), retryAt)
+}
+
+// handle turns a handler panic into an error so one bad event does not stop the dispatcher.
+func (d *Dispatcher) handle(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("handler panicked: %v", p)
+		}
+	}()
+
+	return handler.Handle(ctx, event)
+}
@@ -0,0 +1,185 @@
+package pkgoutbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeStore keeps the outbox in memory and honours the lease of claimed events.
+type fakeStore struct {
+	mu        sync.Mutex
+	events    []Event
+	status    map[uint64]string
+	dueAt     map[uint64]time.Time
+	lastError map[uint64]string
+}
+
+func newFakeStore(events ...Event) *fakeStore {
+	f := &fakeStore{
+		status:    make(map[uint64]string),
+		dueAt:     make(map[uint64]time.Time),
+		lastError: make(map[uint64]string),
+	}
+	_ = f.Record(context.Background(), events...)
+
+	return f
+}
+
+func (f *fakeStore) Record(_ context.Context, events ...Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, e := range events {
+		f.events = append(f.events, e)
+		f.status[e.ID] = statusPending
+	}
+
+	return nil
+}
+
+func (f *fakeStore) Claim(_ context.Context, now time.Time, limit int, lease time.Duration) ([]Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var claimed []Event
+	for _, e := range f.events {
+		if len(claimed) == limit {
+			break
+		}
+
+		if f.status[e.ID] != statusPending || f.dueAt[e.ID].After(now) {
+			continue
+		}
+
+		f.dueAt[e.ID] = now.Add(lease)
+		claimed = append(claimed, e)
+	}
+
+	return claimed, nil
+}
+
+func (f *fakeStore) MarkDispatched(_ context.Context, id uint64, _ time.Time) error {
+	return f.update(id, statusDispatched, "", time.Time{})
+}
+
+func (f *fakeStore) Retry(_ context.Context, id uint64, lastErr string, retryAt time.Time) error {
+	return f.update(id, statusPending, lastErr, retryAt)
+}
+
+func (f *fakeStore) MarkFailed(_ context.Context, id uint64, lastErr string) error {
+	return f.update(id, statusFailed, lastErr, time.Time{})
+}
+
+func (f *fakeStore) update(id uint64, status, lastErr string, dueAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := range f.events {
+		if f.events[i].ID == id {
+			f.events[i].Attempts++
+		}
+	}
+
+	f.status[id] = status
+	f.lastError[id] = lastErr
+	f.dueAt[id] = dueAt
+
+	return nil
+}
+
+func (f *fakeStore) statusOf(id uint64) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.status[id]
+}
+
+func TestDispatcher_DispatchBatch(t *testing.T) {
+	store := newFakeStore(
+		Event{ID: 1, Type: "LoanApproved"},
+		Event{ID: 2, Type: "LoanFullyFunded"},
+		Event{ID: 3, Type: "LoanDisbursed"},
+	)
+
+	d := NewDispatcher(store, zap.NewNop().Sugar(), DispatcherConfig{MaxAttempts: 2, RetryBackoff: time.Nanosecond})
+
+	var handled []uint64
+	d.Register("LoanApproved", HandlerFunc(func(_ context.Context, e Event) error {
+		handled = append(handled, e.ID)
+
+		return nil
+	}))
+	d.Register("LoanFullyFunded", HandlerFunc(func(context.Context, Event) error {
+		return errors.New("mail server down")
+	}))
+
+	n, err := d.DispatchBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []uint64{1}, handled)
+	assert.Equal(t, statusDispatched, store.statusOf(1))
+	assert.Equal(t, statusPending, store.statusOf(2))
+	assert.Equal(t, "mail server down", store.lastError[2])
+	// an event nobody subscribes to is done
+	assert.Equal(t, statusDispatched, store.statusOf(3))
+
+	time.Sleep(time.Millisecond)
+
+	// the second failure exhausts the attempts
+	n, err = d.DispatchBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, statusFailed, store.statusOf(2))
+
+	n, err = d.DispatchBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestDispatcher_DispatchBatch_Panic(t *testing.T) {
+	store := newFakeStore(Event{ID: 1, Type: "LoanApproved"})
+
+	d := NewDispatcher(store, zap.NewNop().Sugar(), DispatcherConfig{})
+	d.Register("LoanApproved", HandlerFunc(func(context.Context, Event) error {
+		panic("boom")
+	}))
+
+	_, err := d.DispatchBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, statusPending, store.statusOf(1))
+	assert.Equal(t, "handler panicked: boom", store.lastError[1])
+}
+
+func TestDispatcher_StartStop(t *testing.T) {
+	store := newFakeStore(Event{ID: 1, Type: "LoanApproved"})
+
+	handled := make(chan Event, 1)
+
+	d := NewDispatcher(store, zap.NewNop().Sugar(), DispatcherConfig{PollInterval: time.Millisecond})
+	d.Register("LoanApproved", HandlerFunc(func(_ context.Context, e Event) error {
+		handled <- e
+
+		return nil
+	}))
+
+	d.Start()
+
+	select {
+	case e := <-handled:
+		assert.Equal(t, uint64(1), e.ID)
+	case <-time.After(time.Second):
+		t.Fatal("event was not dispatched")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, d.Stop(ctx))
+	assert.Equal(t, statusDispatched, store.statusOf(1))
+}
@@ -0,0 +1,72 @@
+// Package pkgoutbox implements the transactional outbox. Events are recorded in the same transaction as the state
+// change they describe and a Dispatcher hands them to their handlers once committed, so an event is never lost after a
+// commit nor published for a change that was rolled back.
+package pkgoutbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type (
+	// Event is a fact about an aggregate, Payload holds its JSON encoded details.
+	Event struct {
+		ID            uint64
+		AggregateType string
+		AggregateID   uint64
+		Type          string
+		Payload       json.RawMessage
+		Attempts      int
+		CreatedAt     time.Time
+	}
+
+	// Recorder appends events to the outbox. Given a context carrying a transaction, the events are only visible to
+	// the dispatcher once that transaction commits.
+	Recorder interface {
+		Record(ctx context.Context, events ...Event) error
+	}
+
+	// Handler reacts to an event. Delivery is at least once, so a handler must tolerate seeing an event again.
+	Handler interface {
+		Handle(ctx context.Context, event Event) error
+	}
+
+	HandlerFunc func(ctx context.Context, event Event) error
+
+	// Registry is where modules subscribe their handlers to event types.
+	Registry interface {
+		Register(eventType string, handler Handler)
+	}
+)
+
+func (f HandlerFunc) Handle(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// NewEvent encodes payload as the JSON payload of a new event.
+func NewEvent(id uint64, aggregateType string, aggregateID uint64, eventType string, payload any) (Event, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("encode %s payload: %w", eventType, err)
+	}
+
+	return Event{
+		ID:            id,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Type:          eventType,
+		Payload:       raw,
+		CreatedAt:     time.Now(),
+	}, nil
+}
+
+// Decode unmarshals the payload of the event into v.
+func (e Event) Decode(v any) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package pkgoutbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+)
+
+const (
+	statusPending    = "PENDING"
+	statusDispatched = "DISPATCHED"
+	statusFailed     = "FAILED"
+)
+
+// Store keeps the outbox for the Dispatcher.
+type Store interface {
+	Recorder
+	// Claim leases up to limit events due at now, they are not claimed again before the lease ends.
+	Claim(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]Event, error)
+	MarkDispatched(ctx context.Context, id uint64, at time.Time) error
+	// Retry records a failed attempt and makes the event due again at retryAt.
+	Retry(ctx context.Context, id uint64, lastErr string, retryAt time.Time) error
+	// MarkFailed records a failed attempt and gives up on the event.
+	MarkFailed(ctx context.Context, id uint64, lastErr string) error
+}
+
+// SQLStore keeps the outbox in the outbox_events table, updated_at is maintained by the table itself.
+type SQLStore struct {
+	db           pkgsql.SQL
+	transactor   pkgsql.Transactor
+	queryBuilder pkgsql.GoquBuilder
+	tableName    string
+}
+
+func NewSQLStore(db pkgsql.SQL, queryBuilder pkgsql.GoquBuilder) *SQLStore {
+	return &SQLStore{
+		db:           db,
+		transactor:   pkgsql.NewTransactor(db),
+		queryBuilder: queryBuilder,
+		tableName:    "outbox_events",
+	}
+}
+
+func (s *SQLStore) Record(ctx context.Context, events ...Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows := make([]any, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, goqu.Record{
+			"id":             e.ID,
+			"aggregate_type": e.AggregateType,
+			"aggregate_id":   e.AggregateID,
+			"event_type":     e.Type,
+			"payload":        string(e.Payload),
+			"status":         statusPending,
+			"attempts":       0,
+			"available_at":   e.CreatedAt,
+			"created_at":     e.CreatedAt,
+			"updated_at":     e.CreatedAt,
+		})
+	}
+
+	query, _, err := s.queryBuilder.Insert(s.tableName).Rows(rows...).ToSQL()
+	if err != nil {
+		return fmt.Errorf("build outbox insert: %w", err)
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, s.db).ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("insert outbox events: %w", err)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n != int64(len(events)) {
+		return errors.Join(fmt.Errorf("failed to insert outbox events"), err)
+	}
+
+	return nil
+}
+
+// Claim locks the due events with SKIP LOCKED and pushes their available_at past the lease in one transaction, so
+// concurrent dispatchers never claim the same event and an event claimed by a crashed dispatcher becomes due again.
+func (s *SQLStore) Claim(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]Event, error) {
+	var events []Event
+
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		query, _, err := s.queryBuilder.
+			Select("id", "aggregate_type", "aggregate_id", "event_type", "payload", "attempts", "created_at").
+			From(s.tableName).
+			Where(goqu.Ex{"status": statusPending}, goqu.C("available_at").Lte(now)).
+			Order(goqu.C("id").Asc()).
+			Limit(uint(limit)). //nolint:gosec // the batch size is a small positive number
+			ForUpdate(exp.SkipLocked).
+			ToSQL()
+		if err != nil {
+			return fmt.Errorf("build outbox claim: %w", err)
+		}
+
+		if events, err = s.query(ctx, query); err != nil || len(events) == 0 {
+			return err
+		}
+
+		ids := make([]uint64, 0, len(events))
+		for _, e := range events {
+			ids = append(ids, e.ID)
+		}
+
+		return s.update(ctx, goqu.Record{"available_at": now.Add(lease)}, goqu.Ex{"id": ids})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (s *SQLStore) MarkDispatched(ctx context.Context, id uint64, at time.Time) error {
+	return s.update(ctx, goqu.Record{
+		"status":        statusDispatched,
+		"attempts":      goqu.L("attempts + 1"),
+		"dispatched_at": at,
+	}, goqu.Ex{"id": id})
+}
+
+func (s *SQLStore) Retry(ctx context.Context, id uint64, lastErr string, retryAt time.Time) error {
+	return s.update(ctx, goqu.Record{
+		"attempts":     goqu.L("attempts + 1"),
+		"last_error":   lastErr,
+		"available_at": retryAt,
+	}, goqu.Ex{"id": id})
+}
+
+func (s *SQLStore) MarkFailed(ctx context.Context, id uint64, lastErr string) error {
+	return s.update(ctx, goqu.Record{
+		"status":     statusFailed,
+		"attempts":   goqu.L("attempts + 1"),
+		"last_error": lastErr,
+	}, goqu.Ex{"id": id})
+}
+
+func (s *SQLStore) query(ctx context.Context, query string) ([]Event, error) {
+	rows, err := pkgsql.ExecutorFromContext(ctx, s.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		var payload []byte
+		if err := rows.Scan(
+			&e.ID,
+			&e.AggregateType,
+			&e.AggregateID,
+			&e.Type,
+			&payload,
+			&e.Attempts,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+
+		e.Payload = payload
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (s *SQLStore) update(ctx context.Context, record goqu.Record, where goqu.Ex) error {
+	query, _, err := s.queryBuilder.Update(s.tableName).Set(record).Where(where).ToSQL()
+	if err != nil {
+		return fmt.Errorf("build outbox update: %w", err)
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, s.db).ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("update outbox events: %w", err)
+	}
+
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return errors.Join(fmt.Errorf("failed to update outbox events"), err)
+	}
+
+	return nil
+}
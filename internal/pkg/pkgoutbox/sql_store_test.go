@@ -0,0 +1,165 @@
+package pkgoutbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLStore_Record(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	event := Event{
+		ID:            1,
+		AggregateType: "loan",
+		AggregateID:   2,
+		Type:          "LoanApproved",
+		Payload:       json.RawMessage(`{"loan_id":2}`),
+		CreatedAt:     createdAt,
+	}
+	query := "INSERT INTO `outbox_events` " +
+		"(`aggregate_id`, `aggregate_type`, `attempts`, `available_at`, `created_at`, `event_type`, `id`, " +
+		"`payload`, `status`, `updated_at`) VALUES " +
+		"(2, 'loan', 0, '2024-01-02 03:04:05', '2024-01-02 03:04:05', 'LoanApproved', 1, " +
+		"'{\\\"loan_id\\\":2}', 'PENDING', '2024-01-02 03:04:05')"
+
+	tests := []struct {
+		name    string
+		mockFn  func(dbmock sqlmock.Sqlmock)
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(query).WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no row inserted",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockFn(dbmock)
+
+			s := NewSQLStore(db, goqu.New("mysql", db))
+			err = s.Record(context.Background(), event)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.NoError(t, dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSQLStore_Claim(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	selectQuery := "SELECT `id`, `aggregate_type`, `aggregate_id`, `event_type`, `payload`, `attempts`, " +
+		"`created_at` FROM `outbox_events` WHERE ((`status` = 'PENDING') AND " +
+		"(`available_at` <= '2024-01-02 03:04:05')) ORDER BY `id` ASC LIMIT 10 FOR UPDATE SKIP LOCKED"
+	columns := []string{"id", "aggregate_type", "aggregate_id", "event_type", "payload", "attempts", "created_at"}
+
+	tests := []struct {
+		name    string
+		mockFn  func(dbmock sqlmock.Sqlmock)
+		want    []Event
+		wantErr bool
+	}{
+		{
+			name: "error query rolls back",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectBegin()
+				dbmock.ExpectQuery(selectQuery).WillReturnError(errors.New("db down"))
+				dbmock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success nothing due",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectBegin()
+				dbmock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(columns))
+				dbmock.ExpectCommit()
+			},
+		},
+		{
+			name: "success leases the claimed events",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectBegin()
+				dbmock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(columns).
+					AddRow(1, "loan", 2, "LoanApproved", []byte(`{"loan_id":2}`), 0, now).
+					AddRow(3, "loan", 2, "LoanDisbursed", []byte(`{"loan_id":2}`), 1, now))
+				dbmock.ExpectExec("UPDATE `outbox_events` SET `available_at`='2024-01-02 03:05:05' " +
+					"WHERE (`id` IN (1, 3))").WillReturnResult(sqlmock.NewResult(0, 2))
+				dbmock.ExpectCommit()
+			},
+			want: []Event{
+				{
+					ID:            1,
+					AggregateType: "loan",
+					AggregateID:   2,
+					Type:          "LoanApproved",
+					Payload:       json.RawMessage(`{"loan_id":2}`),
+					CreatedAt:     now,
+				},
+				{
+					ID:            3,
+					AggregateType: "loan",
+					AggregateID:   2,
+					Type:          "LoanDisbursed",
+					Payload:       json.RawMessage(`{"loan_id":2}`),
+					Attempts:      1,
+					CreatedAt:     now,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockFn(dbmock)
+
+			s := NewSQLStore(db, goqu.New("mysql", db))
+			got, err := s.Claim(context.Background(), now, 10, time.Minute)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSQLStore_Retry(t *testing.T) {
+	db, dbmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	assert.NoError(t, err)
+	defer db.Close()
+
+	dbmock.ExpectExec("UPDATE `outbox_events` SET `attempts`=attempts + 1,`available_at`='2024-01-02 03:04:05'," +
+		"`last_error`='mail server down' WHERE (`id` = 1)").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s := NewSQLStore(db, goqu.New("mysql", db))
+	err = s.Retry(context.Background(), 1, "mail server down", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.NoError(t, dbmock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package pkgsql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type txContextKey struct{}
+
+// Transactor runs a unit of work inside a single database transaction. The transaction is carried by the context
+// passed to fn, so every store call made with that context joins it.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Executor is the subset of *sql.DB and *sql.Tx used by the SQL gateways.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+type transactor struct {
+	db SQL
+}
+
+func NewTransactor(db SQL) Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTx commits when fn returns nil and rolls back otherwise, including when fn panics. A context which already
+// carries a transaction joins it instead of starting a new one.
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback() //nolint:errcheck // the panic is more relevant than the rollback error
+
+			panic(p)
+		}
+
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				err = errors.Join(err, fmt.Errorf("rollback transaction: %w", rbErr))
+			}
+		}
+	}()
+
+	if err = fn(ContextWithTx(ctx, tx)); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func ContextWithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+func TxFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+
+	return tx, ok && tx != nil
+}
+
+// ExecutorFromContext returns the transaction carried by ctx, or db when there is none.
+func ExecutorFromContext(ctx context.Context, db SQL) Executor {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+
+	return db
+}
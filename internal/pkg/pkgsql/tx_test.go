@@ -0,0 +1,83 @@
+package pkgsql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_transactor_WithinTx(t *testing.T) {
+	tests := []struct {
+		name    string
+		mockFn  func(dbmock sqlmock.Sqlmock)
+		fn      func(ctx context.Context) error
+		wantErr bool
+	}{
+		{
+			name: "error begin",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectBegin().WillReturnError(errors.New("begin error"))
+			},
+			fn: func(ctx context.Context) error {
+				return nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "rollback when fn fails",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectBegin()
+				dbmock.ExpectRollback()
+			},
+			fn: func(ctx context.Context) error {
+				return errors.New("fn error")
+			},
+			wantErr: true,
+		},
+		{
+			name: "commit and join nested transaction",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectBegin()
+				dbmock.ExpectExec("UPDATE loans").WillReturnResult(sqlmock.NewResult(0, 1))
+				dbmock.ExpectCommit()
+			},
+			fn: func(ctx context.Context) error {
+				tx, ok := TxFromContext(ctx)
+				if !ok {
+					return errors.New("transaction is not in context")
+				}
+
+				return NewTransactor(nil).WithinTx(ctx, func(nestedCtx context.Context) error {
+					nestedTx, _ := TxFromContext(nestedCtx)
+					if nestedTx != tx {
+						return errors.New("nested call did not join the transaction")
+					}
+
+					_, err := ExecutorFromContext(nestedCtx, nil).ExecContext(nestedCtx, "UPDATE loans")
+
+					return err
+				})
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbmock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockFn(dbmock)
+
+			err = NewTransactor(db).WithinTx(context.Background(), tt.fn)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("transactor.WithinTx() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.NoError(t, dbmock.ExpectationsWereMet())
+		})
+	}
+}
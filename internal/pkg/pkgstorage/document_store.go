@@ -0,0 +1,46 @@
+// Package pkgstorage keeps documents such as approval proofs and agreement letters. Documents are addressed by a
+// slash separated key, so callers never deal with where or how a backend stores them.
+package pkgstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidKey       = errors.New("invalid document key")
+	ErrDocumentNotFound = errors.New("document not found")
+)
+
+type (
+	DocumentStore interface {
+		Put(ctx context.Context, key string, content io.Reader, contentType string) error
+		// Get returns the content of the document, the caller must close it.
+		Get(ctx context.Context, key string) (io.ReadCloser, error)
+		Delete(ctx context.Context, key string) error
+		Stat(ctx context.Context, key string) (DocumentInfo, error)
+		// SignedURL returns a URL that grants read access to the document until the ttl elapses.
+		SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	}
+
+	DocumentInfo struct {
+		Key         string
+		Size        int64
+		ContentType string
+		ModifiedAt  time.Time
+	}
+)
+
+// validateKey rejects keys that are empty, absolute, not in their clean form or escape the store with "..".
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return ErrInvalidKey
+	}
+
+	return nil
+}
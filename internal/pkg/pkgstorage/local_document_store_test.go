@@ -0,0 +1,129 @@
+package pkgstorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalDocumentStore_Put(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		wantErr error
+	}{
+		{name: "error empty key", key: "", wantErr: ErrInvalidKey},
+		{name: "error parent directory", key: "../outside.png", wantErr: ErrInvalidKey},
+		{name: "error nested parent directory", key: "loan/../../outside.png", wantErr: ErrInvalidKey},
+		{name: "error absolute key", key: "/etc/passwd", wantErr: ErrInvalidKey},
+		{name: "error backslash", key: `loan\1\proof.png`, wantErr: ErrInvalidKey},
+		{name: "success", key: "loan/1/approval-proof/2.png"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+
+			s, err := NewLocalDocumentStore(root, "http://localhost/document", []byte("secret"))
+			assert.NoError(t, err)
+
+			err = s.Put(context.Background(), tt.key, strings.NewReader("content"), "image/png")
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			got, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(tt.key)))
+			assert.NoError(t, err)
+			assert.Equal(t, "content", string(got))
+		})
+	}
+}
+
+func TestLocalDocumentStore(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := NewLocalDocumentStore(t.TempDir(), "http://localhost/document/", []byte("secret"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Put(ctx, "loan/1/agreement-letter/letter.pdf", strings.NewReader("%PDF-1.4"), ""))
+
+	info, err := s.Stat(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+	assert.Equal(t, "application/pdf", info.ContentType)
+
+	content, err := s.Get(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.NoError(t, err)
+	got, _ := io.ReadAll(content)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, "%PDF-1.4", string(got))
+
+	_, err = s.Stat(ctx, "loan/1/agreement-letter")
+	assert.ErrorIs(t, err, ErrDocumentNotFound)
+
+	assert.NoError(t, s.Delete(ctx, "loan/1/agreement-letter/letter.pdf"))
+	assert.NoError(t, s.Delete(ctx, "loan/1/agreement-letter/letter.pdf"))
+
+	_, err = s.Stat(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.ErrorIs(t, err, ErrDocumentNotFound)
+
+	_, err = s.Get(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.ErrorIs(t, err, ErrDocumentNotFound)
+}
+
+func TestLocalDocumentStore_Handler(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	s, err := NewLocalDocumentStore(t.TempDir(), "http://localhost/document", []byte("secret"))
+	assert.NoError(t, err)
+	s.now = func() time.Time { return now }
+
+	assert.NoError(t, s.Put(ctx, "loan/1/letter.pdf", strings.NewReader("%PDF-1.4"), ""))
+
+	signedURL, err := s.SignedURL(ctx, "loan/1/letter.pdf", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signedURL, "http://localhost/document/loan/1/letter.pdf?"))
+
+	valid, err := url.Parse(signedURL)
+	assert.NoError(t, err)
+
+	tampered := *valid
+	tampered.Path = "/document/loan/2/letter.pdf"
+
+	tests := []struct {
+		name       string
+		target     string
+		now        time.Time
+		wantStatus int
+	}{
+		{name: "success", target: valid.RequestURI(), now: now, wantStatus: http.StatusOK},
+		{name: "error expired", target: valid.RequestURI(), now: now.Add(2 * time.Minute), wantStatus: http.StatusForbidden},
+		{name: "error other key", target: tampered.RequestURI(), now: now, wantStatus: http.StatusForbidden},
+		{name: "error unsigned", target: "/document/loan/1/letter.pdf", now: now, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.now = func() time.Time { return tt.now }
+
+			rr := httptest.NewRecorder()
+			s.Handler("/document/").ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "%PDF-1.4", rr.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package pkgstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+)
+
+type (
+	// S3Config points the store at AWS S3 or at any S3-compatible server such as MinIO when Endpoint is set.
+	S3Config struct {
+		Endpoint     string
+		Region       string
+		Bucket       string
+		AccessKey    string
+		SecretKey    string
+		UsePathStyle bool
+	}
+
+	// S3DocumentStore stores documents as objects of a single bucket.
+	S3DocumentStore struct {
+		client    *s3.Client
+		presigner *s3.PresignClient
+		bucket    string
+	}
+)
+
+func NewS3DocumentStore(config S3Config) (*S3DocumentStore, error) {
+	if config.Bucket == "" {
+		return nil, errors.New("s3 document store requires a bucket")
+	}
+
+	options := s3.Options{
+		Region:       config.Region,
+		Credentials:  credentials.NewStaticCredentialsProvider(config.AccessKey, config.SecretKey, ""),
+		UsePathStyle: config.UsePathStyle,
+	}
+
+	if config.Endpoint != "" {
+		options.BaseEndpoint = aws.String(config.Endpoint)
+	}
+
+	client := s3.New(options)
+
+	return &S3DocumentStore{
+		client:    client,
+		presigner: s3.NewPresignClient(client),
+		bucket:    config.Bucket,
+	}, nil
+}
+
+func (s *S3DocumentStore) Put(ctx context.Context, key string, content io.Reader, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	// the request signature hashes the payload, which requires rewinding the body
+	body, ok := content.(io.ReadSeeker)
+	if !ok {
+		buf, err := io.ReadAll(content)
+		if err != nil {
+			return err
+		}
+
+		body = bytes.NewReader(buf)
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}
+
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	_, err := s.client.PutObject(ctx, input)
+
+	return err
+}
+
+func (s *S3DocumentStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+
+	return out.Body, nil
+}
+
+func (s *S3DocumentStore) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+func (s *S3DocumentStore) Stat(ctx context.Context, key string) (DocumentInfo, error) {
+	if err := validateKey(key); err != nil {
+		return DocumentInfo{}, err
+	}
+
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return DocumentInfo{}, s.notFound(err)
+	}
+
+	return DocumentInfo{
+		Key:         key,
+		Size:        aws.ToInt64(out.ContentLength),
+		ContentType: aws.ToString(out.ContentType),
+		ModifiedAt:  aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func (s *S3DocumentStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return req.URL, nil
+}
+
+// notFound maps the missing object errors of GetObject and HeadObject to ErrDocumentNotFound. HeadObject responses
+// have no body, so its error only carries the NotFound code.
+func (s *S3DocumentStore) notFound(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NoSuchKey" || apiErr.ErrorCode() == "NotFound") {
+		return ErrDocumentNotFound
+	}
+
+	return err
+}
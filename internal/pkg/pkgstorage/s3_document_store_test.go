@@ -0,0 +1,124 @@
+package pkgstorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is a local stand-in for an S3-compatible server handling path-style object requests of one bucket.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string]fakeS3Object
+}
+
+type fakeS3Object struct {
+	content     []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key, ok := strings.CutPrefix(r.URL.Path, "/"+f.bucket+"/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		content, _ := io.ReadAll(r.Body)
+		f.objects[key] = fakeS3Object{content: content, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet, http.MethodHead:
+		object, ok := f.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+
+			if r.Method == http.MethodGet {
+				_, _ = io.WriteString(w, "<Error><Code>NoSuchKey</Code><Message>missing</Message></Error>")
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", object.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.content)))
+		w.Header().Set("Last-Modified", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC).Format(http.TimeFormat))
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(object.content)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newFakeS3DocumentStore(t *testing.T) (*S3DocumentStore, *fakeS3) {
+	fake := &fakeS3{bucket: "documents", objects: make(map[string]fakeS3Object)}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	s, err := NewS3DocumentStore(S3Config{
+		Endpoint:     server.URL,
+		Region:       "us-east-1",
+		Bucket:       "documents",
+		AccessKey:    "access",
+		SecretKey:    "secret",
+		UsePathStyle: true,
+	})
+	assert.NoError(t, err)
+
+	return s, fake
+}
+
+func TestS3DocumentStore(t *testing.T) {
+	ctx := context.Background()
+	s, fake := newFakeS3DocumentStore(t)
+
+	// a non seekable reader, like a multipart file wrapped by a limit
+	content := io.LimitReader(strings.NewReader("%PDF-1.4"), 1<<20)
+
+	err := s.Put(ctx, "loan/1/agreement-letter/letter.pdf", content, "application/pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(fake.objects["loan/1/agreement-letter/letter.pdf"].content))
+
+	info, err := s.Stat(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+	assert.Equal(t, "application/pdf", info.ContentType)
+
+	document, err := s.Get(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.NoError(t, err)
+	got, _ := io.ReadAll(document)
+	assert.NoError(t, document.Close())
+	assert.Equal(t, "%PDF-1.4", string(got))
+
+	signedURL, err := s.SignedURL(ctx, "loan/1/agreement-letter/letter.pdf", time.Minute)
+	assert.NoError(t, err)
+	assert.Contains(t, signedURL, "/documents/loan/1/agreement-letter/letter.pdf?")
+	assert.Contains(t, signedURL, "X-Amz-Signature=")
+
+	assert.NoError(t, s.Delete(ctx, "loan/1/agreement-letter/letter.pdf"))
+
+	_, err = s.Stat(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.ErrorIs(t, err, ErrDocumentNotFound)
+
+	_, err = s.Get(ctx, "loan/1/agreement-letter/letter.pdf")
+	assert.ErrorIs(t, err, ErrDocumentNotFound)
+
+	assert.ErrorIs(t, s.Put(ctx, "../letter.pdf", strings.NewReader(""), ""), ErrInvalidKey)
+}
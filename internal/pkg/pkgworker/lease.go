@@ -0,0 +1,110 @@
+package pkgworker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+)
+
+// Lease elects the instance of the application running a job when several of them run side by side.
+type Lease interface {
+	// Acquire takes the lease named name until now plus ttl, or extends it when this instance already holds it. It
+	// reports false while another instance holds a lease that has not ended yet.
+	Acquire(ctx context.Context, name string, now time.Time, ttl time.Duration) (bool, error)
+}
+
+// Leased runs job only in the instance holding the lease named name. The lease is taken, or extended, for ttl before
+// every round, so ttl has to outlast the interval of the worker; an instance that stops loses it once ttl has passed.
+func Leased(lease Lease, name string, ttl time.Duration, job Job) Job {
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+
+	return func(ctx context.Context) (bool, error) {
+		held, err := lease.Acquire(ctx, name, time.Now(), ttl)
+		if err != nil || !held {
+			return false, err
+		}
+
+		return job(ctx)
+	}
+}
+
+// SQLLease keeps the leases in the worker_leases table, one row per lease naming its holder until expires_at.
+type SQLLease struct {
+	db           pkgsql.SQL
+	queryBuilder pkgsql.GoquBuilder
+	holder       string
+	tableName    string
+}
+
+// NewSQLLease returns the leases of holder, a name unique to the instance of the application.
+func NewSQLLease(db pkgsql.SQL, queryBuilder pkgsql.GoquBuilder, holder string) *SQLLease {
+	return &SQLLease{
+		db:           db,
+		queryBuilder: queryBuilder,
+		holder:       holder,
+		tableName:    "worker_leases",
+	}
+}
+
+// Acquire creates the lease when it does not exist yet, then takes it over when it ended or is already held by this
+// instance. Every statement is atomic on its own, so only one instance wins an ended lease; reading the holder back
+// tells whether it is this one.
+func (l *SQLLease) Acquire(ctx context.Context, name string, now time.Time, ttl time.Duration) (bool, error) {
+	expiresAt := now.Add(ttl)
+
+	insert, _, err := l.queryBuilder.Insert(l.tableName).
+		Rows(goqu.Record{"name": name, "holder": l.holder, "expires_at": expiresAt}).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+	if err != nil {
+		return false, fmt.Errorf("build lease insert: %w", err)
+	}
+
+	if _, err := pkgsql.ExecutorFromContext(ctx, l.db).ExecContext(ctx, insert); err != nil {
+		return false, fmt.Errorf("insert lease: %w", err)
+	}
+
+	update, _, err := l.queryBuilder.Update(l.tableName).
+		Set(goqu.Record{"holder": l.holder, "expires_at": expiresAt}).
+		Where(
+			goqu.Ex{"name": name},
+			goqu.Or(goqu.Ex{"holder": l.holder}, goqu.C("expires_at").Lte(now)),
+		).
+		ToSQL()
+	if err != nil {
+		return false, fmt.Errorf("build lease update: %w", err)
+	}
+
+	if _, err := pkgsql.ExecutorFromContext(ctx, l.db).ExecContext(ctx, update); err != nil {
+		return false, fmt.Errorf("update lease: %w", err)
+	}
+
+	query, _, err := l.queryBuilder.Select("holder").From(l.tableName).Where(goqu.Ex{"name": name}).ToSQL()
+	if err != nil {
+		return false, fmt.Errorf("build lease query: %w", err)
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, l.db).QueryContext(ctx, query)
+	if err != nil {
+		return false, fmt.Errorf("query lease: %w", err)
+	}
+	defer rows.Close()
+
+	var holder string
+	for rows.Next() {
+		if err := rows.Scan(&holder); err != nil {
+			return false, fmt.Errorf("scan lease: %w", err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("iterate lease: %w", err)
+	}
+
+	return holder == l.holder, nil
+}
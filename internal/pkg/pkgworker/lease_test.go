@@ -0,0 +1,135 @@
+package pkgworker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSQLLease_Acquire(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	insertQuery := "INSERT IGNORE INTO `worker_leases` (`expires_at`, `holder`, `name`) " +
+		"VALUES ('2024-01-02 03:05:05', 'instance-1', 'loan-expiry')"
+	updateQuery := "UPDATE `worker_leases` SET `expires_at`='2024-01-02 03:05:05',`holder`='instance-1' " +
+		"WHERE ((`name` = 'loan-expiry') AND ((`holder` = 'instance-1') OR " +
+		"(`expires_at` <= '2024-01-02 03:04:05')))"
+	selectQuery := "SELECT `holder` FROM `worker_leases` WHERE (`name` = 'loan-expiry')"
+
+	tests := []struct {
+		name    string
+		mockFn  func(dbmock sqlmock.Sqlmock)
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "error insert",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(insertQuery).WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error update",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				dbmock.ExpectExec(updateQuery).WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error query",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				dbmock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				dbmock.ExpectQuery(selectQuery).WillReturnError(errors.New("db down"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success held by another instance",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				dbmock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				dbmock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows([]string{"holder"}).AddRow("instance-2"))
+			},
+			want: false,
+		},
+		{
+			name: "success acquired",
+			mockFn: func(dbmock sqlmock.Sqlmock) {
+				dbmock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+				dbmock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+				dbmock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows([]string{"holder"}).AddRow("instance-1"))
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, dbmock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.mockFn(dbmock)
+
+			l := NewSQLLease(db, goqu.New("mysql", db), "instance-1")
+			got, err := l.Acquire(context.Background(), "loan-expiry", now, time.Minute)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLeased(t *testing.T) {
+	tests := []struct {
+		name     string
+		held     bool
+		err      error
+		wantBusy bool
+		wantRun  bool
+		wantErr  bool
+	}{
+		{
+			name:    "error acquire skips the job",
+			err:     errors.New("db down"),
+			wantErr: true,
+		},
+		{
+			name: "held by another instance skips the job",
+			held: false,
+		},
+		{
+			name:     "held runs the job",
+			held:     true,
+			wantBusy: true,
+			wantRun:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lease := pkgmocks.NewMockLease(t)
+			lease.EXPECT().Acquire(mock.Anything, "test", mock.Anything, time.Minute).Return(tt.held, tt.err).Once()
+
+			ran := false
+			job := Leased(lease, "test", 0, func(context.Context) (bool, error) {
+				ran = true
+
+				return true, nil
+			})
+
+			busy, err := job(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantBusy, busy)
+			assert.Equal(t, tt.wantRun, ran)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Package pkgworker runs the background jobs of the application next to its HTTP server.
+package pkgworker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Job does one round of background work. It reports busy when more work is probably waiting, the worker then runs it
+// again right away instead of waiting for the next tick.
+type Job func(ctx context.Context) (busy bool, err error)
+
+// Periodic runs a job every interval until stopped.
+type Periodic struct {
+	name     string
+	interval time.Duration
+	job      Job
+	logger   *zap.SugaredLogger
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewPeriodic(name string, interval time.Duration, job Job, logger *zap.SugaredLogger) *Periodic {
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		job:      job,
+		logger:   logger,
+	}
+}
+
+func (p *Periodic) Name() string {
+	return p.name
+}
+
+// Start runs the job in the background until Stop is called.
+func (p *Periodic) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go p.loop(ctx)
+}
+
+// Stop ends the loop and waits for the round in progress, or until ctx is done.
+func (p *Periodic) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+
+	p.cancel()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("stop %s worker: %w", p.name, ctx.Err())
+	}
+}
+
+func (p *Periodic) loop(ctx context.Context) {
+	defer close(p.done)
+
+	for {
+		busy, err := p.run(ctx)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			p.logger.Errorw("background job failed", "worker", p.name, "error", err)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil && busy {
+			continue
+		}
+
+		timer := time.NewTimer(p.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// run turns a panic of the job into an error so the worker keeps going.
+func (p *Periodic) run(ctx context.Context) (busy bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return p.job(ctx)
+}
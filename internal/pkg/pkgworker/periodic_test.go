@@ -0,0 +1,56 @@
+package pkgworker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestPeriodic(t *testing.T) {
+	var runs atomic.Int64
+	fourthRun := make(chan struct{})
+
+	p := NewPeriodic("test", time.Millisecond, func(context.Context) (bool, error) {
+		switch runs.Add(1) {
+		case 1:
+			// busy rounds run again right away
+			return true, nil
+		case 2:
+			return false, errors.New("any error")
+		case 3:
+			panic("boom")
+		case 4:
+			close(fourthRun)
+		}
+
+		return false, nil
+	}, zap.NewNop().Sugar())
+
+	p.Start()
+
+	select {
+	case <-fourthRun:
+	case <-time.After(time.Second):
+		t.Fatal("worker stopped running its job")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, p.Stop(ctx))
+
+	stopped := runs.Load()
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load())
+}
+
+func TestPeriodic_StopWithoutStart(t *testing.T) {
+	p := NewPeriodic("test", time.Second, func(context.Context) (bool, error) { return false, nil }, zap.NewNop().Sugar())
+
+	assert.NoError(t, p.Stop(context.Background()))
+}
@@ -0,0 +1,173 @@
+package sqlentity
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+type User struct {
+	ID            uint64
+	Name          string
+	Email         sql.NullString
+	Type          UserType
+	DeactivatedAt sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (u User) Columns() []any {
+	return []any{
+		"id",
+		"name",
+		"email",
+		"type",
+		"deactivated_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func (u User) StringColumns() []string {
+	vals := make([]string, len(u.Columns()))
+	for i, col := range u.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (u *User) Values() []any {
+	return []any{
+		&u.ID,
+		&u.Name,
+		&u.Email,
+		&u.Type,
+		&u.DeactivatedAt,
+		&u.CreatedAt,
+		&u.UpdatedAt,
+	}
+}
+
+func (u *User) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(u.Values()))
+	for i, v := range u.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type Users []User
+
+func (u Users) IsEmpty() bool {
+	return u.Len() == 0
+}
+
+func (u Users) Len() int {
+	return len(u)
+}
+
+func (u Users) First() User {
+	if u.IsEmpty() {
+		return User{}
+	}
+
+	return u[0]
+}
+
+type UserType int
+
+const (
+	Unknown UserType = iota
+	Borrower
+	Investor
+	Employee
+)
+
+func (ut UserType) String() string {
+	return [...]string{"unknown", "borrower", "investor", "employee"}[ut]
+}
+
+func (ut UserType) Value() (driver.Value, error) {
+	return ut.String(), nil
+}
+
+func (ut UserType) getMap() map[string]UserType {
+	return map[string]UserType{
+		"unknown":  Unknown,
+		"borrower": Borrower,
+		"investor": Investor,
+		"employee": Employee,
+	}
+}
+
+func UserTypeFromString(s string) UserType {
+	return Unknown.getMap()[s]
+}
+
+func (ut *UserType) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*ut = ut.getMap()[string(v)]
+	case string:
+		*ut = ut.getMap()[v]
+	default:
+		return errors.New("failed to scan user type")
+	}
+
+	return nil
+}
+
+type DeactivateUser struct {
+	DeactivatedAt sql.NullTime
+}
+
+func (d DeactivateUser) Columns() []any {
+	return []any{
+		"deactivated_at",
+		"updated_at",
+	}
+}
+
+func (d DeactivateUser) StringColumns() []string {
+	vals := make([]string, len(d.Columns()))
+	for i, col := range d.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (d *DeactivateUser) Values() []any {
+	return []any{
+		d.DeactivatedAt,
+		d.DeactivatedAt.Time,
+	}
+}
+
+func (d DeactivateUser) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(d.Values()))
+	for i, v := range d.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (d DeactivateUser) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := d.StringColumns()
+	for i, col := range cols {
+		vals[col] = d.DriverValues()[i]
+	}
+
+	return vals
+}
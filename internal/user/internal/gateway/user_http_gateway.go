@@ -0,0 +1,246 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkghttp/v1"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"go.uber.org/zap"
+)
+
+func NewUserHTTPGateway(
+	httpRouter *httprouter.Router,
+	logger *zap.SugaredLogger,
+	userHTTPEndpoint *UserHTTPEndpoint,
+	validator *validator.Validate,
+	tokenVerifier pkgauth.TokenVerifier,
+) {
+	server := pkghttp.NewServer(
+		pkghttp.WithResponseEncoder(pkghttp.CodeMessageResponseEncoder),
+		pkghttp.WithErrorResponseEncoder(pkghttp.CodeMessageErrorEncoder),
+		pkghttp.WithRequestDecoders(pkghttp.WithPopulateContextFromHeader),
+		pkghttp.WithPreRequestMiddlewares(pkghttp.WithAuthentication(tokenVerifier)),
+	)
+
+	employees := pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleEmployee))
+	// a user reads their own record, employees read every user
+	selfOrEmployees := pkghttp.WithPolicy(allowSelfOrEmployee)
+
+	httpRouter.Handler(http.MethodPost, "/user", server.Serve(userHTTPEndpoint.CreateUser, employees))
+
+	httpRouter.Handler(http.MethodGet, "/user", server.Serve(userHTTPEndpoint.ListUsers, employees))
+
+	httpRouter.Handler(
+		http.MethodGet,
+		"/user/:user_id",
+		server.Serve(userHTTPEndpoint.GetUser, selfOrEmployees),
+	)
+
+	httpRouter.Handler(
+		http.MethodPost,
+		"/user/:user_id/deactivate",
+		server.Serve(userHTTPEndpoint.DeactivateUser, employees),
+	)
+}
+
+// allowSelfOrEmployee allows employees and the user the user_id of the path belongs to.
+func allowSelfOrEmployee(ctx context.Context, principal pkgauth.Principal, _ pkghttp.Request) bool {
+	if principal.Role == pkgauth.RoleEmployee {
+		return true
+	}
+
+	if principal.Role != pkgauth.RoleBorrower && principal.Role != pkgauth.RoleInvestor {
+		return false
+	}
+
+	userID, err := strconv.ParseUint(httprouter.ParamsFromContext(ctx).ByName("user_id"), 10, 64)
+
+	return err == nil && userID == principal.UserID
+}
+
+type UserHTTPEndpoint struct {
+	createUserUsecase     usecase.CreateUser
+	getUserUsecase        usecase.GetUser
+	listUsersUsecase      usecase.ListUsers
+	deactivateUserUsecase usecase.DeactivateUser
+
+	logger    *zap.SugaredLogger
+	validator *validator.Validate
+}
+
+func NewUserHTTPEndpoint(
+	createUserUsecase usecase.CreateUser,
+	getUserUsecase usecase.GetUser,
+	listUsersUsecase usecase.ListUsers,
+	deactivateUserUsecase usecase.DeactivateUser,
+
+	logger *zap.SugaredLogger,
+	validator *validator.Validate,
+) *UserHTTPEndpoint {
+	return &UserHTTPEndpoint{
+		createUserUsecase:     createUserUsecase,
+		getUserUsecase:        getUserUsecase,
+		listUsersUsecase:      listUsersUsecase,
+		deactivateUserUsecase: deactivateUserUsecase,
+
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (u *UserHTTPEndpoint) CreateUser(
+	ctx context.Context,
+	request pkghttp.Request,
+) (any, error) {
+	var input usecase.CreateUserInput
+	if err := request.Decode(&input); err != nil {
+		u.logger.Errorw("failed to decode request", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if err := u.validator.Struct(input); err != nil {
+		u.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	user, err := u.createUserUsecase.Execute(ctx, input)
+	if err != nil {
+		u.logger.Errorw("failed to create user", "error", err)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *UserHTTPEndpoint) GetUser(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.GetUserInput
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	userID := params.ByName("user_id")
+
+	input.UserID, err = strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		u.logger.Errorw("failed to parse user id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := u.validator.Struct(input); err != nil {
+		u.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	user, err := u.getUserUsecase.Execute(ctx, input)
+	if err != nil {
+		u.logger.Errorw("failed to get user", "error", err)
+
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u *UserHTTPEndpoint) ListUsers(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	input, err := u.decodeListUsersQuery(request.URL().Query())
+	if err != nil {
+		u.logger.Errorw("failed to decode query", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := u.validator.Struct(input); err != nil {
+		u.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	users, err := u.listUsersUsecase.Execute(ctx, input)
+	if err != nil {
+		u.logger.Errorw("failed to list users", "error", err)
+
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (u *UserHTTPEndpoint) DeactivateUser(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.DeactivateUserInput
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	userID := params.ByName("user_id")
+
+	input.UserID, err = strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		u.logger.Errorw("failed to parse user id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := u.validator.Struct(input); err != nil {
+		u.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := u.deactivateUserUsecase.Execute(ctx, input); err != nil {
+		u.logger.Errorw("failed to deactivate user", "error", err)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (u *UserHTTPEndpoint) decodeListUsersQuery(query url.Values) (input usecase.ListUsersInput, err error) {
+	input.Type = query.Get("type")
+
+	if v := query.Get("active"); v != "" {
+		active, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, fmt.Errorf("invalid active: %w", err)
+		}
+
+		input.Active = &active
+	}
+
+	if v := query.Get("cursor"); v != "" {
+		if input.Cursor, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return input, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return input, fmt.Errorf("invalid limit: %w", err)
+		}
+
+		input.Limit = uint(limit)
+	}
+
+	return input, nil
+}
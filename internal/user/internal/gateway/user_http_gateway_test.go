@@ -0,0 +1,151 @@
+package gateway_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkghttp/v1"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/gateway"
+	usermocks "github.com/shandysiswandi/test-amartha/internal/user/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestNewUserHTTPGateway_Authorization(t *testing.T) {
+	token, err := pkgauth.NewHMACToken([]byte("secret"), "")
+	assert.NoError(t, err)
+
+	sign := func(userID uint64, role pkgauth.Role) string {
+		signed, err := token.Sign(pkgauth.Principal{UserID: userID, Role: role}, time.Minute)
+		assert.NoError(t, err)
+
+		return "Bearer " + signed
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		mockFn        func(getUser *usermocks.MockGetUser)
+		wantStatus    int
+		wantCode      string
+	}{
+		{
+			name:       "error create user without token",
+			method:     http.MethodPost,
+			path:       "/user",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   pkghttp.RequestAuthenticationFailed.Code,
+		},
+		{
+			name:       "error list users without token",
+			method:     http.MethodGet,
+			path:       "/user",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   pkghttp.RequestAuthenticationFailed.Code,
+		},
+		{
+			name:       "error get user without token",
+			method:     http.MethodGet,
+			path:       "/user/5",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   pkghttp.RequestAuthenticationFailed.Code,
+		},
+		{
+			name:          "error create user as investor",
+			method:        http.MethodPost,
+			path:          "/user",
+			authorization: sign(5, pkgauth.RoleInvestor),
+			wantStatus:    http.StatusForbidden,
+			wantCode:      pkghttp.RequestForbidden.Code,
+		},
+		{
+			name:          "error list users as borrower",
+			method:        http.MethodGet,
+			path:          "/user",
+			authorization: sign(5, pkgauth.RoleBorrower),
+			wantStatus:    http.StatusForbidden,
+			wantCode:      pkghttp.RequestForbidden.Code,
+		},
+		{
+			name:          "error deactivate user as the user",
+			method:        http.MethodPost,
+			path:          "/user/5/deactivate",
+			authorization: sign(5, pkgauth.RoleBorrower),
+			wantStatus:    http.StatusForbidden,
+			wantCode:      pkghttp.RequestForbidden.Code,
+		},
+		{
+			name:          "error get another user",
+			method:        http.MethodGet,
+			path:          "/user/5",
+			authorization: sign(6, pkgauth.RoleInvestor),
+			wantStatus:    http.StatusForbidden,
+			wantCode:      pkghttp.RequestForbidden.Code,
+		},
+		{
+			name:          "error get user as admin",
+			method:        http.MethodGet,
+			path:          "/user/5",
+			authorization: sign(5, pkgauth.RoleAdmin),
+			wantStatus:    http.StatusForbidden,
+			wantCode:      pkghttp.RequestForbidden.Code,
+		},
+		{
+			name:          "success get own user",
+			method:        http.MethodGet,
+			path:          "/user/5",
+			authorization: sign(5, pkgauth.RoleInvestor),
+			mockFn: func(getUser *usermocks.MockGetUser) {
+				getUser.EXPECT().Execute(mock.Anything, usecase.GetUserInput{UserID: 5}).
+					Return(&usecase.User{ID: 5}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantCode:   pkghttp.RequestSuccess.Code,
+		},
+		{
+			name:          "success get user as employee",
+			method:        http.MethodGet,
+			path:          "/user/5",
+			authorization: sign(1, pkgauth.RoleEmployee),
+			mockFn: func(getUser *usermocks.MockGetUser) {
+				getUser.EXPECT().Execute(mock.Anything, usecase.GetUserInput{UserID: 5}).
+					Return(&usecase.User{ID: 5}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantCode:   pkghttp.RequestSuccess.Code,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getUser := usermocks.NewMockGetUser(t)
+			if tt.mockFn != nil {
+				tt.mockFn(getUser)
+			}
+
+			logger := zap.NewNop().Sugar()
+			router := httprouter.New()
+			endpoint := gateway.NewUserHTTPEndpoint(nil, getUser, nil, nil, logger, validator.New())
+			gateway.NewUserHTTPGateway(router, logger, endpoint, validator.New(), token)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"code":"`+tt.wantCode+`"`)
+		})
+	}
+}
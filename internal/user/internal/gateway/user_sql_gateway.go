@@ -0,0 +1,200 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+
+	"go.uber.org/zap"
+)
+
+// ErrUserNotUpdated is returned when an update matches no user.
+var ErrUserNotUpdated = errors.New("user not updated")
+
+type UserSQLGateway struct {
+	db           pkgsql.SQL
+	logger       *zap.SugaredLogger
+	queryBuilder pkgsql.GoquBuilder
+
+	userTableName string
+}
+
+func NewUserSQLGateway(
+	db *sql.DB,
+	logger *zap.SugaredLogger,
+	queryBuilder pkgsql.GoquBuilder,
+) *UserSQLGateway {
+	return &UserSQLGateway{
+		db:           db,
+		logger:       logger,
+		queryBuilder: queryBuilder,
+
+		userTableName: "users",
+	}
+}
+
+func (r *UserSQLGateway) InsertUser(ctx context.Context, in sqlentity.User) error {
+	query := r.queryBuilder.Insert(r.userTableName).Cols(in.Columns()...).Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert user")
+	}
+
+	return nil
+}
+
+type GetUserOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetUserWithIDFilter(userID uint64) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": userID})
+	}
+}
+
+func GetUserWithTypeFilter(userType sqlentity.UserType) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"type": userType})
+	}
+}
+
+// GetUserWithActiveFilter keeps only active users when active is true and only deactivated users otherwise.
+func GetUserWithActiveFilter(active bool) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		if active {
+			return query.Where(goqu.C("deactivated_at").IsNull())
+		}
+
+		return query.Where(goqu.C("deactivated_at").IsNotNull())
+	}
+}
+
+func GetUserWithIDAfterFilter(userID uint64) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("id").Gt(userID))
+	}
+}
+
+func GetUserWithLimit(limit uint) GetUserOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Limit(limit)
+	}
+}
+
+func (r *UserSQLGateway) GetUser(ctx context.Context, opts ...GetUserOption) (sqlentity.Users, error) {
+	var user sqlentity.User
+	query := r.queryBuilder.Select(user.Columns()...).
+		From(r.userTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users sqlentity.Users
+	for rows.Next() {
+		err := rows.Scan(user.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return users, nil
+}
+
+type UpdateUserOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateUserWithIDFilter(userID uint64) UpdateUserOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"id": userID})
+	}
+}
+
+func (r *UserSQLGateway) UpdateUser(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateUserOption,
+) error {
+	query := r.queryBuilder.Update(r.userTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return ErrUserNotUpdated
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type userSQLGatewaySuite struct {
+	db           *sql.DB
+	dbmock       sqlmock.Sqlmock
+	queryBuilder pkgsql.GoquBuilder
+
+	userTableName string
+
+	suite.Suite
+}
+
+func TestUserSQLGatewaySuite(t *testing.T) {
+	suite.Run(t, new(userSQLGatewaySuite))
+}
+
+func (us *userSQLGatewaySuite) SetupSuite() {
+	var err error
+	us.db, us.dbmock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		us.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	us.queryBuilder = goqu.New("mysql", us.db)
+	us.userTableName = "users"
+}
+
+func (us *userSQLGatewaySuite) TestUserSQLGateway_InsertUser() {
+	now := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx context.Context
+		in  sqlentity.User
+	}
+	defaultArgs := args{
+		ctx: context.Background(),
+		in: sqlentity.User{
+			ID:        5,
+			Name:      "E",
+			Type:      sqlentity.Borrower,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "error exec",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Insert(us.userTableName).
+					Cols(a.in.Columns()...).
+					Vals(a.in.Values()).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectExec(query).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error no row inserted",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Insert(us.userTableName).
+					Cols(a.in.Columns()...).
+					Vals(a.in.Values()).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Insert(us.userTableName).
+					Cols(a.in.Columns()...).
+					Vals(a.in.Values()).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		us.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewUserSQLGateway(us.db, zap.NewNop().Sugar(), us.queryBuilder)
+			if err := r.InsertUser(tt.args.ctx, tt.args.in); (err != nil) != tt.wantErr {
+				us.T().Errorf("UserSQLGateway.InsertUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			us.NoError(us.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (us *userSQLGatewaySuite) TestUserSQLGateway_GetUser() {
+	var user sqlentity.User
+	createdAt := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx  context.Context
+		opts []GetUserOption
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		want    sqlentity.Users
+		wantErr bool
+	}{
+		{
+			name: "error query",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetUserOption{GetUserWithIDFilter(1)},
+			},
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Select(user.Columns()...).
+					From(us.userTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"id": 1}).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectQuery(query).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error scan unknown user type",
+			args: args{
+				ctx:  context.Background(),
+				opts: []GetUserOption{GetUserWithIDFilter(1)},
+			},
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Select(user.Columns()...).
+					From(us.userTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"id": 1}).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectQuery(query).WillReturnRows(
+					sqlmock.NewRows(user.StringColumns()).
+						AddRow(1, "A", nil, 1, nil, createdAt, createdAt),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				opts: []GetUserOption{
+					GetUserWithTypeFilter(sqlentity.Investor),
+					GetUserWithActiveFilter(true),
+					GetUserWithIDAfterFilter(1),
+					GetUserWithLimit(2),
+				},
+			},
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Select(user.Columns()...).
+					From(us.userTableName).
+					Order(goqu.C("id").Asc()).
+					Where(goqu.Ex{"type": sqlentity.Investor}).
+					Where(goqu.C("deactivated_at").IsNull()).
+					Where(goqu.C("id").Gt(1)).
+					Limit(2).
+					ToSQL()
+				us.NoError(err)
+
+				// the mysql driver returns text columns as bytes
+				us.dbmock.ExpectQuery(query).WillReturnRows(
+					sqlmock.NewRows(user.StringColumns()).
+						AddRow(2, "B", "b@example.com", []byte("investor"), nil, createdAt, createdAt).
+						AddRow(3, "C", nil, []byte("investor"), createdAt, createdAt, createdAt),
+				)
+			},
+			want: sqlentity.Users{
+				{
+					ID:        2,
+					Name:      "B",
+					Email:     sql.NullString{String: "b@example.com", Valid: true},
+					Type:      sqlentity.Investor,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+				},
+				{
+					ID:            3,
+					Name:          "C",
+					Type:          sqlentity.Investor,
+					DeactivatedAt: sql.NullTime{Valid: true, Time: createdAt},
+					CreatedAt:     createdAt,
+					UpdatedAt:     createdAt,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		us.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewUserSQLGateway(us.db, zap.NewNop().Sugar(), us.queryBuilder)
+			got, err := r.GetUser(tt.args.ctx, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				us.T().Errorf("UserSQLGateway.GetUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			us.Equal(tt.want, got)
+			us.NoError(us.dbmock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (us *userSQLGatewaySuite) TestUserSQLGateway_UpdateUser() {
+	now := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx  context.Context
+		in   sqlentity.UpdateEntity
+		opts []UpdateUserOption
+	}
+	defaultArgs := args{
+		ctx:  context.Background(),
+		in:   sqlentity.DeactivateUser{DeactivatedAt: sql.NullTime{Valid: true, Time: now}},
+		opts: []UpdateUserOption{UpdateUserWithIDFilter(2)},
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(a args)
+		wantErr error
+	}{
+		{
+			name: "error exec",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Update(us.userTableName).
+					Set(a.in.MappedValues()).
+					Where(goqu.Ex{"id": 2}).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectExec(query).WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: sql.ErrConnDone,
+		},
+		{
+			name: "error no row updated",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Update(us.userTableName).
+					Set(a.in.MappedValues()).
+					Where(goqu.Ex{"id": 2}).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: ErrUserNotUpdated,
+		},
+		{
+			name: "success",
+			args: defaultArgs,
+			mockFn: func(a args) {
+				query, _, err := us.queryBuilder.Update(us.userTableName).
+					Set(a.in.MappedValues()).
+					Where(goqu.Ex{"id": 2}).
+					ToSQL()
+				us.NoError(err)
+
+				us.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		us.Run(tt.name, func() {
+			tt.mockFn(tt.args)
+
+			r := NewUserSQLGateway(us.db, zap.NewNop().Sugar(), us.queryBuilder)
+			err := r.UpdateUser(tt.args.ctx, tt.args.in, tt.args.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				us.T().Errorf("UserSQLGateway.UpdateUser() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			us.NoError(us.dbmock.ExpectationsWereMet())
+		})
+	}
+}
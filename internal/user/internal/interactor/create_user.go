@@ -0,0 +1,66 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	CreateUserStore interface {
+		InsertUser(ctx context.Context, in sqlentity.User) error
+	}
+
+	CreateUser struct {
+		store        CreateUserStore
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+func NewCreateUser(
+	store CreateUserStore,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *CreateUser {
+	return &CreateUser{
+		store:        store,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+func (c *CreateUser) Execute(ctx context.Context, in usecase.CreateUserInput) (*usecase.User, error) {
+	userType := sqlentity.UserTypeFromString(in.Type)
+	if userType == sqlentity.Unknown {
+		c.logger.Errorw("invalid user type", "type", in.Type)
+
+		return nil, pkgerror.NewValidationError("invalid user type")
+	}
+
+	now := time.Now()
+	user := sqlentity.User{
+		ID:        c.snowflakeGen.Generate(),
+		Name:      in.Name,
+		Email:     sql.NullString{String: in.Email, Valid: in.Email != ""},
+		Type:      userType,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := c.store.InsertUser(ctx, user); err != nil {
+		c.logger.Errorw("failed to insert user", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := toUserOutput(user)
+
+	return &out, nil
+}
@@ -0,0 +1,95 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	usermocks "github.com/shandysiswandi/test-amartha/internal/user/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestCreateUser_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.CreateUserInput
+	}
+	tests := []struct {
+		name    string
+		args    args
+		mockFn  func(store *usermocks.MockCreateUserStore, snowflakeGen *pkgmocks.MockSnowflake, a args)
+		want    *usecase.User
+		wantErr bool
+	}{
+		{
+			name: "error invalid user type",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.CreateUserInput{Name: "E", Type: "admin"},
+			},
+			mockFn:  func(_ *usermocks.MockCreateUserStore, _ *pkgmocks.MockSnowflake, _ args) {},
+			wantErr: true,
+		},
+		{
+			name: "error insert user",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.CreateUserInput{Name: "E", Type: "investor"},
+			},
+			mockFn: func(store *usermocks.MockCreateUserStore, snowflakeGen *pkgmocks.MockSnowflake, a args) {
+				snowflakeGen.EXPECT().Generate().Return(uint64(5)).Once()
+
+				store.EXPECT().InsertUser(a.ctx, mock.Anything).Return(errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.CreateUserInput{Name: "E", Email: "e@example.com", Type: "borrower"},
+			},
+			mockFn: func(store *usermocks.MockCreateUserStore, snowflakeGen *pkgmocks.MockSnowflake, a args) {
+				snowflakeGen.EXPECT().Generate().Return(uint64(5)).Once()
+
+				store.EXPECT().InsertUser(
+					a.ctx,
+					mock.MatchedBy(func(u sqlentity.User) bool {
+						return u.ID == 5 &&
+							u.Name == "E" &&
+							u.Email.String == "e@example.com" &&
+							u.Type == sqlentity.Borrower &&
+							!u.DeactivatedAt.Valid
+					}),
+				).Return(nil).Once()
+			},
+			want: &usecase.User{ID: 5, Name: "E", Email: "e@example.com", Type: "borrower", Active: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := usermocks.NewMockCreateUserStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			tt.mockFn(store, snowflakeGen, tt.args)
+
+			c := NewCreateUser(store, logger, snowflakeGen)
+			got, err := c.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateUser.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.want != nil {
+				tt.want.CreatedAt = got.CreatedAt
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
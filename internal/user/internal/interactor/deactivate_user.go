@@ -0,0 +1,72 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	DeactivateUserStore interface {
+		GetUser(ctx context.Context, opts ...gateway.GetUserOption) (sqlentity.Users, error)
+		UpdateUser(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateUserOption) error
+	}
+
+	DeactivateUser struct {
+		store  DeactivateUserStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewDeactivateUser(
+	store DeactivateUserStore,
+	logger *zap.SugaredLogger,
+) *DeactivateUser {
+	return &DeactivateUser{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Execute keeps the user row so the loans and investments referencing it stay readable, a deactivated user can no
+// longer act on loans.
+func (d *DeactivateUser) Execute(ctx context.Context, in usecase.DeactivateUserInput) error {
+	users, err := d.store.GetUser(ctx, gateway.GetUserWithIDFilter(in.UserID))
+	if err != nil {
+		d.logger.Errorw("failed to get user", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if users.IsEmpty() {
+		d.logger.Errorw("user not found", "user_id", in.UserID)
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.UserNotFound)
+	}
+
+	if users.First().DeactivatedAt.Valid {
+		d.logger.Errorw("user already deactivated", "user_id", in.UserID)
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.UserAlreadyDeactivated)
+	}
+
+	if err := d.store.UpdateUser(
+		ctx,
+		sqlentity.DeactivateUser{
+			DeactivatedAt: sql.NullTime{Valid: true, Time: time.Now()},
+		},
+		gateway.UpdateUserWithIDFilter(in.UserID),
+	); err != nil {
+		d.logger.Errorw("failed to update user", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
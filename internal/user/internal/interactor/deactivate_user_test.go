@@ -0,0 +1,99 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	usermocks "github.com/shandysiswandi/test-amartha/internal/user/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestDeactivateUser_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.DeactivateUserInput
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFn   func(store *usermocks.MockDeactivateUserStore, a args)
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error get user",
+			args: args{ctx: context.Background(), in: usecase.DeactivateUserInput{UserID: 2}},
+			mockFn: func(store *usermocks.MockDeactivateUserStore, a args) {
+				store.EXPECT().GetUser(a.ctx, mock.Anything).Return(nil, errors.New("any error")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error user not found",
+			args: args{ctx: context.Background(), in: usecase.DeactivateUserInput{UserID: 2}},
+			mockFn: func(store *usermocks.MockDeactivateUserStore, a args) {
+				store.EXPECT().GetUser(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantCode: pkgerror.UserNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error user already deactivated",
+			args: args{ctx: context.Background(), in: usecase.DeactivateUserInput{UserID: 2}},
+			mockFn: func(store *usermocks.MockDeactivateUserStore, a args) {
+				store.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{
+						ID:            2,
+						Type:          sqlentity.Investor,
+						DeactivatedAt: sql.NullTime{Valid: true, Time: time.Now()},
+					}}, nil).Once()
+			},
+			wantCode: pkgerror.UserAlreadyDeactivated,
+			wantErr:  true,
+		},
+		{
+			name: "success",
+			args: args{ctx: context.Background(), in: usecase.DeactivateUserInput{UserID: 2}},
+			mockFn: func(store *usermocks.MockDeactivateUserStore, a args) {
+				store.EXPECT().GetUser(a.ctx, mock.Anything).
+					Return(sqlentity.Users{{ID: 2, Type: sqlentity.Investor}}, nil).Once()
+
+				store.EXPECT().UpdateUser(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.DeactivateUser) bool {
+						return in.DeactivatedAt.Valid
+					}),
+					mock.Anything,
+				).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := usermocks.NewMockDeactivateUserStore(t)
+			tt.mockFn(store, tt.args)
+
+			d := NewDeactivateUser(store, logger)
+			err := d.Execute(tt.args.ctx, tt.args.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeactivateUser.Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantCode != pkgerror.Generic {
+				businessErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, businessErr.Code)
+			}
+		})
+	}
+}
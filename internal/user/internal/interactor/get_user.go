@@ -0,0 +1,51 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	GetUserStore interface {
+		GetUser(ctx context.Context, opts ...gateway.GetUserOption) (sqlentity.Users, error)
+	}
+
+	GetUser struct {
+		store  GetUserStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewGetUser(
+	store GetUserStore,
+	logger *zap.SugaredLogger,
+) *GetUser {
+	return &GetUser{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (g *GetUser) Execute(ctx context.Context, in usecase.GetUserInput) (*usecase.User, error) {
+	users, err := g.store.GetUser(ctx, gateway.GetUserWithIDFilter(in.UserID))
+	if err != nil {
+		g.logger.Errorw("failed to get user", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if users.IsEmpty() {
+		g.logger.Errorw("user not found", "user_id", in.UserID)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.UserNotFound)
+	}
+
+	out := toUserOutput(users.First())
+
+	return &out, nil
+}
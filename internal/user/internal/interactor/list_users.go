@@ -0,0 +1,85 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const defaultListUsersLimit uint = 10
+
+type (
+	ListUsersStore interface {
+		GetUser(ctx context.Context, opts ...gateway.GetUserOption) (sqlentity.Users, error)
+	}
+
+	ListUsers struct {
+		store  ListUsersStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewListUsers(
+	store ListUsersStore,
+	logger *zap.SugaredLogger,
+) *ListUsers {
+	return &ListUsers{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (l *ListUsers) Execute(ctx context.Context, in usecase.ListUsersInput) (*usecase.ListUsersOutput, error) {
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultListUsersLimit
+	}
+
+	opts := []gateway.GetUserOption{gateway.GetUserWithLimit(limit + 1)}
+
+	if in.Type != "" {
+		userType := sqlentity.UserTypeFromString(in.Type)
+		if userType == sqlentity.Unknown {
+			l.logger.Errorw("invalid user type filter", "type", in.Type)
+
+			return nil, pkgerror.NewValidationError("invalid user type")
+		}
+
+		opts = append(opts, gateway.GetUserWithTypeFilter(userType))
+	}
+
+	if in.Active != nil {
+		opts = append(opts, gateway.GetUserWithActiveFilter(*in.Active))
+	}
+
+	if in.Cursor != 0 {
+		opts = append(opts, gateway.GetUserWithIDAfterFilter(in.Cursor))
+	}
+
+	users, err := l.store.GetUser(ctx, opts...)
+	if err != nil {
+		l.logger.Errorw("failed to get user", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.ListUsersOutput{
+		Users: make([]usecase.User, 0, users.Len()),
+	}
+
+	// one extra row was requested to know whether there is a next page
+	if uint(users.Len()) > limit {
+		users = users[:limit]
+		out.NextCursor = users[len(users)-1].ID
+	}
+
+	for _, user := range users {
+		out.Users = append(out.Users, toUserOutput(user))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,23 @@
+package interactor
+
+import (
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+)
+
+func toUserOutput(user sqlentity.User) usecase.User {
+	out := usecase.User{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email.String,
+		Type:      user.Type.String(),
+		Active:    !user.DeactivatedAt.Valid,
+		CreatedAt: user.CreatedAt,
+	}
+
+	if user.DeactivatedAt.Valid {
+		out.DeactivatedAt = &user.DeactivatedAt.Time
+	}
+
+	return out
+}
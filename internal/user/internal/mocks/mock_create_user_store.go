@@ -0,0 +1,85 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package usermocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+)
+
+// MockCreateUserStore is an autogenerated mock type for the CreateUserStore type
+type MockCreateUserStore struct {
+	mock.Mock
+}
+
+type MockCreateUserStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCreateUserStore) EXPECT() *MockCreateUserStore_Expecter {
+	return &MockCreateUserStore_Expecter{mock: &_m.Mock}
+}
+
+// InsertUser provides a mock function with given fields: ctx, in
+func (_m *MockCreateUserStore) InsertUser(ctx context.Context, in sqlentity.User) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.User) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCreateUserStore_InsertUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertUser'
+type MockCreateUserStore_InsertUser_Call struct {
+	*mock.Call
+}
+
+// InsertUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.User
+func (_e *MockCreateUserStore_Expecter) InsertUser(ctx interface{}, in interface{}) *MockCreateUserStore_InsertUser_Call {
+	return &MockCreateUserStore_InsertUser_Call{Call: _e.mock.On("InsertUser", ctx, in)}
+}
+
+func (_c *MockCreateUserStore_InsertUser_Call) Run(run func(ctx context.Context, in sqlentity.User)) *MockCreateUserStore_InsertUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.User))
+	})
+	return _c
+}
+
+func (_c *MockCreateUserStore_InsertUser_Call) Return(_a0 error) *MockCreateUserStore_InsertUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCreateUserStore_InsertUser_Call) RunAndReturn(run func(context.Context, sqlentity.User) error) *MockCreateUserStore_InsertUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCreateUserStore creates a new instance of MockCreateUserStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCreateUserStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCreateUserStore {
+	mock := &MockCreateUserStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
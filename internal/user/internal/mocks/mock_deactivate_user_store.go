@@ -0,0 +1,175 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package usermocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/user/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/user/internal/entity/sqlentity"
+)
+
+// MockDeactivateUserStore is an autogenerated mock type for the DeactivateUserStore type
+type MockDeactivateUserStore struct {
+	mock.Mock
+}
+
+type MockDeactivateUserStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeactivateUserStore) EXPECT() *MockDeactivateUserStore_Expecter {
+	return &MockDeactivateUserStore_Expecter{mock: &_m.Mock}
+}
+
+// GetUser provides a mock function with given fields: ctx, opts
+func (_m *MockDeactivateUserStore) GetUser(ctx context.Context, opts ...gateway.GetUserOption) (sqlentity.Users, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 sqlentity.Users
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetUserOption) (sqlentity.Users, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetUserOption) sqlentity.Users); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.Users)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetUserOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeactivateUserStore_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockDeactivateUserStore_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetUserOption
+func (_e *MockDeactivateUserStore_Expecter) GetUser(ctx interface{}, opts ...interface{}) *MockDeactivateUserStore_GetUser_Call {
+	return &MockDeactivateUserStore_GetUser_Call{Call: _e.mock.On("GetUser",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockDeactivateUserStore_GetUser_Call) Run(run func(ctx context.Context, opts ...gateway.GetUserOption)) *MockDeactivateUserStore_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetUserOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetUserOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDeactivateUserStore_GetUser_Call) Return(_a0 sqlentity.Users, _a1 error) *MockDeactivateUserStore_GetUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeactivateUserStore_GetUser_Call) RunAndReturn(run func(context.Context, ...gateway.GetUserOption) (sqlentity.Users, error)) *MockDeactivateUserStore_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUser provides a mock function with given fields: ctx, in, opts
+func (_m *MockDeactivateUserStore) UpdateUser(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateUserOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateUserOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeactivateUserStore_UpdateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUser'
+type MockDeactivateUserStore_UpdateUser_Call struct {
+	*mock.Call
+}
+
+// UpdateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateUserOption
+func (_e *MockDeactivateUserStore_Expecter) UpdateUser(ctx interface{}, in interface{}, opts ...interface{}) *MockDeactivateUserStore_UpdateUser_Call {
+	return &MockDeactivateUserStore_UpdateUser_Call{Call: _e.mock.On("UpdateUser",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDeactivateUserStore_UpdateUser_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateUserOption)) *MockDeactivateUserStore_UpdateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateUserOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateUserOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDeactivateUserStore_UpdateUser_Call) Return(_a0 error) *MockDeactivateUserStore_UpdateUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeactivateUserStore_UpdateUser_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateUserOption) error) *MockDeactivateUserStore_UpdateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeactivateUserStore creates a new instance of MockDeactivateUserStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeactivateUserStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeactivateUserStore {
+	mock := &MockDeactivateUserStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
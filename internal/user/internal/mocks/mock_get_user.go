@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package usermocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/user/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockGetUser is an autogenerated mock type for the GetUser type
+type MockGetUser struct {
+	mock.Mock
+}
+
+type MockGetUser_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGetUser) EXPECT() *MockGetUser_Expecter {
+	return &MockGetUser_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockGetUser) Execute(ctx context.Context, in usecase.GetUserInput) (*usecase.User, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 *usecase.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.GetUserInput) (*usecase.User, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.GetUserInput) *usecase.User); ok {
+		r0 = rf(ctx, in)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecase.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, usecase.GetUserInput) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockGetUser_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockGetUser_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.GetUserInput
+func (_e *MockGetUser_Expecter) Execute(ctx interface{}, in interface{}) *MockGetUser_Execute_Call {
+	return &MockGetUser_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockGetUser_Execute_Call) Run(run func(ctx context.Context, in usecase.GetUserInput)) *MockGetUser_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.GetUserInput))
+	})
+	return _c
+}
+
+func (_c *MockGetUser_Execute_Call) Return(_a0 *usecase.User, _a1 error) *MockGetUser_Execute_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockGetUser_Execute_Call) RunAndReturn(run func(context.Context, usecase.GetUserInput) (*usecase.User, error)) *MockGetUser_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGetUser creates a new instance of MockGetUser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGetUser(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGetUser {
+	mock := &MockGetUser{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import "context"
+
+type (
+	CreateUser interface {
+		Execute(ctx context.Context, in CreateUserInput) (*User, error)
+	}
+
+	CreateUserInput struct {
+		Name  string `json:"name"  validate:"required,max=255"`
+		Email string `json:"email" validate:"omitempty,email,max=255"`
+		Type  string `json:"type"  validate:"required,oneof=borrower investor employee"`
+	}
+)
@@ -0,0 +1,13 @@
+package usecase
+
+import "context"
+
+type (
+	DeactivateUser interface {
+		Execute(ctx context.Context, in DeactivateUserInput) error
+	}
+
+	DeactivateUserInput struct {
+		UserID uint64 `json:"user_id" validate:"required"`
+	}
+)
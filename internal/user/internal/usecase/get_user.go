@@ -0,0 +1,13 @@
+package usecase
+
+import "context"
+
+type (
+	GetUser interface {
+		Execute(ctx context.Context, in GetUserInput) (*User, error)
+	}
+
+	GetUserInput struct {
+		UserID uint64 `json:"user_id" validate:"required"`
+	}
+)
@@ -0,0 +1,22 @@
+package usecase
+
+import "context"
+
+type (
+	ListUsers interface {
+		Execute(ctx context.Context, in ListUsersInput) (*ListUsersOutput, error)
+	}
+
+	// ListUsersInput filters users by type and activity. Cursor is the last user ID of the previous page.
+	ListUsersInput struct {
+		Type   string `json:"type"   validate:"omitempty,oneof=borrower investor employee"`
+		Active *bool  `json:"active"`
+		Cursor uint64 `json:"cursor"`
+		Limit  uint   `json:"limit"  validate:"omitempty,max=100"`
+	}
+
+	ListUsersOutput struct {
+		Users      []User `json:"users"`
+		NextCursor uint64 `json:"next_cursor,omitempty"`
+	}
+)
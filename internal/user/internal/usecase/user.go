@@ -0,0 +1,13 @@
+package usecase
+
+import "time"
+
+type User struct {
+	ID            uint64     `json:"id"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email,omitempty"`
+	Type          string     `json:"type"`
+	Active        bool       `json:"active"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"database/sql"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/user/internal/interactor"
+	"go.uber.org/zap"
+)
+
+type Exposed struct {
+}
+
+type Dependencies struct {
+	DB            *sql.DB
+	Logger        *zap.SugaredLogger
+	QueryBuilder  pkgsql.GoquBuilder
+	SnowflakeGen  pkguid.Snowflake
+	HttpRouter    *httprouter.Router
+	Validator     *validator.Validate
+	TokenVerifier pkgauth.TokenVerifier
+}
+
+func New(deps Dependencies) *Exposed {
+	userSQLStore := gateway.NewUserSQLGateway(deps.DB, deps.Logger, deps.QueryBuilder)
+
+	createUserUsecase := interactor.NewCreateUser(
+		userSQLStore,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	getUserUsecase := interactor.NewGetUser(
+		userSQLStore,
+		deps.Logger,
+	)
+
+	listUsersUsecase := interactor.NewListUsers(
+		userSQLStore,
+		deps.Logger,
+	)
+
+	deactivateUserUsecase := interactor.NewDeactivateUser(
+		userSQLStore,
+		deps.Logger,
+	)
+
+	userHTTPEndpoint := gateway.NewUserHTTPEndpoint(
+		createUserUsecase,
+		getUserUsecase,
+		listUsersUsecase,
+		deactivateUserUsecase,
+
+		deps.Logger,
+		deps.Validator,
+	)
+
+	gateway.NewUserHTTPGateway(
+		deps.HttpRouter,
+		deps.Logger,
+		userHTTPEndpoint,
+		deps.Validator,
+		deps.TokenVerifier,
+	)
+
+	return &Exposed{}
+}
@@ -0,0 +1,208 @@
+package sqlentity
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// WebhookDelivery is one event sent to one subscription. Payload is the exact body posted to the partner, so a replay
+// sends the same bytes again.
+type WebhookDelivery struct {
+	ID               uint64
+	SubscriptionID   uint64
+	EventID          uint64
+	EventType        string
+	Payload          string
+	Status           DeliveryStatus
+	Attempts         int
+	NextAttemptAt    time.Time
+	LastResponseCode sql.NullString
+	LastError        sql.NullString
+	DeliveredAt      sql.NullTime
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (w WebhookDelivery) Columns() []any {
+	return []any{
+		"id",
+		"subscription_id",
+		"event_id",
+		"event_type",
+		"payload",
+		"status",
+		"attempts",
+		"next_attempt_at",
+		"last_response_code",
+		"last_error",
+		"delivered_at",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func (w WebhookDelivery) StringColumns() []string {
+	vals := make([]string, len(w.Columns()))
+	for i, col := range w.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (w *WebhookDelivery) Values() []any {
+	return []any{
+		&w.ID,
+		&w.SubscriptionID,
+		&w.EventID,
+		&w.EventType,
+		&w.Payload,
+		&w.Status,
+		&w.Attempts,
+		&w.NextAttemptAt,
+		&w.LastResponseCode,
+		&w.LastError,
+		&w.DeliveredAt,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	}
+}
+
+func (w *WebhookDelivery) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(w.Values()))
+	for i, v := range w.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+type WebhookDeliveries []WebhookDelivery
+
+func (w WebhookDeliveries) IsEmpty() bool {
+	return w.Len() == 0
+}
+
+func (w WebhookDeliveries) Len() int {
+	return len(w)
+}
+
+func (w WebhookDeliveries) First() WebhookDelivery {
+	if w.IsEmpty() {
+		return WebhookDelivery{}
+	}
+
+	return w[0]
+}
+
+// UpdateWebhookDeliveryAttempt records the outcome of an attempt, and resets a delivery when it is replayed.
+type UpdateWebhookDeliveryAttempt struct {
+	Status           DeliveryStatus
+	Attempts         int
+	NextAttemptAt    time.Time
+	LastResponseCode sql.NullString
+	LastError        sql.NullString
+	DeliveredAt      sql.NullTime
+}
+
+func (u UpdateWebhookDeliveryAttempt) Columns() []any {
+	return []any{
+		"status",
+		"attempts",
+		"next_attempt_at",
+		"last_response_code",
+		"last_error",
+		"delivered_at",
+	}
+}
+
+func (u UpdateWebhookDeliveryAttempt) StringColumns() []string {
+	vals := make([]string, len(u.Columns()))
+	for i, col := range u.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (u *UpdateWebhookDeliveryAttempt) Values() []any {
+	return []any{
+		u.Status,
+		u.Attempts,
+		u.NextAttemptAt,
+		u.LastResponseCode,
+		u.LastError,
+		u.DeliveredAt,
+	}
+}
+
+func (u UpdateWebhookDeliveryAttempt) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(u.Values()))
+	for i, v := range u.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+func (u UpdateWebhookDeliveryAttempt) MappedValues() map[string]driver.Value {
+	vals := make(map[string]driver.Value)
+	cols := u.StringColumns()
+	for i, col := range cols {
+		vals[col] = u.DriverValues()[i]
+	}
+
+	return vals
+}
+
+type DeliveryStatus int
+
+const (
+	UnknownDeliveryStatus DeliveryStatus = iota
+	DeliveryPending
+	DeliveryDelivered
+	// DeliveryDead gave up after the last attempt, only a replay sends it again.
+	DeliveryDead
+)
+
+func (s DeliveryStatus) String() string {
+	return [...]string{"UNKNOWN", "PENDING", "DELIVERED", "DEAD"}[s]
+}
+
+func (s DeliveryStatus) Value() (driver.Value, error) {
+	return s.String(), nil
+}
+
+func (s DeliveryStatus) getMap() map[string]DeliveryStatus {
+	return map[string]DeliveryStatus{
+		"UNKNOWN":   UnknownDeliveryStatus,
+		"PENDING":   DeliveryPending,
+		"DELIVERED": DeliveryDelivered,
+		"DEAD":      DeliveryDead,
+	}
+}
+
+func DeliveryStatusFromString(s string) DeliveryStatus {
+	return UnknownDeliveryStatus.getMap()[s]
+}
+
+func (s *DeliveryStatus) Scan(value any) error {
+	switch v := value.(type) {
+	case []byte:
+		*s = s.getMap()[string(v)]
+	case string:
+		*s = s.getMap()[v]
+	default:
+		return errors.New("failed to scan delivery status")
+	}
+
+	return nil
+}
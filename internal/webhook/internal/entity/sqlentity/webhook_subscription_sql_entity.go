@@ -0,0 +1,99 @@
+package sqlentity
+
+import (
+	"database/sql/driver"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WebhookSubscription is a partner endpoint told about the loan events listed in EventTypes. The Secret signs every
+// delivery so the partner can verify it came from us.
+type WebhookSubscription struct {
+	ID         uint64
+	URL        string
+	Secret     string
+	EventTypes string
+	Active     bool
+	CreatedBy  uint64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (w WebhookSubscription) Columns() []any {
+	return []any{
+		"id",
+		"url",
+		"secret",
+		"event_types",
+		"active",
+		"created_by",
+		"created_at",
+		"updated_at",
+	}
+}
+
+func (w WebhookSubscription) StringColumns() []string {
+	vals := make([]string, len(w.Columns()))
+	for i, col := range w.Columns() {
+		c, ok := col.(string)
+		if ok {
+			vals[i] = c
+		}
+	}
+
+	return vals
+}
+
+func (w *WebhookSubscription) Values() []any {
+	return []any{
+		&w.ID,
+		&w.URL,
+		&w.Secret,
+		&w.EventTypes,
+		&w.Active,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	}
+}
+
+func (w *WebhookSubscription) DriverValues() []driver.Value {
+	vals := make([]driver.Value, len(w.Values()))
+	for i, v := range w.Values() {
+		vals[i] = v
+	}
+
+	return vals
+}
+
+// EventTypeList splits the comma separated event types the subscription listens to.
+func (w WebhookSubscription) EventTypeList() []string {
+	if w.EventTypes == "" {
+		return []string{}
+	}
+
+	return strings.Split(w.EventTypes, ",")
+}
+
+func (w WebhookSubscription) Subscribes(eventType string) bool {
+	return slices.Contains(w.EventTypeList(), eventType)
+}
+
+type WebhookSubscriptions []WebhookSubscription
+
+func (w WebhookSubscriptions) IsEmpty() bool {
+	return w.Len() == 0
+}
+
+func (w WebhookSubscriptions) Len() int {
+	return len(w)
+}
+
+func (w WebhookSubscriptions) First() WebhookSubscription {
+	if w.IsEmpty() {
+		return WebhookSubscription{}
+	}
+
+	return w[0]
+}
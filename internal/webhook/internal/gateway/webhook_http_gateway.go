@@ -0,0 +1,218 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkghttp/v1"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+func NewWebhookHTTPGateway(
+	httpRouter *httprouter.Router,
+	logger *zap.SugaredLogger,
+	webhookHTTPEndpoint *WebhookHTTPEndpoint,
+	validator *validator.Validate,
+	tokenVerifier pkgauth.TokenVerifier,
+) {
+	server := pkghttp.NewServer(
+		pkghttp.WithResponseEncoder(pkghttp.CodeMessageResponseEncoder),
+		pkghttp.WithErrorResponseEncoder(pkghttp.CodeMessageErrorEncoder),
+		pkghttp.WithRequestDecoders(pkghttp.WithPopulateContextFromHeader),
+		pkghttp.WithPreRequestMiddlewares(pkghttp.WithAuthentication(tokenVerifier)),
+	)
+
+	admins := pkghttp.WithPolicy(pkghttp.AllowRoles(pkgauth.RoleAdmin))
+
+	httpRouter.Handler(
+		http.MethodPost,
+		"/webhook/subscription",
+		server.Serve(webhookHTTPEndpoint.RegisterWebhookSubscription, admins),
+	)
+
+	httpRouter.Handler(
+		http.MethodGet,
+		"/webhook/subscription/:subscription_id/deliveries",
+		server.Serve(webhookHTTPEndpoint.ListWebhookDeliveries, admins),
+	)
+
+	httpRouter.Handler(
+		http.MethodPost,
+		"/webhook/delivery/:delivery_id/replay",
+		server.Serve(webhookHTTPEndpoint.ReplayWebhookDelivery, admins),
+	)
+}
+
+type WebhookHTTPEndpoint struct {
+	registerWebhookSubscriptionUsecase usecase.RegisterWebhookSubscription
+	listWebhookDeliveriesUsecase       usecase.ListWebhookDeliveries
+	replayWebhookDeliveryUsecase       usecase.ReplayWebhookDelivery
+
+	validator *validator.Validate
+	logger    *zap.SugaredLogger
+}
+
+func NewWebhookHTTPEndpoint(
+	registerWebhookSubscriptionUsecase usecase.RegisterWebhookSubscription,
+	listWebhookDeliveriesUsecase usecase.ListWebhookDeliveries,
+	replayWebhookDeliveryUsecase usecase.ReplayWebhookDelivery,
+
+	logger *zap.SugaredLogger,
+	validator *validator.Validate,
+) *WebhookHTTPEndpoint {
+	return &WebhookHTTPEndpoint{
+		registerWebhookSubscriptionUsecase: registerWebhookSubscriptionUsecase,
+		listWebhookDeliveriesUsecase:       listWebhookDeliveriesUsecase,
+		replayWebhookDeliveryUsecase:       replayWebhookDeliveryUsecase,
+
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (w *WebhookHTTPEndpoint) RegisterWebhookSubscription(
+	ctx context.Context,
+	request pkghttp.Request,
+) (any, error) {
+	var input usecase.RegisterWebhookSubscriptionInput
+	if err := request.Decode(&input); err != nil {
+		w.logger.Errorw("failed to decode request", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	principal, err := w.principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	input.CreatedBy = principal.UserID
+
+	if err := w.validator.Struct(input); err != nil {
+		w.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	subscription, err := w.registerWebhookSubscriptionUsecase.Execute(ctx, input)
+	if err != nil {
+		w.logger.Errorw("failed to register webhook subscription", "error", err)
+
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (w *WebhookHTTPEndpoint) ListWebhookDeliveries(
+	ctx context.Context,
+	request pkghttp.Request,
+) (resp any, err error) {
+	input, err := w.decodeListWebhookDeliveriesQuery(request.URL().Query())
+	if err != nil {
+		w.logger.Errorw("failed to decode query", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	subscriptionID := params.ByName("subscription_id")
+
+	input.SubscriptionID, err = strconv.ParseUint(subscriptionID, 10, 64)
+	if err != nil {
+		w.logger.Errorw("failed to parse subscription id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := w.validator.Struct(input); err != nil {
+		w.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	deliveries, err := w.listWebhookDeliveriesUsecase.Execute(ctx, input)
+	if err != nil {
+		w.logger.Errorw("failed to list webhook deliveries", "error", err)
+
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (w *WebhookHTTPEndpoint) ReplayWebhookDelivery(
+	ctx context.Context,
+	_ pkghttp.Request,
+) (resp any, err error) {
+	var input usecase.ReplayWebhookDeliveryInput
+
+	params := httprouter.ParamsFromContext(ctx)
+
+	deliveryID := params.ByName("delivery_id")
+
+	input.DeliveryID, err = strconv.ParseUint(deliveryID, 10, 64)
+	if err != nil {
+		w.logger.Errorw("failed to parse delivery id", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := w.validator.Struct(input); err != nil {
+		w.logger.Errorw("failed to validate request", "error", err)
+
+		return nil, pkgerror.ValidationErrorFrom(err)
+	}
+
+	if err := w.replayWebhookDeliveryUsecase.Execute(ctx, input); err != nil {
+		w.logger.Errorw("failed to replay webhook delivery", "error", err)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (w *WebhookHTTPEndpoint) decodeListWebhookDeliveriesQuery(
+	query url.Values,
+) (input usecase.ListWebhookDeliveriesInput, err error) {
+	input.Status = query.Get("status")
+
+	if v := query.Get("cursor"); v != "" {
+		if input.Cursor, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return input, fmt.Errorf("invalid cursor: %w", err)
+		}
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return input, fmt.Errorf("invalid limit: %w", err)
+		}
+
+		input.Limit = uint(limit)
+	}
+
+	return input, nil
+}
+
+// principal returns the caller authenticated by the WithAuthentication middleware.
+func (w *WebhookHTTPEndpoint) principal(ctx context.Context) (pkgauth.Principal, error) {
+	principal, ok := pkgauth.PrincipalFromContext(ctx)
+	if !ok {
+		w.logger.Errorw("request has no authenticated principal")
+
+		return pkgauth.Principal{}, pkgerror.NewAuthenticationError("request is not authenticated")
+	}
+
+	return principal, nil
+}
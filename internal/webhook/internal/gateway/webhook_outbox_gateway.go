@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// NewWebhookOutboxGateway subscribes the handler to every event type partners can subscribe to.
+func NewWebhookOutboxGateway(
+	registry pkgoutbox.Registry,
+	eventTypes []string,
+	webhookOutboxHandler *WebhookOutboxHandler,
+) {
+	for _, eventType := range eventTypes {
+		registry.Register(eventType, pkgoutbox.HandlerFunc(webhookOutboxHandler.EnqueueDeliveries))
+	}
+}
+
+// WebhookOutboxHandler turns the events published by the outbox into webhook deliveries.
+type WebhookOutboxHandler struct {
+	enqueueWebhookDeliveriesUsecase usecase.EnqueueWebhookDeliveries
+
+	logger *zap.SugaredLogger
+}
+
+func NewWebhookOutboxHandler(
+	enqueueWebhookDeliveriesUsecase usecase.EnqueueWebhookDeliveries,
+	logger *zap.SugaredLogger,
+) *WebhookOutboxHandler {
+	return &WebhookOutboxHandler{
+		enqueueWebhookDeliveriesUsecase: enqueueWebhookDeliveriesUsecase,
+		logger:                          logger,
+	}
+}
+
+// EnqueueDeliveries queues the event for the subscriptions listening to it, the partners are called later by the
+// delivery worker. A redelivered event is not queued twice.
+func (h *WebhookOutboxHandler) EnqueueDeliveries(ctx context.Context, e pkgoutbox.Event) error {
+	err := h.enqueueWebhookDeliveriesUsecase.Execute(ctx, usecase.EnqueueWebhookDeliveriesInput{
+		EventID:     e.ID,
+		EventType:   e.Type,
+		AggregateID: e.AggregateID,
+		Data:        e.Payload,
+		OccurredAt:  e.CreatedAt,
+	})
+	if err != nil {
+		h.logger.Errorw("failed to enqueue webhook deliveries", "event_id", e.ID, "error", err)
+
+		if pkgerror.IsBusinessError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return nil
+}
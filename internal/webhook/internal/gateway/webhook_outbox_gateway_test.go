@@ -0,0 +1,73 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	webhookmocks "github.com/shandysiswandi/test-amartha/internal/webhook/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWebhookOutboxHandler_EnqueueDeliveries(t *testing.T) {
+	event := pkgoutbox.Event{
+		ID:            100,
+		AggregateType: "loan",
+		AggregateID:   1,
+		Type:          "LoanApproved",
+		Payload:       json.RawMessage(`{"loan_id":1}`),
+		CreatedAt:     time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	input := usecase.EnqueueWebhookDeliveriesInput{
+		EventID:     100,
+		EventType:   "LoanApproved",
+		AggregateID: 1,
+		Data:        json.RawMessage(`{"loan_id":1}`),
+		OccurredAt:  event.CreatedAt,
+	}
+
+	tests := []struct {
+		name    string
+		mockFn  func(ctx context.Context, enqueueUC *webhookmocks.MockEnqueueWebhookDeliveries)
+		wantErr bool
+	}{
+		{
+			name: "error enqueue is retried",
+			mockFn: func(ctx context.Context, enqueueUC *webhookmocks.MockEnqueueWebhookDeliveries) {
+				enqueueUC.EXPECT().Execute(ctx, input).Return(pkgerror.ServerErrorFrom(errors.New("db down"))).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success business error is not retried",
+			mockFn: func(ctx context.Context, enqueueUC *webhookmocks.MockEnqueueWebhookDeliveries) {
+				enqueueUC.EXPECT().Execute(ctx, input).
+					Return(pkgerror.NewBusinessErrorCode(pkgerror.WebhookSubscriptionNotFound)).Once()
+			},
+		},
+		{
+			name: "success",
+			mockFn: func(ctx context.Context, enqueueUC *webhookmocks.MockEnqueueWebhookDeliveries) {
+				enqueueUC.EXPECT().Execute(ctx, input).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			enqueueUC := webhookmocks.NewMockEnqueueWebhookDeliveries(t)
+			tt.mockFn(ctx, enqueueUC)
+
+			h := gateway.NewWebhookOutboxHandler(enqueueUC, zap.NewNop().Sugar())
+			err := h.EnqueueDeliveries(ctx, event)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+)
+
+const (
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookDelivery  = "X-Webhook-Delivery"
+
+	// maxPartnerResponseSize bounds how much of a failed response is kept as the error of the delivery.
+	maxPartnerResponseSize = 1 << 10
+)
+
+// WebhookRequest is one signed POST of a delivery to the URL of its subscription.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID uint64
+	EventType  string
+	Body       []byte
+}
+
+// WebhookPartnerGateway posts deliveries to the partners.
+type WebhookPartnerGateway struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookPartnerGateway(timeout time.Duration) *WebhookPartnerGateway {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &WebhookPartnerGateway{
+		client: &http.Client{Timeout: timeout},
+		now:    time.Now,
+	}
+}
+
+// Send posts the request and returns the status code the partner answered with. Anything but a 2xx answer is a
+// pkgerror.PartnerError carrying that status code, a request which got no answer at all has an empty one.
+func (g *WebhookPartnerGateway) Send(ctx context.Context, in WebhookRequest) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, in.URL, bytes.NewReader(in.Body))
+	if err != nil {
+		return "", pkgerror.NewPartnerError("", fmt.Sprintf("build webhook request: %v", err))
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookEvent, in.EventType)
+	req.Header.Set(HeaderWebhookDelivery, strconv.FormatUint(in.DeliveryID, 10))
+	req.Header.Set(HeaderWebhookSignature, SignWebhook(in.Secret, g.now(), in.Body))
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", pkgerror.NewPartnerError("", fmt.Sprintf("send webhook: %v", err))
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxPartnerResponseSize))
+	code := strconv.Itoa(resp.StatusCode)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg := fmt.Sprintf("partner responded %s", resp.Status)
+		if b := strings.TrimSpace(string(body)); b != "" {
+			msg += ": " + b
+		}
+
+		return code, pkgerror.NewPartnerError(code, msg)
+	}
+
+	return code, nil
+}
+
+// SignWebhook computes the X-Webhook-Signature header of a body sent at timestamp. Partners recompute the
+// HMAC-SHA256 of "<t>.<body>" with the secret of their subscription and compare it to v1, and reject old timestamps to
+// stop replays.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
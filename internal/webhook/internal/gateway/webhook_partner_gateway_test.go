@@ -0,0 +1,100 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignWebhook(t *testing.T) {
+	got := SignWebhook("secret", time.Unix(1700000000, 0), []byte(`{"id":1}`))
+
+	// echo -n '1700000000.{"id":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "t=1700000000,v1=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11", got)
+}
+
+func TestWebhookPartnerGateway_Send(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"id":1}`)
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		wantCode     string
+		wantErr      bool
+		wantRespCode string
+	}{
+		{
+			name: "error partner responds non 2xx",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte("maintenance"))
+			},
+			wantCode:     "503",
+			wantErr:      true,
+			wantRespCode: "503",
+		},
+		{
+			name: "success",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				got, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodPost ||
+					string(got) != string(body) ||
+					r.Header.Get("Content-Type") != "application/json" ||
+					r.Header.Get(HeaderWebhookEvent) != "LoanApproved" ||
+					r.Header.Get(HeaderWebhookDelivery) != "7" ||
+					r.Header.Get(HeaderWebhookSignature) != SignWebhook("secret", now, body) {
+					w.WriteHeader(http.StatusBadRequest)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			},
+			wantCode: "204",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			g := NewWebhookPartnerGateway(time.Second)
+			g.now = func() time.Time { return now }
+
+			code, err := g.Send(context.Background(), WebhookRequest{
+				URL:        server.URL,
+				Secret:     "secret",
+				DeliveryID: 7,
+				EventType:  "LoanApproved",
+				Body:       body,
+			})
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.wantErr {
+				perr, ok := pkgerror.AsPartnerError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantRespCode, perr.ResponseCode)
+			}
+		})
+	}
+
+	t.Run("error partner unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		code, err := NewWebhookPartnerGateway(time.Second).Send(context.Background(), WebhookRequest{URL: server.URL})
+		assert.Empty(t, code)
+
+		perr, ok := pkgerror.AsPartnerError(err)
+		assert.True(t, ok)
+		assert.Empty(t, perr.ResponseCode)
+	})
+}
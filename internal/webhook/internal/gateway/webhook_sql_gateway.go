@@ -0,0 +1,391 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"go.uber.org/zap"
+)
+
+// ErrWebhookDeliveryNotUpdated is returned when an update matches no delivery, either because it does not exist or
+// because its status changed since it was read.
+var ErrWebhookDeliveryNotUpdated = errors.New("webhook delivery not updated")
+
+type WebhookSQLGateway struct {
+	db           pkgsql.SQL
+	transactor   pkgsql.Transactor
+	logger       *zap.SugaredLogger
+	queryBuilder pkgsql.GoquBuilder
+
+	webhookSubscriptionTableName string
+	webhookDeliveryTableName     string
+}
+
+func NewWebhookSQLGateway(
+	db *sql.DB,
+	logger *zap.SugaredLogger,
+	queryBuilder pkgsql.GoquBuilder,
+) *WebhookSQLGateway {
+	return &WebhookSQLGateway{
+		db:           db,
+		transactor:   pkgsql.NewTransactor(db),
+		logger:       logger,
+		queryBuilder: queryBuilder,
+
+		webhookSubscriptionTableName: "webhook_subscriptions",
+		webhookDeliveryTableName:     "webhook_deliveries",
+	}
+}
+
+func (r *WebhookSQLGateway) InsertWebhookSubscription(ctx context.Context, in sqlentity.WebhookSubscription) error {
+	query := r.queryBuilder.Insert(r.webhookSubscriptionTableName).Cols(in.Columns()...).Vals(in.Values())
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return fmt.Errorf("failed to insert webhook subscription")
+	}
+
+	return nil
+}
+
+type GetWebhookSubscriptionOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetWebhookSubscriptionWithIDFilter(subscriptionIDs ...uint64) GetWebhookSubscriptionOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": subscriptionIDs})
+	}
+}
+
+func GetWebhookSubscriptionWithActiveFilter(active bool) GetWebhookSubscriptionOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"active": active})
+	}
+}
+
+// GetWebhookSubscriptionWithEventTypeFilter filters subscriptions listening to the event type.
+func GetWebhookSubscriptionWithEventTypeFilter(eventType string) GetWebhookSubscriptionOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.L("FIND_IN_SET(?, ?)", eventType, goqu.C("event_types")).Gt(0))
+	}
+}
+
+func (r *WebhookSQLGateway) GetWebhookSubscription(
+	ctx context.Context,
+	opts ...GetWebhookSubscriptionOption,
+) (sqlentity.WebhookSubscriptions, error) {
+	var subscription sqlentity.WebhookSubscription
+	query := r.queryBuilder.Select(subscription.Columns()...).
+		From(r.webhookSubscriptionTableName).
+		Order(goqu.C("id").Asc())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions sqlentity.WebhookSubscriptions
+	for rows.Next() {
+		err := rows.Scan(subscription.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// InsertWebhookDeliveries skips the deliveries already created for their subscription and event, so an event
+// redelivered by the outbox is not sent twice.
+func (r *WebhookSQLGateway) InsertWebhookDeliveries(ctx context.Context, in sqlentity.WebhookDeliveries) error {
+	if in.IsEmpty() {
+		return nil
+	}
+
+	var delivery sqlentity.WebhookDelivery
+	query := r.queryBuilder.Insert(r.webhookDeliveryTableName).
+		Cols(delivery.Columns()...).
+		OnConflict(goqu.DoNothing())
+
+	for _, d := range in {
+		query = query.Vals(d.Values())
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	if _, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql); err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// ClaimWebhookDeliveries locks the pending deliveries due at now with SKIP LOCKED and pushes their next attempt past
+// the lease in one transaction, so concurrent workers never send the same delivery and a delivery claimed by a crashed
+// worker becomes due again.
+func (r *WebhookSQLGateway) ClaimWebhookDeliveries(
+	ctx context.Context,
+	now time.Time,
+	limit uint,
+	lease time.Duration,
+) (sqlentity.WebhookDeliveries, error) {
+	var deliveries sqlentity.WebhookDeliveries
+
+	err := r.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		deliveries, err = r.GetWebhookDelivery(
+			ctx,
+			GetWebhookDeliveryWithStatusFilter(sqlentity.DeliveryPending),
+			GetWebhookDeliveryWithDueFilter(now),
+			GetWebhookDeliveryWithOrderByID(false),
+			GetWebhookDeliveryWithLimit(limit),
+			GetWebhookDeliveryWithLock(),
+		)
+		if err != nil || deliveries.IsEmpty() {
+			return err
+		}
+
+		ids := make([]uint64, 0, deliveries.Len())
+		for _, d := range deliveries {
+			ids = append(ids, d.ID)
+		}
+
+		query := r.queryBuilder.Update(r.webhookDeliveryTableName).
+			Set(goqu.Record{"next_attempt_at": now.Add(lease)}).
+			Where(goqu.Ex{"id": ids})
+
+		sql, _, err := query.ToSQL()
+		if err != nil {
+			r.logger.Errorw("failed to build query", "error", err)
+
+			return err
+		}
+
+		if _, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql); err != nil {
+			r.logger.Errorw("failed to execute query", "error", err)
+
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+type GetWebhookDeliveryOption func(*goqu.SelectDataset) *goqu.SelectDataset
+
+func GetWebhookDeliveryWithIDFilter(deliveryID uint64) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"id": deliveryID})
+	}
+}
+
+func GetWebhookDeliveryWithSubscriptionIDFilter(subscriptionID uint64) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"subscription_id": subscriptionID})
+	}
+}
+
+func GetWebhookDeliveryWithStatusFilter(status sqlentity.DeliveryStatus) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.Ex{"status": status})
+	}
+}
+
+// GetWebhookDeliveryWithDueFilter filters deliveries whose next attempt is due at now.
+func GetWebhookDeliveryWithDueFilter(now time.Time) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("next_attempt_at").Lte(now))
+	}
+}
+
+func GetWebhookDeliveryWithIDBeforeFilter(deliveryID uint64) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Where(goqu.C("id").Lt(deliveryID))
+	}
+}
+
+func GetWebhookDeliveryWithOrderByID(descending bool) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		if descending {
+			return query.Order(goqu.C("id").Desc())
+		}
+
+		return query.Order(goqu.C("id").Asc())
+	}
+}
+
+func GetWebhookDeliveryWithLimit(limit uint) GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.Limit(limit)
+	}
+}
+
+// GetWebhookDeliveryWithLock locks the rows read until the transaction ends, skipping the rows locked by others.
+func GetWebhookDeliveryWithLock() GetWebhookDeliveryOption {
+	return func(query *goqu.SelectDataset) *goqu.SelectDataset {
+		return query.ForUpdate(exp.SkipLocked)
+	}
+}
+
+func (r *WebhookSQLGateway) GetWebhookDelivery(
+	ctx context.Context,
+	opts ...GetWebhookDeliveryOption,
+) (sqlentity.WebhookDeliveries, error) {
+	var delivery sqlentity.WebhookDelivery
+	query := r.queryBuilder.Select(delivery.Columns()...).From(r.webhookDeliveryTableName)
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return nil, err
+	}
+
+	rows, err := pkgsql.ExecutorFromContext(ctx, r.db).QueryContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries sqlentity.WebhookDeliveries
+	for rows.Next() {
+		err := rows.Scan(delivery.Values()...)
+		if err != nil {
+			r.logger.Errorw("failed to scan row", "error", err)
+
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		r.logger.Errorw("failed to iterate rows", "error", err)
+
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+type UpdateWebhookDeliveryOption func(*goqu.UpdateDataset) *goqu.UpdateDataset
+
+func UpdateWebhookDeliveryWithIDFilter(deliveryID uint64) UpdateWebhookDeliveryOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"id": deliveryID})
+	}
+}
+
+// UpdateWebhookDeliveryWithStatusFilter makes the update a compare-and-swap on the status the delivery was read with.
+func UpdateWebhookDeliveryWithStatusFilter(status sqlentity.DeliveryStatus) UpdateWebhookDeliveryOption {
+	return func(query *goqu.UpdateDataset) *goqu.UpdateDataset {
+		return query.Where(goqu.Ex{"status": status})
+	}
+}
+
+func (r *WebhookSQLGateway) UpdateWebhookDelivery(
+	ctx context.Context,
+	in sqlentity.UpdateEntity,
+	opts ...UpdateWebhookDeliveryOption,
+) error {
+	query := r.queryBuilder.Update(r.webhookDeliveryTableName).Set(in.MappedValues())
+
+	for _, opt := range opts {
+		query = opt(query)
+	}
+
+	sql, _, err := query.ToSQL()
+	if err != nil {
+		r.logger.Errorw("failed to build query", "error", err)
+
+		return err
+	}
+
+	res, err := pkgsql.ExecutorFromContext(ctx, r.db).ExecContext(ctx, sql)
+	if err != nil {
+		r.logger.Errorw("failed to execute query", "error", err)
+
+		return err
+	}
+
+	row, err := res.RowsAffected()
+	if err != nil {
+		r.logger.Errorw("failed to get last insert id", "error", err)
+
+		return err
+	}
+
+	if row == 0 {
+		return ErrWebhookDeliveryNotUpdated
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+package gateway
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/doug-martin/goqu/v9"
+	_ "github.com/doug-martin/goqu/v9/dialect/mysql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type webhookSQLGatewaySuite struct {
+	db           *sql.DB
+	dbmock       sqlmock.Sqlmock
+	queryBuilder pkgsql.GoquBuilder
+
+	now          time.Time
+	subscription sqlentity.WebhookSubscription
+	delivery     sqlentity.WebhookDelivery
+
+	suite.Suite
+}
+
+func TestWebhookSQLGatewaySuite(t *testing.T) {
+	suite.Run(t, new(webhookSQLGatewaySuite))
+}
+
+func (ws *webhookSQLGatewaySuite) SetupTest() {
+	var err error
+	ws.db, ws.dbmock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		ws.T().Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+
+	ws.queryBuilder = goqu.New("mysql", ws.db)
+	ws.now = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ws.subscription = sqlentity.WebhookSubscription{
+		ID:         7,
+		URL:        "https://partner.example.com/hooks",
+		Secret:     "secret",
+		EventTypes: "LoanApproved,LoanDisbursed",
+		Active:     true,
+		CreatedBy:  1,
+		CreatedAt:  ws.now,
+		UpdatedAt:  ws.now,
+	}
+	ws.delivery = sqlentity.WebhookDelivery{
+		ID:             20,
+		SubscriptionID: 7,
+		EventID:        100,
+		EventType:      "LoanApproved",
+		Payload:        `{"id":100}`,
+		Status:         sqlentity.DeliveryPending,
+		NextAttemptAt:  ws.now,
+		CreatedAt:      ws.now,
+		UpdatedAt:      ws.now,
+	}
+}
+
+func (ws *webhookSQLGatewaySuite) TearDownTest() {
+	ws.NoError(ws.dbmock.ExpectationsWereMet())
+	ws.db.Close()
+}
+
+func (ws *webhookSQLGatewaySuite) gateway() *WebhookSQLGateway {
+	return NewWebhookSQLGateway(ws.db, zap.NewNop().Sugar(), ws.queryBuilder)
+}
+
+func (ws *webhookSQLGatewaySuite) TestWebhookSQLGateway_InsertWebhookSubscription() {
+	query := "INSERT INTO `webhook_subscriptions` (`id`, `url`, `secret`, `event_types`, `active`, `created_by`, " +
+		"`created_at`, `updated_at`) VALUES (7, 'https://partner.example.com/hooks', 'secret', " +
+		"'LoanApproved,LoanDisbursed', 1, 1, '2024-01-02 03:04:05', '2024-01-02 03:04:05')"
+
+	ws.Run("error exec", func() {
+		ws.dbmock.ExpectExec(query).WillReturnError(errors.New("db down"))
+		ws.Error(ws.gateway().InsertWebhookSubscription(context.Background(), ws.subscription))
+	})
+
+	ws.Run("error no row inserted", func() {
+		ws.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+		ws.Error(ws.gateway().InsertWebhookSubscription(context.Background(), ws.subscription))
+	})
+
+	ws.Run("success", func() {
+		ws.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+		ws.NoError(ws.gateway().InsertWebhookSubscription(context.Background(), ws.subscription))
+	})
+}
+
+func (ws *webhookSQLGatewaySuite) TestWebhookSQLGateway_GetWebhookSubscription() {
+	query := "SELECT `id`, `url`, `secret`, `event_types`, `active`, `created_by`, `created_at`, `updated_at` " +
+		"FROM `webhook_subscriptions` WHERE ((`active` IS TRUE) AND " +
+		"(FIND_IN_SET('LoanApproved', `event_types`) > 0)) ORDER BY `id` ASC"
+	opts := []GetWebhookSubscriptionOption{
+		GetWebhookSubscriptionWithActiveFilter(true),
+		GetWebhookSubscriptionWithEventTypeFilter("LoanApproved"),
+	}
+
+	ws.Run("error query", func() {
+		ws.dbmock.ExpectQuery(query).WillReturnError(errors.New("db down"))
+
+		got, err := ws.gateway().GetWebhookSubscription(context.Background(), opts...)
+		ws.Error(err)
+		ws.Nil(got)
+	})
+
+	ws.Run("success", func() {
+		ws.dbmock.ExpectQuery(query).WillReturnRows(
+			sqlmock.NewRows(ws.subscription.StringColumns()).AddRow(ws.subscription.DriverValues()...),
+		)
+
+		got, err := ws.gateway().GetWebhookSubscription(context.Background(), opts...)
+		ws.NoError(err)
+		ws.Equal(sqlentity.WebhookSubscriptions{ws.subscription}, got)
+	})
+}
+
+func (ws *webhookSQLGatewaySuite) TestWebhookSQLGateway_InsertWebhookDeliveries() {
+	query := "INSERT IGNORE INTO `webhook_deliveries` (`id`, `subscription_id`, `event_id`, `event_type`, " +
+		"`payload`, `status`, `attempts`, `next_attempt_at`, `last_response_code`, `last_error`, `delivered_at`, " +
+		"`created_at`, `updated_at`) VALUES (20, 7, 100, 'LoanApproved', '{\\\"id\\\":100}', 'PENDING', 0, " +
+		"'2024-01-02 03:04:05', NULL, NULL, NULL, '2024-01-02 03:04:05', '2024-01-02 03:04:05')"
+
+	ws.Run("success nothing to insert", func() {
+		ws.NoError(ws.gateway().InsertWebhookDeliveries(context.Background(), nil))
+	})
+
+	ws.Run("error exec", func() {
+		ws.dbmock.ExpectExec(query).WillReturnError(errors.New("db down"))
+		ws.Error(ws.gateway().InsertWebhookDeliveries(context.Background(), sqlentity.WebhookDeliveries{ws.delivery}))
+	})
+
+	ws.Run("success already delivered event is ignored", func() {
+		ws.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+		ws.NoError(ws.gateway().InsertWebhookDeliveries(context.Background(), sqlentity.WebhookDeliveries{ws.delivery}))
+	})
+}
+
+func (ws *webhookSQLGatewaySuite) TestWebhookSQLGateway_ClaimWebhookDeliveries() {
+	selectQuery := "SELECT `id`, `subscription_id`, `event_id`, `event_type`, `payload`, `status`, `attempts`, " +
+		"`next_attempt_at`, `last_response_code`, `last_error`, `delivered_at`, `created_at`, `updated_at` " +
+		"FROM `webhook_deliveries` WHERE ((`status` = 'PENDING') AND " +
+		"(`next_attempt_at` <= '2024-01-02 03:04:05')) ORDER BY `id` ASC LIMIT 10 FOR UPDATE SKIP LOCKED"
+	updateQuery := "UPDATE `webhook_deliveries` SET `next_attempt_at`='2024-01-02 03:05:05' WHERE (`id` IN (20))"
+
+	ws.Run("error query rolls back", func() {
+		ws.dbmock.ExpectBegin()
+		ws.dbmock.ExpectQuery(selectQuery).WillReturnError(errors.New("db down"))
+		ws.dbmock.ExpectRollback()
+
+		got, err := ws.gateway().ClaimWebhookDeliveries(context.Background(), ws.now, 10, time.Minute)
+		ws.Error(err)
+		ws.Nil(got)
+	})
+
+	ws.Run("success nothing due", func() {
+		ws.dbmock.ExpectBegin()
+		ws.dbmock.ExpectQuery(selectQuery).WillReturnRows(sqlmock.NewRows(ws.delivery.StringColumns()))
+		ws.dbmock.ExpectCommit()
+
+		got, err := ws.gateway().ClaimWebhookDeliveries(context.Background(), ws.now, 10, time.Minute)
+		ws.NoError(err)
+		ws.Empty(got)
+	})
+
+	ws.Run("error lease rolls back", func() {
+		ws.dbmock.ExpectBegin()
+		ws.dbmock.ExpectQuery(selectQuery).WillReturnRows(
+			sqlmock.NewRows(ws.delivery.StringColumns()).AddRow(ws.delivery.DriverValues()...),
+		)
+		ws.dbmock.ExpectExec(updateQuery).WillReturnError(errors.New("db down"))
+		ws.dbmock.ExpectRollback()
+
+		got, err := ws.gateway().ClaimWebhookDeliveries(context.Background(), ws.now, 10, time.Minute)
+		ws.Error(err)
+		ws.Nil(got)
+	})
+
+	ws.Run("success", func() {
+		ws.dbmock.ExpectBegin()
+		ws.dbmock.ExpectQuery(selectQuery).WillReturnRows(
+			sqlmock.NewRows(ws.delivery.StringColumns()).AddRow(ws.delivery.DriverValues()...),
+		)
+		ws.dbmock.ExpectExec(updateQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		ws.dbmock.ExpectCommit()
+
+		got, err := ws.gateway().ClaimWebhookDeliveries(context.Background(), ws.now, 10, time.Minute)
+		ws.NoError(err)
+		ws.Equal(sqlentity.WebhookDeliveries{ws.delivery}, got)
+	})
+}
+
+func (ws *webhookSQLGatewaySuite) TestWebhookSQLGateway_GetWebhookDelivery() {
+	query := "SELECT `id`, `subscription_id`, `event_id`, `event_type`, `payload`, `status`, `attempts`, " +
+		"`next_attempt_at`, `last_response_code`, `last_error`, `delivered_at`, `created_at`, `updated_at` " +
+		"FROM `webhook_deliveries` WHERE ((`subscription_id` = 7) AND (`status` = 'DEAD') AND (`id` < 50)) " +
+		"ORDER BY `id` DESC LIMIT 21"
+	opts := []GetWebhookDeliveryOption{
+		GetWebhookDeliveryWithSubscriptionIDFilter(7),
+		GetWebhookDeliveryWithStatusFilter(sqlentity.DeliveryDead),
+		GetWebhookDeliveryWithIDBeforeFilter(50),
+		GetWebhookDeliveryWithOrderByID(true),
+		GetWebhookDeliveryWithLimit(21),
+	}
+
+	ws.Run("error scan", func() {
+		ws.dbmock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+		got, err := ws.gateway().GetWebhookDelivery(context.Background(), opts...)
+		ws.Error(err)
+		ws.Nil(got)
+	})
+
+	ws.Run("success", func() {
+		ws.dbmock.ExpectQuery(query).WillReturnRows(
+			sqlmock.NewRows(ws.delivery.StringColumns()).AddRow(ws.delivery.DriverValues()...),
+		)
+
+		got, err := ws.gateway().GetWebhookDelivery(context.Background(), opts...)
+		ws.NoError(err)
+		ws.Equal(sqlentity.WebhookDeliveries{ws.delivery}, got)
+	})
+}
+
+func (ws *webhookSQLGatewaySuite) TestWebhookSQLGateway_UpdateWebhookDelivery() {
+	in := &sqlentity.UpdateWebhookDeliveryAttempt{
+		Status:           sqlentity.DeliveryDead,
+		Attempts:         8,
+		NextAttemptAt:    ws.now,
+		LastResponseCode: sql.NullString{String: "500", Valid: true},
+		LastError:        sql.NullString{String: "partner responded 500", Valid: true},
+	}
+	query := "UPDATE `webhook_deliveries` SET `attempts`=8,`delivered_at`=NULL,`last_error`='partner responded 500'," +
+		"`last_response_code`='500',`next_attempt_at`='2024-01-02 03:04:05',`status`='DEAD' " +
+		"WHERE ((`id` = 20) AND (`status` = 'PENDING'))"
+	opts := []UpdateWebhookDeliveryOption{
+		UpdateWebhookDeliveryWithIDFilter(20),
+		UpdateWebhookDeliveryWithStatusFilter(sqlentity.DeliveryPending),
+	}
+
+	ws.Run("error exec", func() {
+		ws.dbmock.ExpectExec(query).WillReturnError(errors.New("db down"))
+		ws.Error(ws.gateway().UpdateWebhookDelivery(context.Background(), in, opts...))
+	})
+
+	ws.Run("error status changed", func() {
+		ws.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 0))
+		ws.ErrorIs(ws.gateway().UpdateWebhookDelivery(context.Background(), in, opts...), ErrWebhookDeliveryNotUpdated)
+	})
+
+	ws.Run("success", func() {
+		ws.dbmock.ExpectExec(query).WillReturnResult(sqlmock.NewResult(0, 1))
+		ws.NoError(ws.gateway().UpdateWebhookDelivery(context.Background(), in, opts...))
+	})
+}
@@ -0,0 +1,27 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgworker"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// NewWebhookWorkerGateway returns the worker sending the due deliveries every interval, it runs again right away while
+// batches come back full.
+func NewWebhookWorkerGateway(
+	interval time.Duration,
+	deliverWebhooksUsecase usecase.DeliverWebhooks,
+	logger *zap.SugaredLogger,
+) *pkgworker.Periodic {
+	return pkgworker.NewPeriodic("webhook-delivery", interval, func(ctx context.Context) (bool, error) {
+		out, err := deliverWebhooksUsecase.Execute(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		return out.HasMore, nil
+	}, logger)
+}
@@ -0,0 +1,239 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDeliveryBatchSize    uint = 50
+	defaultDeliveryLease             = time.Minute
+	defaultDeliveryMaxAttempts       = 8
+	defaultDeliveryRetryBackoff      = 10 * time.Second
+	defaultDeliveryMaxBackoff        = time.Hour
+)
+
+// errWebhookSubscriptionInactive kills the deliveries of a subscription that was deactivated after they were queued.
+var errWebhookSubscriptionInactive = errors.New("webhook subscription is inactive")
+
+type (
+	DeliverWebhooksStore interface {
+		ClaimWebhookDeliveries(
+			ctx context.Context,
+			now time.Time,
+			limit uint,
+			lease time.Duration,
+		) (sqlentity.WebhookDeliveries, error)
+		GetWebhookSubscription(
+			ctx context.Context,
+			opts ...gateway.GetWebhookSubscriptionOption,
+		) (sqlentity.WebhookSubscriptions, error)
+		UpdateWebhookDelivery(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateWebhookDeliveryOption,
+		) error
+	}
+
+	// WebhookSender posts a delivery to a partner and returns the status code it answered with.
+	WebhookSender interface {
+		Send(ctx context.Context, in gateway.WebhookRequest) (string, error)
+	}
+
+	// DeliverWebhooksConfig tunes the delivery worker, zero values fall back to the defaults.
+	DeliverWebhooksConfig struct {
+		// BatchSize is the number of deliveries claimed per run.
+		BatchSize uint
+		// Lease is how long a claimed delivery is hidden from other workers while it is sent.
+		Lease time.Duration
+		// MaxAttempts is the number of attempts before a delivery is dead.
+		MaxAttempts int
+		// RetryBackoff is the wait after the first failed attempt, it doubles on every following one.
+		RetryBackoff time.Duration
+		// MaxBackoff caps the wait between two attempts.
+		MaxBackoff time.Duration
+	}
+
+	DeliverWebhooks struct {
+		store  DeliverWebhooksStore
+		sender WebhookSender
+		config DeliverWebhooksConfig
+
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewDeliverWebhooks(
+	store DeliverWebhooksStore,
+	sender WebhookSender,
+	config DeliverWebhooksConfig,
+	logger *zap.SugaredLogger,
+) *DeliverWebhooks {
+	if config.BatchSize == 0 {
+		config.BatchSize = defaultDeliveryBatchSize
+	}
+
+	if config.Lease <= 0 {
+		config.Lease = defaultDeliveryLease
+	}
+
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultDeliveryMaxAttempts
+	}
+
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = defaultDeliveryRetryBackoff
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultDeliveryMaxBackoff
+	}
+
+	return &DeliverWebhooks{
+		store:  store,
+		sender: sender,
+		config: config,
+		logger: logger,
+	}
+}
+
+// Execute sends one batch of due deliveries. A failed attempt is retried with an exponential backoff until the last
+// attempt, then the delivery is dead until replayed.
+func (d *DeliverWebhooks) Execute(ctx context.Context) (*usecase.DeliverWebhooksOutput, error) {
+	deliveries, err := d.store.ClaimWebhookDeliveries(ctx, time.Now(), d.config.BatchSize, d.config.Lease)
+	if err != nil {
+		d.logger.Errorw("failed to claim webhook deliveries", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.DeliverWebhooksOutput{HasMore: uint(deliveries.Len()) >= d.config.BatchSize}
+	if deliveries.IsEmpty() {
+		return out, nil
+	}
+
+	subscriptionIDs := make([]uint64, 0, deliveries.Len())
+	for _, delivery := range deliveries {
+		subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID)
+	}
+
+	subscriptions, err := d.store.GetWebhookSubscription(
+		ctx,
+		gateway.GetWebhookSubscriptionWithIDFilter(subscriptionIDs...),
+	)
+	if err != nil {
+		d.logger.Errorw("failed to get webhook subscription", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	subscriptionByID := make(map[uint64]sqlentity.WebhookSubscription, subscriptions.Len())
+	for _, subscription := range subscriptions {
+		subscriptionByID[subscription.ID] = subscription
+	}
+
+	var errs []error
+	for _, delivery := range deliveries {
+		// the deliveries left are claimed until their lease ends, another worker sends them then
+		if ctx.Err() != nil {
+			break
+		}
+
+		update, sendErr := d.send(ctx, delivery, subscriptionByID[delivery.SubscriptionID])
+		if sendErr != nil && ctx.Err() != nil {
+			break
+		}
+
+		if err := d.store.UpdateWebhookDelivery(
+			ctx,
+			&update,
+			gateway.UpdateWebhookDeliveryWithIDFilter(delivery.ID),
+			gateway.UpdateWebhookDeliveryWithStatusFilter(sqlentity.DeliveryPending),
+		); err != nil {
+			d.logger.Errorw("failed to update webhook delivery", "delivery_id", delivery.ID, "error", err)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		switch update.Status {
+		case sqlentity.DeliveryDelivered:
+			out.Delivered++
+		case sqlentity.DeliveryDead:
+			d.logger.Warnw("webhook delivery is dead", "delivery_id", delivery.ID, "error", sendErr)
+			out.Dead++
+		default:
+			d.logger.Warnw("webhook delivery failed, retrying", "delivery_id", delivery.ID, "error", sendErr)
+			out.Retried++
+		}
+	}
+
+	if len(errs) > 0 {
+		return out, pkgerror.ServerErrorFrom(errors.Join(errs...))
+	}
+
+	return out, nil
+}
+
+// send makes one attempt of the delivery and returns its outcome along with the error of a failed attempt.
+func (d *DeliverWebhooks) send(
+	ctx context.Context,
+	delivery sqlentity.WebhookDelivery,
+	subscription sqlentity.WebhookSubscription,
+) (sqlentity.UpdateWebhookDeliveryAttempt, error) {
+	now := time.Now()
+	update := sqlentity.UpdateWebhookDeliveryAttempt{
+		Attempts:      delivery.Attempts + 1,
+		NextAttemptAt: now,
+	}
+
+	if !subscription.Active {
+		update.Status = sqlentity.DeliveryDead
+		update.LastError = nullString(errWebhookSubscriptionInactive.Error())
+
+		return update, errWebhookSubscriptionInactive
+	}
+
+	code, err := d.sender.Send(ctx, gateway.WebhookRequest{
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		Body:       []byte(delivery.Payload),
+	})
+	update.LastResponseCode = nullString(code)
+
+	switch {
+	case err == nil:
+		update.Status = sqlentity.DeliveryDelivered
+		update.DeliveredAt = sql.NullTime{Time: now, Valid: true}
+	case update.Attempts >= d.config.MaxAttempts:
+		update.Status = sqlentity.DeliveryDead
+		update.LastError = nullString(err.Error())
+	default:
+		update.Status = sqlentity.DeliveryPending
+		update.LastError = nullString(err.Error())
+		update.NextAttemptAt = now.Add(d.backoff(update.Attempts))
+	}
+
+	return update, err
+}
+
+// backoff is the wait after the given number of failed attempts, RetryBackoff doubled on every attempt after the first
+// and capped at MaxBackoff.
+func (d *DeliverWebhooks) backoff(attempts int) time.Duration {
+	wait := d.config.RetryBackoff
+	for i := 1; i < attempts && wait < d.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+
+	return min(wait, d.config.MaxBackoff)
+}
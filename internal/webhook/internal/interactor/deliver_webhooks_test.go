@@ -0,0 +1,245 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	webhookmocks "github.com/shandysiswandi/test-amartha/internal/webhook/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestDeliverWebhooks_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	config := DeliverWebhooksConfig{
+		BatchSize:    2,
+		Lease:        time.Minute,
+		MaxAttempts:  3,
+		RetryBackoff: 10 * time.Second,
+		MaxBackoff:   time.Hour,
+	}
+	subscription := sqlentity.WebhookSubscription{
+		ID:     7,
+		URL:    "https://partner.example.com/hooks",
+		Secret: "secret",
+		Active: true,
+	}
+	delivery := sqlentity.WebhookDelivery{
+		ID:             20,
+		SubscriptionID: 7,
+		EventID:        100,
+		EventType:      "LoanApproved",
+		Payload:        `{"id":100}`,
+		Status:         sqlentity.DeliveryPending,
+	}
+	withAttempts := func(attempts int) sqlentity.WebhookDelivery {
+		d := delivery
+		d.Attempts = attempts
+
+		return d
+	}
+
+	tests := []struct {
+		name   string
+		mockFn func(
+			ctx context.Context,
+			store *webhookmocks.MockDeliverWebhooksStore,
+			sender *webhookmocks.MockWebhookSender,
+		)
+		want    *usecase.DeliverWebhooksOutput
+		wantErr bool
+	}{
+		{
+			name: "error claim deliveries",
+			mockFn: func(ctx context.Context, store *webhookmocks.MockDeliverWebhooksStore, _ *webhookmocks.MockWebhookSender) {
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(nil, errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success nothing due",
+			mockFn: func(ctx context.Context, store *webhookmocks.MockDeliverWebhooksStore, _ *webhookmocks.MockWebhookSender) {
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).Return(nil, nil).Once()
+			},
+			want: &usecase.DeliverWebhooksOutput{},
+		},
+		{
+			name: "error get subscriptions",
+			mockFn: func(ctx context.Context, store *webhookmocks.MockDeliverWebhooksStore, _ *webhookmocks.MockWebhookSender) {
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(sqlentity.WebhookDeliveries{delivery}, nil).Once()
+				store.EXPECT().GetWebhookSubscription(ctx, mock.Anything).Return(nil, errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success delivered",
+			mockFn: func(
+				ctx context.Context,
+				store *webhookmocks.MockDeliverWebhooksStore,
+				sender *webhookmocks.MockWebhookSender,
+			) {
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(sqlentity.WebhookDeliveries{delivery}, nil).Once()
+				store.EXPECT().GetWebhookSubscription(ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{subscription}, nil).Once()
+				sender.EXPECT().Send(ctx, gateway.WebhookRequest{
+					URL:        subscription.URL,
+					Secret:     subscription.Secret,
+					DeliveryID: 20,
+					EventType:  "LoanApproved",
+					Body:       []byte(`{"id":100}`),
+				}).Return("200", nil).Once()
+				store.EXPECT().UpdateWebhookDelivery(
+					ctx,
+					mock.MatchedBy(func(in *sqlentity.UpdateWebhookDeliveryAttempt) bool {
+						return in.Status == sqlentity.DeliveryDelivered &&
+							in.Attempts == 1 &&
+							in.LastResponseCode.String == "200" &&
+							!in.LastError.Valid &&
+							in.DeliveredAt.Valid
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+			want: &usecase.DeliverWebhooksOutput{Delivered: 1},
+		},
+		{
+			name: "success failed attempt is retried with backoff",
+			mockFn: func(
+				ctx context.Context,
+				store *webhookmocks.MockDeliverWebhooksStore,
+				sender *webhookmocks.MockWebhookSender,
+			) {
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(sqlentity.WebhookDeliveries{withAttempts(1)}, nil).Once()
+				store.EXPECT().GetWebhookSubscription(ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{subscription}, nil).Once()
+				sender.EXPECT().Send(ctx, mock.Anything).
+					Return("503", pkgerror.NewPartnerError("503", "partner responded 503")).Once()
+				store.EXPECT().UpdateWebhookDelivery(
+					ctx,
+					mock.MatchedBy(func(in *sqlentity.UpdateWebhookDeliveryAttempt) bool {
+						wait := time.Until(in.NextAttemptAt)
+
+						return in.Status == sqlentity.DeliveryPending &&
+							in.Attempts == 2 &&
+							in.LastResponseCode.String == "503" &&
+							in.LastError.String == "partner responded 503" &&
+							wait > 19*time.Second && wait <= 20*time.Second
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+			want: &usecase.DeliverWebhooksOutput{Retried: 1},
+		},
+		{
+			name: "success last failed attempt is dead",
+			mockFn: func(
+				ctx context.Context,
+				store *webhookmocks.MockDeliverWebhooksStore,
+				sender *webhookmocks.MockWebhookSender,
+			) {
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(sqlentity.WebhookDeliveries{withAttempts(2)}, nil).Once()
+				store.EXPECT().GetWebhookSubscription(ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{subscription}, nil).Once()
+				sender.EXPECT().Send(ctx, mock.Anything).
+					Return("", pkgerror.NewPartnerError("", "connection refused")).Once()
+				store.EXPECT().UpdateWebhookDelivery(
+					ctx,
+					mock.MatchedBy(func(in *sqlentity.UpdateWebhookDeliveryAttempt) bool {
+						return in.Status == sqlentity.DeliveryDead &&
+							in.Attempts == 3 &&
+							!in.LastResponseCode.Valid &&
+							in.LastError.String == "connection refused"
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+			want: &usecase.DeliverWebhooksOutput{Dead: 1},
+		},
+		{
+			name: "success inactive subscription is dead without sending",
+			mockFn: func(ctx context.Context, store *webhookmocks.MockDeliverWebhooksStore, _ *webhookmocks.MockWebhookSender) {
+				inactive := subscription
+				inactive.Active = false
+
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(sqlentity.WebhookDeliveries{delivery}, nil).Once()
+				store.EXPECT().GetWebhookSubscription(ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{inactive}, nil).Once()
+				store.EXPECT().UpdateWebhookDelivery(
+					ctx,
+					mock.MatchedBy(func(in *sqlentity.UpdateWebhookDeliveryAttempt) bool {
+						return in.Status == sqlentity.DeliveryDead &&
+							in.LastError.String == errWebhookSubscriptionInactive.Error()
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+			want: &usecase.DeliverWebhooksOutput{Dead: 1},
+		},
+		{
+			name: "error update delivery keeps sending the batch",
+			mockFn: func(
+				ctx context.Context,
+				store *webhookmocks.MockDeliverWebhooksStore,
+				sender *webhookmocks.MockWebhookSender,
+			) {
+				other := delivery
+				other.ID = 21
+
+				store.EXPECT().ClaimWebhookDeliveries(ctx, mock.Anything, uint(2), time.Minute).
+					Return(sqlentity.WebhookDeliveries{delivery, other}, nil).Once()
+				store.EXPECT().GetWebhookSubscription(ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{subscription}, nil).Once()
+				sender.EXPECT().Send(ctx, mock.Anything).Return("200", nil).Twice()
+				store.EXPECT().UpdateWebhookDelivery(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("db down")).Once()
+				store.EXPECT().UpdateWebhookDelivery(ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil).Once()
+			},
+			want:    &usecase.DeliverWebhooksOutput{Delivered: 1, HasMore: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := webhookmocks.NewMockDeliverWebhooksStore(t)
+			sender := webhookmocks.NewMockWebhookSender(t)
+			tt.mockFn(ctx, store, sender)
+
+			d := NewDeliverWebhooks(store, sender, config, logger)
+			got, err := d.Execute(ctx)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeliverWebhooks_backoff(t *testing.T) {
+	d := NewDeliverWebhooks(nil, nil, DeliverWebhooksConfig{
+		RetryBackoff: 10 * time.Second,
+		MaxBackoff:   time.Minute,
+	}, zap.NewNop().Sugar())
+
+	assert.Equal(t, 10*time.Second, d.backoff(1))
+	assert.Equal(t, 20*time.Second, d.backoff(2))
+	assert.Equal(t, 40*time.Second, d.backoff(3))
+	assert.Equal(t, time.Minute, d.backoff(4))
+	assert.Equal(t, time.Minute, d.backoff(100))
+}
@@ -0,0 +1,109 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	EnqueueWebhookDeliveriesStore interface {
+		GetWebhookSubscription(
+			ctx context.Context,
+			opts ...gateway.GetWebhookSubscriptionOption,
+		) (sqlentity.WebhookSubscriptions, error)
+		InsertWebhookDeliveries(ctx context.Context, in sqlentity.WebhookDeliveries) error
+	}
+
+	EnqueueWebhookDeliveries struct {
+		store EnqueueWebhookDeliveriesStore
+
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+	}
+
+	// webhookBody is the JSON body posted to the partners. ID is the id of the event, the same for every attempt
+	// and replay, so partners can drop the duplicates.
+	webhookBody struct {
+		ID          uint64          `json:"id"`
+		Type        string          `json:"type"`
+		AggregateID uint64          `json:"aggregate_id"`
+		OccurredAt  time.Time       `json:"occurred_at"`
+		Data        json.RawMessage `json:"data"`
+	}
+)
+
+func NewEnqueueWebhookDeliveries(
+	store EnqueueWebhookDeliveriesStore,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *EnqueueWebhookDeliveries {
+	return &EnqueueWebhookDeliveries{
+		store:        store,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+// Execute creates a pending delivery of the event for every active subscription listening to it. A subscription
+// which already has a delivery of the event keeps it, so enqueueing the same event again is harmless.
+func (e *EnqueueWebhookDeliveries) Execute(ctx context.Context, in usecase.EnqueueWebhookDeliveriesInput) error {
+	subscriptions, err := e.store.GetWebhookSubscription(
+		ctx,
+		gateway.GetWebhookSubscriptionWithActiveFilter(true),
+		gateway.GetWebhookSubscriptionWithEventTypeFilter(in.EventType),
+	)
+	if err != nil {
+		e.logger.Errorw("failed to get webhook subscription", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	if subscriptions.IsEmpty() {
+		return nil
+	}
+
+	body, err := json.Marshal(webhookBody{
+		ID:          in.EventID,
+		Type:        in.EventType,
+		AggregateID: in.AggregateID,
+		OccurredAt:  in.OccurredAt.UTC(),
+		Data:        in.Data,
+	})
+	if err != nil {
+		e.logger.Errorw("failed to encode webhook body", "event_id", in.EventID, "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	now := time.Now()
+	deliveries := make(sqlentity.WebhookDeliveries, 0, subscriptions.Len())
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, sqlentity.WebhookDelivery{
+			ID:             e.snowflakeGen.Generate(),
+			SubscriptionID: subscription.ID,
+			EventID:        in.EventID,
+			EventType:      in.EventType,
+			Payload:        string(body),
+			Status:         sqlentity.DeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	if err := e.store.InsertWebhookDeliveries(ctx, deliveries); err != nil {
+		e.logger.Errorw("failed to insert webhook deliveries", "event_id", in.EventID, "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	webhookmocks "github.com/shandysiswandi/test-amartha/internal/webhook/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestEnqueueWebhookDeliveries_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+
+	type args struct {
+		ctx context.Context
+		in  usecase.EnqueueWebhookDeliveriesInput
+	}
+	in := usecase.EnqueueWebhookDeliveriesInput{
+		EventID:     100,
+		EventType:   "LoanApproved",
+		AggregateID: 1,
+		Data:        json.RawMessage(`{"loan_id":1}`),
+		OccurredAt:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *webhookmocks.MockEnqueueWebhookDeliveriesStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			a args,
+		)
+		wantErr bool
+	}{
+		{
+			name: "error get subscriptions",
+			args: args{ctx: context.Background(), in: in},
+			mockFn: func(
+				store *webhookmocks.MockEnqueueWebhookDeliveriesStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success no subscription listens to the event",
+			args: args{ctx: context.Background(), in: in},
+			mockFn: func(
+				store *webhookmocks.MockEnqueueWebhookDeliveriesStore,
+				_ *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything, mock.Anything).
+					Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "error insert deliveries",
+			args: args{ctx: context.Background(), in: in},
+			mockFn: func(
+				store *webhookmocks.MockEnqueueWebhookDeliveriesStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{{ID: 7, Active: true}}, nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(20).Once()
+				store.EXPECT().InsertWebhookDeliveries(a.ctx, mock.Anything).Return(errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success one delivery per subscription",
+			args: args{ctx: context.Background(), in: in},
+			mockFn: func(
+				store *webhookmocks.MockEnqueueWebhookDeliveriesStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{{ID: 7, Active: true}, {ID: 8, Active: true}}, nil).Once()
+				snowflakeGen.EXPECT().Generate().Return(20).Once()
+				snowflakeGen.EXPECT().Generate().Return(21).Once()
+
+				body := `{"id":100,"type":"LoanApproved","aggregate_id":1,` +
+					`"occurred_at":"2024-01-02T03:04:05Z","data":{"loan_id":1}}`
+				store.EXPECT().InsertWebhookDeliveries(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.WebhookDeliveries) bool {
+						return in.Len() == 2 &&
+							in[0].ID == 20 && in[0].SubscriptionID == 7 &&
+							in[1].ID == 21 && in[1].SubscriptionID == 8 &&
+							in[0].EventID == 100 && in[0].EventType == "LoanApproved" &&
+							in[0].Status == sqlentity.DeliveryPending &&
+							in[0].Payload == body && in[1].Payload == body
+					}),
+				).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := webhookmocks.NewMockEnqueueWebhookDeliveriesStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			tt.mockFn(store, snowflakeGen, tt.args)
+
+			e := NewEnqueueWebhookDeliveries(store, logger, snowflakeGen)
+			err := e.Execute(tt.args.ctx, tt.args.in)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
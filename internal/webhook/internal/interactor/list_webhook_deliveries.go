@@ -0,0 +1,104 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+const defaultListWebhookDeliveriesLimit uint = 20
+
+type (
+	ListWebhookDeliveriesStore interface {
+		GetWebhookSubscription(
+			ctx context.Context,
+			opts ...gateway.GetWebhookSubscriptionOption,
+		) (sqlentity.WebhookSubscriptions, error)
+		GetWebhookDelivery(
+			ctx context.Context,
+			opts ...gateway.GetWebhookDeliveryOption,
+		) (sqlentity.WebhookDeliveries, error)
+	}
+
+	ListWebhookDeliveries struct {
+		store  ListWebhookDeliveriesStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewListWebhookDeliveries(
+	store ListWebhookDeliveriesStore,
+	logger *zap.SugaredLogger,
+) *ListWebhookDeliveries {
+	return &ListWebhookDeliveries{
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (l *ListWebhookDeliveries) Execute(
+	ctx context.Context,
+	in usecase.ListWebhookDeliveriesInput,
+) (*usecase.ListWebhookDeliveriesOutput, error) {
+	subscriptions, err := l.store.GetWebhookSubscription(
+		ctx,
+		gateway.GetWebhookSubscriptionWithIDFilter(in.SubscriptionID),
+	)
+	if err != nil {
+		l.logger.Errorw("failed to get webhook subscription", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	if subscriptions.IsEmpty() {
+		l.logger.Errorw("webhook subscription not found", "subscription_id", in.SubscriptionID)
+
+		return nil, pkgerror.NewBusinessErrorCode(pkgerror.WebhookSubscriptionNotFound)
+	}
+
+	limit := in.Limit
+	if limit == 0 {
+		limit = defaultListWebhookDeliveriesLimit
+	}
+
+	opts := []gateway.GetWebhookDeliveryOption{
+		gateway.GetWebhookDeliveryWithSubscriptionIDFilter(in.SubscriptionID),
+		gateway.GetWebhookDeliveryWithOrderByID(true),
+		gateway.GetWebhookDeliveryWithLimit(limit + 1),
+	}
+
+	if in.Status != "" {
+		opts = append(opts, gateway.GetWebhookDeliveryWithStatusFilter(sqlentity.DeliveryStatusFromString(in.Status)))
+	}
+
+	if in.Cursor != 0 {
+		opts = append(opts, gateway.GetWebhookDeliveryWithIDBeforeFilter(in.Cursor))
+	}
+
+	deliveries, err := l.store.GetWebhookDelivery(ctx, opts...)
+	if err != nil {
+		l.logger.Errorw("failed to get webhook delivery", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	out := &usecase.ListWebhookDeliveriesOutput{
+		Deliveries: make([]usecase.WebhookDelivery, 0, deliveries.Len()),
+	}
+
+	// one extra row was requested to know whether there is a next page
+	if uint(deliveries.Len()) > limit {
+		deliveries = deliveries[:limit]
+		out.NextCursor = deliveries[len(deliveries)-1].ID
+	}
+
+	for _, delivery := range deliveries {
+		out.Deliveries = append(out.Deliveries, toWebhookDeliveryOutput(delivery))
+	}
+
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	webhookmocks "github.com/shandysiswandi/test-amartha/internal/webhook/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestListWebhookDeliveries_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type args struct {
+		ctx context.Context
+		in  usecase.ListWebhookDeliveriesInput
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFn   func(store *webhookmocks.MockListWebhookDeliveriesStore, a args)
+		want     *usecase.ListWebhookDeliveriesOutput
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error get subscription",
+			args: args{ctx: context.Background(), in: usecase.ListWebhookDeliveriesInput{SubscriptionID: 7}},
+			mockFn: func(store *webhookmocks.MockListWebhookDeliveriesStore, a args) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything).Return(nil, errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error subscription not found",
+			args: args{ctx: context.Background(), in: usecase.ListWebhookDeliveriesInput{SubscriptionID: 7}},
+			mockFn: func(store *webhookmocks.MockListWebhookDeliveriesStore, a args) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantCode: pkgerror.WebhookSubscriptionNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error get deliveries",
+			args: args{ctx: context.Background(), in: usecase.ListWebhookDeliveriesInput{SubscriptionID: 7}},
+			mockFn: func(store *webhookmocks.MockListWebhookDeliveriesStore, a args) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{{ID: 7}}, nil).Once()
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success with next page",
+			args: args{
+				ctx: context.Background(),
+				in:  usecase.ListWebhookDeliveriesInput{SubscriptionID: 7, Status: "DEAD", Cursor: 50, Limit: 1},
+			},
+			mockFn: func(store *webhookmocks.MockListWebhookDeliveriesStore, a args) {
+				store.EXPECT().GetWebhookSubscription(a.ctx, mock.Anything).
+					Return(sqlentity.WebhookSubscriptions{{ID: 7}}, nil).Once()
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+					mock.Anything).
+					Return(sqlentity.WebhookDeliveries{
+						{
+							ID:               21,
+							SubscriptionID:   7,
+							EventID:          100,
+							EventType:        "LoanApproved",
+							Status:           sqlentity.DeliveryDead,
+							Attempts:         8,
+							NextAttemptAt:    now,
+							LastResponseCode: sql.NullString{String: "500", Valid: true},
+							LastError:        sql.NullString{String: "partner responded 500", Valid: true},
+							CreatedAt:        now,
+						},
+						{ID: 20, SubscriptionID: 7, Status: sqlentity.DeliveryDead},
+					}, nil).Once()
+			},
+			want: &usecase.ListWebhookDeliveriesOutput{
+				Deliveries: []usecase.WebhookDelivery{
+					{
+						ID:               21,
+						SubscriptionID:   7,
+						EventID:          100,
+						EventType:        "LoanApproved",
+						Status:           "DEAD",
+						Attempts:         8,
+						LastResponseCode: "500",
+						LastError:        "partner responded 500",
+						CreatedAt:        now,
+					},
+				},
+				NextCursor: 21,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := webhookmocks.NewMockListWebhookDeliveriesStore(t)
+			tt.mockFn(store, tt.args)
+
+			l := NewListWebhookDeliveries(store, logger)
+			got, err := l.Execute(tt.args.ctx, tt.args.in)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
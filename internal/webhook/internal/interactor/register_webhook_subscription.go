@@ -0,0 +1,108 @@
+package interactor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+// webhookSecretSize is the number of random bytes of a generated secret.
+const webhookSecretSize = 32
+
+type (
+	RegisterWebhookSubscriptionStore interface {
+		InsertWebhookSubscription(ctx context.Context, in sqlentity.WebhookSubscription) error
+	}
+
+	RegisterWebhookSubscription struct {
+		store      RegisterWebhookSubscriptionStore
+		eventTypes []string
+
+		logger       *zap.SugaredLogger
+		snowflakeGen pkguid.Snowflake
+	}
+)
+
+// NewRegisterWebhookSubscription accepts subscriptions to the given event types only.
+func NewRegisterWebhookSubscription(
+	store RegisterWebhookSubscriptionStore,
+	eventTypes []string,
+	logger *zap.SugaredLogger,
+	snowflakeGen pkguid.Snowflake,
+) *RegisterWebhookSubscription {
+	return &RegisterWebhookSubscription{
+		store:        store,
+		eventTypes:   eventTypes,
+		logger:       logger,
+		snowflakeGen: snowflakeGen,
+	}
+}
+
+func (r *RegisterWebhookSubscription) Execute(
+	ctx context.Context,
+	in usecase.RegisterWebhookSubscriptionInput,
+) (*usecase.RegisterWebhookSubscriptionOutput, error) {
+	for _, eventType := range in.EventTypes {
+		if !slices.Contains(r.eventTypes, eventType) {
+			r.logger.Errorw("unknown webhook event type", "event_type", eventType)
+
+			return nil, pkgerror.NewValidationError(fmt.Sprintf("unknown event type %s", eventType))
+		}
+	}
+
+	eventTypes := slices.Clone(in.EventTypes)
+	slices.Sort(eventTypes)
+	eventTypes = slices.Compact(eventTypes)
+
+	secret := in.Secret
+	if secret == "" {
+		var err error
+		if secret, err = newWebhookSecret(); err != nil {
+			r.logger.Errorw("failed to generate webhook secret", "error", err)
+
+			return nil, pkgerror.ServerErrorFrom(err)
+		}
+	}
+
+	now := time.Now()
+	subscription := sqlentity.WebhookSubscription{
+		ID:         r.snowflakeGen.Generate(),
+		URL:        in.URL,
+		Secret:     secret,
+		EventTypes: strings.Join(eventTypes, ","),
+		Active:     true,
+		CreatedBy:  in.CreatedBy,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := r.store.InsertWebhookSubscription(ctx, subscription); err != nil {
+		r.logger.Errorw("failed to insert webhook subscription", "error", err)
+
+		return nil, pkgerror.ServerErrorFrom(err)
+	}
+
+	return &usecase.RegisterWebhookSubscriptionOutput{
+		WebhookSubscription: toWebhookSubscriptionOutput(subscription),
+		Secret:              secret,
+	}, nil
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
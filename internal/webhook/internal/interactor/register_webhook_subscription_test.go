@@ -0,0 +1,160 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgmocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	webhookmocks "github.com/shandysiswandi/test-amartha/internal/webhook/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestRegisterWebhookSubscription_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	eventTypes := []string{"LoanApproved", "LoanDisbursed"}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.RegisterWebhookSubscriptionInput
+	}
+	tests := []struct {
+		name   string
+		args   args
+		mockFn func(
+			store *webhookmocks.MockRegisterWebhookSubscriptionStore,
+			snowflakeGen *pkgmocks.MockSnowflake,
+			a args,
+		)
+		wantSecret func(t *testing.T, secret string)
+		wantErr    bool
+	}{
+		{
+			name: "error unknown event type",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.RegisterWebhookSubscriptionInput{
+					URL:        "https://partner.example.com/hooks",
+					EventTypes: []string{"LoanApproved", "LoanDeleted"},
+					CreatedBy:  1,
+				},
+			},
+			mockFn: func(
+				_ *webhookmocks.MockRegisterWebhookSubscriptionStore,
+				_ *pkgmocks.MockSnowflake,
+				_ args,
+			) {
+			},
+			wantErr: true,
+		},
+		{
+			name: "error insert subscription",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.RegisterWebhookSubscriptionInput{
+					URL:        "https://partner.example.com/hooks",
+					EventTypes: []string{"LoanApproved"},
+					CreatedBy:  1,
+				},
+			},
+			mockFn: func(
+				store *webhookmocks.MockRegisterWebhookSubscriptionStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				snowflakeGen.EXPECT().Generate().Return(10).Once()
+				store.EXPECT().InsertWebhookSubscription(a.ctx, mock.Anything).Return(errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success with given secret and duplicated event types",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.RegisterWebhookSubscriptionInput{
+					URL:        "https://partner.example.com/hooks",
+					Secret:     "partner-chosen-secret",
+					EventTypes: []string{"LoanDisbursed", "LoanApproved", "LoanDisbursed"},
+					CreatedBy:  1,
+				},
+			},
+			mockFn: func(
+				store *webhookmocks.MockRegisterWebhookSubscriptionStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				snowflakeGen.EXPECT().Generate().Return(10).Once()
+				store.EXPECT().InsertWebhookSubscription(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.WebhookSubscription) bool {
+						return in.ID == 10 &&
+							in.URL == a.in.URL &&
+							in.Secret == "partner-chosen-secret" &&
+							in.EventTypes == "LoanApproved,LoanDisbursed" &&
+							in.Active &&
+							in.CreatedBy == 1
+					}),
+				).Return(nil).Once()
+			},
+			wantSecret: func(t *testing.T, secret string) {
+				assert.Equal(t, "partner-chosen-secret", secret)
+			},
+		},
+		{
+			name: "success with generated secret",
+			args: args{
+				ctx: context.Background(),
+				in: usecase.RegisterWebhookSubscriptionInput{
+					URL:        "https://partner.example.com/hooks",
+					EventTypes: []string{"LoanApproved"},
+					CreatedBy:  1,
+				},
+			},
+			mockFn: func(
+				store *webhookmocks.MockRegisterWebhookSubscriptionStore,
+				snowflakeGen *pkgmocks.MockSnowflake,
+				a args,
+			) {
+				snowflakeGen.EXPECT().Generate().Return(10).Once()
+				store.EXPECT().InsertWebhookSubscription(
+					a.ctx,
+					mock.MatchedBy(func(in sqlentity.WebhookSubscription) bool {
+						return strings.HasPrefix(in.Secret, "whsec_")
+					}),
+				).Return(nil).Once()
+			},
+			wantSecret: func(t *testing.T, secret string) {
+				assert.True(t, strings.HasPrefix(secret, "whsec_"))
+				assert.Len(t, secret, len("whsec_")+2*webhookSecretSize)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := webhookmocks.NewMockRegisterWebhookSubscriptionStore(t)
+			snowflakeGen := pkgmocks.NewMockSnowflake(t)
+			tt.mockFn(store, snowflakeGen, tt.args)
+
+			r := NewRegisterWebhookSubscription(store, eventTypes, logger, snowflakeGen)
+			got, err := r.Execute(tt.args.ctx, tt.args.in)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.wantErr {
+				assert.False(t, pkgerror.IsBusinessError(err))
+
+				return
+			}
+
+			assert.Equal(t, uint64(10), got.ID)
+			assert.Equal(t, tt.args.in.URL, got.URL)
+			assert.True(t, got.Active)
+			tt.wantSecret(t, got.Secret)
+		})
+	}
+}
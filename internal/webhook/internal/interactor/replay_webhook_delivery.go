@@ -0,0 +1,94 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"go.uber.org/zap"
+)
+
+type (
+	ReplayWebhookDeliveryStore interface {
+		GetWebhookDelivery(
+			ctx context.Context,
+			opts ...gateway.GetWebhookDeliveryOption,
+		) (sqlentity.WebhookDeliveries, error)
+		UpdateWebhookDelivery(
+			ctx context.Context,
+			in sqlentity.UpdateEntity,
+			opts ...gateway.UpdateWebhookDeliveryOption,
+		) error
+	}
+
+	ReplayWebhookDelivery struct {
+		store  ReplayWebhookDeliveryStore
+		logger *zap.SugaredLogger
+	}
+)
+
+func NewReplayWebhookDelivery(
+	store ReplayWebhookDeliveryStore,
+	logger *zap.SugaredLogger,
+) *ReplayWebhookDelivery {
+	return &ReplayWebhookDelivery{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// Execute makes the delivery pending again with a fresh set of attempts, the worker sends it with its original body.
+// The error of the last attempt is kept until the next one.
+func (r *ReplayWebhookDelivery) Execute(ctx context.Context, in usecase.ReplayWebhookDeliveryInput) error {
+	deliveries, err := r.store.GetWebhookDelivery(ctx, gateway.GetWebhookDeliveryWithIDFilter(in.DeliveryID))
+	if err != nil {
+		r.logger.Errorw("failed to get webhook delivery", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	var delivery sqlentity.WebhookDelivery
+	if delivery = deliveries.First(); deliveries.IsEmpty() {
+		r.logger.Errorw("webhook delivery not found", "delivery_id", in.DeliveryID)
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.WebhookDeliveryNotFound)
+	}
+
+	if delivery.Status == sqlentity.DeliveryPending {
+		r.logger.Errorw("webhook delivery is still pending", "delivery_id", in.DeliveryID)
+
+		return pkgerror.NewBusinessErrorCode(pkgerror.WebhookDeliveryNotReplayable)
+	}
+
+	if err := r.store.UpdateWebhookDelivery(
+		ctx,
+		&sqlentity.UpdateWebhookDeliveryAttempt{
+			Status:           sqlentity.DeliveryPending,
+			Attempts:         0,
+			NextAttemptAt:    time.Now(),
+			LastResponseCode: delivery.LastResponseCode,
+			LastError:        delivery.LastError,
+			DeliveredAt:      sql.NullTime{},
+		},
+		gateway.UpdateWebhookDeliveryWithIDFilter(delivery.ID),
+		gateway.UpdateWebhookDeliveryWithStatusFilter(delivery.Status),
+	); err != nil {
+		// a concurrent replay made it pending first
+		if errors.Is(err, gateway.ErrWebhookDeliveryNotUpdated) {
+			r.logger.Errorw("webhook delivery was replayed concurrently", "delivery_id", in.DeliveryID)
+
+			return pkgerror.NewBusinessErrorCode(pkgerror.WebhookDeliveryNotReplayable)
+		}
+
+		r.logger.Errorw("failed to update webhook delivery", "error", err)
+
+		return pkgerror.ServerErrorFrom(err)
+	}
+
+	return nil
+}
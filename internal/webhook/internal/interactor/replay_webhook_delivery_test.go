@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgerror"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	webhookmocks "github.com/shandysiswandi/test-amartha/internal/webhook/internal/mocks"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestReplayWebhookDelivery_Execute(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	dead := sqlentity.WebhookDelivery{
+		ID:               20,
+		Status:           sqlentity.DeliveryDead,
+		Attempts:         8,
+		LastResponseCode: sql.NullString{String: "500", Valid: true},
+		LastError:        sql.NullString{String: "partner responded 500", Valid: true},
+	}
+
+	type args struct {
+		ctx context.Context
+		in  usecase.ReplayWebhookDeliveryInput
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFn   func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args)
+		wantCode pkgerror.Code
+		wantErr  bool
+	}{
+		{
+			name: "error get delivery",
+			args: args{ctx: context.Background(), in: usecase.ReplayWebhookDeliveryInput{DeliveryID: 20}},
+			mockFn: func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args) {
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything).Return(nil, errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "error delivery not found",
+			args: args{ctx: context.Background(), in: usecase.ReplayWebhookDeliveryInput{DeliveryID: 20}},
+			mockFn: func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args) {
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything).Return(nil, nil).Once()
+			},
+			wantCode: pkgerror.WebhookDeliveryNotFound,
+			wantErr:  true,
+		},
+		{
+			name: "error delivery still pending",
+			args: args{ctx: context.Background(), in: usecase.ReplayWebhookDeliveryInput{DeliveryID: 20}},
+			mockFn: func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args) {
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything).
+					Return(sqlentity.WebhookDeliveries{{ID: 20, Status: sqlentity.DeliveryPending}}, nil).Once()
+			},
+			wantCode: pkgerror.WebhookDeliveryNotReplayable,
+			wantErr:  true,
+		},
+		{
+			name: "error delivery replayed concurrently",
+			args: args{ctx: context.Background(), in: usecase.ReplayWebhookDeliveryInput{DeliveryID: 20}},
+			mockFn: func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args) {
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything).Return(sqlentity.WebhookDeliveries{dead}, nil).Once()
+				store.EXPECT().UpdateWebhookDelivery(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(gateway.ErrWebhookDeliveryNotUpdated).Once()
+			},
+			wantCode: pkgerror.WebhookDeliveryNotReplayable,
+			wantErr:  true,
+		},
+		{
+			name: "error update delivery",
+			args: args{ctx: context.Background(), in: usecase.ReplayWebhookDeliveryInput{DeliveryID: 20}},
+			mockFn: func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args) {
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything).Return(sqlentity.WebhookDeliveries{dead}, nil).Once()
+				store.EXPECT().UpdateWebhookDelivery(a.ctx, mock.Anything, mock.Anything, mock.Anything).
+					Return(errors.New("db down")).Once()
+			},
+			wantErr: true,
+		},
+		{
+			name: "success dead delivery is pending again",
+			args: args{ctx: context.Background(), in: usecase.ReplayWebhookDeliveryInput{DeliveryID: 20}},
+			mockFn: func(store *webhookmocks.MockReplayWebhookDeliveryStore, a args) {
+				store.EXPECT().GetWebhookDelivery(a.ctx, mock.Anything).Return(sqlentity.WebhookDeliveries{dead}, nil).Once()
+				store.EXPECT().UpdateWebhookDelivery(
+					a.ctx,
+					mock.MatchedBy(func(in *sqlentity.UpdateWebhookDeliveryAttempt) bool {
+						return in.Status == sqlentity.DeliveryPending &&
+							in.Attempts == 0 &&
+							in.LastError == dead.LastError &&
+							!in.DeliveredAt.Valid
+					}),
+					mock.Anything,
+					mock.Anything,
+				).Return(nil).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := webhookmocks.NewMockReplayWebhookDeliveryStore(t)
+			tt.mockFn(store, tt.args)
+
+			r := NewReplayWebhookDelivery(store, logger)
+			err := r.Execute(tt.args.ctx, tt.args.in)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.wantCode != pkgerror.Generic {
+				bizErr, ok := pkgerror.AsBusinessError(err)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantCode, bizErr.Code)
+			}
+		})
+	}
+}
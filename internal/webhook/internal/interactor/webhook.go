@@ -0,0 +1,48 @@
+package interactor
+
+import (
+	"database/sql"
+
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+)
+
+func toWebhookSubscriptionOutput(subscription sqlentity.WebhookSubscription) usecase.WebhookSubscription {
+	return usecase.WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypeList(),
+		Active:     subscription.Active,
+		CreatedBy:  subscription.CreatedBy,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryOutput(delivery sqlentity.WebhookDelivery) usecase.WebhookDelivery {
+	out := usecase.WebhookDelivery{
+		ID:               delivery.ID,
+		SubscriptionID:   delivery.SubscriptionID,
+		EventID:          delivery.EventID,
+		EventType:        delivery.EventType,
+		Status:           delivery.Status.String(),
+		Attempts:         delivery.Attempts,
+		LastResponseCode: delivery.LastResponseCode.String,
+		LastError:        delivery.LastError.String,
+		CreatedAt:        delivery.CreatedAt,
+	}
+
+	// only a pending delivery has another attempt coming
+	if delivery.Status == sqlentity.DeliveryPending {
+		out.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	if delivery.DeliveredAt.Valid {
+		out.DeliveredAt = &delivery.DeliveredAt.Time
+	}
+
+	return out
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,238 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+
+	time "time"
+)
+
+// MockDeliverWebhooksStore is an autogenerated mock type for the DeliverWebhooksStore type
+type MockDeliverWebhooksStore struct {
+	mock.Mock
+}
+
+type MockDeliverWebhooksStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeliverWebhooksStore) EXPECT() *MockDeliverWebhooksStore_Expecter {
+	return &MockDeliverWebhooksStore_Expecter{mock: &_m.Mock}
+}
+
+// ClaimWebhookDeliveries provides a mock function with given fields: ctx, now, limit, lease
+func (_m *MockDeliverWebhooksStore) ClaimWebhookDeliveries(ctx context.Context, now time.Time, limit uint, lease time.Duration) (sqlentity.WebhookDeliveries, error) {
+	ret := _m.Called(ctx, now, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimWebhookDeliveries")
+	}
+
+	var r0 sqlentity.WebhookDeliveries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, time.Duration) (sqlentity.WebhookDeliveries, error)); ok {
+		return rf(ctx, now, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, uint, time.Duration) sqlentity.WebhookDeliveries); ok {
+		r0 = rf(ctx, now, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WebhookDeliveries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, uint, time.Duration) error); ok {
+		r1 = rf(ctx, now, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimWebhookDeliveries'
+type MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit uint
+//   - lease time.Duration
+func (_e *MockDeliverWebhooksStore_Expecter) ClaimWebhookDeliveries(ctx interface{}, now interface{}, limit interface{}, lease interface{}) *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call {
+	return &MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call{Call: _e.mock.On("ClaimWebhookDeliveries", ctx, now, limit, lease)}
+}
+
+func (_c *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call) Run(run func(ctx context.Context, now time.Time, limit uint, lease time.Duration)) *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(uint), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call) Return(_a0 sqlentity.WebhookDeliveries, _a1 error) *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call) RunAndReturn(run func(context.Context, time.Time, uint, time.Duration) (sqlentity.WebhookDeliveries, error)) *MockDeliverWebhooksStore_ClaimWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, opts
+func (_m *MockDeliverWebhooksStore) GetWebhookSubscription(ctx context.Context, opts ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 sqlentity.WebhookSubscriptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) sqlentity.WebhookSubscriptions); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WebhookSubscriptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockDeliverWebhooksStore_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type MockDeliverWebhooksStore_GetWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWebhookSubscriptionOption
+func (_e *MockDeliverWebhooksStore_Expecter) GetWebhookSubscription(ctx interface{}, opts ...interface{}) *MockDeliverWebhooksStore_GetWebhookSubscription_Call {
+	return &MockDeliverWebhooksStore_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockDeliverWebhooksStore_GetWebhookSubscription_Call) Run(run func(ctx context.Context, opts ...gateway.GetWebhookSubscriptionOption)) *MockDeliverWebhooksStore_GetWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWebhookSubscriptionOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWebhookSubscriptionOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDeliverWebhooksStore_GetWebhookSubscription_Call) Return(_a0 sqlentity.WebhookSubscriptions, _a1 error) *MockDeliverWebhooksStore_GetWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockDeliverWebhooksStore_GetWebhookSubscription_Call) RunAndReturn(run func(context.Context, ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error)) *MockDeliverWebhooksStore_GetWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhookDelivery provides a mock function with given fields: ctx, in, opts
+func (_m *MockDeliverWebhooksStore) UpdateWebhookDelivery(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWebhookDeliveryOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWebhookDeliveryOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockDeliverWebhooksStore_UpdateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookDelivery'
+type MockDeliverWebhooksStore_UpdateWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateWebhookDeliveryOption
+func (_e *MockDeliverWebhooksStore_Expecter) UpdateWebhookDelivery(ctx interface{}, in interface{}, opts ...interface{}) *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call {
+	return &MockDeliverWebhooksStore_UpdateWebhookDelivery_Call{Call: _e.mock.On("UpdateWebhookDelivery",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWebhookDeliveryOption)) *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateWebhookDeliveryOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateWebhookDeliveryOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call) Return(_a0 error) *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWebhookDeliveryOption) error) *MockDeliverWebhooksStore_UpdateWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeliverWebhooksStore creates a new instance of MockDeliverWebhooksStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeliverWebhooksStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeliverWebhooksStore {
+	mock := &MockDeliverWebhooksStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	usecase "github.com/shandysiswandi/test-amartha/internal/webhook/internal/usecase"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockEnqueueWebhookDeliveries is an autogenerated mock type for the EnqueueWebhookDeliveries type
+type MockEnqueueWebhookDeliveries struct {
+	mock.Mock
+}
+
+type MockEnqueueWebhookDeliveries_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEnqueueWebhookDeliveries) EXPECT() *MockEnqueueWebhookDeliveries_Expecter {
+	return &MockEnqueueWebhookDeliveries_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx, in
+func (_m *MockEnqueueWebhookDeliveries) Execute(ctx context.Context, in usecase.EnqueueWebhookDeliveriesInput) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, usecase.EnqueueWebhookDeliveriesInput) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEnqueueWebhookDeliveries_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockEnqueueWebhookDeliveries_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in usecase.EnqueueWebhookDeliveriesInput
+func (_e *MockEnqueueWebhookDeliveries_Expecter) Execute(ctx interface{}, in interface{}) *MockEnqueueWebhookDeliveries_Execute_Call {
+	return &MockEnqueueWebhookDeliveries_Execute_Call{Call: _e.mock.On("Execute", ctx, in)}
+}
+
+func (_c *MockEnqueueWebhookDeliveries_Execute_Call) Run(run func(ctx context.Context, in usecase.EnqueueWebhookDeliveriesInput)) *MockEnqueueWebhookDeliveries_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(usecase.EnqueueWebhookDeliveriesInput))
+	})
+	return _c
+}
+
+func (_c *MockEnqueueWebhookDeliveries_Execute_Call) Return(_a0 error) *MockEnqueueWebhookDeliveries_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEnqueueWebhookDeliveries_Execute_Call) RunAndReturn(run func(context.Context, usecase.EnqueueWebhookDeliveriesInput) error) *MockEnqueueWebhookDeliveries_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEnqueueWebhookDeliveries creates a new instance of MockEnqueueWebhookDeliveries. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEnqueueWebhookDeliveries(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEnqueueWebhookDeliveries {
+	mock := &MockEnqueueWebhookDeliveries{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
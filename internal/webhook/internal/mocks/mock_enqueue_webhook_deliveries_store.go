@@ -0,0 +1,160 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+)
+
+// MockEnqueueWebhookDeliveriesStore is an autogenerated mock type for the EnqueueWebhookDeliveriesStore type
+type MockEnqueueWebhookDeliveriesStore struct {
+	mock.Mock
+}
+
+type MockEnqueueWebhookDeliveriesStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockEnqueueWebhookDeliveriesStore) EXPECT() *MockEnqueueWebhookDeliveriesStore_Expecter {
+	return &MockEnqueueWebhookDeliveriesStore_Expecter{mock: &_m.Mock}
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, opts
+func (_m *MockEnqueueWebhookDeliveriesStore) GetWebhookSubscription(ctx context.Context, opts ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 sqlentity.WebhookSubscriptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) sqlentity.WebhookSubscriptions); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WebhookSubscriptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWebhookSubscriptionOption
+func (_e *MockEnqueueWebhookDeliveriesStore_Expecter) GetWebhookSubscription(ctx interface{}, opts ...interface{}) *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	return &MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call) Run(run func(ctx context.Context, opts ...gateway.GetWebhookSubscriptionOption)) *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWebhookSubscriptionOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWebhookSubscriptionOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call) Return(_a0 sqlentity.WebhookSubscriptions, _a1 error) *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call) RunAndReturn(run func(context.Context, ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error)) *MockEnqueueWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InsertWebhookDeliveries provides a mock function with given fields: ctx, in
+func (_m *MockEnqueueWebhookDeliveriesStore) InsertWebhookDeliveries(ctx context.Context, in sqlentity.WebhookDeliveries) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWebhookDeliveries")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.WebhookDeliveries) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWebhookDeliveries'
+type MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// InsertWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.WebhookDeliveries
+func (_e *MockEnqueueWebhookDeliveriesStore_Expecter) InsertWebhookDeliveries(ctx interface{}, in interface{}) *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call {
+	return &MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call{Call: _e.mock.On("InsertWebhookDeliveries", ctx, in)}
+}
+
+func (_c *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call) Run(run func(ctx context.Context, in sqlentity.WebhookDeliveries)) *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.WebhookDeliveries))
+	})
+	return _c
+}
+
+func (_c *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call) Return(_a0 error) *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call) RunAndReturn(run func(context.Context, sqlentity.WebhookDeliveries) error) *MockEnqueueWebhookDeliveriesStore_InsertWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockEnqueueWebhookDeliveriesStore creates a new instance of MockEnqueueWebhookDeliveriesStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockEnqueueWebhookDeliveriesStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockEnqueueWebhookDeliveriesStore {
+	mock := &MockEnqueueWebhookDeliveriesStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,186 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+)
+
+// MockListWebhookDeliveriesStore is an autogenerated mock type for the ListWebhookDeliveriesStore type
+type MockListWebhookDeliveriesStore struct {
+	mock.Mock
+}
+
+type MockListWebhookDeliveriesStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockListWebhookDeliveriesStore) EXPECT() *MockListWebhookDeliveriesStore_Expecter {
+	return &MockListWebhookDeliveriesStore_Expecter{mock: &_m.Mock}
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, opts
+func (_m *MockListWebhookDeliveriesStore) GetWebhookDelivery(ctx context.Context, opts ...gateway.GetWebhookDeliveryOption) (sqlentity.WebhookDeliveries, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 sqlentity.WebhookDeliveries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookDeliveryOption) (sqlentity.WebhookDeliveries, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookDeliveryOption) sqlentity.WebhookDeliveries); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WebhookDeliveries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWebhookDeliveryOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListWebhookDeliveriesStore_GetWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDelivery'
+type MockListWebhookDeliveriesStore_GetWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWebhookDeliveryOption
+func (_e *MockListWebhookDeliveriesStore_Expecter) GetWebhookDelivery(ctx interface{}, opts ...interface{}) *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call {
+	return &MockListWebhookDeliveriesStore_GetWebhookDelivery_Call{Call: _e.mock.On("GetWebhookDelivery",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call) Run(run func(ctx context.Context, opts ...gateway.GetWebhookDeliveryOption)) *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWebhookDeliveryOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWebhookDeliveryOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call) Return(_a0 sqlentity.WebhookDeliveries, _a1 error) *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call) RunAndReturn(run func(context.Context, ...gateway.GetWebhookDeliveryOption) (sqlentity.WebhookDeliveries, error)) *MockListWebhookDeliveriesStore_GetWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscription provides a mock function with given fields: ctx, opts
+func (_m *MockListWebhookDeliveriesStore) GetWebhookSubscription(ctx context.Context, opts ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 sqlentity.WebhookSubscriptions
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) sqlentity.WebhookSubscriptions); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WebhookSubscriptions)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWebhookSubscriptionOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockListWebhookDeliveriesStore_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type MockListWebhookDeliveriesStore_GetWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWebhookSubscriptionOption
+func (_e *MockListWebhookDeliveriesStore_Expecter) GetWebhookSubscription(ctx interface{}, opts ...interface{}) *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	return &MockListWebhookDeliveriesStore_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call) Run(run func(ctx context.Context, opts ...gateway.GetWebhookSubscriptionOption)) *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWebhookSubscriptionOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWebhookSubscriptionOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call) Return(_a0 sqlentity.WebhookSubscriptions, _a1 error) *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call) RunAndReturn(run func(context.Context, ...gateway.GetWebhookSubscriptionOption) (sqlentity.WebhookSubscriptions, error)) *MockListWebhookDeliveriesStore_GetWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockListWebhookDeliveriesStore creates a new instance of MockListWebhookDeliveriesStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockListWebhookDeliveriesStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockListWebhookDeliveriesStore {
+	mock := &MockListWebhookDeliveriesStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
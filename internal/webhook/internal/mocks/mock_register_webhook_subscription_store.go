@@ -0,0 +1,85 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+)
+
+// MockRegisterWebhookSubscriptionStore is an autogenerated mock type for the RegisterWebhookSubscriptionStore type
+type MockRegisterWebhookSubscriptionStore struct {
+	mock.Mock
+}
+
+type MockRegisterWebhookSubscriptionStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRegisterWebhookSubscriptionStore) EXPECT() *MockRegisterWebhookSubscriptionStore_Expecter {
+	return &MockRegisterWebhookSubscriptionStore_Expecter{mock: &_m.Mock}
+}
+
+// InsertWebhookSubscription provides a mock function with given fields: ctx, in
+func (_m *MockRegisterWebhookSubscriptionStore) InsertWebhookSubscription(ctx context.Context, in sqlentity.WebhookSubscription) error {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertWebhookSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.WebhookSubscription) error); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InsertWebhookSubscription'
+type MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// InsertWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.WebhookSubscription
+func (_e *MockRegisterWebhookSubscriptionStore_Expecter) InsertWebhookSubscription(ctx interface{}, in interface{}) *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call {
+	return &MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call{Call: _e.mock.On("InsertWebhookSubscription", ctx, in)}
+}
+
+func (_c *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call) Run(run func(ctx context.Context, in sqlentity.WebhookSubscription)) *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(sqlentity.WebhookSubscription))
+	})
+	return _c
+}
+
+func (_c *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call) Return(_a0 error) *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call) RunAndReturn(run func(context.Context, sqlentity.WebhookSubscription) error) *MockRegisterWebhookSubscriptionStore_InsertWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRegisterWebhookSubscriptionStore creates a new instance of MockRegisterWebhookSubscriptionStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRegisterWebhookSubscriptionStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRegisterWebhookSubscriptionStore {
+	mock := &MockRegisterWebhookSubscriptionStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,175 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+
+	sqlentity "github.com/shandysiswandi/test-amartha/internal/webhook/internal/entity/sqlentity"
+)
+
+// MockReplayWebhookDeliveryStore is an autogenerated mock type for the ReplayWebhookDeliveryStore type
+type MockReplayWebhookDeliveryStore struct {
+	mock.Mock
+}
+
+type MockReplayWebhookDeliveryStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReplayWebhookDeliveryStore) EXPECT() *MockReplayWebhookDeliveryStore_Expecter {
+	return &MockReplayWebhookDeliveryStore_Expecter{mock: &_m.Mock}
+}
+
+// GetWebhookDelivery provides a mock function with given fields: ctx, opts
+func (_m *MockReplayWebhookDeliveryStore) GetWebhookDelivery(ctx context.Context, opts ...gateway.GetWebhookDeliveryOption) (sqlentity.WebhookDeliveries, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 sqlentity.WebhookDeliveries
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookDeliveryOption) (sqlentity.WebhookDeliveries, error)); ok {
+		return rf(ctx, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...gateway.GetWebhookDeliveryOption) sqlentity.WebhookDeliveries); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sqlentity.WebhookDeliveries)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...gateway.GetWebhookDeliveryOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDelivery'
+type MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - opts ...gateway.GetWebhookDeliveryOption
+func (_e *MockReplayWebhookDeliveryStore_Expecter) GetWebhookDelivery(ctx interface{}, opts ...interface{}) *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call {
+	return &MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call{Call: _e.mock.On("GetWebhookDelivery",
+		append([]interface{}{ctx}, opts...)...)}
+}
+
+func (_c *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call) Run(run func(ctx context.Context, opts ...gateway.GetWebhookDeliveryOption)) *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.GetWebhookDeliveryOption, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.GetWebhookDeliveryOption)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call) Return(_a0 sqlentity.WebhookDeliveries, _a1 error) *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call) RunAndReturn(run func(context.Context, ...gateway.GetWebhookDeliveryOption) (sqlentity.WebhookDeliveries, error)) *MockReplayWebhookDeliveryStore_GetWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhookDelivery provides a mock function with given fields: ctx, in, opts
+func (_m *MockReplayWebhookDeliveryStore) UpdateWebhookDelivery(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWebhookDeliveryOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWebhookDeliveryOption) error); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookDelivery'
+type MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in sqlentity.UpdateEntity
+//   - opts ...gateway.UpdateWebhookDeliveryOption
+func (_e *MockReplayWebhookDeliveryStore_Expecter) UpdateWebhookDelivery(ctx interface{}, in interface{}, opts ...interface{}) *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	return &MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call{Call: _e.mock.On("UpdateWebhookDelivery",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call) Run(run func(ctx context.Context, in sqlentity.UpdateEntity, opts ...gateway.UpdateWebhookDeliveryOption)) *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gateway.UpdateWebhookDeliveryOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(gateway.UpdateWebhookDeliveryOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(sqlentity.UpdateEntity), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call) Return(_a0 error) *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call) RunAndReturn(run func(context.Context, sqlentity.UpdateEntity, ...gateway.UpdateWebhookDeliveryOption) error) *MockReplayWebhookDeliveryStore_UpdateWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReplayWebhookDeliveryStore creates a new instance of MockReplayWebhookDeliveryStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReplayWebhookDeliveryStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReplayWebhookDeliveryStore {
+	mock := &MockReplayWebhookDeliveryStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package webhookmocks
+
+import (
+	context "context"
+
+	gateway "github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockWebhookSender is an autogenerated mock type for the WebhookSender type
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+type MockWebhookSender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookSender) EXPECT() *MockWebhookSender_Expecter {
+	return &MockWebhookSender_Expecter{mock: &_m.Mock}
+}
+
+// Send provides a mock function with given fields: ctx, in
+func (_m *MockWebhookSender) Send(ctx context.Context, in gateway.WebhookRequest) (string, error) {
+	ret := _m.Called(ctx, in)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Send")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, gateway.WebhookRequest) (string, error)); ok {
+		return rf(ctx, in)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, gateway.WebhookRequest) string); ok {
+		r0 = rf(ctx, in)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, gateway.WebhookRequest) error); ok {
+		r1 = rf(ctx, in)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockWebhookSender_Send_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Send'
+type MockWebhookSender_Send_Call struct {
+	*mock.Call
+}
+
+// Send is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in gateway.WebhookRequest
+func (_e *MockWebhookSender_Expecter) Send(ctx interface{}, in interface{}) *MockWebhookSender_Send_Call {
+	return &MockWebhookSender_Send_Call{Call: _e.mock.On("Send", ctx, in)}
+}
+
+func (_c *MockWebhookSender_Send_Call) Run(run func(ctx context.Context, in gateway.WebhookRequest)) *MockWebhookSender_Send_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(gateway.WebhookRequest))
+	})
+	return _c
+}
+
+func (_c *MockWebhookSender_Send_Call) Return(_a0 string, _a1 error) *MockWebhookSender_Send_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockWebhookSender_Send_Call) RunAndReturn(run func(context.Context, gateway.WebhookRequest) (string, error)) *MockWebhookSender_Send_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockWebhookSender creates a new instance of MockWebhookSender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookSender {
+	mock := &MockWebhookSender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
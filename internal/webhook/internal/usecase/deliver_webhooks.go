@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+)
+
+type (
+	DeliverWebhooks interface {
+		Execute(ctx context.Context) (*DeliverWebhooksOutput, error)
+	}
+
+	// DeliverWebhooksOutput counts the outcome of one batch of deliveries. HasMore reports a full batch, more
+	// deliveries are probably due.
+	DeliverWebhooksOutput struct {
+		Delivered int
+		Retried   int
+		Dead      int
+		HasMore   bool
+	}
+)
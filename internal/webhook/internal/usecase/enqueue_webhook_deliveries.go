@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+type (
+	EnqueueWebhookDeliveries interface {
+		Execute(ctx context.Context, in EnqueueWebhookDeliveriesInput) error
+	}
+
+	// EnqueueWebhookDeliveriesInput is a loan event to deliver to every active subscription listening to its type.
+	EnqueueWebhookDeliveriesInput struct {
+		EventID     uint64          `json:"event_id"     validate:"required"`
+		EventType   string          `json:"event_type"   validate:"required"`
+		AggregateID uint64          `json:"aggregate_id" validate:"required"`
+		Data        json.RawMessage `json:"data"         validate:"required"`
+		OccurredAt  time.Time       `json:"occurred_at"  validate:"required"`
+	}
+)
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+)
+
+type (
+	ListWebhookDeliveries interface {
+		Execute(ctx context.Context, in ListWebhookDeliveriesInput) (*ListWebhookDeliveriesOutput, error)
+	}
+
+	// ListWebhookDeliveriesInput lists the deliveries of a subscription from the newest, Cursor is the last delivery
+	// ID of the previous page.
+	ListWebhookDeliveriesInput struct {
+		SubscriptionID uint64 `json:"subscription_id" validate:"required"`
+		Status         string `json:"status"          validate:"omitempty,oneof=PENDING DELIVERED DEAD"`
+		Cursor         uint64 `json:"cursor"`
+		Limit          uint   `json:"limit"           validate:"omitempty,max=100"`
+	}
+
+	ListWebhookDeliveriesOutput struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+		NextCursor uint64            `json:"next_cursor,omitempty"`
+	}
+)
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"context"
+)
+
+type (
+	RegisterWebhookSubscription interface {
+		Execute(ctx context.Context, in RegisterWebhookSubscriptionInput) (*RegisterWebhookSubscriptionOutput, error)
+	}
+
+	// RegisterWebhookSubscriptionInput subscribes URL to the loan event types. A secret is generated when none is
+	// given.
+	RegisterWebhookSubscriptionInput struct {
+		URL        string   `json:"url"         validate:"required,http_url,max=2048"`
+		Secret     string   `json:"secret"      validate:"omitempty,min=16,max=255"`
+		EventTypes []string `json:"event_types" validate:"required,min=1,dive,required"`
+		CreatedBy  uint64   `json:"-"           validate:"required"`
+	}
+
+	// RegisterWebhookSubscriptionOutput is the only place the secret is ever returned, partners need it to verify the
+	// signature of the deliveries.
+	RegisterWebhookSubscriptionOutput struct {
+		WebhookSubscription
+		Secret string `json:"secret"`
+	}
+)
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"context"
+)
+
+type (
+	ReplayWebhookDelivery interface {
+		Execute(ctx context.Context, in ReplayWebhookDeliveryInput) error
+	}
+
+	// ReplayWebhookDeliveryInput sends a dead or already delivered delivery again, with a fresh set of attempts.
+	ReplayWebhookDeliveryInput struct {
+		DeliveryID uint64 `json:"delivery_id" validate:"required"`
+	}
+)
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"time"
+)
+
+type (
+	WebhookSubscription struct {
+		ID         uint64    `json:"id"`
+		URL        string    `json:"url"`
+		EventTypes []string  `json:"event_types"`
+		Active     bool      `json:"active"`
+		CreatedBy  uint64    `json:"created_by"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	// WebhookDelivery is an event sent, or still to be sent, to a subscription. LastResponseCode is the HTTP status
+	// the partner answered the last attempt with, empty when it could not be reached.
+	WebhookDelivery struct {
+		ID               uint64     `json:"id"`
+		SubscriptionID   uint64     `json:"subscription_id"`
+		EventID          uint64     `json:"event_id"`
+		EventType        string     `json:"event_type"`
+		Status           string     `json:"status"`
+		Attempts         int        `json:"attempts"`
+		NextAttemptAt    *time.Time `json:"next_attempt_at,omitempty"`
+		LastResponseCode string     `json:"last_response_code,omitempty"`
+		LastError        string     `json:"last_error,omitempty"`
+		DeliveredAt      *time.Time `json:"delivered_at,omitempty"`
+		CreatedAt        time.Time  `json:"created_at"`
+	}
+)
@@ -0,0 +1,111 @@
+package webhook
+
+import (
+	"database/sql"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgauth"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgoutbox"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgsql"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkguid"
+	"github.com/shandysiswandi/test-amartha/internal/pkg/pkgworker"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/gateway"
+	"github.com/shandysiswandi/test-amartha/internal/webhook/internal/interactor"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// Exposed carries the background workers of the module, the application starts them with its HTTP server.
+type Exposed struct {
+	Workers []*pkgworker.Periodic
+}
+
+type Dependencies struct {
+	Config        *viper.Viper
+	DB            *sql.DB
+	Logger        *zap.SugaredLogger
+	QueryBuilder  pkgsql.GoquBuilder
+	SnowflakeGen  pkguid.Snowflake
+	HttpRouter    *httprouter.Router
+	Validator     *validator.Validate
+	TokenVerifier pkgauth.TokenVerifier
+	OutboxEvents  pkgoutbox.Registry
+	// EventTypes are the event types partners can subscribe to.
+	EventTypes []string
+}
+
+func New(deps Dependencies) *Exposed {
+	webhookSQLStore := gateway.NewWebhookSQLGateway(deps.DB, deps.Logger, deps.QueryBuilder)
+	webhookPartnerGateway := gateway.NewWebhookPartnerGateway(deps.Config.GetDuration("webhook.delivery.timeout"))
+
+	registerWebhookSubscriptionUsecase := interactor.NewRegisterWebhookSubscription(
+		webhookSQLStore,
+		deps.EventTypes,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	enqueueWebhookDeliveriesUsecase := interactor.NewEnqueueWebhookDeliveries(
+		webhookSQLStore,
+		deps.Logger,
+		deps.SnowflakeGen,
+	)
+
+	deliverWebhooksUsecase := interactor.NewDeliverWebhooks(
+		webhookSQLStore,
+		webhookPartnerGateway,
+		interactor.DeliverWebhooksConfig{
+			BatchSize:    deps.Config.GetUint("webhook.delivery.batch_size"),
+			Lease:        deps.Config.GetDuration("webhook.delivery.lease"),
+			MaxAttempts:  deps.Config.GetInt("webhook.delivery.max_attempts"),
+			RetryBackoff: deps.Config.GetDuration("webhook.delivery.retry_backoff"),
+			MaxBackoff:   deps.Config.GetDuration("webhook.delivery.max_backoff"),
+		},
+		deps.Logger,
+	)
+
+	listWebhookDeliveriesUsecase := interactor.NewListWebhookDeliveries(
+		webhookSQLStore,
+		deps.Logger,
+	)
+
+	replayWebhookDeliveryUsecase := interactor.NewReplayWebhookDelivery(
+		webhookSQLStore,
+		deps.Logger,
+	)
+
+	webhookHTTPEndpoint := gateway.NewWebhookHTTPEndpoint(
+		registerWebhookSubscriptionUsecase,
+		listWebhookDeliveriesUsecase,
+		replayWebhookDeliveryUsecase,
+
+		deps.Logger,
+		deps.Validator,
+	)
+
+	gateway.NewWebhookHTTPGateway(
+		deps.HttpRouter,
+		deps.Logger,
+		webhookHTTPEndpoint,
+		deps.Validator,
+		deps.TokenVerifier,
+	)
+
+	webhookOutboxHandler := gateway.NewWebhookOutboxHandler(
+		enqueueWebhookDeliveriesUsecase,
+		deps.Logger,
+	)
+
+	gateway.NewWebhookOutboxGateway(deps.OutboxEvents, deps.EventTypes, webhookOutboxHandler)
+
+	webhookDeliveryWorker := gateway.NewWebhookWorkerGateway(
+		deps.Config.GetDuration("webhook.delivery.poll_interval"),
+		deliverWebhooksUsecase,
+		deps.Logger,
+	)
+
+	return &Exposed{
+		Workers: []*pkgworker.Periodic{webhookDeliveryWorker},
+	}
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS loan_status_histories (
+    id BIGINT PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    from_status VARCHAR(100) NOT NULL,
+    to_status VARCHAR(100) NOT NULL,
+    actor_user_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_loan_status_histories_loan_id (loan_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS loan_status_histories;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE loans ADD COLUMN version BIGINT NOT NULL DEFAULT 0 COMMENT "Optimistic lock, incremented on every update";
+
+-- +goose Down
+ALTER TABLE loans DROP COLUMN version;
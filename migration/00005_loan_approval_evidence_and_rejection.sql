@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE loans
+    ADD COLUMN approval_proof_document_key VARCHAR(255) NULL DEFAULT NULL AFTER agreement_letter_document_url,
+    ADD COLUMN rejection_date TIMESTAMP NULL DEFAULT NULL AFTER approval_proof_document_key,
+    ADD COLUMN rejection_employee_id BIGINT NULL DEFAULT NULL AFTER rejection_date,
+    ADD COLUMN rejection_reason TEXT NULL AFTER rejection_employee_id,
+    MODIFY COLUMN status VARCHAR(100) NOT NULL COMMENT "proposed, approved, rejected, invested, disbursed";
+
+-- +goose Down
+ALTER TABLE loans
+    DROP COLUMN approval_proof_document_key,
+    DROP COLUMN rejection_date,
+    DROP COLUMN rejection_employee_id,
+    DROP COLUMN rejection_reason,
+    MODIFY COLUMN status VARCHAR(100) NOT NULL COMMENT "proposed, approved, invested, disbursed";
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS loan_documents (
+    id BIGINT PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    type VARCHAR(100) NOT NULL COMMENT "agreement_letter",
+    document_key VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum_sha256 CHAR(64) NOT NULL,
+    uploaded_by BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_loan_documents_document_key (document_key),
+    INDEX idx_loan_documents_loan_id (loan_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS loan_documents;
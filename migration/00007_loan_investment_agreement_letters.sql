@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE loan_investments
+    ADD COLUMN agreement_letter_document_key VARCHAR(255) NULL AFTER amount;
+
+-- +goose Down
+ALTER TABLE loan_investments
+    DROP COLUMN agreement_letter_document_key;
@@ -0,0 +1,24 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN email VARCHAR(255) NULL DEFAULT NULL AFTER name;
+
+CREATE TABLE IF NOT EXISTS loan_notifications (
+    id BIGINT PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    investment_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    template VARCHAR(100) NOT NULL COMMENT "agreement_letter",
+    recipient VARCHAR(255) NOT NULL,
+    status VARCHAR(100) NOT NULL COMMENT "pending, sent, failed",
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    sent_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_loan_notifications_investment_template (investment_id, template),
+    INDEX idx_loan_notifications_loan_id (loan_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS loan_notifications;
+
+ALTER TABLE users DROP COLUMN email;
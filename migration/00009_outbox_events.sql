@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT PRIMARY KEY,
+    aggregate_type VARCHAR(100) NOT NULL COMMENT "loan",
+    aggregate_id BIGINT NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(100) NOT NULL COMMENT "pending, dispatched, failed",
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NULL,
+    available_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    dispatched_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_outbox_events_status_available_at (status, available_at),
+    INDEX idx_outbox_events_aggregate (aggregate_type, aggregate_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS outbox_events;
@@ -0,0 +1,34 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id BIGINT PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types VARCHAR(1024) NOT NULL COMMENT "comma separated loan event types",
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT PRIMARY KEY,
+    subscription_id BIGINT NOT NULL,
+    event_id BIGINT NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(100) NOT NULL COMMENT "pending, delivered, dead",
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_response_code VARCHAR(10) NULL DEFAULT NULL,
+    last_error TEXT NULL,
+    delivered_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_webhook_deliveries_subscription_event (subscription_id, event_id),
+    INDEX idx_webhook_deliveries_status_next_attempt_at (status, next_attempt_at)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP TABLE IF EXISTS webhook_subscriptions;
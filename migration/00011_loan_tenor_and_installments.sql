@@ -0,0 +1,25 @@
+-- +goose Up
+ALTER TABLE loans
+    ADD COLUMN tenor_months INT NULL COMMENT "number of monthly installments, unset for loans proposed before tenors" AFTER interest_rate,
+    ADD COLUMN repayment_method VARCHAR(100) NOT NULL DEFAULT 'FLAT' COMMENT "flat, annuity" AFTER tenor_months;
+
+CREATE TABLE IF NOT EXISTS loan_installments (
+    id BIGINT PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    installment_number INT NOT NULL,
+    due_date DATE NOT NULL,
+    principal_amount DECIMAL(10, 2) NOT NULL,
+    interest_amount DECIMAL(10, 2) NOT NULL,
+    total_amount DECIMAL(10, 2) NOT NULL,
+    outstanding_balance DECIMAL(10, 2) NOT NULL COMMENT "principal left once this installment is repaid",
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_loan_installments_loan_number (loan_id, installment_number)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS loan_installments;
+
+ALTER TABLE loans
+    DROP COLUMN repayment_method,
+    DROP COLUMN tenor_months;
@@ -0,0 +1,47 @@
+-- +goose Up
+ALTER TABLE loans
+    MODIFY COLUMN status VARCHAR(100) NOT NULL COMMENT "proposed, approved, rejected, invested, disbursed, late, repaid, defaulted";
+
+ALTER TABLE loan_installments
+    ADD COLUMN paid_principal_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER outstanding_balance,
+    ADD COLUMN paid_interest_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER paid_principal_amount,
+    ADD COLUMN status VARCHAR(100) NOT NULL DEFAULT 'PENDING' COMMENT "pending, partial, paid" AFTER paid_interest_amount,
+    ADD COLUMN paid_at TIMESTAMP NULL DEFAULT NULL AFTER status;
+
+CREATE TABLE IF NOT EXISTS loan_repayments (
+    id BIGINT PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    borrower_id BIGINT NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    principal_amount DECIMAL(10, 2) NOT NULL,
+    interest_amount DECIMAL(10, 2) NOT NULL,
+    overpaid_amount DECIMAL(10, 2) NOT NULL COMMENT "part of the payment left once every installment is paid",
+    paid_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_loan_repayments_loan_id (loan_id)
+);
+
+CREATE TABLE IF NOT EXISTS loan_repayment_allocations (
+    id BIGINT PRIMARY KEY,
+    repayment_id BIGINT NOT NULL,
+    installment_id BIGINT NOT NULL,
+    principal_amount DECIMAL(10, 2) NOT NULL,
+    interest_amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_loan_repayment_allocations_repayment_id (repayment_id),
+    INDEX idx_loan_repayment_allocations_installment_id (installment_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS loan_repayment_allocations;
+
+DROP TABLE IF EXISTS loan_repayments;
+
+ALTER TABLE loan_installments
+    DROP COLUMN paid_at,
+    DROP COLUMN status,
+    DROP COLUMN paid_interest_amount,
+    DROP COLUMN paid_principal_amount;
+
+ALTER TABLE loans
+    MODIFY COLUMN status VARCHAR(100) NOT NULL COMMENT "proposed, approved, rejected, invested, disbursed";
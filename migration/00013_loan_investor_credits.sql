@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS loan_investor_credits (
+    id BIGINT PRIMARY KEY,
+    loan_id BIGINT NOT NULL,
+    repayment_id BIGINT NOT NULL,
+    investment_id BIGINT NOT NULL,
+    investor_id BIGINT NOT NULL,
+    principal_amount DECIMAL(10, 2) NOT NULL,
+    interest_amount DECIMAL(10, 2) NOT NULL COMMENT "interest credited to the investor, net of the platform fee",
+    fee_amount DECIMAL(10, 2) NOT NULL COMMENT "platform fee taken from the interest of the investor",
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX uq_loan_investor_credits_repayment_investment (repayment_id, investment_id),
+    INDEX idx_loan_investor_credits_loan_id (loan_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS loan_investor_credits;
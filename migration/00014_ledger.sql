@@ -0,0 +1,66 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id BIGINT PRIMARY KEY,
+    type VARCHAR(100) NOT NULL COMMENT "investor_wallet, borrower, platform_escrow, fee_revenue",
+    owner_id BIGINT NOT NULL DEFAULT 0 COMMENT "user owning the account, 0 for the accounts of the platform",
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX uq_ledger_accounts_type_owner_id (type, owner_id)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id BIGINT PRIMARY KEY,
+    reference VARCHAR(100) NOT NULL COMMENT "money movement the entry records, e.g. investment:<id>",
+    loan_id BIGINT NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    posted_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX uq_ledger_entries_reference (reference),
+    INDEX idx_ledger_entries_loan_id (loan_id)
+);
+
+CREATE TABLE IF NOT EXISTS ledger_lines (
+    id BIGINT PRIMARY KEY,
+    entry_id BIGINT NOT NULL,
+    account_id BIGINT NOT NULL,
+    debit_amount DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT "money leaving the account",
+    credit_amount DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT "money entering the account",
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_ledger_lines_entry_id (entry_id),
+    INDEX idx_ledger_lines_account_id (account_id)
+);
+
+-- journal entries are immutable, a mistake is corrected by posting another entry
+-- +goose StatementBegin
+CREATE TRIGGER trg_ledger_entries_no_update BEFORE UPDATE ON ledger_entries FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'ledger entries are immutable';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER trg_ledger_entries_no_delete BEFORE DELETE ON ledger_entries FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'ledger entries are immutable';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER trg_ledger_lines_no_update BEFORE UPDATE ON ledger_lines FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'ledger lines are immutable';
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER trg_ledger_lines_no_delete BEFORE DELETE ON ledger_lines FOR EACH ROW
+    SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'ledger lines are immutable';
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_ledger_lines_no_delete;
+
+DROP TRIGGER IF EXISTS trg_ledger_lines_no_update;
+
+DROP TRIGGER IF EXISTS trg_ledger_entries_no_delete;
+
+DROP TRIGGER IF EXISTS trg_ledger_entries_no_update;
+
+DROP TABLE IF EXISTS ledger_lines;
+
+DROP TABLE IF EXISTS ledger_entries;
+
+DROP TABLE IF EXISTS ledger_accounts;
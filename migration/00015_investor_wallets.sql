@@ -0,0 +1,33 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS investor_wallets (
+    investor_id BIGINT PRIMARY KEY,
+    balance DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT "money in the wallet, reserved money included",
+    reserved_amount DECIMAL(15, 2) NOT NULL DEFAULT 0 COMMENT "money held for investments in loans not fully funded yet",
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    CONSTRAINT chk_investor_wallets_reserved_amount CHECK (reserved_amount >= 0 AND reserved_amount <= balance)
+);
+
+CREATE TABLE IF NOT EXISTS wallet_reservations (
+    id BIGINT PRIMARY KEY,
+    investor_id BIGINT NOT NULL,
+    loan_id BIGINT NOT NULL,
+    investment_id BIGINT NOT NULL,
+    amount DECIMAL(15, 2) NOT NULL,
+    status VARCHAR(100) NOT NULL COMMENT "held, captured, released",
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX uq_wallet_reservations_investment_id (investment_id),
+    INDEX idx_wallet_reservations_loan_id_status (loan_id, status)
+);
+
+ALTER TABLE ledger_accounts
+    MODIFY COLUMN type VARCHAR(100) NOT NULL COMMENT "investor_wallet, borrower, platform_escrow, fee_revenue, settlement";
+
+-- +goose Down
+ALTER TABLE ledger_accounts
+    MODIFY COLUMN type VARCHAR(100) NOT NULL COMMENT "investor_wallet, borrower, platform_escrow, fee_revenue";
+
+DROP TABLE IF EXISTS wallet_reservations;
+
+DROP TABLE IF EXISTS investor_wallets;
@@ -0,0 +1,27 @@
+-- +goose Up
+ALTER TABLE loans
+    MODIFY COLUMN status VARCHAR(100) NOT NULL COMMENT "proposed, approved, rejected, invested, disbursed, late, repaid, defaulted, expired",
+    ADD COLUMN funding_deadline TIMESTAMP NULL DEFAULT NULL COMMENT "an approved loan not fully funded by then expires" AFTER approval_proof_document_key,
+    ADD INDEX idx_loans_status_funding_deadline (status, funding_deadline);
+
+-- loans already open for investment get the default funding window from now on, not from their approval, so none of
+-- them expires on the first run of the worker
+UPDATE loans
+SET funding_deadline = NOW() + INTERVAL 30 DAY
+WHERE status = 'APPROVED' AND funding_deadline IS NULL;
+
+CREATE TABLE IF NOT EXISTS worker_leases (
+    name VARCHAR(100) PRIMARY KEY,
+    holder VARCHAR(100) NOT NULL COMMENT "instance of the application running the worker until expires_at",
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS worker_leases;
+
+ALTER TABLE loans
+    DROP INDEX idx_loans_status_funding_deadline,
+    DROP COLUMN funding_deadline,
+    MODIFY COLUMN status VARCHAR(100) NOT NULL COMMENT "proposed, approved, rejected, invested, disbursed, late, repaid, defaulted";
@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE loan_investments
+    ADD COLUMN status VARCHAR(100) NOT NULL DEFAULT 'ACTIVE' COMMENT "active, cancelled, released" AFTER agreement_letter_document_key,
+    ADD COLUMN cancelled_by BIGINT NULL DEFAULT NULL AFTER status,
+    ADD COLUMN cancelled_at TIMESTAMP NULL DEFAULT NULL AFTER cancelled_by;
+
+-- +goose Down
+ALTER TABLE loan_investments
+    DROP COLUMN cancelled_at,
+    DROP COLUMN cancelled_by,
+    DROP COLUMN status;